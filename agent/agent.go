@@ -23,6 +23,7 @@ import (
 	_ "net/http/pprof" // Needed to run the pprof server
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -33,11 +34,14 @@ import (
 
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/common"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/control"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcssource"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/profile"
 	pubsubinternal "github.com/GoogleCloudPlatform/cloud-ingest/agent/pubsub"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/stats"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/copy"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tracing"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/versions"
 )
 
@@ -46,6 +50,11 @@ var (
 	credsFile          = flag.String("creds-file", "", "The service account JSON key file. Use the default credentials if empty.")
 	printVersion       = flag.Bool("version", false, "Print build/version info and exit.")
 	enableStatsTracker = flag.Bool("enable-stats-log", true, "Enable stats logging to INFO logs.")
+	statsHTTPAddr      = flag.String("stats-http-addr", "", "If set, serve the agent stats as JSON at /stats on this address, for example localhost:8080. Requires enable-stats-log.")
+	prometheusAddr     = flag.String("prometheus-addr", "", "If set, serve Prometheus metrics at /metrics on this address, for example localhost:9090. Requires enable-stats-log.")
+	adminHTTPAddr      = flag.String("admin-http-addr", "", "If set, serve the agent's in-flight tasks as JSON at /tasks on this address, for example localhost:8081. POST to /tasks?cancel=<task> cancels that task, which is then redelivered, possibly to another agent. Tasks blocked in a syscall are only abandoned if task-deadline is set. Only listen on a trusted address, requests are not authenticated.")
+	otelEndpoint       = flag.String("otel-endpoint", "", "If set, export OpenTelemetry traces of copy tasks to the OTLP/HTTP collector at this address, for example localhost:4318.")
+	shutdownTimeout    = flag.Duration("shutdown-timeout", 30*time.Second, "On SIGTERM or SIGINT, how long to wait for in-flight tasks to finish before cancelling them.")
 
 	cpuProfile    = flag.Bool("cpu-profile", false, "Whether to record cpu usage and store the data in the log directory")
 	heapProfile   = flag.Bool("mem-profile", false, "Whether to record heap usage and store the data in the log directory")
@@ -128,7 +137,7 @@ func catchCtrlC(cancel context.CancelFunc) {
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		for _ = range c {
-			fmt.Println("\n\nCaught ^C, cleaning up and exiting (please wait)...")
+			fmt.Println("\n\nCaught ^C, finishing in-flight tasks and exiting (please wait)...")
 			cancel() // Cancel the main context.
 			// Further CTRL-Cs will be treated normally (forcing immediate exit).
 			signal.Reset()
@@ -136,10 +145,33 @@ func catchCtrlC(cancel context.CancelFunc) {
 	}()
 }
 
+// waitForTasks waits until wg is done, which happens once the TaskProcessors
+// have finished their in-flight tasks. The tasks are cancelled if they don't
+// finish within the shutdown-timeout.
+func waitForTasks(wg *sync.WaitGroup, cancelWork context.CancelFunc) {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(*shutdownTimeout):
+		glog.Warningf("In-flight tasks didn't finish within %v, cancelling them.", *shutdownTimeout)
+		cancelWork()
+		<-done
+	}
+}
+
 func main() {
 	defer fmt.Println("Exited gracefully.")
 	defer glog.Flush()
+	// The ctx is cancelled on SIGTERM or SIGINT, which stops pulling new tasks.
+	// The workCtx is used by in-flight tasks, and is only cancelled if they
+	// don't finish within the shutdown-timeout.
 	ctx, cancel := context.WithCancel(context.Background())
+	workCtx, cancelWork := context.WithCancel(context.Background())
+	defer cancelWork()
 	catchCtrlC(cancel)
 
 	if *printVersion {
@@ -171,33 +203,71 @@ func main() {
 		profile.ContinuouslyRecord(ctx, logDir, *heapProfile, *cpuProfile, *profileFreq)
 	}
 
-	pubSubClient, storageClient, httpc := createClients(ctx)
+	if *otelEndpoint != "" {
+		shutdownTracing := tracing.Init(*otelEndpoint)
+		defer func() {
+			// Flush the buffered spans, without holding up the exit for long.
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(flushCtx); err != nil {
+				glog.Warningf("Failed to flush traces to %s, err: %v", *otelEndpoint, err)
+			}
+		}()
+	}
+
+	if err := registerObjectSources(); err != nil {
+		glog.Fatalf("Failed to register object sources: %v", err)
+	}
+
+	pubSubClient, storageClient, httpc := createClients(workCtx)
+	// Objects listed from gs:// source directories are copied from GCS.
+	gcssource.Register(gcloud.NewGCSClient(storageClient))
 
 	// Create the PubSub topics and subscriptions.
 	listSub, copySub, controlSub, deleteSub, listTopic, copyTopic, pulseTopic, deleteTopic := pubsubinternal.CreatePubSubTopicsAndSubs(ctx, pubSubClient)
 	defer controlSub.Delete(context.Background())
 	var st *stats.Tracker
 	if *enableStatsTracker {
-		st = stats.NewTracker(ctx) // Created after PubSub topics/subs so STDOUT doesn't get stomped.
+		st = stats.NewTracker(workCtx) // Created after PubSub topics/subs so STDOUT doesn't get stomped.
+	}
+	if *statsHTTPAddr != "" {
+		if st == nil {
+			glog.Fatalf("The stats-http-addr flag requires enable-stats-log.")
+		}
+		go st.ServeStats(ctx, *statsHTTPAddr)
+	}
+	if *prometheusAddr != "" {
+		if st == nil {
+			glog.Fatalf("The prometheus-addr flag requires enable-stats-log.")
+		}
+		go stats.ServePrometheus(ctx, *prometheusAddr)
 	}
 
-	control.NewPulseSender(ctx, pubsubinternal.NewPubSubTopicWrapper(pulseTopic), logDir, st)
+	control.NewPulseSender(ctx, cancel, pubsubinternal.NewPubSubTopicWrapper(pulseTopic), logDir, st)
 
 	controlHandler := control.NewControlHandler(controlSub, st, logDir)
 	go controlHandler.Process(ctx)
 
-	listProcessor := tasks.NewListProcessor(storageClient, listSub, listTopic, st)
-	go listProcessor.Process(ctx)
-
-	copyProcessor := tasks.NewCopyProcessor(storageClient, httpc, copySub, copyTopic, st)
-	go copyProcessor.Process(ctx)
-
-	deleteProcessor := tasks.NewDeleteProcessor(storageClient, deleteSub, deleteTopic, st)
-	go deleteProcessor.Process(ctx)
+	inFlight := tasks.NewInFlightTasks()
+	if *adminHTTPAddr != "" {
+		go inFlight.ServeInFlightTasks(ctx, *adminHTTPAddr)
+	}
 
-	// Block until the ctx is cancelled.
-	select {
-	case <-ctx.Done():
-		break
+	var wg sync.WaitGroup
+	for _, tp := range []*tasks.TaskProcessor{
+		tasks.NewListProcessor(storageClient, listSub, listTopic, st),
+		tasks.NewCopyProcessor(storageClient, httpc, copySub, copyTopic, st),
+		tasks.NewDeleteProcessor(storageClient, deleteSub, deleteTopic, st),
+	} {
+		tp.InFlight = inFlight
+		wg.Add(1)
+		go func(tp *tasks.TaskProcessor) {
+			defer wg.Done()
+			tp.Process(ctx, workCtx)
+		}(tp)
 	}
+
+	// Block until the ctx is cancelled, then let the in-flight tasks finish.
+	<-ctx.Done()
+	waitForTasks(&wg, cancelWork)
 }
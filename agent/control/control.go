@@ -41,6 +41,9 @@ type ControlHandler struct {
 	// Test hooks.
 	processJobRunBandwidths func(jobBWs []*controlpb.JobRunBandwidth, st *stats.Tracker)
 	processAgentUpdateMsg   func(au *controlpb.AgentUpdate, agentID *pulsepb.AgentId, agentLogsDir string)
+	processLogVerbosities   func(lvs []*controlpb.LogVerbosity, agentID *pulsepb.AgentId)
+	processAgentBandwidths  func(agentBWs []*controlpb.AgentBandwidth, agentID *pulsepb.AgentId)
+	processJobRunPauses     func(pauses []*controlpb.JobRunPause)
 }
 
 // NewControlHandler creates an instance of ControlHandler.
@@ -52,6 +55,9 @@ func NewControlHandler(s *pubsub.Subscription, st *stats.Tracker, logDir string)
 		logDir:                  logDir,
 		processJobRunBandwidths: rate.ProcessJobRunBandwidths,
 		processAgentUpdateMsg:   agentupdate.ProcessAgentUpdateMsg,
+		processLogVerbosities:   newLogVerbositySetter().process,
+		processAgentBandwidths:  rate.ProcessAgentBandwidths,
+		processJobRunPauses:     rate.ProcessJobRunPauses,
 	}
 }
 
@@ -83,7 +89,10 @@ func (ch *ControlHandler) processMessage(_ context.Context, msg *pubsub.Message)
 	}
 
 	ch.processJobRunBandwidths(controlMsg.GetJobRunsBandwidths(), ch.statsTracker)
+	ch.processJobRunPauses(controlMsg.GetJobRunPauses())
 	ch.processAgentUpdateMsg(controlMsg.GetAgentUpdates(), common.AgentID(), ch.logDir)
+	ch.processLogVerbosities(controlMsg.GetLogVerbosities(), common.AgentID())
+	ch.processAgentBandwidths(controlMsg.GetAgentBandwidths(), common.AgentID())
 
 	ch.lastUpdate = msg.PublishTime
 	ch.statsTracker.RecordCtrlMsg(msg.PublishTime)
@@ -59,9 +59,15 @@ func TestProcessMessage(t *testing.T) {
 		ch := NewControlHandler(nil, nil, logDir)
 		processJobRunBandwidthsCalled := false
 		processAgentUpdateCalled := false
+		processLogVerbositiesCalled := false
+		processAgentBandwidthsCalled := false
+		processJobRunPausesCalled := false
 		ch.lastUpdate = now
 		ch.processJobRunBandwidths = func(_ []*controlpb.JobRunBandwidth, _ *stats.Tracker) { processJobRunBandwidthsCalled = true }
 		ch.processAgentUpdateMsg = func(_ *controlpb.AgentUpdate, _ *pulsepb.AgentId, _ string) { processAgentUpdateCalled = true }
+		ch.processLogVerbosities = func(_ []*controlpb.LogVerbosity, _ *pulsepb.AgentId) { processLogVerbositiesCalled = true }
+		ch.processAgentBandwidths = func(_ []*controlpb.AgentBandwidth, _ *pulsepb.AgentId) { processAgentBandwidthsCalled = true }
+		ch.processJobRunPauses = func(_ []*controlpb.JobRunPause) { processJobRunPausesCalled = true }
 		msg := &pubsub.Message{
 			Data:        tc.msg,
 			PublishTime: tc.ts,
@@ -73,5 +79,14 @@ func TestProcessMessage(t *testing.T) {
 		if processAgentUpdateCalled != tc.wantCalled {
 			t.Errorf("processMessage(%q) called processAgentUpdateMsg = %t, want: %t", tc.desc, processAgentUpdateCalled, tc.wantCalled)
 		}
+		if processLogVerbositiesCalled != tc.wantCalled {
+			t.Errorf("processMessage(%q) called processLogVerbosities = %t, want: %t", tc.desc, processLogVerbositiesCalled, tc.wantCalled)
+		}
+		if processAgentBandwidthsCalled != tc.wantCalled {
+			t.Errorf("processMessage(%q) called processAgentBandwidths = %t, want: %t", tc.desc, processAgentBandwidthsCalled, tc.wantCalled)
+		}
+		if processJobRunPausesCalled != tc.wantCalled {
+			t.Errorf("processMessage(%q) called processJobRunPauses = %t, want: %t", tc.desc, processJobRunPausesCalled, tc.wantCalled)
+		}
 	}
 }
@@ -0,0 +1,59 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"flag"
+	"strconv"
+
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+
+	controlpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/control_go_proto"
+	pulsepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/pulse_go_proto"
+)
+
+// logVerbositySetter sets the glog verbosity requested for this agent by
+// control messages.
+type logVerbositySetter struct {
+	defaultVerbosity string // The verbosity the agent was started with.
+}
+
+func newLogVerbositySetter() *logVerbositySetter {
+	return &logVerbositySetter{defaultVerbosity: flag.Lookup("v").Value.String()}
+}
+
+// process sets the glog verbosity to the one requested for agentID. If no
+// verbosity is requested for agentID the default verbosity is restored, so
+// dropping an agent from the control message undoes the change.
+func (s *logVerbositySetter) process(lvs []*controlpb.LogVerbosity, agentID *pulsepb.AgentId) {
+	v := s.defaultVerbosity
+	for _, lv := range lvs {
+		for _, id := range lv.GetAgentIds() {
+			if proto.Equal(id, agentID) {
+				v = strconv.Itoa(int(lv.GetVerbosity()))
+			}
+		}
+	}
+	if v == flag.Lookup("v").Value.String() {
+		return
+	}
+	if err := flag.Set("v", v); err != nil {
+		glog.Errorf("Failed to set the log verbosity to %v, err: %v", v, err)
+		return
+	}
+	glog.Infof("Set the log verbosity to %v", v)
+}
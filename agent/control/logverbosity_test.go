@@ -0,0 +1,51 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"flag"
+	"testing"
+
+	controlpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/control_go_proto"
+	pulsepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/pulse_go_proto"
+)
+
+func TestLogVerbositySetter(t *testing.T) {
+	defer flag.Set("v", flag.Lookup("v").Value.String())
+	flag.Set("v", "1")
+
+	agentID := &pulsepb.AgentId{HostName: "host", ProcessId: "123"}
+	otherID := &pulsepb.AgentId{HostName: "host", ProcessId: "456"}
+	s := newLogVerbositySetter()
+	tests := []struct {
+		desc string
+		lvs  []*controlpb.LogVerbosity
+		want string
+	}{
+		{"No verbosities", nil, "1"},
+		{"Other agent", []*controlpb.LogVerbosity{{AgentIds: []*pulsepb.AgentId{otherID}, Verbosity: 3}}, "1"},
+		{"This agent", []*controlpb.LogVerbosity{{AgentIds: []*pulsepb.AgentId{otherID, agentID}, Verbosity: 3}}, "3"},
+		{"Unchanged", []*controlpb.LogVerbosity{{AgentIds: []*pulsepb.AgentId{agentID}, Verbosity: 3}}, "3"},
+		{"Lowered", []*controlpb.LogVerbosity{{AgentIds: []*pulsepb.AgentId{agentID}, Verbosity: 0}}, "0"},
+		{"Restored default", nil, "1"},
+	}
+	for _, tc := range tests {
+		s.process(tc.lvs, agentID)
+		if got := flag.Lookup("v").Value.String(); got != tc.want {
+			t.Errorf("%s: verbosity = %v, want %v", tc.desc, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"bufio"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	pulsepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/pulse_go_proto"
+)
+
+var (
+	meminfoPath = "/proc/meminfo" // Only exists on Linux. Overridden in tests.
+)
+
+// machineInfo returns the metadata of the machine running the agent.
+func machineInfo() *pulsepb.MachineInfo {
+	return &pulsepb.MachineInfo{
+		Os:               runtime.GOOS,
+		Arch:             runtime.GOARCH,
+		CpuCount:         int64(runtime.NumCPU()),
+		TotalMemoryBytes: totalMemory(),
+	}
+}
+
+// totalMemory returns the total memory of the machine in bytes, read from the
+// MemTotal line of /proc/meminfo. Returns 0 if it's unknown.
+func totalMemory() int64 {
+	f, err := os.Open(meminfoPath)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		// The line looks like "MemTotal:       16318412 kB".
+		fields := strings.Fields(s.Text())
+		if len(fields) != 3 || fields[0] != "MemTotal:" || fields[2] != "kB" {
+			continue
+		}
+		kb, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0
+		}
+		return kb * 1024
+	}
+	return 0
+}
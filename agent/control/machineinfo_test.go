@@ -0,0 +1,66 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package control
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestTotalMemory(t *testing.T) {
+	defer func(p string) { meminfoPath = p }(meminfoPath)
+	tmpDir, err := ioutil.TempDir("", "test-machineinfo-")
+	if err != nil {
+		t.Fatalf("TempDir got err: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tests := []struct {
+		desc    string
+		meminfo string
+		want    int64
+	}{
+		{"MemTotal", "MemTotal:       16318412 kB\nMemFree:         1234567 kB\n", 16318412 * 1024},
+		{"MemTotal not first", "MemFree:         1234567 kB\nMemTotal:  2048 kB\n", 2048 * 1024},
+		{"No MemTotal", "MemFree:         1234567 kB\n", 0},
+		{"Unparseable", "MemTotal:       lots kB\n", 0},
+	}
+	for i, tc := range tests {
+		meminfoPath = filepath.Join(tmpDir, fmt.Sprintf("meminfo-%d", i))
+		if err := ioutil.WriteFile(meminfoPath, []byte(tc.meminfo), 0644); err != nil {
+			t.Fatalf("WriteFile got err: %v", err)
+		}
+		if got := totalMemory(); got != tc.want {
+			t.Errorf("%s: totalMemory() = %d, want %d", tc.desc, got, tc.want)
+		}
+	}
+
+	meminfoPath = filepath.Join(tmpDir, "missing")
+	if got := totalMemory(); got != 0 {
+		t.Errorf("totalMemory() with a missing meminfo = %d, want 0", got)
+	}
+}
+
+func TestMachineInfo(t *testing.T) {
+	m := machineInfo()
+	if m.Os != runtime.GOOS || m.Arch != runtime.GOARCH || m.CpuCount != int64(runtime.NumCPU()) {
+		t.Errorf("machineInfo() = %v, want os %q, arch %q and %d CPUs", m, runtime.GOOS, runtime.GOARCH, runtime.NumCPU())
+	}
+}
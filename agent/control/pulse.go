@@ -17,6 +17,9 @@ package control
 
 import (
 	"context"
+	"flag"
+	"runtime"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/pubsub"
@@ -26,6 +29,8 @@ import (
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/versions"
 	"github.com/golang/glog"
 	"github.com/golang/protobuf/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pulsepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/pulse_go_proto"
 )
@@ -34,6 +39,9 @@ var (
 	sendTickerMaker = func() common.Ticker {
 		return common.NewClockTicker(pulseFrequency)
 	}
+
+	pulseFailureThreshold = flag.Int("pulse-failure-threshold", 30, "The number of consecutive pulses which fail to publish, for example because the pulse topic was deleted, after which the agent is considered disconnected. At one pulse every 10s, the default is about 5 minutes.")
+	exitOnPulseFailure    = flag.Bool("exit-on-pulse-failure", false, "Gracefully shut down the agent once pulse-failure-threshold consecutive pulses failed to publish, so a disconnected agent doesn't keep processing stale work.")
 )
 
 const (
@@ -51,29 +59,56 @@ type PulseSender struct {
 	// Used to get live bandwidth measurements.
 	statsTracker *stats.Tracker
 
+	// Cancels the agent's context, to shut down a disconnected agent.
+	cancel context.CancelFunc
+	// The number of consecutive pulses which failed to publish.
+	failures int
+
 	// Time of instantiation of this struct.
 	startTime time.Time
 
+	// Machine metadata, collected in the background so pulses are never
+	// delayed by it. Nil until it has been collected.
+	machineMu sync.Mutex
+	machine   *pulsepb.MachineInfo
+
 	// Testing hooks.
 	selectDone func()
 	sendTicker common.Ticker
 }
 
-// NewPulseSender returns a new PulseSender.
-func NewPulseSender(ctx context.Context, t pubsubinternal.PSTopic, logsDir string, st *stats.Tracker) *PulseSender {
+// NewPulseSender returns a new PulseSender. If the exit-on-pulse-failure flag
+// is set, cancel is called once pulses have failed to publish
+// pulse-failure-threshold times in a row.
+func NewPulseSender(ctx context.Context, cancel context.CancelFunc, t pubsubinternal.PSTopic, logsDir string, st *stats.Tracker) *PulseSender {
 	ps := &PulseSender{
 		pulseTopic:   t,
 		logsDir:      common.LogDir(logsDir),
 		version:      versions.AgentVersion().String(),
 		statsTracker: st,
+		cancel:       cancel,
 		startTime:    time.Now(),
 		selectDone:   func() {},
 		sendTicker:   sendTickerMaker(),
 	}
+	go ps.collectMachineInfo()
 	go ps.sendPulses(ctx)
 	return ps
 }
 
+func (ps *PulseSender) collectMachineInfo() {
+	m := machineInfo()
+	ps.machineMu.Lock()
+	defer ps.machineMu.Unlock()
+	ps.machine = m
+}
+
+func (ps *PulseSender) machineInfo() *pulsepb.MachineInfo {
+	ps.machineMu.Lock()
+	defer ps.machineMu.Unlock()
+	return ps.machine
+}
+
 func (ps *PulseSender) sendPulses(ctx context.Context) {
 	for {
 		select {
@@ -95,13 +130,40 @@ func (ps *PulseSender) sendPulses(ctx context.Context) {
 			if err != nil {
 				glog.Errorf("sendPulses err, Publish(%v) got err: %v", psm, err)
 			}
+			ps.recordPublishResult(err)
 		}
 		ps.selectDone() // Testing hook.
 	}
 }
 
+// recordPublishResult tracks the consecutive pulse publish failures, and
+// shuts down the agent once they reach the pulse-failure-threshold if the
+// exit-on-pulse-failure flag is set.
+func (ps *PulseSender) recordPublishResult(err error) {
+	if err == nil {
+		ps.failures = 0
+		return
+	}
+	ps.failures++
+	if status.Code(err) == codes.NotFound {
+		glog.Errorf("The pulse topic was not found, it may have been deleted.")
+	}
+	if *pulseFailureThreshold <= 0 || ps.failures != *pulseFailureThreshold {
+		return
+	}
+	if *exitOnPulseFailure && ps.cancel != nil {
+		glog.Errorf("%d consecutive pulses failed to publish, shutting down the disconnected agent.", ps.failures)
+		ps.cancel()
+		return
+	}
+	glog.Warningf("%d consecutive pulses failed to publish, the agent may be disconnected.", ps.failures)
+}
+
 func (ps *PulseSender) pulseMsg() *pulsepb.Msg {
 	s := ps.statsTracker.AccumulatedPulseStats()
+	live := ps.statsTracker.LiveStats()
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
 	return &pulsepb.Msg{
 		AgentId:       common.AgentID(),
 		AgentVersion:  ps.version,
@@ -117,9 +179,23 @@ func (ps *PulseSender) pulseMsg() *pulsepb.Msg {
 		CopyReadMs:                s.CopyReadMs,
 		CopyWriteMs:               s.CopyWriteMs,
 		CopyInternalRetries:       s.CopyInternalRetries,
+		CopyReadOverlapMs:         s.CopyReadOverlapMs,
+		CopyDedupHits:             s.CopyDedupHits,
+		CopyDedupBytes:            s.CopyDedupBytes,
+		CopyResentBytes:           s.CopyResentBytes,
 		ListDirOpenMs:             s.ListDirOpenMs,
 		ListDirReadMs:             s.ListDirReadMs,
 		ListFileWriteMs:           s.ListFileWriteMs,
 		ListDirWriteMs:            s.ListDirWriteMs,
+		TaskHeldMs:                s.TaskHeldMs,
+
+		// Live stats, not reset.
+		AgentLifetimeCopiedBytes: live.CopyBytes,
+		CopyTasksInFlight:        live.CopyTasksInFlight,
+		ListTasksInFlight:        live.ListTasksInFlight,
+
+		MachineInfo:    ps.machineInfo(),
+		NumGoroutines:  int64(runtime.NumGoroutine()),
+		HeapAllocBytes: int64(mem.HeapAlloc),
 	}
 }
@@ -26,10 +26,15 @@ import (
 	pubsubinternal "github.com/GoogleCloudPlatform/cloud-ingest/agent/pubsub"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/stats"
 	pulsepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/pulse_go_proto"
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+var errNotFound = status.Error(codes.NotFound, "topic not found")
+
 func TestPulseSender(t *testing.T) {
 	tests := []int{1, 3, 10, 100}
 	for _, numPulses := range tests {
@@ -49,7 +54,7 @@ func TestPulseSender(t *testing.T) {
 		}
 
 		logsDir := "/tmp/mylogs"
-		ps := NewPulseSender(ctx, mockPulseTopic, logsDir, st)
+		ps := NewPulseSender(ctx, nil, mockPulseTopic, logsDir, st)
 		ps.version = "1.2.3"
 
 		mockPulseTopic.EXPECT().Publish(ctx, gomock.Any()).MaxTimes(numPulses).MinTimes(numPulses).Return(mockPublishResult)
@@ -65,6 +70,53 @@ func TestPulseSender(t *testing.T) {
 	}
 }
 
+func TestPulseSenderExitOnFailures(t *testing.T) {
+	defer func(n int, b bool) { *pulseFailureThreshold, *exitOnPulseFailure = n, b }(*pulseFailureThreshold, *exitOnPulseFailure)
+	*pulseFailureThreshold = 3
+	tests := []struct {
+		desc       string
+		exit       bool
+		results    []error
+		wantCancel bool
+	}{
+		{"Below threshold", true, []error{errNotFound, errNotFound}, false},
+		{"Reset by success", true, []error{errNotFound, errNotFound, nil, errNotFound, errNotFound}, false},
+		{"At threshold", true, []error{errNotFound, errNotFound, errNotFound}, true},
+		{"Exit disabled", false, []error{errNotFound, errNotFound, errNotFound}, false},
+	}
+	for _, tc := range tests {
+		*exitOnPulseFailure = tc.exit
+		ctx := context.Background()
+		ctrl := gomock.NewController(t)
+		mockPulseTopic := pubsubinternal.NewMockPSTopic(ctrl)
+		var calls []*gomock.Call
+		for _, err := range tc.results {
+			mockPublishResult := pubsubinternal.NewMockPSPublishResult(ctrl)
+			mockPublishResult.EXPECT().Get(ctx).Return("", err)
+			calls = append(calls, mockPulseTopic.EXPECT().Publish(ctx, gomock.Any()).Return(mockPublishResult))
+		}
+		gomock.InOrder(calls...)
+
+		mockSendTicker := common.NewMockTicker()
+		sendTickerMaker = func() common.Ticker {
+			return mockSendTicker
+		}
+		cancelled := false
+		ps := NewPulseSender(ctx, func() { cancelled = true }, mockPulseTopic, "/tmp/mylogs", nil)
+		var wg sync.WaitGroup
+		ps.selectDone = func() { wg.Done() }
+		for range tc.results {
+			wg.Add(1)
+			mockSendTicker.Tick()
+			wg.Wait()
+		}
+		if cancelled != tc.wantCancel {
+			t.Errorf("%s: cancelled = %v, want %v", tc.desc, cancelled, tc.wantCancel)
+		}
+		ctrl.Finish()
+	}
+}
+
 func TestPulseMsg(t *testing.T) {
 	agentMsgCmpOpt := cmp.Comparer(func(x, y pulsepb.Msg) bool {
 		return (cmp.Equal(x.AgentId, y.AgentId) && x.AgentLogsDir == y.AgentLogsDir &&
@@ -133,3 +185,26 @@ func TestPulseMsg(t *testing.T) {
 		}
 	}
 }
+
+func TestPulseMsgLiveStats(t *testing.T) {
+	unusedMockTicker := common.NewMockTicker()
+	sendTickerMaker = func() common.Ticker { return unusedMockTicker }
+
+	st := stats.NewTracker(context.Background())
+	st.RecordTaskStart(&taskpb.TaskReqMsg{Spec: &taskpb.Spec{Spec: &taskpb.Spec_ListSpec{&taskpb.ListSpec{}}}})
+	ps := &PulseSender{statsTracker: st}
+	got := ps.pulseMsg()
+	if got.ListTasksInFlight != 1 || got.CopyTasksInFlight != 0 || got.AgentLifetimeCopiedBytes != 0 {
+		t.Errorf("ps.pulseMsg() = %v, want 1 list task and 0 copy tasks in flight", got)
+	}
+	if got.NumGoroutines <= 0 || got.HeapAllocBytes <= 0 {
+		t.Errorf("ps.pulseMsg() = %v, want positive goroutine and heap stats", got)
+	}
+	if got.MachineInfo != nil {
+		t.Errorf("ps.pulseMsg() machine info = %v before it was collected, want nil", got.MachineInfo)
+	}
+	ps.collectMachineInfo()
+	if got := ps.pulseMsg(); got.MachineInfo == nil {
+		t.Errorf("ps.pulseMsg() machine info is nil after it was collected")
+	}
+}
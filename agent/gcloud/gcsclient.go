@@ -26,6 +26,10 @@ import (
 
 // Pass-through wrapper for Google Cloud Storage client.
 type GCS interface {
+	Compose(ctx context.Context, bucketName, objectName string, srcObjectNames []string,
+		cond storage.Conditions, attrs *storage.ObjectAttrs) (*storage.ObjectAttrs, error)
+	CopyObject(ctx context.Context, srcBucketName, srcObjectName string, srcGeneration int64,
+		bucketName, objectName string, cond storage.Conditions, attrs *storage.ObjectAttrs) (*storage.ObjectAttrs, error)
 	CreateBucket(ctx context.Context, projectId, bucketName string, attrs *storage.BucketAttrs) error
 	DeleteBucket(ctx context.Context, bucketName string) error
 	DeleteObject(ctx context.Context, bucketName, objectName string, genNumber int64) error
@@ -35,6 +39,8 @@ type GCS interface {
 	NewWriter(ctx context.Context, bucketName, objectName string) WriteCloserWithError
 	NewWriterWithCondition(ctx context.Context, bucketName, objectName string,
 		cond storage.Conditions) WriteCloserWithError
+	UpdateAttrs(ctx context.Context, bucketName, objectName string, cond storage.Conditions,
+		attrs storage.ObjectAttrsToUpdate) (*storage.ObjectAttrs, error)
 }
 
 type WriteCloserWithError interface {
@@ -57,8 +63,47 @@ func NewGCSClient(client *storage.Client) *GCSClient {
 	return &GCSClient{client}
 }
 
+// withCondition returns obj with the preconditions cond. A zero cond is
+// skipped, since the storage client rejects empty conditions.
+func withCondition(obj *storage.ObjectHandle, cond storage.Conditions) *storage.ObjectHandle {
+	if cond == (storage.Conditions{}) {
+		return obj
+	}
+	return obj.If(cond)
+}
+
 // Pass-through method implementations.
 
+func (gcs *GCSClient) Compose(ctx context.Context, bucketName, objectName string, srcObjectNames []string,
+	cond storage.Conditions, attrs *storage.ObjectAttrs) (*storage.ObjectAttrs, error) {
+
+	bucket := gcs.client.Bucket(bucketName)
+	var srcs []*storage.ObjectHandle
+	for _, name := range srcObjectNames {
+		srcs = append(srcs, bucket.Object(name))
+	}
+	composer := withCondition(bucket.Object(objectName), cond).ComposerFrom(srcs...)
+	if attrs != nil {
+		composer.ObjectAttrs = *attrs
+	}
+	return composer.Run(ctx)
+}
+
+func (gcs *GCSClient) CopyObject(ctx context.Context, srcBucketName, srcObjectName string, srcGeneration int64,
+	bucketName, objectName string, cond storage.Conditions, attrs *storage.ObjectAttrs) (*storage.ObjectAttrs, error) {
+
+	src := gcs.client.Bucket(srcBucketName).Object(srcObjectName)
+	if srcGeneration != 0 {
+		src = src.Generation(srcGeneration)
+	}
+	copier := withCondition(gcs.client.Bucket(bucketName).Object(objectName), cond).CopierFrom(src)
+	if attrs != nil {
+		copier.ObjectAttrs = *attrs
+		copier.DestinationKMSKeyName = attrs.KMSKeyName
+	}
+	return copier.Run(ctx)
+}
+
 func (gcs *GCSClient) CreateBucket(ctx context.Context, projectId, bucketName string, attrs *storage.BucketAttrs) error {
 	return gcs.client.Bucket(bucketName).Create(ctx, projectId, attrs)
 }
@@ -95,7 +140,13 @@ func (gcs *GCSClient) NewWriter(ctx context.Context,
 func (gcs *GCSClient) NewWriterWithCondition(ctx context.Context,
 	bucketName, objectName string, cond storage.Conditions) WriteCloserWithError {
 
-	return gcs.client.Bucket(bucketName).Object(objectName).If(cond).NewWriter(ctx)
+	return withCondition(gcs.client.Bucket(bucketName).Object(objectName), cond).NewWriter(ctx)
+}
+
+func (gcs *GCSClient) UpdateAttrs(ctx context.Context, bucketName, objectName string, cond storage.Conditions,
+	attrs storage.ObjectAttrsToUpdate) (*storage.ObjectAttrs, error) {
+
+	return withCondition(gcs.client.Bucket(bucketName).Object(objectName), cond).Update(ctx, attrs)
 }
 
 // NewObjectIterator returns an in-memory instance of ObjectIterator. Prefer this approach
@@ -1,12 +1,17 @@
 package gcloud
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	"cloud.google.com/go/storage"
 	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
 )
 
 func TestNewObjectIterator_Empty(t *testing.T) {
@@ -61,3 +66,51 @@ func TestNewObjectIterator_Error(t *testing.T) {
 		}
 	}
 }
+
+func TestGCSClientConditions(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		if strings.Contains(r.URL.Path, "/rewriteTo/") {
+			w.Write([]byte(`{"done": true, "resource": {"bucket": "bucket", "name": "object"}}`))
+			return
+		}
+		w.Write([]byte(`{"bucket": "bucket", "name": "object"}`))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	client, err := storage.NewClient(ctx, option.WithEndpoint(server.URL+"/storage/v1/"), option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("storage.NewClient got err: %v", err)
+	}
+	gcs := NewGCSClient(client)
+
+	calls := map[string]func(cond storage.Conditions) error{
+		"Compose": func(cond storage.Conditions) error {
+			_, err := gcs.Compose(ctx, "bucket", "object", []string{"src"}, cond, nil)
+			return err
+		},
+		"CopyObject": func(cond storage.Conditions) error {
+			_, err := gcs.CopyObject(ctx, "bucket", "src", 0, "bucket", "object", cond, nil)
+			return err
+		},
+		"UpdateAttrs": func(cond storage.Conditions) error {
+			_, err := gcs.UpdateAttrs(ctx, "bucket", "object", cond, storage.ObjectAttrsToUpdate{ContentType: "text/plain"})
+			return err
+		},
+	}
+	for name, call := range calls {
+		// The storage client rejects empty conditions, so they're omitted.
+		if err := call(storage.Conditions{}); err != nil {
+			t.Errorf("%s with empty conditions got err: %v", name, err)
+		} else if strings.Contains(gotQuery, "ifGenerationMatch") {
+			t.Errorf("%s with empty conditions sent query %q, want no precondition", name, gotQuery)
+		}
+		if err := call(storage.Conditions{GenerationMatch: 5}); err != nil {
+			t.Errorf("%s with conditions got err: %v", name, err)
+		} else if !strings.Contains(gotQuery, "ifGenerationMatch=5") {
+			t.Errorf("%s with conditions sent query %q, want ifGenerationMatch=5", name, gotQuery)
+		}
+	}
+}
@@ -35,6 +35,36 @@ func (m *MockGCS) EXPECT() *MockGCSMockRecorder {
 	return m.recorder
 }
 
+// Compose mocks base method
+func (m *MockGCS) Compose(ctx context.Context, bucketName, objectName string, srcObjectNames []string, cond storage.Conditions, attrs *storage.ObjectAttrs) (*storage.ObjectAttrs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compose", ctx, bucketName, objectName, srcObjectNames, cond, attrs)
+	ret0, _ := ret[0].(*storage.ObjectAttrs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compose indicates an expected call of Compose
+func (mr *MockGCSMockRecorder) Compose(ctx, bucketName, objectName, srcObjectNames, cond, attrs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compose", reflect.TypeOf((*MockGCS)(nil).Compose), ctx, bucketName, objectName, srcObjectNames, cond, attrs)
+}
+
+// CopyObject mocks base method
+func (m *MockGCS) CopyObject(ctx context.Context, srcBucketName, srcObjectName string, srcGeneration int64, bucketName, objectName string, cond storage.Conditions, attrs *storage.ObjectAttrs) (*storage.ObjectAttrs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyObject", ctx, srcBucketName, srcObjectName, srcGeneration, bucketName, objectName, cond, attrs)
+	ret0, _ := ret[0].(*storage.ObjectAttrs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyObject indicates an expected call of CopyObject
+func (mr *MockGCSMockRecorder) CopyObject(ctx, srcBucketName, srcObjectName, srcGeneration, bucketName, objectName, cond, attrs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyObject", reflect.TypeOf((*MockGCS)(nil).CopyObject), ctx, srcBucketName, srcObjectName, srcGeneration, bucketName, objectName, cond, attrs)
+}
+
 // CreateBucket mocks base method
 func (m *MockGCS) CreateBucket(ctx context.Context, projectId, bucketName string, attrs *storage.BucketAttrs) error {
 	m.ctrl.T.Helper()
@@ -149,6 +179,21 @@ func (mr *MockGCSMockRecorder) NewWriterWithCondition(ctx, bucketName, objectNam
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewWriterWithCondition", reflect.TypeOf((*MockGCS)(nil).NewWriterWithCondition), ctx, bucketName, objectName, cond)
 }
 
+// UpdateAttrs mocks base method
+func (m *MockGCS) UpdateAttrs(ctx context.Context, bucketName, objectName string, cond storage.Conditions, attrs storage.ObjectAttrsToUpdate) (*storage.ObjectAttrs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAttrs", ctx, bucketName, objectName, cond, attrs)
+	ret0, _ := ret[0].(*storage.ObjectAttrs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAttrs indicates an expected call of UpdateAttrs
+func (mr *MockGCSMockRecorder) UpdateAttrs(ctx, bucketName, objectName, cond, attrs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAttrs", reflect.TypeOf((*MockGCS)(nil).UpdateAttrs), ctx, bucketName, objectName, cond, attrs)
+}
+
 // MockWriteCloserWithError is a mock of WriteCloserWithError interface
 type MockWriteCloserWithError struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,99 @@
+// Package gcssource reads objects from GCS, for "gs://bucket/object" source
+// paths, so objects listed from a GCS source directory are copied like files.
+package gcssource
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"google.golang.org/api/iterator"
+)
+
+// ObjectSource is a common.ObjectSource of "gs://bucket/object" paths.
+type ObjectSource struct {
+	gcs gcloud.GCS
+}
+
+// NewObjectSource returns an ObjectSource reading objects with the given client.
+func NewObjectSource(gcs gcloud.GCS) *ObjectSource {
+	return &ObjectSource{gcs: gcs}
+}
+
+// Register registers an ObjectSource for gs:// paths, reading objects with
+// the given client.
+func Register(gcs gcloud.GCS) {
+	common.RegisterObjectSource(common.GCSScheme, NewObjectSource(gcs))
+}
+
+// parsePath splits a "gs://bucket/object" path into its bucket and object.
+func parsePath(p string) (bucket, object string) {
+	parts := strings.SplitN(strings.TrimPrefix(p, common.GCSScheme), "/", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+// objectInfo returns the info of the object at p with the given attrs. The
+// object's mtime is the mtime of the file it was copied from, if any, so it
+// matches the mtime it was listed with.
+func objectInfo(p string, attrs *storage.ObjectAttrs) *common.ObjectInfo {
+	return &common.ObjectInfo{
+		Path:  p,
+		Bytes: attrs.Size,
+		MTime: time.Unix(common.ObjectMTime(attrs), 0),
+	}
+}
+
+// Stat implements common.ObjectSource.
+func (s *ObjectSource) Stat(ctx context.Context, p string) (*common.ObjectInfo, error) {
+	bucket, object := parsePath(p)
+	attrs, err := s.gcs.GetAttrs(ctx, bucket, object)
+	if err != nil {
+		return nil, err
+	}
+	return objectInfo(p, attrs), nil
+}
+
+// NewReader implements common.ObjectSource.
+func (s *ObjectSource) NewReader(ctx context.Context, p string) (io.ReadCloser, error) {
+	bucket, object := parsePath(p)
+	return s.gcs.NewRangeReader(ctx, bucket, object, 0, -1)
+}
+
+// List implements common.ObjectSource.
+func (s *ObjectSource) List(ctx context.Context, p string, fn func(*common.ObjectInfo) error) error {
+	bucket, prefix := parsePath(p)
+	if prefix != "" {
+		prefix = strings.TrimSuffix(prefix, "/") + "/"
+	}
+	it := s.gcs.ListObjects(ctx, bucket, &storage.Query{Prefix: prefix, Delimiter: "/"})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if attrs.Prefix != "" {
+			dir := common.GCSScheme + bucket + "/" + strings.TrimSuffix(attrs.Prefix, "/")
+			if err := fn(&common.ObjectInfo{Path: dir, Dir: true}); err != nil {
+				return err
+			}
+			continue
+		}
+		if attrs.Name == prefix {
+			// Skip the placeholder object some tools create for the directory itself.
+			continue
+		}
+		if err := fn(objectInfo(common.GCSScheme+bucket+"/"+attrs.Name, attrs)); err != nil {
+			return err
+		}
+	}
+}
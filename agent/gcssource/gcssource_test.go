@@ -0,0 +1,75 @@
+package gcssource
+
+import (
+	"context"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/mock/gomock"
+)
+
+func TestObjectSource(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	updated := time.Unix(2000, 0)
+	attrs := &storage.ObjectAttrs{Name: "data/a", Size: 10, Updated: updated, Metadata: map[string]string{common.MTIME_ATTR_NAME: "1000"}}
+	mockGCS.EXPECT().GetAttrs(gomock.Any(), "bucket", "data/a").Return(attrs, nil)
+	mockGCS.EXPECT().NewRangeReader(gomock.Any(), "bucket", "data/a", int64(0), int64(-1)).Return(ioutil.NopCloser(strings.NewReader("0123456789")), nil)
+	mockGCS.EXPECT().ListObjects(gomock.Any(), "bucket", &storage.Query{Prefix: "data/", Delimiter: "/"}).Return(gcloud.NewObjectIterator(
+		&storage.ObjectAttrs{Prefix: "data/nested/"},
+		&storage.ObjectAttrs{Name: "data/"}, // The directory placeholder object.
+		attrs,
+		&storage.ObjectAttrs{Name: "data/b", Size: 5, Updated: updated},
+	))
+
+	src, err := common.ObjectSourceFor("gs://bucket/data/a")
+	if src != nil || err != nil {
+		t.Fatalf("ObjectSourceFor() before Register got %v, %v, want nil, nil", src, err)
+	}
+	Register(mockGCS)
+	defer common.RegisterObjectSource(common.GCSScheme, nil)
+	if src, err = common.ObjectSourceFor("gs://bucket/data/a"); src == nil || err != nil {
+		t.Fatalf("ObjectSourceFor() got %v, %v, want the registered source", src, err)
+	}
+	ctx := context.Background()
+
+	// The mtime is the one the object was listed with.
+	info, err := src.Stat(ctx, "gs://bucket/data/a")
+	if err != nil {
+		t.Fatalf("Stat() got err: %v", err)
+	}
+	if want := (&common.ObjectInfo{Path: "gs://bucket/data/a", Bytes: 10, MTime: time.Unix(1000, 0)}); !reflect.DeepEqual(info, want) {
+		t.Errorf("Stat() = %+v, want %+v", info, want)
+	}
+
+	r, err := src.NewReader(ctx, "gs://bucket/data/a")
+	if err != nil {
+		t.Fatalf("NewReader() got err: %v", err)
+	}
+	if b, _ := ioutil.ReadAll(r); string(b) != "0123456789" {
+		t.Errorf("NewReader() read %q, want %q", b, "0123456789")
+	}
+
+	var got []*common.ObjectInfo
+	if err := src.List(ctx, "gs://bucket/data", func(info *common.ObjectInfo) error {
+		got = append(got, info)
+		return nil
+	}); err != nil {
+		t.Fatalf("List() got err: %v", err)
+	}
+	want := []*common.ObjectInfo{
+		{Path: "gs://bucket/data/nested", Dir: true},
+		{Path: "gs://bucket/data/a", Bytes: 10, MTime: time.Unix(1000, 0)},
+		{Path: "gs://bucket/data/b", Bytes: 5, MTime: updated},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %+v, want %+v", got, want)
+	}
+}
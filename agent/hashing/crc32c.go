@@ -0,0 +1,120 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package hashing holds the CRC32C logic shared by the agent's handlers, so
+// that CRC32Cs are always computed the same way GCS computes them.
+package hashing
+
+import (
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+var (
+	// Castagnoli is the CRC32C table used by GCS.
+	Castagnoli = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// NewCRC32C returns a hash.Hash32 computing the CRC32C.
+func NewCRC32C() hash.Hash32 {
+	return crc32.New(Castagnoli)
+}
+
+// CRC32C returns the CRC32C of data.
+func CRC32C(data []byte) uint32 {
+	return crc32.Checksum(data, Castagnoli)
+}
+
+// CRC32CUpdatingReader is an io.Reader that wraps another io.Reader and a
+// starting crc32c. This reader updates the crc32c as bytes are read.
+type CRC32CUpdatingReader struct {
+	reader io.Reader
+	curCRC *uint32
+}
+
+// NewCRC32CUpdatingReader returns a CRC32CUpdatingReader. 'currentCRC' is the
+// starting crc32c value for the reader, and will be updated as bytes are read.
+func NewCRC32CUpdatingReader(r io.Reader, currentCRC *uint32) io.Reader {
+	return &CRC32CUpdatingReader{reader: r, curCRC: currentCRC}
+}
+
+// Read implements the io.Reader interface.
+func (cr *CRC32CUpdatingReader) Read(buf []byte) (n int, err error) {
+	if n, err = cr.reader.Read(buf); err != nil {
+		return 0, err
+	}
+	*cr.curCRC = crc32.Update(*cr.curCRC, Castagnoli, buf[:n])
+	return n, nil
+}
+
+// CombineCRC32C returns the CRC32C of the concatenation of two byte streams,
+// given crc1 of the first stream and crc2 of the second stream of length len2.
+// This is a port of zlib's crc32_combine.
+func CombineCRC32C(crc1, crc2 uint32, len2 int64) uint32 {
+	if len2 <= 0 {
+		return crc1
+	}
+	even := make([]uint32, 32) // Even-power-of-two zeros operator.
+	odd := make([]uint32, 32)  // Odd-power-of-two zeros operator.
+
+	// Put the operator for one zero bit in odd.
+	odd[0] = crc32.Castagnoli
+	row := uint32(1)
+	for n := 1; n < 32; n++ {
+		odd[n] = row
+		row <<= 1
+	}
+	gf2MatrixSquare(even, odd) // Put the operator for two zero bits in even.
+	gf2MatrixSquare(odd, even) // Put the operator for four zero bits in odd.
+
+	// Apply len2 zeros to crc1. The first square puts the operator for one
+	// zero byte (eight zero bits) in even.
+	for {
+		gf2MatrixSquare(even, odd)
+		if len2&1 != 0 {
+			crc1 = gf2MatrixTimes(even, crc1)
+		}
+		len2 >>= 1
+		if len2 == 0 {
+			break
+		}
+		gf2MatrixSquare(odd, even)
+		if len2&1 != 0 {
+			crc1 = gf2MatrixTimes(odd, crc1)
+		}
+		len2 >>= 1
+		if len2 == 0 {
+			break
+		}
+	}
+	return crc1 ^ crc2
+}
+
+func gf2MatrixTimes(mat []uint32, vec uint32) uint32 {
+	var sum uint32
+	for i := 0; vec != 0; i, vec = i+1, vec>>1 {
+		if vec&1 != 0 {
+			sum ^= mat[i]
+		}
+	}
+	return sum
+}
+
+func gf2MatrixSquare(square, mat []uint32) {
+	for n := 0; n < 32; n++ {
+		square[n] = gf2MatrixTimes(mat, mat[n])
+	}
+}
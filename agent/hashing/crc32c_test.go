@@ -0,0 +1,94 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package hashing
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+const (
+	testContent = "Ephemeral test file content for copy_test.go."
+	testCRC32C  = 3923584507 // CRC32C of testContent.
+)
+
+func TestCRC32CUpdatingReader(t *testing.T) {
+	tests := []struct {
+		desc     string
+		input    string
+		startCRC int
+		want     int
+	}{
+		{"Empty", "", 1234, 1234},
+		{"Basic", "this is some data", 0, 1363046907},
+		{"Basic, non-zero start", "this is some data", 1234, 59782035},
+	}
+	for _, tc := range tests {
+		var r io.Reader = strings.NewReader(tc.input)
+		crc := uint32(tc.startCRC)
+		r = NewCRC32CUpdatingReader(r, &crc)
+
+		buf := make([]byte, 256)
+		var err error
+		for err == nil {
+			_, err = r.Read(buf)
+		}
+
+		if crc != uint32(tc.want) {
+			t.Errorf("%v: got crc %v, want %v", tc.desc, crc, tc.want)
+		}
+	}
+}
+
+func TestCRC32CRoundTrip(t *testing.T) {
+	data := []byte(testContent)
+	if got := CRC32C(data); got != testCRC32C {
+		t.Errorf("CRC32C(%q) = %d, want %d", testContent, got, testCRC32C)
+	}
+
+	h := NewCRC32C()
+	h.Write(data)
+	if got := h.Sum32(); got != testCRC32C {
+		t.Errorf("NewCRC32C() of %q got %d, want %d", testContent, got, testCRC32C)
+	}
+
+	// Reading one byte at a time, resuming from the CRC32C of a prefix, matches
+	// the CRC32C of the whole content.
+	for split := 0; split <= len(data); split++ {
+		crc := CRC32C(data[:split])
+		r := NewCRC32CUpdatingReader(iotest.OneByteReader(strings.NewReader(testContent[split:])), &crc)
+		if _, err := io.Copy(ioutil.Discard, r); err != nil {
+			t.Fatalf("io.Copy() got err: %v", err)
+		}
+		if crc != testCRC32C {
+			t.Errorf("reader resumed at %d got %d, want %d", split, crc, testCRC32C)
+		}
+	}
+}
+
+func TestCombineCRC32C(t *testing.T) {
+	data := []byte(testContent)
+	for split := 0; split <= len(data); split++ {
+		crc1 := CRC32C(data[:split])
+		crc2 := CRC32C(data[split:])
+		if got := CombineCRC32C(crc1, crc2, int64(len(data)-split)); got != testCRC32C {
+			t.Errorf("CombineCRC32C split at %d got %d, want %d", split, got, testCRC32C)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !s3
+// +build !s3
+
+package main
+
+// registerObjectSources does nothing, since the agent was built without the s3
+// build tag.
+func registerObjectSources() error {
+	return nil
+}
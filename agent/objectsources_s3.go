@@ -0,0 +1,13 @@
+//go:build s3
+// +build s3
+
+package main
+
+import (
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/s3source"
+)
+
+// registerObjectSources registers the sources of s3:// paths.
+func registerObjectSources() error {
+	return s3source.Register()
+}
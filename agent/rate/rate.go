@@ -16,24 +16,38 @@ limitations under the License.
 package rate
 
 import (
+	"flag"
 	"io"
 	"math"
 	"sync"
 	"time"
 
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/stats"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 
 	"golang.org/x/time/rate"
 
 	controlpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/control_go_proto"
+	pulsepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/pulse_go_proto"
 )
 
 var (
-	mu       sync.RWMutex     // Protects jobRunBW and projectBWLimiter.
-	jobRunBW map[string]int64 // JobrunRelRsrcName to bandwidth mapping.
+	maxBytesPerSecPerFile = flag.Int64("max-bytes-per-sec-per-file", 0, "The maximum bandwidth (bytes per second) used to copy a single file, in addition to the job run bandwidth limit. If 0, copies of a single file are only limited by the job run bandwidth.")
+	agentMaxBytesPerSec   = flag.Int64("agent-max-bytes-per-sec", 0, "The maximum bandwidth (bytes per second) used by this agent across all job runs, in addition to the job run bandwidth limit. If 0, the agent is only limited by the job run bandwidth. Control messages may override it.")
+
+	mu            sync.RWMutex             // Protects the job run maps, projectBWLimiter, and the agent and schedule bandwidth caps.
+	jobRunBW      map[string]int64         // JobrunRelRsrcName to bandwidth mapping.
+	jobRunWorkDur map[string]time.Duration // JobrunRelRsrcName to copy work duration, for job runs that set one.
+	pausedJobRuns = map[string]bool{}      // JobrunRelRsrcNames paused by control messages.
 
 	// Project-wide bandwidth limiter.
 	projectBWLimiter = rate.NewLimiter(rate.Limit(math.MaxInt64), math.MaxInt32)
+
+	// Agent-wide bandwidth cap, and its limiter. The limiter is nil if there's no cap.
+	agentBW        int64
+	agentBWLimiter *rate.Limiter
+	agentBWOnce    sync.Once // Initializes the cap from the flag.
 )
 
 // ProcessJobRunBandwidths updates the jobRunBW mapping and projectBWLimiter given the values
@@ -47,14 +61,24 @@ func ProcessJobRunBandwidths(jobBWs []*controlpb.JobRunBandwidth, st *stats.Trac
 	// total project BW over the active job runs. Here we aggregate it again to control
 	// the BW on a project level.
 	jrBW := make(map[string]int64)
+	jrWorkDur := make(map[string]time.Duration)
 	var projectBW int64
+	var jobRuns []string
 	for _, jobBW := range jobBWs {
+		jobRuns = append(jobRuns, jobBW.JobrunRelRsrcName)
 		jrBW[jobBW.JobrunRelRsrcName] = jobBW.Bandwidth
+		if jobBW.CopyWorkDurationMs > 0 {
+			jrWorkDur[jobBW.JobrunRelRsrcName] = time.Duration(jobBW.CopyWorkDurationMs) * time.Millisecond
+		}
 		projectBW += jobBW.Bandwidth
 	}
+	// The job runs listed are the active ones, the stats of the others are no
+	// longer needed.
+	st.RecordActiveJobRuns(jobRuns)
 	mu.Lock()
 	defer mu.Unlock()
 	jobRunBW = jrBW
+	jobRunWorkDur = jrWorkDur
 	if diff := math.Abs(float64(projectBW) - float64(projectBWLimiter.Limit())); diff > 0.0000001 {
 		burst := math.MaxInt32
 		if projectBW < int64(burst) {
@@ -67,31 +91,164 @@ func ProcessJobRunBandwidths(jobBWs []*controlpb.JobRunBandwidth, st *stats.Trac
 	}
 }
 
-// IsJobRunActive returns a bool indicating if a job run is active (paused).
+// CopyWorkDuration returns the copy work duration set for the job run by the
+// control messages, or def if the job run doesn't set one.
+func CopyWorkDuration(jobrunRelRsrcName string, def time.Duration) time.Duration {
+	mu.RLock()
+	defer mu.RUnlock()
+	if d, ok := jobRunWorkDur[jobrunRelRsrcName]; ok {
+		return d
+	}
+	return def
+}
+
+// initAgentBandwidth sets the agent-wide bandwidth cap from the
+// agent-max-bytes-per-sec flag, the first time it's called.
+func initAgentBandwidth() {
+	agentBWOnce.Do(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		setAgentBandwidth(*agentMaxBytesPerSec)
+	})
+}
+
+// setAgentBandwidth sets the agent-wide bandwidth cap, a non-positive bw
+// removes the cap. Must be called holding mu.
+func setAgentBandwidth(bw int64) {
+	if bw < 0 {
+		bw = 0
+	}
+	if bw == agentBW {
+		return
+	}
+	glog.Infof("Agent bandwidth cap set to %v bytes/s (0 means no cap), was %v", bw, agentBW)
+	agentBW = bw
+	agentBWLimiter = nil
+	if bw > 0 {
+		agentBWLimiter = newLimiter(bw)
+	}
+}
+
+// newLimiter returns a limiter for bw bytes per second, whose burst is at most
+// one second of data.
+func newLimiter(bw int64) *rate.Limiter {
+	burst := math.MaxInt32
+	if bw < int64(burst) {
+		burst = int(bw)
+	}
+	return rate.NewLimiter(rate.Limit(bw), burst)
+}
+
+// ProcessAgentBandwidths sets the agent-wide bandwidth cap to the one requested
+// for agentID in the control message. If no cap is requested for agentID the
+// agent-max-bytes-per-sec flag value is restored.
+func ProcessAgentBandwidths(agentBWs []*controlpb.AgentBandwidth, agentID *pulsepb.AgentId) {
+	initAgentBandwidth()
+	bw := *agentMaxBytesPerSec
+	for _, abw := range agentBWs {
+		for _, id := range abw.GetAgentIds() {
+			if proto.Equal(id, agentID) {
+				bw = abw.GetMaxBytesPerSec()
+			}
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	setAgentBandwidth(bw)
+}
+
+// ProcessJobRunPauses pauses and resumes the job runs listed in the control
+// message. Job runs that aren't listed keep their paused state.
+func ProcessJobRunPauses(pauses []*controlpb.JobRunPause) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, p := range pauses {
+		if p.Paused == pausedJobRuns[p.JobrunRelRsrcName] {
+			continue
+		}
+		if p.Paused {
+			glog.Infof("Pausing job run %v", p.JobrunRelRsrcName)
+			pausedJobRuns[p.JobrunRelRsrcName] = true
+		} else {
+			glog.Infof("Resuming job run %v", p.JobrunRelRsrcName)
+			delete(pausedJobRuns, p.JobrunRelRsrcName)
+		}
+	}
+}
+
+// IsJobRunActive returns a bool indicating if a job run is active (not paused).
+// A job run is paused if it has no bandwidth, or if it was paused by a
+// control message.
 func IsJobRunActive(jobrunRelRsrcName string) bool {
 	mu.RLock()
 	defer mu.RUnlock()
-	return jobRunBW[jobrunRelRsrcName] != 0
+	return jobRunBW[jobrunRelRsrcName] != 0 && !pausedJobRuns[jobrunRelRsrcName]
+}
+
+// FileLimiter enforces the per-file bandwidth limit. A new FileLimiter should
+// be used for every file, and shared by all readers of that file.
+type FileLimiter struct {
+	limiter *rate.Limiter
+}
+
+// NewFileLimiter returns a FileLimiter, or nil if there is no per-file limit.
+func NewFileLimiter() *FileLimiter {
+	bw := *maxBytesPerSecPerFile
+	if bw <= 0 {
+		return nil
+	}
+	burst := math.MaxInt32
+	if bw < int64(burst) {
+		burst = int(bw)
+	}
+	lim := rate.NewLimiter(rate.Limit(bw), burst)
+	// Drain the initial burst, so a file never exceeds the limit.
+	lim.AllowN(time.Now(), burst)
+	return &FileLimiter{limiter: lim}
 }
 
 // RateLimitingReader is an io.Reader that wraps another io.Reader and
 // enforces rate limiting during the Read function.
 type RateLimitingReader struct {
-	reader io.Reader
+	reader      io.Reader
+	fileLimiter *FileLimiter // Optional, enforces the per-file bandwidth limit.
 }
 
-// NewRateLimitingReader returns a RateLimitingReader.
+// NewRateLimitingReader returns a reader limited by the project bandwidth, the
+// agent bandwidth cap and a fresh per-file limiter.
 func NewRateLimitingReader(r io.Reader) io.Reader {
-	return &RateLimitingReader{reader: r}
+	return NewFileRateLimitingReader(r, NewFileLimiter())
+}
+
+// NewFileRateLimitingReader returns a reader limited by the project bandwidth,
+// the agent bandwidth cap and fileLimiter, which may be nil. The effective rate
+// is the minimum of the limits.
+func NewFileRateLimitingReader(r io.Reader, fileLimiter *FileLimiter) io.Reader {
+	return &RateLimitingReader{reader: r, fileLimiter: fileLimiter}
 }
 
 // Read implements the io.Reader interface.
 func (rlr *RateLimitingReader) Read(buf []byte) (n int, err error) {
 	// Shrink the read buf if necessary. This ensures the read doesn't just
 	// block for one massive copy, and instead hands out data every second.
+	initAgentBandwidth()
+	applyBandwidthSchedule(time.Now())
 	mu.RLock()
 	lim := int(projectBWLimiter.Limit())
+	for _, l := range []*rate.Limiter{agentBWLimiter, scheduleBWLimiter} {
+		if l == nil {
+			continue
+		}
+		if capLim := l.Burst(); lim <= 0 || capLim < lim {
+			lim = capLim
+		}
+	}
 	mu.RUnlock()
+	if rlr.fileLimiter != nil {
+		if fileLim := rlr.fileLimiter.limiter.Burst(); lim <= 0 || fileLim < lim {
+			lim = fileLim
+		}
+	}
 	if 0 < lim && lim < len(buf) {
 		buf = buf[0:lim]
 	}
@@ -101,13 +258,33 @@ func (rlr *RateLimitingReader) Read(buf []byte) (n int, err error) {
 		return 0, err
 	}
 
-	// Enforce the rate limit.
+	// Enforce the rate limits. All reservations are made at the same time, so
+	// waiting for the longest delay satisfies all limiters.
+	now := time.Now()
+	var delay time.Duration
 	mu.RLock()
-	r := projectBWLimiter.ReserveN(time.Now(), n)
+	r := projectBWLimiter.ReserveN(now, n)
+	var capReservations []*rate.Reservation
+	for _, l := range []*rate.Limiter{agentBWLimiter, scheduleBWLimiter} {
+		if l != nil {
+			capReservations = append(capReservations, l.ReserveN(now, n))
+		}
+	}
 	mu.RUnlock()
 	if r.OK() {
-		time.Sleep(r.Delay())
+		delay = r.DelayFrom(now)
+	}
+	for _, cr := range capReservations {
+		if cr.OK() && cr.DelayFrom(now) > delay {
+			delay = cr.DelayFrom(now)
+		}
+	}
+	if rlr.fileLimiter != nil {
+		if fr := rlr.fileLimiter.limiter.ReserveN(now, n); fr.OK() && fr.DelayFrom(now) > delay {
+			delay = fr.DelayFrom(now)
+		}
 	}
+	time.Sleep(delay)
 
 	return n, nil
 }
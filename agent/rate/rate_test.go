@@ -27,6 +27,7 @@ import (
 	"golang.org/x/time/rate"
 
 	controlpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/control_go_proto"
+	pulsepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/pulse_go_proto"
 )
 
 func TestProcessJobRunBandwidths(t *testing.T) {
@@ -81,6 +82,53 @@ func TestProcessJobRunBandwidths(t *testing.T) {
 	}
 }
 
+func TestCopyWorkDuration(t *testing.T) {
+	ProcessJobRunBandwidths([]*controlpb.JobRunBandwidth{
+		{JobrunRelRsrcName: "job-1", Bandwidth: 10, CopyWorkDurationMs: 1500},
+		{JobrunRelRsrcName: "job-2", Bandwidth: 20},
+	}, nil)
+	tests := []struct {
+		jobRun string
+		want   time.Duration
+	}{
+		{"job-1", 1500 * time.Millisecond},
+		{"job-2", time.Minute},
+		{"job-3", time.Minute},
+	}
+	for _, tc := range tests {
+		if got := CopyWorkDuration(tc.jobRun, time.Minute); got != tc.want {
+			t.Errorf("CopyWorkDuration(%q) = %v, want: %v", tc.jobRun, got, tc.want)
+		}
+	}
+}
+
+func TestProcessJobRunPauses(t *testing.T) {
+	ProcessJobRunBandwidths([]*controlpb.JobRunBandwidth{
+		{JobrunRelRsrcName: "job-1", Bandwidth: 10},
+		{JobrunRelRsrcName: "job-2", Bandwidth: 20},
+	}, nil)
+	defer ProcessJobRunPauses([]*controlpb.JobRunPause{{JobrunRelRsrcName: "job-1"}, {JobrunRelRsrcName: "job-2"}})
+	tests := []struct {
+		desc       string
+		pauses     []*controlpb.JobRunPause
+		wantActive map[string]bool
+	}{
+		{"none", nil, map[string]bool{"job-1": true, "job-2": true, "job-3": false}},
+		{"pause one", []*controlpb.JobRunPause{{JobrunRelRsrcName: "job-1", Paused: true}}, map[string]bool{"job-1": false, "job-2": true}},
+		{"not listed keeps state", nil, map[string]bool{"job-1": false, "job-2": true}},
+		{"pause unknown", []*controlpb.JobRunPause{{JobrunRelRsrcName: "job-3", Paused: true}}, map[string]bool{"job-1": false, "job-3": false}},
+		{"resume", []*controlpb.JobRunPause{{JobrunRelRsrcName: "job-1"}, {JobrunRelRsrcName: "job-3"}}, map[string]bool{"job-1": true, "job-2": true, "job-3": false}},
+	}
+	for _, tc := range tests {
+		ProcessJobRunPauses(tc.pauses)
+		for jr, want := range tc.wantActive {
+			if got := IsJobRunActive(jr); got != want {
+				t.Errorf("ProcessJobRunPauses(%q): IsJobRunActive(%q) = %v, want: %v", tc.desc, jr, got, want)
+			}
+		}
+	}
+}
+
 func TestRateLimitingReaderReadNoBufferResize(t *testing.T) {
 	readBuf := make([]byte, 1000)
 	reader := bytes.NewReader(readBuf)
@@ -141,3 +189,132 @@ func TestRateLimitingReaderReadBufferResize(t *testing.T) {
 		t.Errorf("total time want >=1s, got %v", totalTime)
 	}
 }
+
+func TestNewFileLimiter(t *testing.T) {
+	defer func(bw int64) { *maxBytesPerSecPerFile = bw }(*maxBytesPerSecPerFile)
+
+	*maxBytesPerSecPerFile = 0
+	if fl := NewFileLimiter(); fl != nil {
+		t.Errorf("NewFileLimiter() = %v, want nil", fl)
+	}
+	*maxBytesPerSecPerFile = 1000
+	fl := NewFileLimiter()
+	if fl == nil {
+		t.Fatal("NewFileLimiter() = nil, want a limiter")
+	}
+	if got, want := fl.limiter.Limit(), rate.Limit(1000); got != want {
+		t.Errorf("NewFileLimiter() Limit() = %v, want: %v", got, want)
+	}
+}
+
+func TestRateLimitingReaderReadPerFileLimit(t *testing.T) {
+	defer func(bw int64) { *maxBytesPerSecPerFile = bw }(*maxBytesPerSecPerFile)
+	*maxBytesPerSecPerFile = 1000 // One byte per millisecond.
+	projectBWLimiter = rate.NewLimiter(rate.Limit(math.MaxInt64), math.MaxInt32)
+
+	readBuf := make([]byte, 2000)
+	reader := bytes.NewReader(readBuf)
+	r := NewRateLimitingReader(reader)
+	writeBuf := make([]byte, 2000)
+
+	start := time.Now()
+	// The per-file limit shrinks the read to 1000 bytes, which take ~1s.
+	n, err := r.Read(writeBuf)
+	if err != nil {
+		t.Error("Read got err:", err)
+	}
+	if n != 1000 {
+		t.Errorf("want Read 1000 bytes, got %d", n)
+	}
+	totalTime := time.Since(start)
+	if totalTime < 1*time.Second {
+		t.Errorf("total time want >=1s, got %v", totalTime)
+	}
+}
+
+func TestRateLimitingReaderReadMinOfLimits(t *testing.T) {
+	defer func(bw int64) { *maxBytesPerSecPerFile = bw }(*maxBytesPerSecPerFile)
+	*maxBytesPerSecPerFile = 1000000                                    // Much higher than the project limit.
+	projectBWLimiter = rate.NewLimiter(rate.Limit(1000), math.MaxInt32) // One byte per millisecond.
+	// Drain the limiter, so we can get accurate timing.
+	projectBWLimiter.WaitN(context.Background(), math.MaxInt32)
+
+	reader := bytes.NewReader(make([]byte, 1000))
+	r := NewRateLimitingReader(reader)
+	writeBuf := make([]byte, 10)
+
+	start := time.Now()
+	// The project limit is the lower one, so 10 bytes take ~10ms.
+	if _, err := r.Read(writeBuf); err != nil {
+		t.Error("Read got err:", err)
+	}
+	if totalTime := time.Since(start); totalTime < 9*time.Millisecond {
+		t.Errorf("total time want >=9ms, got %v", totalTime)
+	}
+}
+
+func TestProcessAgentBandwidths(t *testing.T) {
+	defer func(bw int64) { *agentMaxBytesPerSec = bw }(*agentMaxBytesPerSec)
+	*agentMaxBytesPerSec = 100
+	defer func() {
+		mu.Lock()
+		setAgentBandwidth(0)
+		mu.Unlock()
+	}()
+
+	agentID := &pulsepb.AgentId{HostName: "host", ProcessId: "123"}
+	otherID := &pulsepb.AgentId{HostName: "host", ProcessId: "456"}
+	tests := []struct {
+		desc      string
+		agentBWs  []*controlpb.AgentBandwidth
+		wantBW    int64
+		wantLimit rate.Limit // Zero for no limiter.
+	}{
+		{"Flag", nil, 100, rate.Limit(100)},
+		{"Other agent", []*controlpb.AgentBandwidth{{AgentIds: []*pulsepb.AgentId{otherID}, MaxBytesPerSec: 10}}, 100, rate.Limit(100)},
+		{"This agent", []*controlpb.AgentBandwidth{{AgentIds: []*pulsepb.AgentId{otherID, agentID}, MaxBytesPerSec: 10}}, 10, rate.Limit(10)},
+		{"No cap", []*controlpb.AgentBandwidth{{AgentIds: []*pulsepb.AgentId{agentID}, MaxBytesPerSec: 0}}, 0, 0},
+		{"Restored flag", nil, 100, rate.Limit(100)},
+	}
+	for _, tc := range tests {
+		ProcessAgentBandwidths(tc.agentBWs, agentID)
+		if agentBW != tc.wantBW {
+			t.Errorf("ProcessAgentBandwidths(%q): agentBW = %v, want: %v", tc.desc, agentBW, tc.wantBW)
+		}
+		var gotLimit rate.Limit
+		if agentBWLimiter != nil {
+			gotLimit = agentBWLimiter.Limit()
+		}
+		if gotLimit != tc.wantLimit {
+			t.Errorf("ProcessAgentBandwidths(%q): Limit() = %v, want: %v", tc.desc, gotLimit, tc.wantLimit)
+		}
+	}
+}
+
+func TestRateLimitingReaderReadAgentLimit(t *testing.T) {
+	projectBWLimiter = rate.NewLimiter(rate.Limit(math.MaxInt64), math.MaxInt32)
+	initAgentBandwidth()
+	mu.Lock()
+	setAgentBandwidth(1000) // One byte per millisecond.
+	// Drain the limiter, so we can get accurate timing.
+	agentBWLimiter.AllowN(time.Now(), 1000)
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		setAgentBandwidth(0)
+		mu.Unlock()
+	}()
+
+	reader := bytes.NewReader(make([]byte, 1000))
+	r := NewRateLimitingReader(reader)
+	writeBuf := make([]byte, 10)
+
+	start := time.Now()
+	// The agent limit is the lower one, so 10 bytes take ~10ms.
+	if _, err := r.Read(writeBuf); err != nil {
+		t.Error("Read got err:", err)
+	}
+	if totalTime := time.Since(start); totalTime < 9*time.Millisecond {
+		t.Errorf("total time want >=9ms, got %v", totalTime)
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rate
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"golang.org/x/time/rate"
+)
+
+var (
+	schedule bandwidthSchedule
+
+	// The bandwidth cap of the current schedule window, and its limiter.
+	// Protected by mu. The limiter is nil outside of the windows.
+	scheduleBW        int64
+	scheduleBWLimiter *rate.Limiter
+)
+
+func init() {
+	flag.Var(&schedule, "bandwidth-schedule", "Comma separated time-of-day windows capping the agent bandwidth, in the agent's local time, for example \"09:00-17:00=100000000\" caps the bandwidth to 100MB/s during business hours. A window may span midnight, for example \"22:00-06:00=50000000\". Outside the windows the bandwidth isn't capped by the schedule. The cap applies in addition to the other bandwidth limits.")
+}
+
+// bandwidthWindow caps the bandwidth between the start and end times of day.
+type bandwidthWindow struct {
+	start, end time.Duration // Since midnight.
+	bw         int64         // Bytes per second.
+}
+
+func (w bandwidthWindow) String() string {
+	hm := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+	}
+	return fmt.Sprintf("%s-%s=%d", hm(w.start), hm(w.end), w.bw)
+}
+
+// contains returns true if the time of day d falls in the window.
+func (w bandwidthWindow) contains(d time.Duration) bool {
+	if w.start < w.end {
+		return w.start <= d && d < w.end
+	}
+	// The window spans midnight.
+	return d >= w.start || d < w.end
+}
+
+// bandwidthSchedule is a flag.Value holding the bandwidth-schedule windows.
+type bandwidthSchedule []bandwidthWindow
+
+// String implements the flag.Value interface.
+func (s *bandwidthSchedule) String() string {
+	var windows []string
+	for _, w := range *s {
+		windows = append(windows, w.String())
+	}
+	return strings.Join(windows, ",")
+}
+
+// Set implements the flag.Value interface. The flag may be repeated, its
+// windows are added to the schedule.
+func (s *bandwidthSchedule) Set(value string) error {
+	for _, window := range strings.Split(value, ",") {
+		w, err := parseBandwidthWindow(strings.TrimSpace(window))
+		if err != nil {
+			return err
+		}
+		*s = append(*s, w)
+	}
+	return nil
+}
+
+// parseBandwidthWindow parses a window formatted as HH:MM-HH:MM=bytesPerSec.
+func parseBandwidthWindow(window string) (bandwidthWindow, error) {
+	var w bandwidthWindow
+	parts := strings.SplitN(window, "=", 2)
+	if len(parts) != 2 {
+		return w, fmt.Errorf("invalid bandwidth window %q, want HH:MM-HH:MM=bytesPerSec", window)
+	}
+	times := strings.Split(parts[0], "-")
+	if len(times) != 2 {
+		return w, fmt.Errorf("invalid bandwidth window %q, want HH:MM-HH:MM=bytesPerSec", window)
+	}
+	var err error
+	if w.bw, err = strconv.ParseInt(parts[1], 10, 64); err != nil || w.bw <= 0 {
+		return w, fmt.Errorf("invalid bandwidth in window %q, want a positive number of bytes per second", window)
+	}
+	for i, p := range []*time.Duration{&w.start, &w.end} {
+		t, err := time.Parse("15:04", times[i])
+		if err != nil {
+			return w, fmt.Errorf("invalid time of day in window %q: %v", window, err)
+		}
+		*p = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	if w.start == w.end {
+		return w, fmt.Errorf("invalid bandwidth window %q, the start and end times are the same", window)
+	}
+	return w, nil
+}
+
+// bandwidthAt returns the bandwidth cap at time t, or 0 if t isn't in any of
+// the windows. If the windows overlap the first one applies.
+func (s bandwidthSchedule) bandwidthAt(t time.Time) int64 {
+	d := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	for _, w := range s {
+		if w.contains(d) {
+			return w.bw
+		}
+	}
+	return 0
+}
+
+// applyBandwidthSchedule updates the schedule's bandwidth cap for time now. It's
+// called on every read, so running copies adjust when a window starts or ends.
+func applyBandwidthSchedule(now time.Time) {
+	if len(schedule) == 0 {
+		return
+	}
+	bw := schedule.bandwidthAt(now)
+	mu.RLock()
+	unchanged := bw == scheduleBW
+	mu.RUnlock()
+	if unchanged {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if bw == scheduleBW {
+		return // Another reader got here first.
+	}
+	glog.Infof("Bandwidth schedule cap set to %v bytes/s (0 means no cap), was %v", bw, scheduleBW)
+	scheduleBW = bw
+	scheduleBWLimiter = nil
+	if bw > 0 {
+		scheduleBWLimiter = newLimiter(bw)
+	}
+}
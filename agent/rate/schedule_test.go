@@ -0,0 +1,139 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rate
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestBandwidthScheduleSet(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"09:00-17:00=100", "09:00-17:00=100", false},
+		{"09:00-17:00=100, 22:00-06:00=50", "09:00-17:00=100,22:00-06:00=50", false},
+		{"9:00-17:00=100", "09:00-17:00=100", false},
+		{"09:00-17:00", "", true},
+		{"09:00=100", "", true},
+		{"09:00-25:00=100", "", true},
+		{"09:00-17:00=0", "", true},
+		{"09:00-17:00=abc", "", true},
+		{"09:00-09:00=100", "", true},
+	}
+	for _, tc := range tests {
+		var s bandwidthSchedule
+		err := s.Set(tc.value)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("Set(%q) got err: %v, want err: %v", tc.value, err, tc.wantErr)
+			continue
+		}
+		if got := s.String(); !tc.wantErr && got != tc.want {
+			t.Errorf("Set(%q) String() = %q, want %q", tc.value, got, tc.want)
+		}
+	}
+}
+
+func TestBandwidthAt(t *testing.T) {
+	var s bandwidthSchedule
+	if err := s.Set("09:00-17:00=100,22:00-06:00=50,12:00-13:00=10"); err != nil {
+		t.Fatalf("Set got err: %v", err)
+	}
+	tests := []struct {
+		hour, min int
+		want      int64
+	}{
+		{8, 59, 0},
+		{9, 0, 100},
+		{12, 30, 100}, // The first matching window applies.
+		{16, 59, 100},
+		{17, 0, 0},
+		{23, 0, 50},
+		{0, 0, 50},
+		{5, 59, 50},
+		{6, 0, 0},
+	}
+	for _, tc := range tests {
+		at := time.Date(2019, 6, 1, tc.hour, tc.min, 0, 0, time.Local)
+		if got := s.bandwidthAt(at); got != tc.want {
+			t.Errorf("bandwidthAt(%02d:%02d) = %v, want %v", tc.hour, tc.min, got, tc.want)
+		}
+	}
+}
+
+func TestApplyBandwidthSchedule(t *testing.T) {
+	defer func(s bandwidthSchedule) { schedule = s }(schedule)
+	defer func() {
+		mu.Lock()
+		scheduleBW, scheduleBWLimiter = 0, nil
+		mu.Unlock()
+	}()
+	schedule = bandwidthSchedule{{start: 9 * time.Hour, end: 17 * time.Hour, bw: 1000}}
+
+	tests := []struct {
+		desc      string
+		hour      int
+		wantLimit rate.Limit // Zero for no limiter.
+	}{
+		{"Before the window", 8, 0},
+		{"In the window", 10, rate.Limit(1000)},
+		{"After the window", 18, 0},
+	}
+	for _, tc := range tests {
+		applyBandwidthSchedule(time.Date(2019, 6, 1, tc.hour, 0, 0, 0, time.Local))
+		var gotLimit rate.Limit
+		if scheduleBWLimiter != nil {
+			gotLimit = scheduleBWLimiter.Limit()
+		}
+		if gotLimit != tc.wantLimit {
+			t.Errorf("applyBandwidthSchedule(%q): Limit() = %v, want: %v", tc.desc, gotLimit, tc.wantLimit)
+		}
+	}
+}
+
+func TestRateLimitingReaderReadScheduleLimit(t *testing.T) {
+	defer func(s bandwidthSchedule) { schedule = s }(schedule)
+	defer func() {
+		mu.Lock()
+		scheduleBW, scheduleBWLimiter = 0, nil
+		mu.Unlock()
+	}()
+	projectBWLimiter = rate.NewLimiter(rate.Limit(math.MaxInt64), math.MaxInt32)
+	// A window covering the whole day, so the test doesn't depend on the time.
+	schedule = bandwidthSchedule{{start: 0, end: 24*time.Hour - time.Minute, bw: 1000}, {start: 24*time.Hour - time.Minute, end: 0, bw: 1000}}
+	applyBandwidthSchedule(time.Now())
+	// Drain the limiter, so we can get accurate timing.
+	scheduleBWLimiter.AllowN(time.Now(), 1000)
+
+	reader := bytes.NewReader(make([]byte, 1000))
+	r := NewRateLimitingReader(reader)
+	writeBuf := make([]byte, 10)
+
+	start := time.Now()
+	// The schedule limit is the lower one, so 10 bytes take ~10ms.
+	if _, err := r.Read(writeBuf); err != nil {
+		t.Error("Read got err:", err)
+	}
+	if totalTime := time.Since(start); totalTime < 9*time.Millisecond {
+		t.Errorf("total time want >=9ms, got %v", totalTime)
+	}
+}
@@ -0,0 +1,135 @@
+//go:build s3
+// +build s3
+
+// Package s3source reads objects from S3-compatible stores, for "s3://bucket/key"
+// source paths. It's only built with the s3 build tag, so that other builds of
+// the agent don't depend on the AWS SDK.
+package s3source
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+var (
+	endpoint       = flag.String("s3-endpoint", "", "The endpoint of the S3-compatible store that s3:// source paths are read from. If empty, AWS S3 is used.")
+	region         = flag.String("s3-region", "us-east-1", "The region of the S3-compatible store that s3:// source paths are read from.")
+	forcePathStyle = flag.Bool("s3-force-path-style", false, "Address buckets of the S3-compatible store as path components rather than host names, which many S3-compatible stores require.")
+)
+
+// Client is the subset of the S3 API used by the ObjectSource.
+type Client interface {
+	HeadObjectWithContext(ctx aws.Context, input *s3.HeadObjectInput, opts ...request.Option) (*s3.HeadObjectOutput, error)
+	GetObjectWithContext(ctx aws.Context, input *s3.GetObjectInput, opts ...request.Option) (*s3.GetObjectOutput, error)
+	ListObjectsV2PagesWithContext(ctx aws.Context, input *s3.ListObjectsV2Input, fn func(*s3.ListObjectsV2Output, bool) bool, opts ...request.Option) error
+}
+
+// ObjectSource is a common.ObjectSource of "s3://bucket/key" paths.
+type ObjectSource struct {
+	client Client
+}
+
+// NewObjectSource returns an ObjectSource reading objects with the given client.
+func NewObjectSource(client Client) *ObjectSource {
+	return &ObjectSource{client: client}
+}
+
+// Register registers an ObjectSource for s3:// paths, using the credentials
+// found in the environment and the s3-* flags. It must be called after the
+// flags are parsed.
+func Register() error {
+	cfg := aws.NewConfig().WithRegion(*region).WithS3ForcePathStyle(*forcePathStyle)
+	if *endpoint != "" {
+		cfg = cfg.WithEndpoint(*endpoint)
+	}
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return fmt.Errorf("couldn't create S3 session: %v", err)
+	}
+	common.RegisterObjectSource(common.S3Scheme, NewObjectSource(s3.New(sess)))
+	return nil
+}
+
+// parsePath splits an "s3://bucket/key" path into its bucket and key.
+func parsePath(p string) (bucket, key string) {
+	parts := strings.SplitN(strings.TrimPrefix(p, common.S3Scheme), "/", 2)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return parts[0], ""
+}
+
+// Stat implements common.ObjectSource.
+func (s *ObjectSource) Stat(ctx context.Context, p string) (*common.ObjectInfo, error) {
+	bucket, key := parsePath(p)
+	out, err := s.client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if err != nil {
+		return nil, err
+	}
+	return &common.ObjectInfo{
+		Path:  p,
+		Bytes: aws.Int64Value(out.ContentLength),
+		MTime: aws.TimeValue(out.LastModified),
+	}, nil
+}
+
+// NewReader implements common.ObjectSource.
+func (s *ObjectSource) NewReader(ctx context.Context, p string) (io.ReadCloser, error) {
+	bucket, key := parsePath(p)
+	out, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+// List implements common.ObjectSource.
+func (s *ObjectSource) List(ctx context.Context, p string, fn func(*common.ObjectInfo) error) error {
+	bucket, prefix := parsePath(p)
+	if prefix != "" {
+		prefix = strings.TrimSuffix(prefix, "/") + "/"
+	}
+	input := &s3.ListObjectsV2Input{
+		Bucket:    aws.String(bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}
+	var fnErr error
+	err := s.client.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, cp := range page.CommonPrefixes {
+			dir := common.S3Scheme + bucket + "/" + strings.TrimSuffix(aws.StringValue(cp.Prefix), "/")
+			if fnErr = fn(&common.ObjectInfo{Path: dir, Dir: true}); fnErr != nil {
+				return false
+			}
+		}
+		for _, obj := range page.Contents {
+			key := aws.StringValue(obj.Key)
+			if key == prefix {
+				// Skip the placeholder object some tools create for the directory itself.
+				continue
+			}
+			info := &common.ObjectInfo{
+				Path:  common.S3Scheme + bucket + "/" + key,
+				Bytes: aws.Int64Value(obj.Size),
+				MTime: aws.TimeValue(obj.LastModified),
+			}
+			if fnErr = fn(info); fnErr != nil {
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	return fnErr
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	fileSizeBuckets = flag.String("file-size-buckets", "1KiB,16KiB,128KiB,1MiB,16MiB,128MiB,1GiB,16GiB", "Comma separated upper bounds of the file size histogram buckets, in increasing order. Sizes may use the KiB, MiB, GiB and TiB suffixes. Files at or above the last bound are counted in a final bucket.")
+)
+
+// HistogramBucket is a bucket of a SizeHistogram.
+type HistogramBucket struct {
+	Bucket string // For example "<1KiB", or ">=16GiB" for the last bucket.
+	Count  uint64
+}
+
+// SizeHistogram counts sizes in buckets with increasing upper bounds.
+type SizeHistogram struct {
+	bounds []int64
+	counts []uint64 // The last count is for sizes at or above the last bound.
+}
+
+// newSizeHistogram returns a SizeHistogram with the given bucket upper bounds.
+func newSizeHistogram(bounds []int64) *SizeHistogram {
+	return &SizeHistogram{bounds: bounds, counts: make([]uint64, len(bounds)+1)}
+}
+
+func (h *SizeHistogram) add(size int64) {
+	i := 0
+	for i < len(h.bounds) && size >= h.bounds[i] {
+		i++
+	}
+	h.counts[i]++
+}
+
+// Buckets returns the buckets of the histogram, in increasing size order.
+func (h *SizeHistogram) Buckets() []HistogramBucket {
+	var buckets []HistogramBucket
+	for i, c := range h.counts {
+		var b string
+		if i < len(h.bounds) {
+			b = "<" + sizeString(h.bounds[i])
+		} else {
+			b = ">=" + sizeString(h.bounds[len(h.bounds)-1])
+		}
+		buckets = append(buckets, HistogramBucket{Bucket: b, Count: c})
+	}
+	return buckets
+}
+
+func (h *SizeHistogram) String() string {
+	var s []string
+	for _, b := range h.Buckets() {
+		s = append(s, fmt.Sprintf("%v:%v", b.Bucket, b.Count))
+	}
+	return strings.Join(s, " ")
+}
+
+var sizeSuffixes = []struct {
+	suffix string
+	mult   int64
+}{
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+	{"B", 1},
+}
+
+// parseSizeBuckets parses a comma separated list of increasing sizes, such as
+// "1KiB,1MiB,512MiB".
+func parseSizeBuckets(s string) ([]int64, error) {
+	var bounds []int64
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		num, mult := f, int64(1)
+		for _, ss := range sizeSuffixes {
+			if strings.HasSuffix(f, ss.suffix) {
+				num, mult = strings.TrimSuffix(f, ss.suffix), ss.mult
+				break
+			}
+		}
+		n, err := strconv.ParseInt(num, 10, 64)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid size %q in file size buckets %q", f, s)
+		}
+		b := n * mult
+		if len(bounds) > 0 && b <= bounds[len(bounds)-1] {
+			return nil, fmt.Errorf("file size buckets %q are not in increasing order", s)
+		}
+		bounds = append(bounds, b)
+	}
+	return bounds, nil
+}
+
+// sizeString formats b using the largest binary suffix that divides it.
+func sizeString(b int64) string {
+	for _, ss := range sizeSuffixes {
+		if b%ss.mult == 0 {
+			return fmt.Sprintf("%d%s", b/ss.mult, ss.suffix)
+		}
+	}
+	return fmt.Sprintf("%dB", b)
+}
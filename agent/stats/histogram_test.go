@@ -0,0 +1,104 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/common"
+)
+
+func TestParseSizeBuckets(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    []int64
+		wantErr bool
+	}{
+		{"1KiB", []int64{1024}, false},
+		{"100,1KiB, 1MiB,2GiB,1TiB", []int64{100, 1 << 10, 1 << 20, 2 << 30, 1 << 40}, false},
+		{"10B,20B", []int64{10, 20}, false},
+		{"", nil, true},
+		{"1KB", nil, true},
+		{"0", nil, true},
+		{"-1KiB", nil, true},
+		{"1MiB,1KiB", nil, true},
+		{"1KiB,1024", nil, true},
+	}
+	for _, tc := range tests {
+		got, err := parseSizeBuckets(tc.s)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("parseSizeBuckets(%q) got err: %v, want err: %v", tc.s, err, tc.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("parseSizeBuckets(%q) = %v, want %v", tc.s, got, tc.want)
+		}
+	}
+}
+
+func TestSizeHistogram(t *testing.T) {
+	h := newSizeHistogram([]int64{1 << 10, 1 << 20, 1536 << 20})
+	for _, size := range []int64{0, 1, 1023, 1024, 1 << 20, 1 << 31, 1 << 40} {
+		h.add(size)
+	}
+	want := []HistogramBucket{
+		{"<1KiB", 3},
+		{"<1MiB", 1},
+		{"<1536MiB", 1},
+		{">=1536MiB", 2},
+	}
+	if got := h.Buckets(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Buckets() = %v, want %v", got, want)
+	}
+	if got, want := h.String(), "<1KiB:3 <1MiB:1 <1536MiB:1 >=1536MiB:2"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestTrackerRecordFileSize(t *testing.T) {
+	// Create an unused mock ticker to prevent accidental calls to selectDone.
+	unusedMockTicker := common.NewMockTicker()
+	accumulatorTickerMaker = func() common.Ticker { return unusedMockTicker }
+	displayTickerMaker = func() common.Ticker { return unusedMockTicker }
+	mockFileSizeLogTicker := common.NewMockTicker()
+	fileSizeLogTickerMaker = func() common.Ticker { return mockFileSizeLogTicker }
+
+	st := NewTracker(context.Background())
+	var wg sync.WaitGroup
+	st.selectDone = func() { wg.Done() } // The test hook.
+	wg.Add(4)
+	st.RecordFileSize(10)
+	st.RecordFileSize(20)
+	st.RecordFileSize(2 << 20)
+	mockFileSizeLogTicker.Tick() // Logging the histogram must not change it.
+	wg.Wait()                    // Force the Tracker to collect the recorded stats.
+
+	wg.Add(1) // For the snapshot request.
+	s, err := st.Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("Snapshot got err: %v", err)
+	}
+	counts := make(map[string]uint64)
+	for _, b := range s.FileSizes {
+		counts[b.Bucket] = b.Count
+	}
+	if counts["<1KiB"] != 2 || counts["<16MiB"] != 1 {
+		t.Errorf("Snapshot FileSizes = %v, want 2 in <1KiB and 1 in <16MiB", s.FileSizes)
+	}
+}
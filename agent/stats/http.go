@@ -0,0 +1,74 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const snapshotTimeout = 5 * time.Second
+
+// ServeHTTP implements the http.Handler interface, responding with the
+// current Snapshot encoded as JSON.
+func (t *Tracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), snapshotTimeout)
+	defer cancel()
+	s, err := t.Snapshot(ctx)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(s); err != nil {
+		glog.Warningf("Failed to write the stats response, err: %v", err)
+	}
+}
+
+// ServeStats serves the Tracker's stats as JSON at "/stats" on addr, until
+// ctx is cancelled.
+func (t *Tracker) ServeStats(ctx context.Context, addr string) {
+	Serve(ctx, addr, "/stats", t)
+}
+
+// ServePrometheus serves the Prometheus metrics at "/metrics" on addr, until
+// ctx is cancelled.
+func ServePrometheus(ctx context.Context, addr string) {
+	Serve(ctx, addr, "/metrics", promhttp.Handler())
+}
+
+// Serve serves h at pattern on addr, until ctx is cancelled.
+func Serve(ctx context.Context, addr, pattern string, h http.Handler) {
+	mux := http.NewServeMux()
+	mux.Handle(pattern, h)
+	srv := &http.Server{Addr: addr, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		glog.Errorf("HTTP server for %v on %v failed, err: %v", pattern, addr, err)
+	}
+}
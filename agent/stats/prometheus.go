@@ -0,0 +1,78 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const promNamespace = "cloud_ingest_agent"
+
+var (
+	// promPulseStats holds a counter for each PulseStats field, in field order.
+	promPulseStats = newPromPulseStatsCounters()
+
+	promTasksInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: promNamespace,
+		Name:      "tasks_in_flight",
+		Help:      "The number of tasks being processed, by task type.",
+	}, []string{"task"})
+	promCopiesInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: promNamespace,
+		Name:      "copies_in_flight",
+		Help:      "The number of files being copied.",
+	})
+)
+
+func init() {
+	for _, c := range promPulseStats {
+		prometheus.MustRegister(c)
+	}
+	prometheus.MustRegister(promTasksInFlight, promCopiesInFlight)
+}
+
+var camelCaseBoundary = regexp.MustCompile("([a-z0-9])([A-Z])")
+
+// newPromPulseStatsCounters returns a counter for each PulseStats field. The
+// counter names are the snake_case field names, for example CopyWriteMs is
+// exported as cloud_ingest_agent_copy_write_ms_total.
+func newPromPulseStatsCounters() []prometheus.Counter {
+	var counters []prometheus.Counter
+	psType := reflect.TypeOf(PulseStats{})
+	for i := 0; i < psType.NumField(); i++ {
+		field := psType.Field(i).Name
+		counters = append(counters, prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: promNamespace,
+			Name:      strings.ToLower(camelCaseBoundary.ReplaceAllString(field, "${1}_${2}")) + "_total",
+			Help:      "The agent's cumulative PulseStats " + field + ".",
+		}))
+	}
+	return counters
+}
+
+// recordPromPulseStats adds ps to the PulseStats counters.
+func recordPromPulseStats(ps *PulseStats) {
+	psv := reflect.ValueOf(ps).Elem()
+	for i, c := range promPulseStats {
+		if v := psv.Field(i).Int(); v > 0 {
+			c.Add(float64(v))
+		}
+	}
+}
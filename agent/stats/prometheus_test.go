@@ -0,0 +1,89 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package stats
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/common"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestPromPulseStatsCounterNames(t *testing.T) {
+	tests := []struct {
+		i    int
+		want string
+	}{
+		{0, "cloud_ingest_agent_copy_bytes_total"},
+		{6, "cloud_ingest_agent_copy_write_ms_total"},
+		{7, "cloud_ingest_agent_copy_internal_retries_total"},
+	}
+	for _, tc := range tests {
+		if got := promPulseStats[tc.i].Desc().String(); !strings.Contains(got, `"`+tc.want+`"`) {
+			t.Errorf("promPulseStats[%d] desc = %v, want name %v", tc.i, got, tc.want)
+		}
+	}
+}
+
+func TestTrackerRecordsPromMetrics(t *testing.T) {
+	// Create an unused mock ticker to prevent accidental calls to selectDone.
+	unusedMockTicker := common.NewMockTicker()
+	accumulatorTickerMaker = func() common.Ticker { return unusedMockTicker }
+	displayTickerMaker = func() common.Ticker { return unusedMockTicker }
+
+	st := NewTracker(context.Background())
+	var wg sync.WaitGroup
+	st.selectDone = func() { wg.Done() } // The test hook.
+
+	copyBytes := testutil.ToFloat64(promPulseStats[0])
+	copyWriteMs := testutil.ToFloat64(promPulseStats[6])
+	wg.Add(1)
+	st.RecordPulseStats("", &PulseStats{CopyBytes: 100, CopyWriteMs: 5})
+	wg.Wait() // Force the Tracker to collect the recorded stats.
+	if got, want := testutil.ToFloat64(promPulseStats[0])-copyBytes, float64(100); got != want {
+		t.Errorf("copy_bytes_total increased by %v, want %v", got, want)
+	}
+	if got, want := testutil.ToFloat64(promPulseStats[6])-copyWriteMs, float64(5); got != want {
+		t.Errorf("copy_write_ms_total increased by %v, want %v", got, want)
+	}
+
+	listTasks := promTasksInFlight.WithLabelValues("list")
+	req := &taskpb.TaskReqMsg{Spec: &taskpb.Spec{Spec: &taskpb.Spec_ListSpec{ListSpec: &taskpb.ListSpec{}}}}
+	st.RecordTaskStart(req)
+	if got := testutil.ToFloat64(listTasks); got != 1 {
+		t.Errorf("tasks_in_flight{task=list} = %v, want 1", got)
+	}
+	wg.Add(1)
+	st.RecordTaskResp(&taskpb.TaskRespMsg{ReqSpec: req.Spec})
+	wg.Wait()
+	if got := testutil.ToFloat64(listTasks); got != 0 {
+		t.Errorf("tasks_in_flight{task=list} = %v, want 0", got)
+	}
+
+	st.RecordCopyStart()
+	if got := testutil.ToFloat64(promCopiesInFlight); got != 1 {
+		t.Errorf("copies_in_flight = %v, want 1", got)
+	}
+	st.RecordCopyEnd()
+	if got := testutil.ToFloat64(promCopiesInFlight); got != 0 {
+		t.Errorf("copies_in_flight = %v, want 0", got)
+	}
+}
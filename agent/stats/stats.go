@@ -22,7 +22,9 @@ import (
 	"math"
 	"reflect"
 	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/common"
@@ -35,6 +37,7 @@ import (
 const (
 	statsDisplayFreq = 1 * time.Second // The frequency of displaying stats to stdout.
 	accumulatorFreq  = 1 * time.Second // The frequency of accumulating bytes copied.
+	fileSizeLogFreq  = 1 * time.Minute // The frequency of logging the file size histogram and file system throughputs.
 )
 
 var (
@@ -44,6 +47,9 @@ var (
 	accumulatorTickerMaker = func() common.Ticker {
 		return common.NewClockTicker(accumulatorFreq)
 	}
+	fileSizeLogTickerMaker = func() common.Ticker {
+		return common.NewClockTicker(fileSizeLogFreq)
+	}
 )
 
 type taskDur struct {
@@ -51,12 +57,21 @@ type taskDur struct {
 	dur  time.Duration
 }
 
+// jobRunPulseStats are PulseStats recorded for a job run, identified by its
+// relative resource name. The job run may be empty if it's unknown.
+type jobRunPulseStats struct {
+	jobRun string
+	ps     *PulseStats
+}
+
 type lifetimeStats struct {
 	PulseStats // Embedded struct.
 
 	taskDone    map[string]uint64
 	ctrlMsgTime time.Time
 	bwLimit     int64
+	fileSizes   *SizeHistogram
+	fsTypes     map[string]*FsTypeStats
 }
 
 // PulseStats contains stats which are sent with each Agent pulse message.
@@ -74,6 +89,11 @@ type PulseStats struct {
 	ListDirReadMs         int64
 	ListFileWriteMs       int64
 	ListDirWriteMs        int64
+	TaskHeldMs            int64
+	CopyReadOverlapMs     int64
+	CopyDedupHits         int64
+	CopyDedupBytes        int64
+	CopyResentBytes       int64
 }
 
 func (ps1 *PulseStats) add(ps2 *PulseStats) {
@@ -95,9 +115,18 @@ func (ps1 *PulseStats) sub(ps2 *PulseStats) {
 // Tracker collects stats about the Agent and provides a display to STDOUT.
 // Stats are collected by calling the various Record* functions as appropriate.
 type Tracker struct {
-	taskDoneChan chan string    // Channel to record task completions.
-	bwLimitChan  chan int64     // Channel to record the bandwidth limit.
-	ctrlMsgChan  chan time.Time // Channel to record control message timing.
+	// Accessed atomically, so they're the first fields to ensure 64-bit alignment.
+	concurrentCopies  int64
+	copyBytes         int64 // Bytes copied since the Agent started.
+	copyTasksInFlight int64
+	listTasksInFlight int64
+
+	taskDoneChan chan string         // Channel to record task completions.
+	bwLimitChan  chan int64          // Channel to record the bandwidth limit.
+	ctrlMsgChan  chan time.Time      // Channel to record control message timing.
+	fileSizeChan chan int64          // Channel to record the sizes of copied files.
+	fsTypeChan   chan fsTypeCopy     // Channel to record copies by source file system type.
+	snapshotChan chan chan *Snapshot // Channel to request Snapshots of the lifetime stats.
 
 	lifetime  lifetimeStats       // Cumulative for the lifetime of this procces.
 	tpTracker *throughput.Tracker // Measures outgoing copy throughput.
@@ -106,33 +135,49 @@ type Tracker struct {
 
 	// For managing accumulated pulse stats.
 	pulseStatsMu   sync.Mutex
-	pulseStatsChan chan *PulseStats
+	pulseStatsChan chan jobRunPulseStats
 	currPulseStats PulseStats
 	prevPulseStats PulseStats
 
+	// Lifetime pulse stats keyed by job run relative resource name.
+	jobRunStatsMu sync.Mutex
+	jobRunStats   map[string]*PulseStats
+
 	// Testing hooks.
 	selectDone        func()
 	displayTicker     common.Ticker
 	accumulatorTicker common.Ticker
+	fileSizeLogTicker common.Ticker
 }
 
 // NewTracker returns a new Tracker, which can then be used to record stats.
 func NewTracker(ctx context.Context) *Tracker {
+	bounds, err := parseSizeBuckets(*fileSizeBuckets)
+	if err != nil {
+		glog.Fatalf("Invalid file-size-buckets flag: %v", err)
+	}
 	t := &Tracker{
 		// Large buffers to avoid blocking.
 		taskDoneChan: make(chan string, 100),
 		bwLimitChan:  make(chan int64, 10),
 		ctrlMsgChan:  make(chan time.Time, 10),
+		fileSizeChan: make(chan int64, 100),
+		fsTypeChan:   make(chan fsTypeCopy, 100),
+		snapshotChan: make(chan chan *Snapshot),
 		lifetime: lifetimeStats{
 			taskDone:    map[string]uint64{"copy": 0, "list": 0},
 			ctrlMsgTime: time.Now(),
 			bwLimit:     math.MaxInt32,
+			fileSizes:   newSizeHistogram(bounds),
+			fsTypes:     make(map[string]*FsTypeStats),
 		},
-		pulseStatsChan:    make(chan *PulseStats, 100),
+		pulseStatsChan:    make(chan jobRunPulseStats, 100),
+		jobRunStats:       make(map[string]*PulseStats),
 		tpTracker:         throughput.NewTracker(ctx),
 		selectDone:        func() {},
 		displayTicker:     displayTickerMaker(),
 		accumulatorTicker: accumulatorTickerMaker(),
+		fileSizeLogTicker: fileSizeLogTickerMaker(),
 	}
 	go t.track(ctx)
 	return t
@@ -151,25 +196,63 @@ func (t *Tracker) AccumulatedPulseStats() *PulseStats {
 	return &d
 }
 
+// taskType returns the type of task for the spec, or "" if it's unknown.
+func taskType(spec *taskpb.Spec) string {
+	if spec.GetCopySpec() != nil || spec.GetCopyBundleSpec() != nil {
+		return "copy"
+	} else if spec.GetListSpec() != nil {
+		return "list"
+	} else if spec.GetDeleteBundleSpec() != nil {
+		return "delete"
+	} else if spec.GetVerifySpec() != nil {
+		return "verify"
+	} else if spec.GetReconcileSpec() != nil {
+		return "reconcile"
+	}
+	return ""
+}
+
+// RecordTaskStart tracks the start of a task, which must be followed by a call
+// to RecordTaskResp. Takes no action for a nil receiver.
+func (t *Tracker) RecordTaskStart(req *taskpb.TaskReqMsg) {
+	if t == nil {
+		return
+	}
+	if task := taskType(req.Spec); task != "" {
+		promTasksInFlight.WithLabelValues(task).Inc()
+		if n := t.tasksInFlight(task); n != nil {
+			atomic.AddInt64(n, 1)
+		}
+	}
+}
+
+// tasksInFlight returns the counter of in-flight tasks of the given type, or
+// nil if that type isn't counted.
+func (t *Tracker) tasksInFlight(task string) *int64 {
+	switch task {
+	case "copy":
+		return &t.copyTasksInFlight
+	case "list":
+		return &t.listTasksInFlight
+	}
+	return nil
+}
+
 // RecordTaskResp tracks the count of completed tasks. Takes no action for a nil receiver.
 func (t *Tracker) RecordTaskResp(resp *taskpb.TaskRespMsg) {
 	if t == nil {
 		return
 	}
-	task := ""
-	if resp.ReqSpec.GetCopySpec() != nil || resp.ReqSpec.GetCopyBundleSpec() != nil {
-		task = "copy"
-	} else if resp.ReqSpec.GetListSpec() != nil {
-		task = "list"
-	} else if resp.ReqSpec.GetDeleteBundleSpec() != nil {
-		task = "delete"
-	} else {
+	task := taskType(resp.ReqSpec)
+	if task == "" {
 		glog.Errorf("resp.ReqSpec doesn't match any known spec type: %v", resp.ReqSpec)
+		return
 	}
-
-	if task != "" {
-		t.taskDoneChan <- task // Record the task completion.
+	promTasksInFlight.WithLabelValues(task).Dec()
+	if n := t.tasksInFlight(task); n != nil {
+		atomic.AddInt64(n, -1)
 	}
+	t.taskDoneChan <- task // Record the task completion.
 }
 
 // CopyByteTrackingReader is an io.Reader that wraps another io.Reader and
@@ -177,34 +260,38 @@ func (t *Tracker) RecordTaskResp(resp *taskpb.TaskRespMsg) {
 type CopyByteTrackingReader struct {
 	reader  io.Reader
 	tracker *Tracker
+	jobRun  string
 }
 
-// NewCopyByteTrackingReader returns a CopyByteTrackingReader.
+// NewCopyByteTrackingReader returns a CopyByteTrackingReader, which tracks the
+// bytes read for the given job run.
 // Returns the passed in reader for a nil receiver.
-func (t *Tracker) NewCopyByteTrackingReader(r io.Reader) io.Reader {
+func (t *Tracker) NewCopyByteTrackingReader(jobRun string, r io.Reader) io.Reader {
 	if t == nil {
 		return r
 	}
-	return &CopyByteTrackingReader{reader: r, tracker: t}
+	return &CopyByteTrackingReader{reader: r, tracker: t, jobRun: jobRun}
 }
 
 // Read implements the io.Reader interface.
 func (cbtr *CopyByteTrackingReader) Read(buf []byte) (n int, err error) {
 	start := time.Now()
 	n, err = cbtr.reader.Read(buf)
-	cbtr.tracker.pulseStatsChan <- &PulseStats{
+	cbtr.tracker.pulseStatsChan <- jobRunPulseStats{cbtr.jobRun, &PulseStats{
 		CopyReadMs: DurMs(start),
 		CopyBytes:  int64(n),
-	}
+	}}
 	cbtr.tracker.tpTracker.RecordBytesSent(int64(n))
+	atomic.AddInt64(&cbtr.tracker.copyBytes, int64(n))
 	return n, err
 }
 
 // TimingReader is an io.Reader that wraps another io.Reader and
-// tracks the total duration of the Read calls.
+// tracks the total duration and bytes of the Read calls.
 type TimingReader struct {
-	reader  io.Reader
-	readDur time.Duration
+	reader    io.Reader
+	readDur   time.Duration
+	readBytes int64
 }
 
 // NewTimingReader returns a TimingReader.
@@ -217,6 +304,7 @@ func (tr *TimingReader) Read(buf []byte) (n int, err error) {
 	start := time.Now()
 	n, err = tr.reader.Read(buf)
 	tr.readDur += time.Now().Sub(start)
+	tr.readBytes += int64(n)
 	return n, err
 }
 
@@ -225,22 +313,29 @@ func (tr *TimingReader) ReadDur() time.Duration {
 	return tr.readDur
 }
 
+// ReadBytes returns the total bytes read by this reader's Read calls.
+func (tr *TimingReader) ReadBytes() int64 {
+	return tr.readBytes
+}
+
 // ListByteTrackingWriter is an io.Writer that wraps another io.Writer and
 // performs byte tracking during the Write function.
 type ListByteTrackingWriter struct {
 	writer  io.Writer
 	tracker *Tracker
+	jobRun  string
 	file    bool
 }
 
-// NewListByteTrackingWriter returns a ListByteTrackingWriter. If 'file' is true, timing stats
-// will be written for ListFileWriteMs. If false, timing stats will be written for ListDirWriteMs.
+// NewListByteTrackingWriter returns a ListByteTrackingWriter, which tracks the bytes written for
+// the given job run. If 'file' is true, timing stats will be written for ListFileWriteMs. If
+// false, timing stats will be written for ListDirWriteMs.
 // Returns the passed in writer for a nil receiver.
-func (t *Tracker) NewListByteTrackingWriter(w io.Writer, file bool) io.Writer {
+func (t *Tracker) NewListByteTrackingWriter(jobRun string, w io.Writer, file bool) io.Writer {
 	if t == nil {
 		return w
 	}
-	return &ListByteTrackingWriter{writer: w, tracker: t, file: file}
+	return &ListByteTrackingWriter{writer: w, tracker: t, jobRun: jobRun, file: file}
 }
 
 // Write implements the io.Writer interface.
@@ -253,17 +348,66 @@ func (lbtw *ListByteTrackingWriter) Write(p []byte) (n int, err error) {
 	} else {
 		ps.ListDirWriteMs = DurMs(start)
 	}
-	lbtw.tracker.pulseStatsChan <- ps
+	lbtw.tracker.pulseStatsChan <- jobRunPulseStats{lbtw.jobRun, ps}
 	return n, err
 }
 
-// RecordPulseStats tracks stats contained within 'ps'.
+// RecordPulseStats tracks stats contained within 'ps' for the given job run. The stats are
+// always added to the agent-wide stats, and also to the job run's stats if jobRun isn't empty.
 // Takes no action for a nil receiver.
-func (t *Tracker) RecordPulseStats(ps *PulseStats) {
+func (t *Tracker) RecordPulseStats(jobRun string, ps *PulseStats) {
 	if t == nil {
 		return
 	}
-	t.pulseStatsChan <- ps
+	t.pulseStatsChan <- jobRunPulseStats{jobRun, ps}
+}
+
+// StatsForJobRun returns the lifetime PulseStats recorded for the job run with the given
+// relative resource name. Returns empty PulseStats for a nil receiver or an unknown job run.
+func (t *Tracker) StatsForJobRun(jobRun string) PulseStats {
+	if t == nil {
+		return PulseStats{}
+	}
+	t.jobRunStatsMu.Lock()
+	defer t.jobRunStatsMu.Unlock()
+	if ps, ok := t.jobRunStats[jobRun]; ok {
+		return *ps
+	}
+	return PulseStats{}
+}
+
+// RecordActiveJobRuns forgets the stats of every job run which isn't among the given active job
+// runs, so the stats of finished job runs don't accumulate over the agent's lifetime.
+// Takes no action for a nil receiver.
+func (t *Tracker) RecordActiveJobRuns(jobRuns []string) {
+	if t == nil {
+		return
+	}
+	active := make(map[string]bool, len(jobRuns))
+	for _, jobRun := range jobRuns {
+		active[jobRun] = true
+	}
+	t.jobRunStatsMu.Lock()
+	defer t.jobRunStatsMu.Unlock()
+	for jobRun := range t.jobRunStats {
+		if !active[jobRun] {
+			delete(t.jobRunStats, jobRun)
+		}
+	}
+}
+
+func (t *Tracker) recordJobRunPulseStats(jrps jobRunPulseStats) {
+	if jrps.jobRun == "" {
+		return
+	}
+	t.jobRunStatsMu.Lock()
+	defer t.jobRunStatsMu.Unlock()
+	ps, ok := t.jobRunStats[jrps.jobRun]
+	if !ok {
+		ps = &PulseStats{}
+		t.jobRunStats[jrps.jobRun] = ps
+	}
+	ps.add(jrps.ps)
 }
 
 // DurMs returns the duration in millis between time.Now() and 'start'.
@@ -289,6 +433,142 @@ func (t *Tracker) RecordCtrlMsg(time time.Time) {
 	t.ctrlMsgChan <- time
 }
 
+// RecordFileSize tracks the size of a file being copied in the file size
+// histogram. Takes no action for a nil receiver.
+func (t *Tracker) RecordFileSize(size int64) {
+	if t == nil {
+		return
+	}
+	t.fileSizeChan <- size
+}
+
+// FsTypeStats are the copies from a single type of source file system.
+type FsTypeStats struct {
+	Files  int64
+	Bytes  int64
+	CopyMs int64 // Duration in millis spent copying the files.
+}
+
+// BytesPerSec returns the average throughput of the copies.
+func (s FsTypeStats) BytesPerSec() int64 {
+	if s.CopyMs <= 0 {
+		return 0
+	}
+	return s.Bytes * 1000 / s.CopyMs
+}
+
+// fsTypeCopy is a copy from a file of a source file system type.
+type fsTypeCopy struct {
+	fsType string
+	bytes  int64
+	dur    time.Duration
+}
+
+// RecordCopyFsType tracks a copy of bytes which took dur, from a source file
+// system of the given type, such as "nfs". Takes no action for a nil receiver
+// or an unknown fsType.
+func (t *Tracker) RecordCopyFsType(fsType string, bytes int64, dur time.Duration) {
+	if t == nil || fsType == "" {
+		return
+	}
+	t.fsTypeChan <- fsTypeCopy{fsType, bytes, dur}
+}
+
+// RecordCopyStart tracks the start of a file copy, which must be followed by a
+// call to RecordCopyEnd. Takes no action for a nil receiver.
+func (t *Tracker) RecordCopyStart() {
+	if t == nil {
+		return
+	}
+	atomic.AddInt64(&t.concurrentCopies, 1)
+	promCopiesInFlight.Inc()
+}
+
+// RecordCopyEnd tracks the end of a file copy. Takes no action for a nil receiver.
+func (t *Tracker) RecordCopyEnd() {
+	if t == nil {
+		return
+	}
+	atomic.AddInt64(&t.concurrentCopies, -1)
+	promCopiesInFlight.Dec()
+}
+
+// LiveStats are measurements of the Agent at a point in time, unlike the
+// PulseStats which accumulate between pulses.
+type LiveStats struct {
+	CopyBytes         int64 // Bytes copied since the Agent started.
+	CopyTasksInFlight int64
+	ListTasksInFlight int64
+}
+
+// LiveStats returns the current LiveStats. Unlike Snapshot it never blocks.
+// Returns empty LiveStats for a nil receiver.
+func (t *Tracker) LiveStats() LiveStats {
+	if t == nil {
+		return LiveStats{}
+	}
+	return LiveStats{
+		CopyBytes:         atomic.LoadInt64(&t.copyBytes),
+		CopyTasksInFlight: atomic.LoadInt64(&t.copyTasksInFlight),
+		ListTasksInFlight: atomic.LoadInt64(&t.listTasksInFlight),
+	}
+}
+
+// Snapshot contains the lifetime stats of the Agent at a point in time.
+type Snapshot struct {
+	PulseStats // Embedded struct.
+
+	TasksDone        map[string]uint64
+	BWLimit          int64
+	CtrlMsgAgeMs     int64
+	TxRate           int64 // Copy throughput in bytes/s.
+	ConcurrentCopies int64
+	FileSizes        []HistogramBucket
+	FsTypes          map[string]FsTypeStats // Keyed by source file system type.
+}
+
+// Snapshot returns a Snapshot of the current stats. It's safe to call
+// concurrently with the Record* functions. Returns an empty Snapshot for a nil
+// receiver.
+func (t *Tracker) Snapshot(ctx context.Context) (*Snapshot, error) {
+	if t == nil {
+		return &Snapshot{}, nil
+	}
+	c := make(chan *Snapshot, 1)
+	select {
+	case t.snapshotChan <- c:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	select {
+	case s := <-c:
+		return s, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// snapshot must only be called from the track goroutine.
+func (t *Tracker) snapshot() *Snapshot {
+	s := &Snapshot{
+		PulseStats:       t.lifetime.PulseStats,
+		TasksDone:        make(map[string]uint64),
+		BWLimit:          t.lifetime.bwLimit,
+		CtrlMsgAgeMs:     DurMs(t.lifetime.ctrlMsgTime),
+		TxRate:           t.tpTracker.Throughput(),
+		ConcurrentCopies: atomic.LoadInt64(&t.concurrentCopies),
+		FileSizes:        t.lifetime.fileSizes.Buckets(),
+		FsTypes:          make(map[string]FsTypeStats),
+	}
+	for k, v := range t.lifetime.taskDone {
+		s.TasksDone[k] = v
+	}
+	for k, v := range t.lifetime.fsTypes {
+		s.FsTypes[k] = *v
+	}
+	return s
+}
+
 func (t *Tracker) track(ctx context.Context) {
 	for {
 		select {
@@ -299,16 +579,36 @@ func (t *Tracker) track(ctx context.Context) {
 			return
 		case task := <-t.taskDoneChan:
 			t.lifetime.taskDone[task]++
-		case ps := <-t.pulseStatsChan:
-			t.lifetime.PulseStats.add(ps)
+		case jrps := <-t.pulseStatsChan:
+			t.lifetime.PulseStats.add(jrps.ps)
+			t.recordJobRunPulseStats(jrps)
+			recordPromPulseStats(jrps.ps)
 		case agentBW := <-t.bwLimitChan:
 			t.lifetime.bwLimit = agentBW
 		case time := <-t.ctrlMsgChan:
 			t.lifetime.ctrlMsgTime = time
+		case size := <-t.fileSizeChan:
+			t.lifetime.fileSizes.add(size)
+		case c := <-t.fsTypeChan:
+			s, ok := t.lifetime.fsTypes[c.fsType]
+			if !ok {
+				s = &FsTypeStats{}
+				t.lifetime.fsTypes[c.fsType] = s
+			}
+			s.Files++
+			s.Bytes += c.bytes
+			s.CopyMs += int64(c.dur / time.Millisecond)
+		case c := <-t.snapshotChan:
+			c <- t.snapshot()
 		case <-t.displayTicker.GetChannel():
 			t.displayStats()
 		case <-t.accumulatorTicker.GetChannel():
 			t.accumulatePulseStats()
+		case <-t.fileSizeLogTicker.GetChannel():
+			glog.Infof("File size histogram: %v", t.lifetime.fileSizes)
+			if len(t.lifetime.fsTypes) > 0 {
+				glog.Infof("Copy throughput by source file system: %v", t.fsTypesString())
+			}
 		}
 		t.selectDone() // Testing hook.
 	}
@@ -322,6 +622,23 @@ func (t *Tracker) accumulatePulseStats() {
 	t.prevPulseStats = t.lifetime.PulseStats
 }
 
+// fsTypesString returns the copy throughput of each source file system type,
+// such as "[ext4:3 files 1.0MiB/s nfs:2 files 512.0KiB/s]". It must only be
+// called from the track goroutine.
+func (t *Tracker) fsTypesString() string {
+	var keys []string
+	for k := range t.lifetime.fsTypes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		s := t.lifetime.fsTypes[k]
+		parts = append(parts, fmt.Sprintf("%v:%d files %v/s", k, s.Files, byteCountBinary(s.BytesPerSec(), 0)))
+	}
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
 func (t *Tracker) displayStats() string {
 	// Generate the transmission rate and sum.
 	txRate := fmt.Sprintf("txRate:%v/s", byteCountBinary(t.tpTracker.Throughput(), 7))
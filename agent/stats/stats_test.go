@@ -17,7 +17,12 @@ package stats
 
 import (
 	"context"
+	"encoding/json"
+	"io/ioutil"
 	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
@@ -50,13 +55,13 @@ func TestTrackerRecordBWLimit(t *testing.T) {
 
 var (
 	psEmpty = &PulseStats{}
-	ps1     = &PulseStats{1, 0, 1, 0, 1, 0, 1, 0, 0, 1, 0, 1, 0}
-	ps2     = &PulseStats{0, 1, 0, 1, 0, 1, 0, 1, 1, 0, 1, 0, 1}
-	ps3     = &PulseStats{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1} // ps3 = ps1 + ps2
-	ps4     = &PulseStats{1, 2, 3, 1, 2, 3, 1, 2, 2, 3, 1, 2, 3}
-	ps5     = &PulseStats{2, 4, 6, 2, 4, 6, 2, 4, 4, 6, 2, 4, 6} // ps5 = ps4 + ps4
-	ps6     = &PulseStats{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9}
-	ps7     = &PulseStats{8, 7, 6, 8, 7, 6, 8, 7, 7, 6, 8, 7, 6} // ps7 = ps6 - ps4
+	ps1     = &PulseStats{1, 0, 1, 0, 1, 0, 1, 0, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1}
+	ps2     = &PulseStats{0, 1, 0, 1, 0, 1, 0, 1, 1, 0, 1, 0, 1, 0, 1, 0, 1, 0}
+	ps3     = &PulseStats{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1} // ps3 = ps1 + ps2
+	ps4     = &PulseStats{1, 2, 3, 1, 2, 3, 1, 2, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2}
+	ps5     = &PulseStats{2, 4, 6, 2, 4, 6, 2, 4, 4, 6, 2, 4, 6, 2, 4, 6, 2, 4} // ps5 = ps4 + ps4
+	ps6     = &PulseStats{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9}
+	ps7     = &PulseStats{8, 7, 6, 8, 7, 6, 8, 7, 7, 6, 8, 7, 6, 8, 7, 6, 8, 7} // ps7 = ps6 - ps4
 )
 
 func TestTrackerAccumulatedPulseStats(t *testing.T) {
@@ -97,7 +102,7 @@ func TestTrackerAccumulatedPulseStats(t *testing.T) {
 			case string:
 				mockAccumulatorTicker.Tick()
 			case *PulseStats:
-				st.pulseStatsChan <- v
+				st.pulseStatsChan <- jobRunPulseStats{ps: v}
 			default:
 				t.Fatalf("Unrecognized input type: %T %v", i, i)
 			}
@@ -256,7 +261,7 @@ func TestTrackerDisplayStats(t *testing.T) {
 				st.RecordTaskResp(v)
 			case int:
 				st.tpTracker.RecordBytesSent(int64(v))
-				st.pulseStatsChan <- &PulseStats{CopyBytes: int64(v)}
+				st.pulseStatsChan <- jobRunPulseStats{ps: &PulseStats{CopyBytes: int64(v)}}
 			case time.Time:
 				st.RecordCtrlMsg(v)
 			default:
@@ -316,3 +321,233 @@ func TestByteCountBinary(t *testing.T) {
 		}
 	}
 }
+
+func TestTaskType(t *testing.T) {
+	tests := []struct {
+		spec *taskpb.Spec
+		want string
+	}{
+		{copyTaskRespMsg.ReqSpec, "copy"},
+		{copyBundleTaskRespMsg.ReqSpec, "copy"},
+		{listTaskRespMsg.ReqSpec, "list"},
+		{deleteTaskRespMsg.ReqSpec, "delete"},
+		{&taskpb.Spec{Spec: &taskpb.Spec_VerifySpec{VerifySpec: &taskpb.VerifySpec{}}}, "verify"},
+		{&taskpb.Spec{Spec: &taskpb.Spec_ReconcileSpec{ReconcileSpec: &taskpb.ReconcileSpec{}}}, "reconcile"},
+		{&taskpb.Spec{}, ""},
+	}
+	for _, tc := range tests {
+		if got := taskType(tc.spec); got != tc.want {
+			t.Errorf("taskType(%v) = %q, want %q", tc.spec, got, tc.want)
+		}
+	}
+}
+
+func TestTimingReader(t *testing.T) {
+	tr := NewTimingReader(strings.NewReader("0123456789"))
+	buf := make([]byte, 4)
+	for {
+		if _, err := tr.Read(buf); err != nil {
+			break
+		}
+	}
+	if got := tr.ReadBytes(); got != 10 {
+		t.Errorf("ReadBytes() = %v, want 10", got)
+	}
+}
+
+func TestTrackerSnapshot(t *testing.T) {
+	// Create an unused mock ticker to prevent accidental calls to selectDone.
+	unusedMockTicker := common.NewMockTicker()
+	accumulatorTickerMaker = func() common.Ticker { return unusedMockTicker }
+	displayTickerMaker = func() common.Ticker { return unusedMockTicker }
+
+	st := NewTracker(context.Background())
+	var wg sync.WaitGroup
+	st.selectDone = func() { wg.Done() } // The test hook.
+	wg.Add(3)
+	st.RecordPulseStats("", ps4)
+	st.RecordBWLimit(123456)
+	st.RecordTaskResp(&taskpb.TaskRespMsg{ReqSpec: &taskpb.Spec{Spec: &taskpb.Spec_ListSpec{ListSpec: &taskpb.ListSpec{}}}})
+	wg.Wait() // Force the Tracker to collect the recorded stats.
+	st.RecordCopyStart()
+	st.RecordCopyStart()
+	st.RecordCopyEnd()
+
+	wg.Add(1) // For the snapshot request.
+	s, err := st.Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("Snapshot got err: %v", err)
+	}
+	if s.PulseStats != *ps4 {
+		t.Errorf("Snapshot PulseStats = %v, want %v", s.PulseStats, *ps4)
+	}
+	if s.BWLimit != 123456 {
+		t.Errorf("Snapshot BWLimit = %v, want 123456", s.BWLimit)
+	}
+	if got := s.TasksDone["list"]; got != 1 {
+		t.Errorf("Snapshot TasksDone[list] = %v, want 1", got)
+	}
+	if s.ConcurrentCopies != 1 {
+		t.Errorf("Snapshot ConcurrentCopies = %v, want 1", s.ConcurrentCopies)
+	}
+}
+
+func TestTrackerSnapshotFsTypes(t *testing.T) {
+	unusedMockTicker := common.NewMockTicker()
+	accumulatorTickerMaker = func() common.Ticker { return unusedMockTicker }
+	displayTickerMaker = func() common.Ticker { return unusedMockTicker }
+
+	st := NewTracker(context.Background())
+	var wg sync.WaitGroup
+	st.selectDone = func() { wg.Done() } // The test hook.
+	wg.Add(3)
+	st.RecordCopyFsType("nfs", 1000, 500*time.Millisecond)
+	st.RecordCopyFsType("nfs", 3000, 1500*time.Millisecond)
+	st.RecordCopyFsType("", 5000, time.Second) // Ignored.
+	st.RecordCopyFsType("ext4", 2000, time.Second)
+	wg.Wait()
+
+	wg.Add(1) // For the snapshot request.
+	s, err := st.Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("Snapshot got err: %v", err)
+	}
+	want := map[string]FsTypeStats{
+		"nfs":  {Files: 2, Bytes: 4000, CopyMs: 2000},
+		"ext4": {Files: 1, Bytes: 2000, CopyMs: 1000},
+	}
+	if !reflect.DeepEqual(s.FsTypes, want) {
+		t.Errorf("Snapshot FsTypes = %v, want %v", s.FsTypes, want)
+	}
+	if got := s.FsTypes["nfs"].BytesPerSec(); got != 2000 {
+		t.Errorf("FsTypes[nfs].BytesPerSec() = %v, want 2000", got)
+	}
+}
+
+func TestTrackerSnapshotCtxDone(t *testing.T) {
+	unusedMockTicker := common.NewMockTicker()
+	accumulatorTickerMaker = func() common.Ticker { return unusedMockTicker }
+	displayTickerMaker = func() common.Ticker { return unusedMockTicker }
+
+	trackerCtx, cancel := context.WithCancel(context.Background())
+	st := NewTracker(trackerCtx)
+	cancel()
+
+	ctx, cancel2 := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel2()
+	// The Tracker may or may not have stopped tracking yet, so only an error
+	// is checked if the Snapshot fails.
+	if _, err := st.Snapshot(ctx); err != nil && err != context.DeadlineExceeded {
+		t.Errorf("Snapshot got err: %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestTrackerStatsForJobRun(t *testing.T) {
+	unusedMockTicker := common.NewMockTicker()
+	accumulatorTickerMaker = func() common.Ticker { return unusedMockTicker }
+	displayTickerMaker = func() common.Ticker { return unusedMockTicker }
+
+	st := NewTracker(context.Background())
+	var wg sync.WaitGroup
+	st.selectDone = func() { wg.Done() } // The test hook.
+	wg.Add(4)
+	st.RecordPulseStats("jobrun-a", &PulseStats{CopyBytes: 10, CopyOpenMs: 1})
+	st.RecordPulseStats("jobrun-a", &PulseStats{CopyBytes: 5})
+	st.RecordPulseStats("jobrun-b", &PulseStats{ListBytes: 7})
+	st.RecordPulseStats("", &PulseStats{CopyBytes: 100})
+	wg.Wait() // Force the Tracker to collect the recorded stats.
+
+	tests := []struct {
+		jobRun string
+		want   PulseStats
+	}{
+		{"jobrun-a", PulseStats{CopyBytes: 15, CopyOpenMs: 1}},
+		{"jobrun-b", PulseStats{ListBytes: 7}},
+		{"jobrun-unknown", PulseStats{}},
+		{"", PulseStats{}},
+	}
+	for _, tc := range tests {
+		if got := st.StatsForJobRun(tc.jobRun); got != tc.want {
+			t.Errorf("StatsForJobRun(%q) = %+v, want %+v", tc.jobRun, got, tc.want)
+		}
+	}
+
+	// The stats of job runs which are no longer active are forgotten.
+	st.RecordActiveJobRuns([]string{"jobrun-b"})
+	if got := st.StatsForJobRun("jobrun-a"); got != (PulseStats{}) {
+		t.Errorf("StatsForJobRun(%q) after it's inactive = %+v, want none", "jobrun-a", got)
+	}
+	if got, want := st.StatsForJobRun("jobrun-b"), (PulseStats{ListBytes: 7}); got != want {
+		t.Errorf("StatsForJobRun(%q) while it's active = %+v, want %+v", "jobrun-b", got, want)
+	}
+
+	// The agent-wide stats include all job runs.
+	wg.Add(1) // For the snapshot request.
+	s, err := st.Snapshot(context.Background())
+	if err != nil {
+		t.Fatalf("Snapshot got err: %v", err)
+	}
+	if got, want := s.CopyBytes, int64(115); got != want {
+		t.Errorf("Snapshot CopyBytes = %v, want %v", got, want)
+	}
+}
+
+func TestTrackerServeHTTP(t *testing.T) {
+	unusedMockTicker := common.NewMockTicker()
+	accumulatorTickerMaker = func() common.Ticker { return unusedMockTicker }
+	displayTickerMaker = func() common.Ticker { return unusedMockTicker }
+
+	st := NewTracker(context.Background())
+	st.RecordCopyStart()
+
+	rec := httptest.NewRecorder()
+	st.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stats", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("ServeHTTP got status %v, want %v", rec.Code, http.StatusOK)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("ServeHTTP got Content-Type %q, want application/json", got)
+	}
+	var s Snapshot
+	if err := json.Unmarshal(rec.Body.Bytes(), &s); err != nil {
+		t.Fatalf("json.Unmarshal(%q) got err: %v", rec.Body.String(), err)
+	}
+	if s.ConcurrentCopies != 1 {
+		t.Errorf("ConcurrentCopies = %v, want 1", s.ConcurrentCopies)
+	}
+
+	rec = httptest.NewRecorder()
+	st.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/stats", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("ServeHTTP POST got status %v, want %v", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestTrackerLiveStats(t *testing.T) {
+	unusedMockTicker := common.NewMockTicker()
+	accumulatorTickerMaker = func() common.Ticker { return unusedMockTicker }
+	displayTickerMaker = func() common.Ticker { return unusedMockTicker }
+
+	var nilTracker *Tracker
+	if got := nilTracker.LiveStats(); got != (LiveStats{}) {
+		t.Errorf("nil Tracker LiveStats() = %+v, want empty", got)
+	}
+
+	st := NewTracker(context.Background())
+	copyReq := &taskpb.TaskReqMsg{Spec: &taskpb.Spec{Spec: &taskpb.Spec_CopySpec{&taskpb.CopySpec{}}}}
+	listReq := &taskpb.TaskReqMsg{Spec: &taskpb.Spec{Spec: &taskpb.Spec_ListSpec{&taskpb.ListSpec{}}}}
+	st.RecordTaskStart(copyReq)
+	st.RecordTaskStart(copyReq)
+	st.RecordTaskStart(listReq)
+	st.RecordTaskResp(&taskpb.TaskRespMsg{ReqSpec: copyReq.Spec})
+
+	r := st.NewCopyByteTrackingReader("jobrun", strings.NewReader("0123456789"))
+	if _, err := ioutil.ReadAll(r); err != nil {
+		t.Fatalf("ReadAll got err: %v", err)
+	}
+
+	want := LiveStats{CopyBytes: 10, CopyTasksInFlight: 1, ListTasksInFlight: 1}
+	if got := st.LiveStats(); got != want {
+		t.Errorf("LiveStats() = %+v, want %+v", got, want)
+	}
+}
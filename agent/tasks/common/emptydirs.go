@@ -0,0 +1,55 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"flag"
+	"os"
+	"strings"
+)
+
+var (
+	emptyDirMarker = flag.String("empty-dir-marker", "", "If set, empty directories are listed as a zero-byte file named by appending this marker to the directory's path, and copied as a zero-byte object. For example, \"/\" preserves the empty directory dir as the object \"dir/\". Empty directories are not copied if this is empty.")
+)
+
+// EmptyDirMarker returns the configured empty directory marker, empty if
+// empty directories aren't preserved.
+func EmptyDirMarker() string {
+	return *emptyDirMarker
+}
+
+// SetEmptyDirMarker sets the empty directory marker.
+func SetEmptyDirMarker(marker string) {
+	*emptyDirMarker = marker
+}
+
+// EmptyDirOfMarker returns the directory whose empty directory marker is at
+// path, and whether path is such a marker. A path naming an existing file is
+// never a marker.
+func EmptyDirOfMarker(path string) (string, bool) {
+	marker := EmptyDirMarker()
+	if marker == "" || !strings.HasSuffix(path, marker) {
+		return "", false
+	}
+	if fi, err := os.Lstat(path); err == nil && !fi.IsDir() {
+		return "", false
+	}
+	dir := strings.TrimSuffix(path, marker)
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		return "", false
+	}
+	return dir, true
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmptyDirOfMarker(t *testing.T) {
+	tmpDir := CreateTmpDir("", "test-empty-dirs-")
+	defer os.RemoveAll(tmpDir)
+	file := filepath.Join(tmpDir, "data.keep")
+	if err := ioutil.WriteFile(file, []byte("content"), 0644); err != nil {
+		t.Fatalf("WriteFile(%q) got err: %v", file, err)
+	}
+	defer SetEmptyDirMarker("")
+
+	tests := []struct {
+		marker  string
+		path    string
+		wantDir string
+		wantOK  bool
+	}{
+		{"", tmpDir + "/", "", false},
+		{"/", tmpDir + "/", tmpDir, true},
+		{"/", file, "", false},
+		{".keep", tmpDir + ".keep", tmpDir, true},
+		{".keep", file, "", false},
+		{"/", filepath.Join(tmpDir, "missing") + "/", "", false},
+	}
+	for _, tc := range tests {
+		SetEmptyDirMarker(tc.marker)
+		dir, ok := EmptyDirOfMarker(tc.path)
+		if dir != tc.wantDir || ok != tc.wantOK {
+			t.Errorf("EmptyDirOfMarker(%q) with marker %q = (%q, %v), want (%q, %v)", tc.path, tc.marker, dir, ok, tc.wantDir, tc.wantOK)
+		}
+	}
+}
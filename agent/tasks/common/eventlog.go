@@ -0,0 +1,123 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// Log formats, see the log-format flag.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+var (
+	eventLogFormat = logFormat(LogFormatText)
+
+	// JSON events are written to eventOut, guarded by eventMu. Tests replace it.
+	eventMu  sync.Mutex
+	eventOut io.Writer = os.Stderr
+)
+
+func init() {
+	flag.Var(&eventLogFormat, "log-format", "The format of the task, copy and retry event logs. \"text\" logs them with glog, \"json\" writes them to stderr as one JSON object per line.")
+}
+
+// logFormat is a flag.Value holding one of the LogFormat constants.
+type logFormat string
+
+// String implements the flag.Value interface.
+func (f *logFormat) String() string {
+	return string(*f)
+}
+
+// Set implements the flag.Value interface.
+func (f *logFormat) Set(format string) error {
+	switch format {
+	case LogFormatText, LogFormatJSON:
+		*f = logFormat(format)
+		return nil
+	default:
+		return fmt.Errorf("invalid log format %q, must be %q or %q", format, LogFormatText, LogFormatJSON)
+	}
+}
+
+// Fields are the structured fields of a logged event.
+type Fields map[string]interface{}
+
+// LogEvent logs an agent event, such as a task finishing or a copy request
+// being retried, along with its fields. In the JSON log format each event is a
+// single line with the "time" and "event" fields added, so it can be parsed
+// without matching the text of glog lines.
+func LogEvent(event string, fields Fields) {
+	if eventLogFormat != LogFormatJSON {
+		glog.Info(eventText(event, fields))
+		return
+	}
+	line, err := eventJSON(event, fields, time.Now())
+	if err != nil {
+		glog.Errorf("Failed to marshal %s event %v, err: %v", event, fields, err)
+		return
+	}
+	eventMu.Lock()
+	defer eventMu.Unlock()
+	eventOut.Write(line)
+}
+
+// eventText formats an event as its name followed by its fields as key=value
+// pairs in key order.
+func eventText(event string, fields Fields) string {
+	var keys []string
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteString(event)
+	for _, k := range keys {
+		fmt.Fprintf(&b, " %s=%v", k, fields[k])
+	}
+	return b.String()
+}
+
+// eventJSON formats an event as a newline terminated JSON object.
+func eventJSON(event string, fields Fields, now time.Time) ([]byte, error) {
+	obj := make(map[string]interface{}, len(fields)+2)
+	for k, v := range fields {
+		if err, ok := v.(error); ok {
+			v = err.Error() // Errors don't marshal to anything useful.
+		}
+		obj[k] = v
+	}
+	obj["time"] = now.UTC().Format(time.RFC3339Nano)
+	obj["event"] = event
+	line, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return append(line, '\n'), nil
+}
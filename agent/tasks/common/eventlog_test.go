@@ -0,0 +1,100 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestLogFormatSet(t *testing.T) {
+	var f logFormat
+	for _, format := range []string{LogFormatText, LogFormatJSON} {
+		if err := f.Set(format); err != nil {
+			t.Errorf("Set(%q) got err: %v", format, err)
+		}
+		if got := f.String(); got != format {
+			t.Errorf("String() = %q, want %q", got, format)
+		}
+	}
+	if err := f.Set("xml"); err == nil {
+		t.Errorf("Set(%q) got nil err, want err", "xml")
+	}
+}
+
+func TestEventText(t *testing.T) {
+	got := eventText("copy_success", Fields{"src_file": "/a/b", "bytes_copied": 10})
+	if want := "copy_success bytes_copied=10 src_file=/a/b"; got != want {
+		t.Errorf("eventText() = %q, want %q", got, want)
+	}
+}
+
+func TestEventJSON(t *testing.T) {
+	now := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	line, err := eventJSON("copy_failure", Fields{"bytes_copied": 10, "error": errors.New("boom")}, now)
+	if err != nil {
+		t.Fatalf("eventJSON() got err: %v", err)
+	}
+	if !bytes.HasSuffix(line, []byte("\n")) {
+		t.Errorf("eventJSON() = %q, want a trailing newline", line)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(line, &got); err != nil {
+		t.Fatalf("json.Unmarshal(%q) got err: %v", line, err)
+	}
+	want := map[string]interface{}{
+		"time":         "2019-09-01T12:00:00Z",
+		"event":        "copy_failure",
+		"bytes_copied": float64(10),
+		"error":        "boom",
+	}
+	if len(got) != len(want) {
+		t.Errorf("eventJSON() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("eventJSON() field %q = %v, want %v", k, got[k], v)
+		}
+	}
+}
+
+func TestLogEventJSON(t *testing.T) {
+	defer func(f logFormat) { eventLogFormat = f }(eventLogFormat)
+	defer func(w io.Writer) { eventOut = w }(eventOut)
+	var buf bytes.Buffer
+	eventOut = &buf
+	eventLogFormat = LogFormatJSON
+
+	LogEvent("task_start", Fields{"task": "t1"})
+	LogEvent("task_finish", Fields{"task": "t1"})
+	lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+	if len(lines) != 2 {
+		t.Fatalf("LogEvent() wrote %d lines, want 2: %q", len(lines), buf.String())
+	}
+	for i, event := range []string{"task_start", "task_finish"} {
+		var got map[string]interface{}
+		if err := json.Unmarshal(lines[i], &got); err != nil {
+			t.Fatalf("json.Unmarshal(%q) got err: %v", lines[i], err)
+		}
+		if got["event"] != event || got["task"] != "t1" {
+			t.Errorf("LogEvent() line %d = %v, want event %q and task %q", i, got, event, "t1")
+		}
+	}
+}
@@ -18,6 +18,7 @@ package common
 import (
 	"net/http"
 	"os"
+	"strings"
 
 	"cloud.google.com/go/storage"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/common"
@@ -28,6 +29,37 @@ import (
 	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
 )
 
+// isKMSError returns true if the GCS error was caused by Cloud KMS, for
+// example when GCS lacks permission to use a customer-managed encryption key.
+func isKMSError(err *googleapi.Error) bool {
+	if strings.Contains(strings.ToLower(err.Message), "kms") {
+		return true
+	}
+	for _, item := range err.Errors {
+		if strings.Contains(strings.ToLower(item.Message), "kms") {
+			return true
+		}
+	}
+	return false
+}
+
+// IsQuotaExceeded returns true if err is a GCS error caused by an exhausted
+// quota. Unlike rate limit errors, which GCS also returns as HTTP 429, these
+// aren't worth retrying.
+func IsQuotaExceeded(err error) bool {
+	t, ok := err.(*googleapi.Error)
+	if !ok || t.Code != http.StatusTooManyRequests {
+		return false
+	}
+	for _, item := range t.Errors {
+		switch item.Reason {
+		case "quotaExceeded", "dailyLimitExceeded":
+			return true
+		}
+	}
+	return false
+}
+
 // GetFailureTypeFromError attempts to identify the passed in error and returns a taskpb.FailureType.
 func GetFailureTypeFromError(err error) taskpb.FailureType {
 	if err == nil {
@@ -37,16 +69,23 @@ func GetFailureTypeFromError(err error) taskpb.FailureType {
 		return taskpb.FailureType_FILE_NOT_FOUND_FAILURE
 	}
 	if os.IsPermission(err) {
-		return taskpb.FailureType_PERMISSION_FAILURE
+		return taskpb.FailureType_PERMISSION_DENIED_FAILURE
 	}
 	if t, ok := err.(*googleapi.Error); ok {
 		switch t.Code {
 		case http.StatusPreconditionFailed:
 			return taskpb.FailureType_PRECONDITION_FAILURE
 		case http.StatusForbidden:
+			if isKMSError(t) {
+				return taskpb.FailureType_KMS_PERMISSION_FAILURE
+			}
 			return taskpb.FailureType_PERMISSION_FAILURE
 		case http.StatusUnauthorized:
 			return taskpb.FailureType_PERMISSION_FAILURE
+		case http.StatusTooManyRequests:
+			if IsQuotaExceeded(t) {
+				return taskpb.FailureType_QUOTA_EXCEEDED_FAILURE
+			}
 		}
 	}
 	if t, ok := err.(AgentError); ok {
@@ -0,0 +1,74 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestGetFailureTypeFromError(t *testing.T) {
+	tests := []struct {
+		desc string
+		err  error
+		want taskpb.FailureType
+	}{
+		{"Nil error", nil, taskpb.FailureType_UNSET_FAILURE_TYPE},
+		{"Unknown error", errors.New("some error"), taskpb.FailureType_UNKNOWN_FAILURE},
+		{"File not found", &os.PathError{Op: "open", Path: "f", Err: os.ErrNotExist}, taskpb.FailureType_FILE_NOT_FOUND_FAILURE},
+		{"Permission denied", &os.PathError{Op: "open", Path: "f", Err: os.ErrPermission}, taskpb.FailureType_PERMISSION_DENIED_FAILURE},
+		{"Precondition", &googleapi.Error{Code: http.StatusPreconditionFailed}, taskpb.FailureType_PRECONDITION_FAILURE},
+		{"Forbidden", &googleapi.Error{Code: http.StatusForbidden, Message: "Access denied."}, taskpb.FailureType_PERMISSION_FAILURE},
+		{"KMS forbidden", &googleapi.Error{Code: http.StatusForbidden, Message: "Permission denied on Cloud KMS key."}, taskpb.FailureType_KMS_PERMISSION_FAILURE},
+		{"KMS forbidden item", &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Message: "cloudkms.cryptoKeyVersions.useToEncrypt denied"}}}, taskpb.FailureType_KMS_PERMISSION_FAILURE},
+		{"Rate limited", &googleapi.Error{Code: http.StatusTooManyRequests, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}}, taskpb.FailureType_UNKNOWN_FAILURE},
+		{"Quota exceeded", &googleapi.Error{Code: http.StatusTooManyRequests, Errors: []googleapi.ErrorItem{{Reason: "quotaExceeded"}}}, taskpb.FailureType_QUOTA_EXCEEDED_FAILURE},
+		{"AgentError", AgentError{FailureType: taskpb.FailureType_HASH_MISMATCH_FAILURE}, taskpb.FailureType_HASH_MISMATCH_FAILURE},
+	}
+	for _, tc := range tests {
+		if got := GetFailureTypeFromError(tc.err); got != tc.want {
+			t.Errorf("%s: GetFailureTypeFromError(%v) got %v, want %v", tc.desc, tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestGetFailureTypeFromErrorPermissionDenied(t *testing.T) {
+	tmpDir := CreateTmpDir("", "test-helpers-")
+	defer os.RemoveAll(tmpDir)
+	tmpFile := filepath.Join(tmpDir, "unreadable")
+	if err := ioutil.WriteFile(tmpFile, []byte("content"), 0000); err != nil {
+		t.Fatalf("WriteFile(%q) got err: %v", tmpFile, err)
+	}
+	if err := os.Chmod(tmpFile, 0000); err != nil {
+		t.Fatalf("os.Chmod(%q, 0000) got err: %v", tmpFile, err)
+	}
+	f, err := os.Open(tmpFile)
+	if err == nil {
+		f.Close()
+		t.Skip("os.Open succeeded on a 0000 file, probably running as root")
+	}
+	if got, want := GetFailureTypeFromError(err), taskpb.FailureType_PERMISSION_DENIED_FAILURE; got != want {
+		t.Errorf("GetFailureTypeFromError(%v) got %v, want %v", err, got, want)
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"flag"
+	"strconv"
+
+	"cloud.google.com/go/storage"
+)
+
+// MTIME_ATTR_NAME is the default name of the GCS object custom metadata
+// attribute holding the source file mtime.
+const MTIME_ATTR_NAME = "goog-reserved-file-mtime"
+
+var (
+	// MTimeAttrName is the name of the object metadata attribute holding the
+	// source file mtime. It's written by copy tasks and read when listing GCS
+	// sources.
+	MTimeAttrName = flag.String("mtime-attr-name", MTIME_ATTR_NAME, "The name of the GCS object custom metadata attribute holding the source file mtime.")
+)
+
+// ObjectMTime returns the mtime of the source file an object was copied from,
+// falling back to the object's update time.
+func ObjectMTime(attrs *storage.ObjectAttrs) int64 {
+	if mtime, err := strconv.ParseInt(attrs.Metadata[*MTimeAttrName], 10, 64); err == nil {
+		return mtime
+	}
+	return attrs.Updated.Unix()
+}
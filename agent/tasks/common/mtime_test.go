@@ -0,0 +1,42 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+)
+
+func TestObjectMTime(t *testing.T) {
+	updated := time.Unix(2000, 0)
+	tests := []struct {
+		desc     string
+		metadata map[string]string
+		want     int64
+	}{
+		{"No metadata", nil, 2000},
+		{"MTime metadata", map[string]string{MTIME_ATTR_NAME: "1000"}, 1000},
+		{"Invalid mtime metadata", map[string]string{MTIME_ATTR_NAME: "yesterday"}, 2000},
+	}
+	for _, tc := range tests {
+		attrs := &storage.ObjectAttrs{Metadata: tc.metadata, Updated: updated}
+		if got := ObjectMTime(attrs); got != tc.want {
+			t.Errorf("%s: ObjectMTime() = %d, want %d", tc.desc, got, tc.want)
+		}
+	}
+}
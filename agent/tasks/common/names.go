@@ -0,0 +1,95 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Destination object name normalizations, see the dst-name-normalization flag.
+const (
+	NameNormalizationNFC   = "nfc"
+	NameNormalizationLower = "lower"
+)
+
+var dstNameNormalization nameNormalization
+
+func init() {
+	flag.Var(&dstNameNormalization, "dst-name-normalization", "Comma separated normalizations applied to destination object names. \"nfc\" converts names to Unicode NFC, and \"lower\" converts them to lower case. Copies of source files whose names collide once normalized fail, rather than overwriting each other.")
+}
+
+// nameNormalization is a flag.Value holding the enabled normalizations.
+type nameNormalization struct {
+	nfc, lower bool
+}
+
+// String implements the flag.Value interface.
+func (n *nameNormalization) String() string {
+	var s []string
+	if n.nfc {
+		s = append(s, NameNormalizationNFC)
+	}
+	if n.lower {
+		s = append(s, NameNormalizationLower)
+	}
+	return strings.Join(s, ",")
+}
+
+// Set implements the flag.Value interface.
+func (n *nameNormalization) Set(value string) error {
+	var v nameNormalization
+	for _, s := range strings.Split(value, ",") {
+		switch s {
+		case "":
+		case NameNormalizationNFC:
+			v.nfc = true
+		case NameNormalizationLower:
+			v.lower = true
+		default:
+			return fmt.Errorf("invalid name normalization %q, must be %q or %q", s, NameNormalizationNFC, NameNormalizationLower)
+		}
+	}
+	*n = v
+	return nil
+}
+
+// SetDstNameNormalization sets the destination name normalizations from a
+// dst-name-normalization flag value.
+func SetDstNameNormalization(value string) error {
+	return dstNameNormalization.Set(value)
+}
+
+// DstNamesNormalized returns true if any destination name normalization is
+// enabled.
+func DstNamesNormalized() bool {
+	return dstNameNormalization.nfc || dstNameNormalization.lower
+}
+
+// NormalizeDstName applies the enabled normalizations to a destination object
+// name, or part of one.
+func NormalizeDstName(name string) string {
+	if dstNameNormalization.nfc {
+		name = norm.NFC.String(name)
+	}
+	if dstNameNormalization.lower {
+		name = strings.ToLower(name)
+	}
+	return name
+}
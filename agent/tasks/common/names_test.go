@@ -0,0 +1,51 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+)
+
+func TestNormalizeDstName(t *testing.T) {
+	defer SetDstNameNormalization("")
+	nfd := "cafe\u0301/Re\u0301sume\u0301.txt"
+	tests := []struct {
+		normalization string
+		want          string
+		wantErr       bool
+	}{
+		{"", nfd, false},
+		{"nfc", "caf\u00e9/R\u00e9sum\u00e9.txt", false},
+		{"lower", "cafe\u0301/re\u0301sume\u0301.txt", false},
+		{"nfc,lower", "caf\u00e9/r\u00e9sum\u00e9.txt", false},
+		{"upper", "", true},
+	}
+	for _, tc := range tests {
+		err := SetDstNameNormalization(tc.normalization)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("SetDstNameNormalization(%q) got err: %v, want err: %v", tc.normalization, err, tc.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if got := dstNameNormalization.String(); got != tc.normalization {
+			t.Errorf("String() = %q, want %q", got, tc.normalization)
+		}
+		if got := NormalizeDstName(nfd); got != tc.want {
+			t.Errorf("NormalizeDstName(%q) with %q = %q, want %q", nfd, tc.normalization, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// S3Scheme is the scheme of "s3://bucket/key" source paths, which are read
+// from an S3-compatible store. The agent only supports them when built with
+// the s3 build tag.
+const S3Scheme = "s3://"
+
+// GCSScheme is the scheme of "gs://bucket/object" source paths, which are read
+// from GCS.
+const GCSScheme = "gs://"
+
+// ObjectInfo describes an object or directory of an ObjectSource. It
+// implements os.FileInfo, so objects can be copied like files.
+type ObjectInfo struct {
+	Path  string // The object's source path, including the scheme.
+	Bytes int64
+	MTime time.Time
+	Dir   bool // Whether this is a directory, i.e. a prefix of other objects.
+}
+
+// Name implements os.FileInfo.
+func (o *ObjectInfo) Name() string { return path.Base(o.Path) }
+
+// Size implements os.FileInfo.
+func (o *ObjectInfo) Size() int64 { return o.Bytes }
+
+// Mode implements os.FileInfo.
+func (o *ObjectInfo) Mode() os.FileMode {
+	if o.Dir {
+		return os.ModeDir | 0555
+	}
+	return 0444
+}
+
+// ModTime implements os.FileInfo.
+func (o *ObjectInfo) ModTime() time.Time { return o.MTime }
+
+// IsDir implements os.FileInfo.
+func (o *ObjectInfo) IsDir() bool { return o.Dir }
+
+// Sys implements os.FileInfo.
+func (o *ObjectInfo) Sys() interface{} { return nil }
+
+// ObjectSource is a source of objects other than the local file system, such
+// as an S3-compatible store. Paths include the source's scheme.
+type ObjectSource interface {
+	// Stat returns the info of the object at p.
+	Stat(ctx context.Context, p string) (*ObjectInfo, error)
+	// NewReader returns a reader of the content of the object at p.
+	NewReader(ctx context.Context, p string) (io.ReadCloser, error)
+	// List calls fn for each object directly within the directory at p, and
+	// for each directory nested one level below it.
+	List(ctx context.Context, p string, fn func(*ObjectInfo) error) error
+}
+
+var (
+	objectSourcesMu sync.Mutex
+	objectSources   = make(map[string]ObjectSource) // Keyed by scheme.
+
+	// The schemes which have an ObjectSource in some build of the agent.
+	knownObjectSchemes = []string{S3Scheme}
+)
+
+// RegisterObjectSource registers src as the source of paths with the given
+// scheme, such as "s3://". It replaces any previously registered source.
+func RegisterObjectSource(scheme string, src ObjectSource) {
+	objectSourcesMu.Lock()
+	defer objectSourcesMu.Unlock()
+	if src == nil {
+		delete(objectSources, scheme)
+		return
+	}
+	objectSources[scheme] = src
+}
+
+// ObjectSourceFor returns the ObjectSource of p, or nil if p is a local path.
+// It returns an error if p has the scheme of a source which isn't registered
+// in this build of the agent.
+func ObjectSourceFor(p string) (ObjectSource, error) {
+	objectSourcesMu.Lock()
+	defer objectSourcesMu.Unlock()
+	for scheme, src := range objectSources {
+		if strings.HasPrefix(p, scheme) {
+			return src, nil
+		}
+	}
+	for _, scheme := range knownObjectSchemes {
+		if strings.HasPrefix(p, scheme) {
+			return nil, fmt.Errorf("%s paths such as %s aren't supported by this agent, it must be built with support for them", scheme, p)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"testing"
+)
+
+func TestObjectSourceFor(t *testing.T) {
+	fake := &FakeObjectSource{}
+	defer RegisterObjectSource(S3Scheme, nil)
+	tests := []struct {
+		desc       string
+		register   ObjectSource
+		path       string
+		wantSource ObjectSource
+		wantErr    bool
+	}{
+		{"Local path", nil, "/mnt/a/b", nil, false},
+		{"Unregistered scheme", nil, "s3://bucket/key", nil, true},
+		{"Registered scheme", fake, "s3://bucket/key", fake, false},
+		{"Registered scheme, local path", fake, "/mnt/s3://bucket", nil, false},
+	}
+	for _, tc := range tests {
+		RegisterObjectSource(S3Scheme, tc.register)
+		src, err := ObjectSourceFor(tc.path)
+		if src != tc.wantSource || (err != nil) != tc.wantErr {
+			t.Errorf("%s: ObjectSourceFor(%q) = %v, %v, want %v, err %v", tc.desc, tc.path, src, err, tc.wantSource, tc.wantErr)
+		}
+	}
+}
+
+func TestObjectInfo(t *testing.T) {
+	file := &ObjectInfo{Path: "s3://bucket/a/b.txt", Bytes: 5}
+	if file.Name() != "b.txt" || file.Size() != 5 || file.IsDir() || file.Mode().IsDir() {
+		t.Errorf("file ObjectInfo got name %q, size %d, dir %v, mode %v, want b.txt, 5, false, not a dir", file.Name(), file.Size(), file.IsDir(), file.Mode())
+	}
+	dir := &ObjectInfo{Path: "s3://bucket/a", Dir: true}
+	if dir.Name() != "a" || !dir.IsDir() || !dir.Mode().IsDir() {
+		t.Errorf("dir ObjectInfo got name %q, dir %v, mode %v, want a, true, a dir", dir.Name(), dir.IsDir(), dir.Mode())
+	}
+}
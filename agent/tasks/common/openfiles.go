@@ -0,0 +1,77 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"flag"
+	"sync"
+
+	"golang.org/x/sync/semaphore"
+)
+
+var (
+	maxOpenFiles = flag.Int("max-open-files", 0, "The maximum number of files and directories the copy and list tasks keep open at once. Tasks wait for others to close their files rather than exceeding it. If 0, it's half of the process's soft open file limit, or unlimited if the OS has no such limit.")
+
+	openFilesMu  sync.Mutex          // Protects openFilesSem and openFilesSet.
+	openFilesSem *semaphore.Weighted // Nil if there's no limit.
+	openFilesSet bool                // True once openFilesSem is initialized.
+)
+
+// openFilesLimit returns the max-open-files budget, 0 if it's unlimited.
+func openFilesLimit() int64 {
+	if *maxOpenFiles > 0 {
+		return int64(*maxOpenFiles)
+	}
+	return defaultMaxOpenFiles()
+}
+
+func newOpenFilesSem() *semaphore.Weighted {
+	if n := openFilesLimit(); n > 0 {
+		return semaphore.NewWeighted(n)
+	}
+	return nil
+}
+
+// SetMaxOpenFiles sets the max-open-files budget. Files acquired before the
+// change are released to the old budget.
+func SetMaxOpenFiles(n int) {
+	openFilesMu.Lock()
+	defer openFilesMu.Unlock()
+	*maxOpenFiles = n
+	openFilesSem = newOpenFilesSem()
+	openFilesSet = true
+}
+
+// AcquireOpenFile waits until another file can be opened within the
+// max-open-files budget, or until ctx is done. The returned func must be
+// called once the file is closed.
+func AcquireOpenFile(ctx context.Context) (release func(), err error) {
+	openFilesMu.Lock()
+	if !openFilesSet {
+		openFilesSem = newOpenFilesSem()
+		openFilesSet = true
+	}
+	sem := openFilesSem
+	openFilesMu.Unlock()
+	if sem == nil {
+		return func() {}, nil
+	}
+	if err := sem.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	return func() { sem.Release(1) }, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAcquireOpenFile(t *testing.T) {
+	defer SetMaxOpenFiles(0)
+	SetMaxOpenFiles(1)
+
+	release, err := AcquireOpenFile(context.Background())
+	if err != nil {
+		t.Fatalf("AcquireOpenFile() got err: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := AcquireOpenFile(ctx); err == nil {
+		t.Errorf("AcquireOpenFile() over budget got nil err, want err")
+	}
+
+	// A waiting caller gets the file once it's released.
+	done := make(chan error)
+	go func() {
+		r, err := AcquireOpenFile(context.Background())
+		if err == nil {
+			r()
+		}
+		done <- err
+	}()
+	release()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("AcquireOpenFile() after release got err: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Errorf("AcquireOpenFile() still waiting after release")
+	}
+}
+
+func TestAcquireOpenFileUnlimited(t *testing.T) {
+	defer SetMaxOpenFiles(0)
+	SetMaxOpenFiles(0)
+	if defaultMaxOpenFiles() > 0 {
+		t.Skip("the default budget is limited on this OS")
+	}
+	for i := 0; i < 10; i++ {
+		if _, err := AcquireOpenFile(context.Background()); err != nil {
+			t.Fatalf("AcquireOpenFile() got err: %v", err)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+//go:build !windows
+// +build !windows
+
+package common
+
+import (
+	"math"
+	"syscall"
+)
+
+// defaultMaxOpenFiles returns half of the process's soft open file limit,
+// leaving the rest for sockets, log files and the like. It returns 0 if the
+// limit can't be read or is unlimited.
+func defaultMaxOpenFiles() int64 {
+	var rlim syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &rlim); err != nil {
+		return 0
+	}
+	if uint64(rlim.Cur) > math.MaxInt32 {
+		return 0
+	}
+	return int64(rlim.Cur) / 2
+}
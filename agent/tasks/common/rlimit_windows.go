@@ -0,0 +1,5 @@
+package common
+
+// defaultMaxOpenFiles returns 0, Windows has no per-process open file limit
+// to stay below.
+func defaultMaxOpenFiles() int64 { return 0 }
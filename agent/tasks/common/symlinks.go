@@ -0,0 +1,59 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"flag"
+	"fmt"
+)
+
+// Symlink policies, see the symlink-policy flag.
+const (
+	SymlinkPolicySkip         = "skip"
+	SymlinkPolicyFollow       = "follow"
+	SymlinkPolicyCopyAsObject = "copy-as-object"
+)
+
+var (
+	symlinkPolicy = flag.String("symlink-policy", SymlinkPolicySkip, "How symlinks are handled. \"skip\" omits symlinks from listings, \"follow\" lists the symlink target (symlinks pointing to one of their ancestor directories are skipped), and \"copy-as-object\" copies the symlink target path as the object content.")
+)
+
+// SymlinkPolicy returns the configured symlink policy.
+func SymlinkPolicy() string {
+	return *symlinkPolicy
+}
+
+// SetSymlinkPolicy sets the symlink policy. It returns an error if the policy
+// is not one of the SymlinkPolicy constants.
+func SetSymlinkPolicy(policy string) error {
+	if err := ValidateSymlinkPolicy(policy); err != nil {
+		return err
+	}
+	*symlinkPolicy = policy
+	return nil
+}
+
+// ValidateSymlinkPolicy returns an error if the policy is not one of the
+// SymlinkPolicy constants.
+func ValidateSymlinkPolicy(policy string) error {
+	switch policy {
+	case SymlinkPolicySkip, SymlinkPolicyFollow, SymlinkPolicyCopyAsObject:
+		return nil
+	default:
+		return fmt.Errorf("invalid symlink policy %q, must be one of %q, %q or %q",
+			policy, SymlinkPolicySkip, SymlinkPolicyFollow, SymlinkPolicyCopyAsObject)
+	}
+}
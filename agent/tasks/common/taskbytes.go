@@ -0,0 +1,56 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package common
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+)
+
+type taskBytesKey struct{}
+
+// WithTaskBytes returns a copy of ctx carrying counter, to which readers
+// returned by NewTaskBytesReader for the context add the bytes they read.
+// This lets the bytes copied so far by a single in-flight task be inspected.
+func WithTaskBytes(ctx context.Context, counter *int64) context.Context {
+	return context.WithValue(ctx, taskBytesKey{}, counter)
+}
+
+// taskBytesReader is an io.Reader which adds the bytes read to a task's
+// counter.
+type taskBytesReader struct {
+	reader  io.Reader
+	counter *int64
+}
+
+// NewTaskBytesReader returns a reader adding the bytes read from r to the
+// counter carried by ctx, see WithTaskBytes. Returns r if ctx carries no
+// counter.
+func NewTaskBytesReader(ctx context.Context, r io.Reader) io.Reader {
+	counter, ok := ctx.Value(taskBytesKey{}).(*int64)
+	if !ok {
+		return r
+	}
+	return &taskBytesReader{reader: r, counter: counter}
+}
+
+// Read implements the io.Reader interface.
+func (tbr *taskBytesReader) Read(buf []byte) (int, error) {
+	n, err := tbr.reader.Read(buf)
+	atomic.AddInt64(tbr.counter, int64(n))
+	return n, err
+}
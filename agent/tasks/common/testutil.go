@@ -17,11 +17,15 @@ package common
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
+	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/storage"
 
@@ -46,6 +50,60 @@ func NewStringReadCloser(s string) *stringReadCloser {
 	return &stringReadCloser{strings.NewReader(s), false}
 }
 
+// FakeObjectSource is an in-memory ObjectSource of the Objects content, keyed
+// by their path. All objects have the given MTime.
+type FakeObjectSource struct {
+	Objects map[string]string
+	MTime   time.Time
+}
+
+// Stat implements ObjectSource.
+func (f *FakeObjectSource) Stat(_ context.Context, p string) (*ObjectInfo, error) {
+	content, ok := f.Objects[p]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return &ObjectInfo{Path: p, Bytes: int64(len(content)), MTime: f.MTime}, nil
+}
+
+// NewReader implements ObjectSource.
+func (f *FakeObjectSource) NewReader(_ context.Context, p string) (io.ReadCloser, error) {
+	content, ok := f.Objects[p]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return NewStringReadCloser(content), nil
+}
+
+// List implements ObjectSource. Objects and directories are listed in order.
+func (f *FakeObjectSource) List(_ context.Context, p string, fn func(*ObjectInfo) error) error {
+	prefix := strings.TrimSuffix(p, "/") + "/"
+	var paths []string
+	for objPath := range f.Objects {
+		paths = append(paths, objPath)
+	}
+	sort.Strings(paths)
+	lastDir := ""
+	for _, objPath := range paths {
+		if !strings.HasPrefix(objPath, prefix) {
+			continue
+		}
+		info := &ObjectInfo{Path: objPath, Bytes: int64(len(f.Objects[objPath])), MTime: f.MTime}
+		if i := strings.Index(objPath[len(prefix):], "/"); i >= 0 {
+			dir := objPath[:len(prefix)+i]
+			if dir == lastDir {
+				continue
+			}
+			lastDir = dir
+			info = &ObjectInfo{Path: dir, Dir: true}
+		}
+		if err := fn(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // StringWriteCloser implements WriteCloser interface for faking storage.Writer.
 type StringWriteCloser struct {
 	buffer bytes.Buffer
@@ -1,40 +1,122 @@
 package copy
 
 import (
+	"errors"
+	"flag"
+	"math/rand"
+	"net/http"
+	"strconv"
 	"time"
 )
 
 var (
-	minBackOffDelay  = 1 * time.Second
-	maxBackOffDelay  = 32 * time.Second
-	totalDelayCutoff = 5 * time.Minute
+	minBackOffDelay   = flag.Duration("backoff-initial-delay", 1*time.Second, "The initial delay before retrying a failed resumable copy request.")
+	maxBackOffDelay   = flag.Duration("backoff-max-delay", 32*time.Second, "The maximum delay between retries of a failed resumable copy request.")
+	backOffMultiplier = flag.Float64("backoff-multiplier", 2, "The factor (>= 1) the retry delay grows by after each failed resumable copy request.")
+	maxBackOffRetries = flag.Int("backoff-max-retries", 0, "The maximum number of retries of a failed resumable copy request. If 0, requests are retried until the total delay exceeds 5 minutes.")
+	totalDelayCutoff  = 5 * time.Minute
+
+	// jitter returns the actual delay to wait given the backoff delay. It
+	// applies "full jitter", picking a delay uniformly from [0, delay], so the
+	// many concurrent copy goroutines don't retry in lockstep.
+	jitter = func(delay time.Duration) time.Duration {
+		return time.Duration(rand.Int63n(int64(delay) + 1))
+	}
 )
 
+// validateBackOffFlags returns an error if the backoff flags don't make the
+// delay grow. Without a positive delay the total delay never reaches its
+// cutoff, so failed requests would be retried in a hot loop forever.
+func validateBackOffFlags() error {
+	if *minBackOffDelay <= 0 {
+		return errors.New("backoff-initial-delay must be positive")
+	} else if *maxBackOffDelay <= 0 {
+		return errors.New("backoff-max-delay must be positive")
+	} else if *backOffMultiplier < 1 {
+		return errors.New("backoff-multiplier must be at least 1")
+	} else if *maxBackOffRetries < 0 {
+		return errors.New("backoff-max-retries must not be negative")
+	}
+	return nil
+}
+
 // Backoff provides a back-off scheme for retrying events.
 type BackOff struct {
 	prevDelay  time.Duration
 	totalDelay time.Duration
+	retries    int
 }
 
 // GetDelay returns a delay duration and bool indicating whether or not the caller
 // should continue using the delay and retrying the event. Every iteration the
-// delay grows exponentially up to the maxBackOffDelay.
+// delay grows exponentially up to the maxBackOffDelay, and the returned delay is
+// jittered. The total delay and the retry limit are computed before jitter.
 func (b *BackOff) GetDelay() (time.Duration, bool) {
-	if b.totalDelay > totalDelayCutoff {
+	if b.totalDelay > totalDelayCutoff || (*maxBackOffRetries > 0 && b.retries >= *maxBackOffRetries) {
 		return 0, false
 	}
 	var delay time.Duration
-	if b.prevDelay < minBackOffDelay {
-		delay = minBackOffDelay
-	} else if b.prevDelay >= maxBackOffDelay {
-		delay = maxBackOffDelay
+	if b.prevDelay < *minBackOffDelay {
+		delay = *minBackOffDelay
 	} else {
-		delay = b.prevDelay * 2
-		if delay >= maxBackOffDelay {
-			delay = maxBackOffDelay
+		multiplier := *backOffMultiplier
+		if multiplier < 1 {
+			multiplier = 1
 		}
+		delay = time.Duration(float64(b.prevDelay) * multiplier)
+	}
+	if delay >= *maxBackOffDelay {
+		delay = *maxBackOffDelay
 	}
+	b.retries++
 	b.totalDelay += delay
 	b.prevDelay = delay
-	return delay, true
+	return jitter(delay), true
+}
+
+// WaitAtLeast returns the delay to wait given the delay from GetDelay and the
+// delay requested by the server, and whether the caller should still retry.
+// The requested delay is capped at maxBackOffDelay, and counts towards the
+// total delay in place of the backoff delay.
+func (b *BackOff) WaitAtLeast(delay, requested time.Duration) (time.Duration, bool) {
+	if requested > *maxBackOffDelay {
+		requested = *maxBackOffDelay
+	}
+	if requested <= delay {
+		return delay, true
+	}
+	if requested > b.prevDelay {
+		b.totalDelay += requested - b.prevDelay
+	}
+	if b.totalDelay > totalDelayCutoff {
+		return 0, false
+	}
+	return requested, true
+}
+
+// retryAfter returns the delay requested by the Retry-After header of a 429 or
+// 503 response, and whether there was one. The header may hold a number of
+// seconds or an HTTP date.
+func retryAfter(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp == nil || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != http.StatusServiceUnavailable) {
+		return 0, false
+	}
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	if d := t.Sub(now); d > 0 {
+		return d, true
+	}
+	return 0, true
 }
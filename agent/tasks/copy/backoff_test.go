@@ -1,11 +1,22 @@
 package copy
 
 import (
+	"net/http"
 	"testing"
 	"time"
 )
 
+func noJitter(delay time.Duration) time.Duration {
+	return delay
+}
+
 func TestGetDelay(t *testing.T) {
+	defer func(j func(time.Duration) time.Duration) { jitter = j }(jitter)
+	jitter = noJitter
+	defer func(min, max, total time.Duration) {
+		*minBackOffDelay, *maxBackOffDelay, totalDelayCutoff = min, max, total
+	}(*minBackOffDelay, *maxBackOffDelay, totalDelayCutoff)
+
 	tests := []struct {
 		desc    string
 		minBOD  time.Duration
@@ -18,15 +29,15 @@ func TestGetDelay(t *testing.T) {
 		{"MinDelay=MaxDelay", 1 * time.Second, 1 * time.Second, 10 * time.Second},
 	}
 	for _, tc := range tests {
-		minBackOffDelay = tc.minBOD
-		maxBackOffDelay = tc.maxBOD
+		*minBackOffDelay = tc.minBOD
+		*maxBackOffDelay = tc.maxBOD
 		totalDelayCutoff = tc.totalDC
 
 		var b BackOff
 		for i := uint(0); true; i++ {
-			wantDelay := (1 << i) * minBackOffDelay
-			if wantDelay > maxBackOffDelay {
-				wantDelay = maxBackOffDelay
+			wantDelay := (1 << i) * *minBackOffDelay
+			if wantDelay > *maxBackOffDelay {
+				wantDelay = *maxBackOffDelay
 			}
 			gotDelay, gotRetry := b.GetDelay()
 			if !gotRetry {
@@ -39,8 +50,166 @@ func TestGetDelay(t *testing.T) {
 		if b.totalDelay < totalDelayCutoff {
 			t.Errorf("%v, totalDelay %v, want >= %v", tc.desc, b.totalDelay, totalDelayCutoff)
 		}
-		if maxTotalDelay := totalDelayCutoff + maxBackOffDelay; b.totalDelay > maxTotalDelay {
+		if maxTotalDelay := totalDelayCutoff + *maxBackOffDelay; b.totalDelay > maxTotalDelay {
 			t.Errorf("%v, totalDelay %v, want <= %v", tc.desc, b.totalDelay, maxTotalDelay)
 		}
 	}
 }
+
+func TestGetDelayMultiplier(t *testing.T) {
+	defer func(j func(time.Duration) time.Duration) { jitter = j }(jitter)
+	jitter = noJitter
+	defer func(m float64) { *backOffMultiplier = m }(*backOffMultiplier)
+	*backOffMultiplier = 1.5
+
+	var b BackOff
+	wantDelays := []time.Duration{1 * time.Second, 1500 * time.Millisecond, 2250 * time.Millisecond}
+	for i, wantDelay := range wantDelays {
+		if gotDelay, _ := b.GetDelay(); gotDelay != wantDelay {
+			t.Errorf("iteration %d: gotDelay = %v, want %v", i, gotDelay, wantDelay)
+		}
+	}
+}
+
+func TestGetDelayMaxRetries(t *testing.T) {
+	defer func(r int) { *maxBackOffRetries = r }(*maxBackOffRetries)
+	*maxBackOffRetries = 3
+
+	var b BackOff
+	for i := 0; i < 3; i++ {
+		if _, retry := b.GetDelay(); !retry {
+			t.Errorf("iteration %d: got retry false, want true", i)
+		}
+	}
+	if _, retry := b.GetDelay(); retry {
+		t.Error("got retry true after max retries, want false")
+	}
+}
+
+func TestGetDelayJitter(t *testing.T) {
+	var b BackOff
+	for i := 0; i < 10; i++ {
+		gotDelay, retry := b.GetDelay()
+		if !retry {
+			break
+		}
+		if gotDelay < 0 || gotDelay > b.prevDelay {
+			t.Errorf("iteration %d: gotDelay = %v, want in [0, %v]", i, gotDelay, b.prevDelay)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2019, 9, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		desc       string
+		status     int
+		retryAfter string
+		want       time.Duration
+		wantOK     bool
+	}{
+		{"Seconds", 429, "7", 7 * time.Second, true},
+		{"503 seconds", 503, "120", 2 * time.Minute, true},
+		{"HTTP date", 503, "Sun, 01 Sep 2019 12:00:30 GMT", 30 * time.Second, true},
+		{"HTTP date in the past", 429, "Sun, 01 Sep 2019 11:00:00 GMT", 0, true},
+		{"No header", 429, "", 0, false},
+		{"Negative seconds", 429, "-1", 0, false},
+		{"Invalid", 429, "soon", 0, false},
+		{"Not throttled", 500, "7", 0, false},
+	}
+	for _, tc := range tests {
+		resp := &http.Response{StatusCode: tc.status, Header: make(http.Header)}
+		if tc.retryAfter != "" {
+			resp.Header.Set("Retry-After", tc.retryAfter)
+		}
+		got, ok := retryAfter(resp, now)
+		if got != tc.want || ok != tc.wantOK {
+			t.Errorf("%s: retryAfter() = %v, %v, want %v, %v", tc.desc, got, ok, tc.want, tc.wantOK)
+		}
+	}
+	if _, ok := retryAfter(nil, now); ok {
+		t.Error("retryAfter(nil) got ok, want !ok")
+	}
+}
+
+func TestWaitAtLeast(t *testing.T) {
+	defer func(j func(time.Duration) time.Duration) { jitter = j }(jitter)
+	jitter = noJitter
+	defer func(min, max, total time.Duration) {
+		*minBackOffDelay, *maxBackOffDelay, totalDelayCutoff = min, max, total
+	}(*minBackOffDelay, *maxBackOffDelay, totalDelayCutoff)
+	*minBackOffDelay, *maxBackOffDelay, totalDelayCutoff = 1*time.Second, 32*time.Second, 1*time.Minute
+
+	tests := []struct {
+		desc      string
+		requested time.Duration
+		want      time.Duration
+		wantTotal time.Duration
+	}{
+		{"Shorter than the backoff", 500 * time.Millisecond, 1 * time.Second, 1 * time.Second},
+		{"Longer than the backoff", 10 * time.Second, 10 * time.Second, 10 * time.Second},
+		{"Clamped", 1 * time.Hour, 32 * time.Second, 32 * time.Second},
+	}
+	for _, tc := range tests {
+		var b BackOff
+		delay, _ := b.GetDelay()
+		got, retry := b.WaitAtLeast(delay, tc.requested)
+		if got != tc.want || !retry {
+			t.Errorf("%s: WaitAtLeast() = %v, %v, want %v, true", tc.desc, got, retry, tc.want)
+		}
+		if b.totalDelay != tc.wantTotal {
+			t.Errorf("%s: totalDelay %v, want %v", tc.desc, b.totalDelay, tc.wantTotal)
+		}
+	}
+
+	// Requested delays count towards the total delay cutoff, so a second 32s
+	// wait isn't retried since it would exceed the 1 minute cutoff.
+	var b BackOff
+	for i := 0; ; i++ {
+		delay, retry := b.GetDelay()
+		if retry {
+			delay, retry = b.WaitAtLeast(delay, 1*time.Hour)
+		}
+		if !retry {
+			if i != 1 {
+				t.Errorf("retries stopped after %d delays, want 1", i)
+			}
+			break
+		}
+		if delay != *maxBackOffDelay {
+			t.Errorf("iteration %d: delay %v, want %v", i, delay, *maxBackOffDelay)
+		}
+	}
+	if b.totalDelay <= totalDelayCutoff {
+		t.Errorf("totalDelay %v, want > %v", b.totalDelay, totalDelayCutoff)
+	}
+}
+
+func TestValidateBackOffFlags(t *testing.T) {
+	defer func(min, max time.Duration, m float64, r int) {
+		*minBackOffDelay, *maxBackOffDelay, *backOffMultiplier, *maxBackOffRetries = min, max, m, r
+	}(*minBackOffDelay, *maxBackOffDelay, *backOffMultiplier, *maxBackOffRetries)
+
+	tests := []struct {
+		desc       string
+		minBOD     time.Duration
+		maxBOD     time.Duration
+		multiplier float64
+		retries    int
+		wantErr    bool
+	}{
+		{"Defaults", time.Second, 32 * time.Second, 2, 0, false},
+		{"Zero delays", 0, 0, 2, 0, true},
+		{"Zero initial delay", 0, 32 * time.Second, 2, 0, true},
+		{"Zero max delay", time.Second, 0, 2, 0, true},
+		{"Negative initial delay", -time.Second, 32 * time.Second, 2, 0, true},
+		{"Shrinking multiplier", time.Second, 32 * time.Second, 0.5, 0, true},
+		{"Negative retries", time.Second, 32 * time.Second, 2, -1, true},
+	}
+	for _, tc := range tests {
+		*minBackOffDelay, *maxBackOffDelay, *backOffMultiplier, *maxBackOffRetries = tc.minBOD, tc.maxBOD, tc.multiplier, tc.retries
+		if err := validateBackOffFlags(); (err != nil) != tc.wantErr {
+			t.Errorf("%s: validateBackOffFlags got err %v, want err %v", tc.desc, err, tc.wantErr)
+		}
+	}
+}
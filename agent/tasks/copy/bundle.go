@@ -0,0 +1,42 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package copy
+
+import (
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+// SplitIntoBundles groups files, in order, into CopyBundleSpecs holding at
+// most maxFiles files and at most maxBytes bytes, using each CopySpec's
+// FileBytes. A file larger than maxBytes gets a bundle of its own. A limit of
+// zero or less is ignored.
+func SplitIntoBundles(files []*taskpb.CopySpec, maxFiles int, maxBytes int64) []*taskpb.CopyBundleSpec {
+	var bundles []*taskpb.CopyBundleSpec
+	var curr *taskpb.CopyBundleSpec
+	var currBytes int64
+	for _, f := range files {
+		full := curr != nil && ((maxFiles > 0 && len(curr.BundledFiles) >= maxFiles) ||
+			(maxBytes > 0 && currBytes+f.FileBytes > maxBytes))
+		if curr == nil || full {
+			curr = &taskpb.CopyBundleSpec{}
+			currBytes = 0
+			bundles = append(bundles, curr)
+		}
+		curr.BundledFiles = append(curr.BundledFiles, &taskpb.BundledFile{CopySpec: f})
+		currBytes += f.FileBytes
+	}
+	return bundles
+}
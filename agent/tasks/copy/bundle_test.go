@@ -0,0 +1,62 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package copy
+
+import (
+	"reflect"
+	"testing"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestSplitIntoBundles(t *testing.T) {
+	tests := []struct {
+		desc     string
+		sizes    []int64
+		maxFiles int
+		maxBytes int64
+		want     [][]int64 // The file sizes in each bundle.
+	}{
+		{"No files", nil, 2, 100, nil},
+		{"One bundle", []int64{10, 20, 30}, 5, 100, [][]int64{{10, 20, 30}}},
+		{"Max files", []int64{1, 1, 1, 1, 1}, 2, 100, [][]int64{{1, 1}, {1, 1}, {1}}},
+		{"Max bytes", []int64{40, 40, 40, 20}, 10, 100, [][]int64{{40, 40}, {40, 20}}},
+		{"Exactly max bytes", []int64{50, 50, 1}, 10, 100, [][]int64{{50, 50}, {1}}},
+		{"File over max bytes", []int64{1, 500, 1, 1}, 10, 100, [][]int64{{1}, {500}, {1, 1}}},
+		{"Only a file over max bytes", []int64{500}, 10, 100, [][]int64{{500}}},
+		{"Consecutive files over max bytes", []int64{500, 600}, 10, 100, [][]int64{{500}, {600}}},
+		{"Empty files", []int64{0, 0, 0}, 2, 100, [][]int64{{0, 0}, {0}}},
+		{"No file limit", []int64{1, 1, 1}, 0, 100, [][]int64{{1, 1, 1}}},
+		{"No byte limit", []int64{500, 600, 700}, 2, 0, [][]int64{{500, 600}, {700}}},
+	}
+	for _, tc := range tests {
+		var files []*taskpb.CopySpec
+		for _, s := range tc.sizes {
+			files = append(files, &taskpb.CopySpec{FileBytes: s})
+		}
+		var got [][]int64
+		for _, b := range SplitIntoBundles(files, tc.maxFiles, tc.maxBytes) {
+			var sizes []int64
+			for _, bf := range b.BundledFiles {
+				sizes = append(sizes, bf.CopySpec.FileBytes)
+			}
+			got = append(got, sizes)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: SplitIntoBundles(%v, %d, %d) = %v, want %v", tc.desc, tc.sizes, tc.maxFiles, tc.maxBytes, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package copy
+
+import (
+	"flag"
+	"time"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+const (
+	// GCS requires the chunks of a resumable upload, other than the final
+	// chunk, to be a multiple of 256KiB.
+	chunkSizeAlignment = 256 * 1024
+
+	// Adaptive chunks are sized so a request takes about this long at the
+	// throughput of the previous chunk.
+	adaptiveChunkTargetDur = 30 * time.Second
+)
+
+var (
+	adaptiveChunkSize    = flag.Bool("adaptive-chunk-size", false, "Adjust the size of each resumable copy request from copy-chunk-size, based on the throughput and retries of the file's previous request. Chunks grow on fast links, which amortizes the per-request overhead, and shrink on slow or flaky links, which wastes less on retries.")
+	adaptiveChunkMinSize = flag.Int64("adaptive-chunk-min-size", 16*1024*1024, "The smallest chunk size used with adaptive-chunk-size.")
+	adaptiveChunkMaxSize = flag.Int64("adaptive-chunk-max-size", 1024*1024*1024, "The largest chunk size used with adaptive-chunk-size.")
+)
+
+// chunkSize returns the number of bytes the next resumable copy request of c
+// sends. A value <= 0 indicates that the rest of the file is sent.
+func chunkSize(c *taskpb.CopySpec) int64 {
+	if *adaptiveChunkSize && *copyChunkSize > 0 && c.ChunkSize > 0 {
+		return c.ChunkSize
+	}
+	return int64(*copyChunkSize)
+}
+
+// nextChunkSize returns the size of the chunk following a chunk of size bytes,
+// whose request took dur after the given number of retries. A chunk that was
+// retried halves the size. Otherwise the size targets adaptiveChunkTargetDur
+// at the chunk's throughput, but at most halves or doubles. The result is
+// bounded by the adaptive-chunk-min-size and adaptive-chunk-max-size flags, and
+// aligned to chunkSizeAlignment.
+func nextChunkSize(size int64, dur time.Duration, retries int) int64 {
+	next := size / 2
+	if retries == 0 {
+		next = size * 2
+		if dur > 0 {
+			if n := int64(float64(size) * float64(adaptiveChunkTargetDur) / float64(dur)); n < next {
+				next = n
+			}
+		}
+		if next < size/2 {
+			next = size / 2
+		}
+	}
+	if next < *adaptiveChunkMinSize {
+		next = *adaptiveChunkMinSize
+	}
+	if next > *adaptiveChunkMaxSize {
+		next = *adaptiveChunkMaxSize
+	}
+	next -= next % chunkSizeAlignment
+	if next < chunkSizeAlignment {
+		next = chunkSizeAlignment
+	}
+	return next
+}
@@ -0,0 +1,114 @@
+package copy
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+const mib = 1024 * 1024
+
+func TestChunkSize(t *testing.T) {
+	defer func(b bool, v int) { *adaptiveChunkSize, *copyChunkSize = b, v }(*adaptiveChunkSize, *copyChunkSize)
+	tests := []struct {
+		desc          string
+		adaptive      bool
+		copyChunkSize int
+		specChunkSize int64
+		want          int64
+	}{
+		{"Not adaptive", false, 128 * mib, 64 * mib, 128 * mib},
+		{"Adaptive, first chunk", true, 128 * mib, 0, 128 * mib},
+		{"Adaptive", true, 128 * mib, 64 * mib, 64 * mib},
+		{"Adaptive, whole file", true, 0, 64 * mib, 0},
+	}
+	for _, tc := range tests {
+		*adaptiveChunkSize = tc.adaptive
+		*copyChunkSize = tc.copyChunkSize
+		c := &taskpb.CopySpec{ChunkSize: tc.specChunkSize}
+		if got := chunkSize(c); got != tc.want {
+			t.Errorf("%s: chunkSize() = %d, want %d", tc.desc, got, tc.want)
+		}
+	}
+}
+
+func TestNextChunkSize(t *testing.T) {
+	defer func(min, max int64) { *adaptiveChunkMinSize, *adaptiveChunkMaxSize = min, max }(*adaptiveChunkMinSize, *adaptiveChunkMaxSize)
+	*adaptiveChunkMinSize = 16 * mib
+	*adaptiveChunkMaxSize = 1024 * mib
+	tests := []struct {
+		desc    string
+		size    int64
+		dur     time.Duration
+		retries int
+		want    int64
+	}{
+		{"On target", 128 * mib, 30 * time.Second, 0, 128 * mib},
+		{"Fast", 128 * mib, 20 * time.Second, 0, 192 * mib},
+		{"Very fast doubles", 128 * mib, time.Second, 0, 256 * mib},
+		{"No duration doubles", 128 * mib, 0, 0, 256 * mib},
+		{"Slow", 128 * mib, 40 * time.Second, 0, 96 * mib},
+		{"Very slow halves", 128 * mib, 10 * time.Minute, 0, 64 * mib},
+		{"Retried halves", 128 * mib, time.Second, 1, 64 * mib},
+		{"Min size", 16 * mib, time.Second, 3, 16 * mib},
+		{"Max size", 1024 * mib, time.Second, 0, 1024 * mib},
+		{"Aligned", 100 * mib, 31 * time.Second, 0, 96*mib + 768*1024},
+	}
+	for _, tc := range tests {
+		if got := nextChunkSize(tc.size, tc.dur, tc.retries); got != tc.want {
+			t.Errorf("%s: nextChunkSize(%d, %v, %d) = %d, want %d", tc.desc, tc.size, tc.dur, tc.retries, got, tc.want)
+		}
+	}
+}
+
+func TestCopyResumableChunkAdaptive(t *testing.T) {
+	defer func(b bool) { *adaptiveChunkSize = b }(*adaptiveChunkSize)
+	*adaptiveChunkSize = true
+	h := CopyHandler{}
+	var gotContentLength int64
+	h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
+		gotContentLength = req.ContentLength
+		io.Copy(ioutil.Discard, req.Body)
+		return &http.Response{
+			StatusCode: 200,
+			Header:     make(map[string][]string),
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	}
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	srcFile, err := os.Open(tmpFile)
+	if err != nil {
+		t.Fatalf("os.Open(%q) got err: %v", tmpFile, err)
+	}
+	defer srcFile.Close()
+	stats, err := srcFile.Stat()
+	if err != nil {
+		t.Fatalf("Stat() got err: %v", err)
+	}
+
+	copySpec := testCopySpec(77, 1000, "ruID").GetCopySpec()
+	copySpec.ChunkSize = 10
+	if err := h.copyResumableChunk(context.Background(), "", copySpec, srcFile, stats, &taskpb.CopyLog{}); err != nil {
+		t.Fatalf("copyResumableChunk got err: %v", err)
+	}
+	if gotContentLength != 10 {
+		t.Errorf("copyResumableChunk sent %d bytes, want 10", gotContentLength)
+	}
+	if copySpec.BytesCopied != 10 {
+		t.Errorf("copySpec.BytesCopied = %d, want 10", copySpec.BytesCopied)
+	}
+	if copySpec.ChunkSize != *adaptiveChunkMinSize {
+		t.Errorf("copySpec.ChunkSize = %d, want %d", copySpec.ChunkSize, *adaptiveChunkMinSize)
+	}
+}
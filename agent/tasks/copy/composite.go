@@ -0,0 +1,267 @@
+package copy
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/hashing"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/rate"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/stats"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/glog"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+const (
+	// GCS allows at most 32 source objects in a single compose request.
+	maxComposeComponents = 32
+
+	// componentTokenLen is the number of hex digits of the token which makes
+	// the component names of each composite upload attempt unique.
+	componentTokenLen = 16
+)
+
+var (
+	// componentToken returns a random token for the component names of a
+	// composite upload attempt, so concurrent or retried uploads of the same
+	// file never overwrite or delete each other's components.
+	componentToken = func() (string, error) {
+		b := make([]byte, componentTokenLen/2)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(b), nil
+	}
+)
+
+// component is a byte range of a source file which is uploaded as a temporary
+// GCS object, and later composed into the final object.
+type component struct {
+	name       string
+	offset     int64
+	length     int64
+	crc32c     uint32
+	generation int64 // Non-zero once the component object has been written.
+}
+
+// componentName returns the name of the temporary object holding the i'th
+// component of dstObject, for the upload attempt identified by token.
+func componentName(dstObject, token string, i int) string {
+	return fmt.Sprintf("%s.cloud-ingest-component-%s-%d", dstObject, token, i)
+}
+
+// splitComponents splits a file of the given size into at most n components,
+// named for the upload attempt identified by token.
+func splitComponents(dstObject, token string, size int64, n int) []*component {
+	if n > maxComposeComponents {
+		n = maxComposeComponents
+	}
+	if int64(n) > size {
+		n = int(size)
+	}
+	if n < 1 {
+		n = 1
+	}
+	length := (size + int64(n) - 1) / int64(n)
+	var comps []*component
+	for offset := int64(0); offset < size || len(comps) == 0; offset += length {
+		if offset+length > size {
+			length = size - offset
+		}
+		comps = append(comps, &component{
+			name:   componentName(dstObject, token, len(comps)),
+			offset: offset,
+			length: length,
+		})
+	}
+	return comps
+}
+
+// copyComponent uploads a single component of srcFile to its temporary object
+// and verifies the component's CRC32C.
+// All components of a file share fileLimiter, which may be nil.
+func (h *CopyHandler) copyComponent(ctx context.Context, jobRun string, c *taskpb.CopySpec, srcFile *os.File, comp *component, fileLimiter *rate.FileLimiter) error {
+	w := h.gcs.NewWriter(ctx, c.DstBucket, comp.name)
+	if t, ok := w.(*storage.Writer); ok {
+		// Components are stored like the final object until they're deleted.
+		t.StorageClass = c.StorageClass
+		t.KMSKeyName = c.KmsKeyName
+	}
+
+	var srcCRC32C uint32
+	r := h.statsTracker.NewCopyByteTrackingReader(jobRun, io.NewSectionReader(srcFile, comp.offset, comp.length))
+	r = common.NewTaskBytesReader(ctx, r)
+	r = rate.NewFileRateLimitingReader(r, fileLimiter) // Wrap with a RateLimitingReader.
+	r = hashing.NewCRC32CUpdatingReader(r, &srcCRC32C) // Wrap with a CRC32CUpdatingReader.
+	tr := stats.NewTimingReader(r)                     // Wrap with a TimingReader.
+
+	writeStart := time.Now()
+	_, err := io.Copy(w, tr)
+	h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopyWriteMs: stats.DurMs(writeStart.Add(tr.ReadDur()))})
+	if err != nil {
+		w.CloseWithError(err)
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	dstAttrs := w.Attrs()
+	comp.generation = dstAttrs.Generation
+	comp.crc32c = srcCRC32C
+	if dstAttrs.CRC32C != srcCRC32C {
+		return common.AgentError{
+			Msg: fmt.Sprintf("CRC32C mismatch for file %s component %s (%d) against object %s (%d)",
+				c.SrcFile, comp.name, srcCRC32C, comp.name, dstAttrs.CRC32C),
+			FailureType: taskpb.FailureType_HASH_MISMATCH_FAILURE,
+		}
+	}
+	return nil
+}
+
+// deleteComponents deletes the temporary objects of all written components.
+// Failures are logged, since they don't affect the final object.
+func (h *CopyHandler) deleteComponents(bucket string, comps []*component) {
+	// Use a fresh context so cleanup still happens if the copy was cancelled.
+	ctx := context.Background()
+	for _, comp := range comps {
+		if comp.generation == 0 {
+			continue
+		}
+		if err := h.gcs.DeleteObject(ctx, bucket, comp.name, comp.generation); err != nil {
+			glog.Warningf("Failed to delete composite upload component %v/%v, err: %v", bucket, comp.name, err)
+		}
+	}
+}
+
+// copyComposite performs a parallel composite upload of srcFile. The file is
+// split into components which are uploaded concurrently, then composed into
+// the destination object. The temporary component objects are always deleted.
+// The upload can't be resumed by a later task, so it isn't bounded by the
+// copy-work-duration, but no more components are started once the job run is
+// paused.
+func (h *CopyHandler) copyComposite(ctx context.Context, jobRun string, c *taskpb.CopySpec, srcFile *os.File, fileinfo os.FileInfo, cl *taskpb.CopyLog) error {
+	token, err := componentToken()
+	if err != nil {
+		return err
+	}
+	comps := splitComponents(c.DstObject, token, fileinfo.Size(), *compositeUploadComponents)
+	defer h.deleteComponents(c.DstBucket, comps)
+
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	compChan := make(chan *component, len(comps))
+	for _, comp := range comps {
+		compChan <- comp
+	}
+	close(compChan)
+	errChan := make(chan error, len(comps))
+	fileLimiter := rate.NewFileLimiter()
+	worker := func() {
+		for comp := range compChan {
+			if cctx.Err() != nil {
+				return
+			}
+			if !rate.IsJobRunActive(jobRun) {
+				errChan <- common.AgentError{
+					Msg:         fmt.Sprintf("job run %s is not active", jobRun),
+					FailureType: taskpb.FailureType_NOT_ACTIVE_JOBRUN,
+				}
+				cancel()
+				return
+			}
+			if err := h.copyComponent(cctx, jobRun, c, srcFile, comp, fileLimiter); err != nil {
+				errChan <- err
+				cancel()
+			}
+		}
+	}
+
+	// The calling goroutine always uploads components. Additional workers are
+	// only started while the concurrentCopySem has spare capacity, which
+	// bounds the concurrency without deadlocking against bundled copies that
+	// already hold the semaphore.
+	var wg sync.WaitGroup
+	for i := 1; i < len(comps) && h.concurrentCopySem.TryAcquire(1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer h.concurrentCopySem.Release(1)
+			worker()
+		}()
+	}
+	worker()
+	wg.Wait()
+	close(errChan)
+	if err := <-errChan; err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Compose the final object, and compute its expected CRC32C.
+	var srcNames []string
+	var srcCRC32C uint32
+	for i, comp := range comps {
+		srcNames = append(srcNames, comp.name)
+		if i == 0 {
+			srcCRC32C = comp.crc32c
+		} else {
+			srcCRC32C = hashing.CombineCRC32C(srcCRC32C, comp.crc32c, comp.length)
+		}
+	}
+	attrs := &storage.ObjectAttrs{
+		Metadata:      objectMetadata(c, fileinfo),
+		StorageClass:  c.StorageClass,
+		ContentType:   c.ContentType,
+		PredefinedACL: c.PredefinedAcl,
+	}
+	dstName, cond := c.DstObject, common.GetGCSGenerationNumCondition(expectedGeneration(c))
+	if *uploadViaTempObject {
+		// The destination object's preconditions are checked when the
+		// temporary object is copied to it.
+		dstName, cond = tempObjectName(c.DstObject), storage.Conditions{}
+	}
+	dstAttrs, err := h.gcs.Compose(ctx, c.DstBucket, dstName, srcNames, cond, attrs)
+	if err != nil {
+		return objectExistsError(c, err)
+	}
+	if dstName != c.DstObject {
+		defer h.deleteTempObject(c.DstBucket, dstName, dstAttrs.Generation)
+	}
+
+	// Record some attributes. Composite objects have no MD5.
+	cl.DstBytes = dstAttrs.Size
+	cl.DstCrc32C = dstAttrs.CRC32C
+	cl.DstMTime = dstAttrs.Updated.Unix()
+	cl.DstMetadata = dstAttrs.Metadata
+	cl.SrcCrc32C = srcCRC32C
+	cl.BytesCopied = fileinfo.Size()
+
+	// Verify the CRC32C.
+	if dstAttrs.CRC32C != srcCRC32C {
+		return common.AgentError{
+			Msg: fmt.Sprintf("CRC32C mismatch for file %s (%d) against object %s (%d)",
+				c.SrcFile, srcCRC32C, c.DstObject, dstAttrs.CRC32C),
+			FailureType: taskpb.FailureType_HASH_MISMATCH_FAILURE,
+		}
+	}
+	if dstName != c.DstObject {
+		if dstAttrs, err = h.finalizeTempObject(ctx, c, dstName, dstAttrs); err != nil {
+			return err
+		}
+		cl.DstMTime = dstAttrs.Updated.Unix()
+		cl.DstMetadata = dstAttrs.Metadata
+	}
+	h.recordUpload(jobRun, c, fileinfo.Size(), srcCRC32C, dstAttrs.Generation, dstAttrs.ContentType)
+	return nil
+}
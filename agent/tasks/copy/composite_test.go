@@ -0,0 +1,223 @@
+package copy
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/hashing"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/rate"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/mock/gomock"
+	"golang.org/x/sync/semaphore"
+
+	controlpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/control_go_proto"
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestSplitComponents(t *testing.T) {
+	tests := []struct {
+		desc        string
+		size        int64
+		n           int
+		wantLengths []int64
+	}{
+		{"Even split", 9, 3, []int64{3, 3, 3}},
+		{"Uneven split", 10, 3, []int64{4, 4, 2}},
+		{"More components than bytes", 2, 8, []int64{1, 1}},
+		{"Single component", 5, 1, []int64{5}},
+		{"Empty file", 0, 4, []int64{0}},
+		{"Too many components", 64, 64, []int64{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2}},
+	}
+	for _, tc := range tests {
+		comps := splitComponents("object", "token", tc.size, tc.n)
+		if len(comps) != len(tc.wantLengths) {
+			t.Errorf("%s: got %d components, want %d", tc.desc, len(comps), len(tc.wantLengths))
+			continue
+		}
+		var offset int64
+		for i, comp := range comps {
+			if comp.offset != offset || comp.length != tc.wantLengths[i] {
+				t.Errorf("%s: component %d got offset %d length %d, want offset %d length %d",
+					tc.desc, i, comp.offset, comp.length, offset, tc.wantLengths[i])
+			}
+			if want := componentName("object", "token", i); comp.name != want {
+				t.Errorf("%s: component %d got name %q, want %q", tc.desc, i, comp.name, want)
+			}
+			offset += comp.length
+		}
+	}
+}
+
+func setupComponentWriters(mockGCS *gcloud.MockGCS, comps []*component, badComponent int) {
+	for i, comp := range comps {
+		content := testFileContent[comp.offset : comp.offset+comp.length]
+		crc := hashing.CRC32C([]byte(content))
+		if i == badComponent {
+			crc++
+		}
+		writer := common.NewStringWriteCloser(&storage.ObjectAttrs{CRC32C: crc, Generation: int64(i + 1)})
+		mockGCS.EXPECT().NewWriter(gomock.Any(), "bucket", comp.name).Return(writer)
+		mockGCS.EXPECT().DeleteObject(gomock.Any(), "bucket", comp.name, int64(i+1)).Return(nil)
+	}
+}
+
+func TestCopyComposite(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	defer func(n int) { *compositeUploadComponents = n }(*compositeUploadComponents)
+	*compositeUploadComponents = 4
+
+	defer func(f func() (string, error)) { componentToken = f }(componentToken)
+	componentToken = func() (string, error) { return "token", nil }
+	setActiveJobRun("jobrun")
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	srcFile, _ := os.Open(tmpFile)
+	defer srcFile.Close()
+	fileinfo, _ := srcFile.Stat()
+
+	comps := splitComponents("object", "token", fileinfo.Size(), *compositeUploadComponents)
+	var names []string
+	for _, comp := range comps {
+		names = append(names, comp.name)
+	}
+	gcsModTime := time.Now()
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	setupComponentWriters(mockGCS, comps, -1)
+	mockGCS.EXPECT().Compose(gomock.Any(), "bucket", "object", names, storage.Conditions{DoesNotExist: true}, gomock.Any()).Return(
+		&storage.ObjectAttrs{CRC32C: testCRC32C, Size: fileinfo.Size(), Updated: gcsModTime}, nil)
+
+	h := CopyHandler{gcs: mockGCS, concurrentCopySem: semaphore.NewWeighted(2)}
+	c := testCopySpec(0, 0, "").GetCopySpec()
+	cl := &taskpb.CopyLog{}
+	if err := h.copyComposite(context.Background(), "jobrun", c, srcFile, fileinfo, cl); err != nil {
+		t.Fatalf("copyComposite got err: %v", err)
+	}
+	if cl.SrcCrc32C != testCRC32C || cl.DstCrc32C != testCRC32C {
+		t.Errorf("copyComposite got SrcCrc32C %d DstCrc32C %d, want %d", cl.SrcCrc32C, cl.DstCrc32C, testCRC32C)
+	}
+	if cl.BytesCopied != fileinfo.Size() || cl.DstMTime != gcsModTime.Unix() {
+		t.Errorf("copyComposite got log %+v", cl)
+	}
+}
+
+func TestCopyCompositeViaTempObject(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	defer func(n int) { *compositeUploadComponents = n }(*compositeUploadComponents)
+	*compositeUploadComponents = 2
+	defer func(b bool) { *uploadViaTempObject = b }(*uploadViaTempObject)
+	*uploadViaTempObject = true
+
+	defer func(f func() (string, error)) { componentToken = f }(componentToken)
+	componentToken = func() (string, error) { return "token", nil }
+	setActiveJobRun("jobrun")
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	srcFile, _ := os.Open(tmpFile)
+	defer srcFile.Close()
+	fileinfo, _ := srcFile.Stat()
+
+	comps := splitComponents("object", "token", fileinfo.Size(), *compositeUploadComponents)
+	var names []string
+	for _, comp := range comps {
+		names = append(names, comp.name)
+	}
+	tmpName := tempObjectName("object")
+	gcsModTime := time.Now()
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	setupComponentWriters(mockGCS, comps, -1)
+	// The temporary object is composed without preconditions, which are
+	// instead checked when it's copied to the destination object.
+	mockGCS.EXPECT().Compose(gomock.Any(), "bucket", tmpName, names, storage.Conditions{}, gomock.Any()).Return(
+		&storage.ObjectAttrs{CRC32C: testCRC32C, Size: fileinfo.Size(), Generation: 7}, nil)
+	mockGCS.EXPECT().CopyObject(gomock.Any(), "bucket", tmpName, int64(7), "bucket", "object", storage.Conditions{DoesNotExist: true}, gomock.Any()).Return(
+		&storage.ObjectAttrs{CRC32C: testCRC32C, Size: fileinfo.Size(), Generation: 8, Updated: gcsModTime}, nil)
+	mockGCS.EXPECT().DeleteObject(gomock.Any(), "bucket", tmpName, int64(7)).Return(nil)
+
+	h := CopyHandler{gcs: mockGCS, concurrentCopySem: semaphore.NewWeighted(2)}
+	c := testCopySpec(0, 0, "").GetCopySpec()
+	cl := &taskpb.CopyLog{}
+	if err := h.copyComposite(context.Background(), "jobrun", c, srcFile, fileinfo, cl); err != nil {
+		t.Fatalf("copyComposite got err: %v", err)
+	}
+	if cl.DstCrc32C != testCRC32C || cl.DstMTime != gcsModTime.Unix() {
+		t.Errorf("copyComposite got log %+v", cl)
+	}
+}
+
+func TestCopyCompositeComponentMismatch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	defer func(n int) { *compositeUploadComponents = n }(*compositeUploadComponents)
+	*compositeUploadComponents = 2
+
+	defer func(f func() (string, error)) { componentToken = f }(componentToken)
+	componentToken = func() (string, error) { return "token", nil }
+	setActiveJobRun("jobrun")
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	srcFile, _ := os.Open(tmpFile)
+	defer srcFile.Close()
+	fileinfo, _ := srcFile.Stat()
+
+	// The components are uploaded serially since the semaphore has no spare
+	// capacity, so the second component is never uploaded.
+	comps := splitComponents("object", "token", fileinfo.Size(), *compositeUploadComponents)
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	setupComponentWriters(mockGCS, comps[:1], 0)
+
+	h := CopyHandler{gcs: mockGCS, concurrentCopySem: semaphore.NewWeighted(0)}
+	c := testCopySpec(0, 0, "").GetCopySpec()
+	err := h.copyComposite(context.Background(), "jobrun", c, srcFile, fileinfo, &taskpb.CopyLog{})
+	if got := common.GetFailureTypeFromError(err); got != taskpb.FailureType_HASH_MISMATCH_FAILURE {
+		t.Errorf("copyComposite got failure type %v (err: %v), want HASH_MISMATCH_FAILURE", got, err)
+	}
+}
+
+// setActiveJobRun gives jobRun bandwidth, so it's active.
+func setActiveJobRun(jobRun string) {
+	rate.ProcessJobRunBandwidths([]*controlpb.JobRunBandwidth{
+		&controlpb.JobRunBandwidth{JobrunRelRsrcName: jobRun, Bandwidth: 10 * 1024 * 1024},
+	}, nil)
+}
+
+func TestCopyCompositeJobRunPaused(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	setActiveJobRun("jobrun")
+	rate.ProcessJobRunPauses([]*controlpb.JobRunPause{&controlpb.JobRunPause{JobrunRelRsrcName: "jobrun", Paused: true}})
+	defer rate.ProcessJobRunPauses([]*controlpb.JobRunPause{&controlpb.JobRunPause{JobrunRelRsrcName: "jobrun", Paused: false}})
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	srcFile, _ := os.Open(tmpFile)
+	defer srcFile.Close()
+	fileinfo, _ := srcFile.Stat()
+
+	// No components are uploaded.
+	h := CopyHandler{gcs: gcloud.NewMockGCS(mockCtrl), concurrentCopySem: semaphore.NewWeighted(0)}
+	c := testCopySpec(0, 0, "").GetCopySpec()
+	err := h.copyComposite(context.Background(), "jobrun", c, srcFile, fileinfo, &taskpb.CopyLog{})
+	if got := common.GetFailureTypeFromError(err); got != taskpb.FailureType_NOT_ACTIVE_JOBRUN {
+		t.Errorf("copyComposite got failure type %v (err: %v), want NOT_ACTIVE_JOBRUN", got, err)
+	}
+}
+
+func TestComponentToken(t *testing.T) {
+	t1, err := componentToken()
+	if err != nil {
+		t.Fatalf("componentToken got err: %v", err)
+	}
+	t2, _ := componentToken()
+	if len(t1) != componentTokenLen || t1 == t2 {
+		t.Errorf("componentToken got %q and %q, want distinct tokens of %d hex digits", t1, t2, componentTokenLen)
+	}
+}
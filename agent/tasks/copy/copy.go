@@ -19,31 +19,42 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
+	"math"
 	"net"
 	"net/http"
 	"os"
 	"path"
 	"runtime"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"cloud.google.com/go/storage"
 	agentcommon "github.com/GoogleCloudPlatform/cloud-ingest/agent/common"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/hashing"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/rate"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/stats"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tracing"
 	"github.com/golang/glog"
 	"github.com/golang/protobuf/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/net/context/ctxhttp"
+	"golang.org/x/net/http/httpguts"
 	"golang.org/x/sync/semaphore"
 	"google.golang.org/api/gensupport"
 	"google.golang.org/api/googleapi"
@@ -57,17 +68,34 @@ import (
 const (
 	userAgent         = "google-cloud-ingest-on-premises-agent TransferService/1.0 (GPN:transferservice_onpremnfs; Data moved from onpremnfs to GCS)"
 	userAgentInternal = "google-cloud-ingest-on-premises-agent"
-	MTIME_ATTR_NAME   = "goog-reserved-file-mtime"
+	MTIME_ATTR_NAME   = common.MTIME_ATTR_NAME
+
+	// The smallest read buffer used for a file copy, matching bufio's default.
+	minFileReadBuf = 4 * 1024
 )
 
 var (
-	internalTesting     = flag.Bool("internal-testing", false, "Agent running for Google internal testing purposes.")
-	copyFilesPerCPU     = flag.Int("copy-files-per-cpu", 8, "Files to copy (per CPU) in parallel. Can be overridden by setting copy-files.")
-	copyFiles           = flag.Int("copy-files", 0, "Files to copy in parallel. If > 0 this will override copy-files-per-cpu.")
-	fileReadBuf         = flag.Int("file-read-buf", 1*1024*1024, "Read buffer size for each concurrent file copy. Increasing this raises Agent memory usage, but decreases potential reads to the source file system.")
-	copyChunkSize       = flag.Int("copy-chunk-size", 128*1024*1024, "The amount of bytes to send in a single HTTP request.")
-	copyEntireFileLimit = flag.Int("copy-entire-file-limit", 8*1024*1024, "Copy a file in a single HTTP request if it's below this size.")
-	copyWorkDuration    = flag.Duration("copy-work-duration", 1*time.Minute, "The amount of time to spend copying a single file.")
+	internalTesting           = flag.Bool("internal-testing", false, "Agent running for Google internal testing purposes.")
+	copyFilesPerCPU           = flag.Int("copy-files-per-cpu", 8, "Files to copy (per CPU) in parallel. Can be overridden by setting copy-files.")
+	copyFiles                 = flag.Int("copy-files", 0, "Files to copy in parallel. If > 0 this will override copy-files-per-cpu.")
+	bundleFileConcurrency     = flag.Int("bundle-file-concurrency", 0, "Files of a single copy bundle to copy in parallel. If > 0, each bundle takes one of the copy-files slots for all its files, so bundles of small, latency bound files can fan out beyond copy-files. Files larger than copy-entire-file-limit are still copied one at a time per bundle. If 0, each bundled file takes one of the copy-files slots.")
+	fileReadBuf               = flag.Int("file-read-buf", 1*1024*1024, "Maximum read buffer size for each concurrent file copy. Smaller files get a buffer scaled to their size. Increasing this raises Agent memory usage, but decreases potential reads to the source file system.")
+	copyChunkSize             = flag.Int("copy-chunk-size", 128*1024*1024, "The amount of bytes to send in a single HTTP request.")
+	readAheadBuffers          = flag.Int("read-ahead-buffers", 0, "The number of file-read-buf sized buffers each resumable copy request reads ahead of the upload, so reading the source file overlaps with sending it. If 0, the file is read as it's sent.")
+	copyEntireFileLimit       = flag.Int("copy-entire-file-limit", 8*1024*1024, "Copy a file in a single HTTP request if it's below this size.")
+	compositeUploadThreshold  = flag.Int64("composite-upload-threshold", 0, "Copy files of at least this size as parallel composite uploads. Composite uploads are disabled if this is 0.")
+	compositeUploadComponents = flag.Int("composite-upload-components", 8, "The number of components (at most 32) a parallel composite upload is split into.")
+	copyWorkDuration          = flag.Duration("copy-work-duration", 1*time.Minute, "The amount of time to spend copying a single file.")
+	chunkRequestTimeout       = flag.Duration("chunk-request-timeout", 0, "The timeout of each resumable copy request, after which the request is retried. Each retry gets a new timeout. If 0, requests don't time out.")
+	verifyMD5                 = flag.Bool("verify-md5", false, "Compute the MD5 of each source file and verify it against the MD5 of the GCS object. Only files copied in a single request are verified, since the MD5 can't be carried across resumable copy requests.")
+	skipUnchanged             = flag.Bool("skip-unchanged", false, "Skip copying files whose destination object already exists with the same size and mtime, and whose generation matches the task's expected generation.")
+	deleteSource              = flag.Bool("delete-source-on-success", false, "Delete each source file once its copy to GCS has completed and been verified.")
+	computeSHA256             = flag.Bool("compute-sha256", false, "Compute the SHA256 of each source file and record it in the copy log, for auditing. Only files copied in a single request are hashed. This is CPU intensive.")
+	gzipFiles                 = flag.String("gzip-files", "", "Comma separated glob patterns (e.g. \"*.log,*.csv\") matched against source file names. Matching files are compressed and uploaded with Content-Encoding: gzip, in a single copy request.")
+	mtimeAttrName             = common.MTimeAttrName // Shared with the list handlers.
+	preservePOSIX             = flag.Bool("preserve-posix", false, "Store the uid, gid and mode of each source file as custom metadata on the GCS object. Has no effect on Windows.")
+	minFileStabilityAge       = flag.Duration("min-file-stability-age", 0, "Don't copy files modified more recently than this, since they may still be being written. Their copy tasks are nacked, to be redelivered later. If 0, files are copied regardless of their mtime.")
+	trustSourceChecksum       = flag.Bool("trust-source-checksum", false, "If a copy task carries the CRC32C of its source file, send it to GCS to verify the upload instead of computing it. This saves CPU for trusted sources which already checksum their files. Gzipped and composite uploads always compute the CRC32C.")
 )
 
 // NewResumableHttpClient creates a new http.Client suitable for resumable copies.
@@ -82,11 +110,15 @@ func NewResumableHttpClient(ctx context.Context, opts ...option.ClientOption) (*
 		option.WithUserAgent(userAgentStr),
 	}
 	opts = append(o, opts...)
-	hc, _, err := htransport.NewClient(ctx, opts...)
+	base, err := baseTransport()
 	if err != nil {
 		return nil, fmt.Errorf("couldn't create resumable HTTP client, err: %v", err)
 	}
-	return hc, nil
+	trans, err := htransport.NewTransport(ctx, base, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create resumable HTTP client, err: %v", err)
+	}
+	return &http.Client{Transport: trans}, nil
 }
 
 // CopyHandler is responsible for handling copy tasks.
@@ -95,6 +127,8 @@ type CopyHandler struct {
 	hc                *http.Client
 	concurrentCopySem *semaphore.Weighted // Limits the number of concurrent goroutines uploading files.
 	statsTracker      *stats.Tracker      // For tracking bytes sent/copied.
+	verifyHandler     *VerifyHandler      // Handles the verify tasks sent on the copy subscription.
+	dedup             *dedupCache         // Remembers uploaded objects by content, may be nil.
 
 	// Exposed here only for testing purposes.
 	httpDoFunc func(context.Context, *http.Client, *http.Request) (*http.Response, error)
@@ -102,6 +136,18 @@ type CopyHandler struct {
 
 // NewCopyHandler creates a CopyHandler with storage.Client and http.Client.
 func NewCopyHandler(storageClient *storage.Client, hc *http.Client, st *stats.Tracker) *CopyHandler {
+	if err := validateMetadataKey(*mtimeAttrName); err != nil {
+		glog.Fatalf("Invalid mtime-attr-name flag: %v", err)
+	}
+	if err := common.ValidateSymlinkPolicy(common.SymlinkPolicy()); err != nil {
+		glog.Fatalf("Invalid symlink-policy flag: %v", err)
+	}
+	if err := validateBackOffFlags(); err != nil {
+		glog.Fatalf("Invalid backoff flags: %v", err)
+	}
+	if err := validateObjectNameLengthPolicy(*objectNameLengthPolicy); err != nil {
+		glog.Fatalf("Invalid object-name-length-policy flag: %v", err)
+	}
 	cf := *copyFiles
 	if cf <= 0 {
 		cf = *copyFilesPerCPU * runtime.NumCPU()
@@ -113,7 +159,22 @@ func NewCopyHandler(storageClient *storage.Client, hc *http.Client, st *stats.Tr
 		concurrentCopySem: semaphore.NewWeighted(int64(cf)),
 		httpDoFunc:        ctxhttp.Do,
 		statsTracker:      st,
+		verifyHandler:     NewVerifyHandler(storageClient, st),
+		dedup:             newDedupCache(*dedupCacheSize),
+	}
+}
+
+// validateMetadataKey returns an error if key can't be used as a GCS custom
+// metadata key. Keys are sent as part of "x-goog-meta-<key>" HTTP headers, so
+// they must be valid HTTP header field names.
+func validateMetadataKey(key string) error {
+	if key == "" {
+		return errors.New("metadata key must not be empty")
+	}
+	if !httpguts.ValidHeaderFieldName("x-goog-meta-" + key) {
+		return fmt.Errorf("metadata key %q contains characters not allowed in an HTTP header name", key)
 	}
+	return nil
 }
 
 func checkResumableFileStats(c *taskpb.CopySpec, fileinfo os.FileInfo) error {
@@ -136,10 +197,27 @@ func checkResumableFileStats(c *taskpb.CopySpec, fileinfo os.FileInfo) error {
 	return nil
 }
 
-func (h *CopyHandler) checkFileStats(beforeStats os.FileInfo, f *os.File) error {
+// checkFileStability returns an error if the file was modified within the
+// min-file-stability-age before now.
+func checkFileStability(fileinfo os.FileInfo, now time.Time) error {
+	if *minFileStabilityAge <= 0 {
+		return nil
+	}
+	if age := now.Sub(fileinfo.ModTime()); age < *minFileStabilityAge {
+		return common.AgentError{
+			Msg: fmt.Sprintf(
+				"File was modified %v ago, less than the min-file-stability-age %v. It may still be being written.",
+				age.Round(time.Second), *minFileStabilityAge),
+			FailureType: taskpb.FailureType_FILE_NOT_STABLE_FAILURE,
+		}
+	}
+	return nil
+}
+
+func (h *CopyHandler) checkFileStats(jobRun string, beforeStats os.FileInfo, f *os.File) error {
 	statStart := time.Now()
 	afterStats, err := f.Stat()
-	h.statsTracker.RecordPulseStats(&stats.PulseStats{CopyStatMs: stats.DurMs(statStart)})
+	h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopyStatMs: stats.DurMs(statStart)})
 	if err != nil {
 		return err
 	}
@@ -154,30 +232,166 @@ func (h *CopyHandler) checkFileStats(beforeStats os.FileInfo, f *os.File) error
 	return nil
 }
 
-func (h *CopyHandler) handleCopySpec(ctx context.Context, copySpec *taskpb.CopySpec) (*taskpb.CopyLog, error) {
-	cl := &taskpb.CopyLog{
+// errObjectExists is returned by copies with the SKIP_IF_EXISTS overwrite
+// policy whose destination object already exists. handleCopySpec records them
+// as skipped.
+var errObjectExists = errors.New("destination object already exists")
+
+// expectedGeneration returns the generation the destination object must have
+// for the copy to write it, 0 if the object must not exist.
+func expectedGeneration(c *taskpb.CopySpec) int64 {
+	if c.OverwritePolicy != taskpb.OverwritePolicy_OVERWRITE {
+		return 0
+	}
+	return c.ExpectedGenerationNum
+}
+
+// objectExistsError maps a GCS precondition failure of a copy which mustn't
+// overwrite its destination object to the error of its overwrite policy.
+// Other errors are returned unchanged.
+func objectExistsError(c *taskpb.CopySpec, err error) error {
+	if apiErr, ok := err.(*googleapi.Error); !ok || apiErr.Code != http.StatusPreconditionFailed {
+		return err
+	}
+	switch c.OverwritePolicy {
+	case taskpb.OverwritePolicy_SKIP_IF_EXISTS:
+		return errObjectExists
+	case taskpb.OverwritePolicy_FAIL_IF_EXISTS:
+		return common.AgentError{
+			Msg:         fmt.Sprintf("object %s already exists in bucket %s, err: %v", c.DstObject, c.DstBucket, err),
+			FailureType: taskpb.FailureType_OBJECT_ALREADY_EXISTS_FAILURE,
+		}
+	}
+	return err
+}
+
+// isUnchanged returns true if the destination object exists, has the same
+// size and mtime as the source file, and has the generation expected by the
+// copy spec. The generation check ensures a skip only happens when the copy's
+// generation precondition would otherwise have succeeded. The size of a
+// gzipped object is its compressed size, so only its mtime is compared.
+func (h *CopyHandler) isUnchanged(ctx context.Context, c *taskpb.CopySpec, fileinfo os.FileInfo) (bool, *storage.ObjectAttrs, error) {
+	if expectedGeneration(c) == 0 {
+		// The copy requires that the object does not exist.
+		return false, nil, nil
+	}
+	attrs, err := h.gcs.GetAttrs(ctx, c.DstBucket, c.DstObject)
+	if err == storage.ErrObjectNotExist {
+		return false, nil, nil
+	} else if err != nil {
+		return false, nil, err
+	}
+	if attrs.Generation != c.ExpectedGenerationNum {
+		return false, attrs, nil
+	}
+	if attrs.ContentEncoding != "gzip" && attrs.Size != fileinfo.Size() {
+		return false, attrs, nil
+	}
+	mtime, ok := attrs.Metadata[*mtimeAttrName]
+	if !ok || mtime != strconv.FormatInt(fileinfo.ModTime().Unix(), 10) {
+		return false, attrs, nil
+	}
+	return true, attrs, nil
+}
+
+func (h *CopyHandler) handleCopySpec(ctx context.Context, jobRun string, copySpec *taskpb.CopySpec) (cl *taskpb.CopyLog, err error) {
+	ctx, span := tracing.StartSpan(ctx, "handleCopySpec",
+		attribute.String("job_run", jobRun),
+		attribute.String("src_file", copySpec.SrcFile),
+		attribute.String("dst_bucket", copySpec.DstBucket),
+		attribute.Int64("bytes_copied", copySpec.BytesCopied),
+	)
+	defer func() {
+		if cl != nil {
+			span.SetAttributes(
+				attribute.String("dst_file", cl.DstFile),
+				attribute.Int64("src_bytes", cl.SrcBytes),
+				attribute.Int64("bytes_copied", cl.BytesCopied),
+				attribute.Bool("skipped", cl.Skipped),
+			)
+		}
+		tracing.End(span, err)
+	}()
+
+	// Transform the DstObject first, so the copy and its log use the final name.
+	if err := transformDstObject(copySpec); err != nil {
+		return &taskpb.CopyLog{SrcFile: copySpec.SrcFile, DstFile: path.Join(copySpec.DstBucket, copySpec.DstObject)}, err
+	}
+	if common.DstNamesNormalized() {
+		copySpec.DstObject = common.NormalizeDstName(copySpec.DstObject)
+		if err := checkDstNameCollision(ctx, copySpec.SrcFile); err != nil {
+			return &taskpb.CopyLog{SrcFile: copySpec.SrcFile, DstFile: path.Join(copySpec.DstBucket, copySpec.DstObject)}, err
+		}
+	}
+	if err := checkDstObjectLength(copySpec); err != nil {
+		return &taskpb.CopyLog{SrcFile: copySpec.SrcFile, DstFile: path.Join(copySpec.DstBucket, copySpec.DstObject)}, err
+	}
+	cl = &taskpb.CopyLog{
 		SrcFile: copySpec.SrcFile,
 		DstFile: path.Join(copySpec.DstBucket, copySpec.DstObject),
 	}
+	defer func() {
+		if err == errObjectExists {
+			cl.Skipped = true
+			err = nil
+		}
+	}()
 
 	resumedCopy, err := checkCopyTaskSpec(copySpec)
 	if err != nil {
 		return cl, err
 	}
+	resumedFromSpec := resumedCopy
+
+	src, err := common.ObjectSourceFor(copySpec.SrcFile)
+	if err != nil {
+		return cl, err
+	}
+	if copySpec.MetadataOnly {
+		if src != nil {
+			return cl, fmt.Errorf("metadata-only copies from %s aren't supported", copySpec.SrcFile)
+		}
+		return cl, h.updateMetadataOnly(ctx, jobRun, copySpec, cl)
+	}
+	if src != nil {
+		if resumedCopy {
+			return cl, fmt.Errorf("copies from %s can't be resumed", copySpec.SrcFile)
+		}
+		return cl, h.copyFromObjectSource(ctx, jobRun, copySpec, src, cl)
+	}
 
-	// Open the on-premises file, and check the file stats if necessary.
-	openStart := time.Now()
 	srcFileOSPath := agentcommon.OSPath(copySpec.SrcFile)
+	if dir, ok := common.EmptyDirOfMarker(srcFileOSPath); ok {
+		return cl, h.copyEmptyDirMarker(ctx, jobRun, copySpec, dir, cl)
+	}
+	if common.SymlinkPolicy() == common.SymlinkPolicyCopyAsObject {
+		if fileinfo, err := os.Lstat(srcFileOSPath); err == nil && fileinfo.Mode()&os.ModeSymlink != 0 {
+			return cl, h.copySymlink(ctx, jobRun, copySpec, srcFileOSPath, fileinfo, cl)
+		}
+	}
+
+	// Open the on-premises file, and check the file stats if necessary. The
+	// open file budget is released once srcFile is closed.
+	releaseOpenFile, err := common.AcquireOpenFile(ctx)
+	if err != nil {
+		return cl, err
+	}
+	defer releaseOpenFile()
+	openStart := time.Now()
+	_, openSpan := tracing.StartSpan(ctx, "open")
 	srcFile, err := os.Open(srcFileOSPath)
-	h.statsTracker.RecordPulseStats(&stats.PulseStats{CopyOpenMs: stats.DurMs(openStart)})
+	tracing.End(openSpan, err)
+	h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopyOpenMs: stats.DurMs(openStart)})
 	if err != nil {
 		return cl, err
 	}
 	defer srcFile.Close()
 
 	statStart := time.Now()
+	_, statSpan := tracing.StartSpan(ctx, "stat")
 	fileinfo, err := srcFile.Stat()
-	h.statsTracker.RecordPulseStats(&stats.PulseStats{CopyStatMs: stats.DurMs(statStart)})
+	tracing.End(statSpan, err)
+	h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopyStatMs: stats.DurMs(statStart)})
 	if err != nil {
 		return cl, err
 	}
@@ -186,19 +400,62 @@ func (h *CopyHandler) handleCopySpec(ctx context.Context, copySpec *taskpb.CopyS
 	// there won't be any double counting.
 	cl.SrcBytes = fileinfo.Size()
 	cl.SrcMTime = fileinfo.ModTime().Unix()
+	cl.SrcFsType = fsType(srcFile)
+	startBytes := copySpec.BytesCopied
+	defer func() {
+		if err == nil && !cl.Skipped {
+			h.statsTracker.RecordCopyFsType(cl.SrcFsType, cl.BytesCopied-startBytes, time.Since(openStart))
+		}
+	}()
 	if resumedCopy {
 		// TODO(b/74009003): When implementing "synchronization" rethink how
 		// the file stat parameters are set and compared.
 		if err = checkResumableFileStats(copySpec, fileinfo); err != nil {
 			return cl, err
 		}
+	} else {
+		// Resumed copies were checked and recorded when the copy was started.
+		if err = checkFileStability(fileinfo, time.Now()); err != nil {
+			return cl, err
+		}
+		h.statsTracker.RecordFileSize(fileinfo.Size())
+	}
+
+	if !resumedCopy && *skipUnchanged {
+		unchanged, dstAttrs, err := h.isUnchanged(ctx, copySpec, fileinfo)
+		if err != nil {
+			return cl, err
+		}
+		if unchanged {
+			cl.DstBytes = dstAttrs.Size
+			cl.DstCrc32C = dstAttrs.CRC32C
+			cl.DstMTime = dstAttrs.Updated.Unix()
+			cl.DstMd5 = base64.StdEncoding.EncodeToString(dstAttrs.MD5)
+			cl.Skipped = true
+			return cl, nil
+		}
+	}
+
+	if !resumedCopy {
+		if copied, err := h.copyDuplicate(ctx, jobRun, copySpec, fileinfo, cl); copied || err != nil {
+			return cl, err
+		}
 	}
 
 	// Copy the entire file or start a resumable copy.
 	if !resumedCopy {
 		// Start a copy. If the file is small enough copy the entire file, otherwise begin a resumable copy.
-		if fileinfo.Size() <= int64(*copyEntireFileLimit) || *copyChunkSize <= 0 {
-			err = h.copyEntireFile(ctx, copySpec, srcFile, fileinfo, cl)
+		// Gzipped files are always copied in a single request, since the
+		// compressed stream can't be resumed at a source file offset.
+		// Composite uploads aren't used with KMS keys since compose can't
+		// set the destination object's KMS key.
+		if fileinfo.Size() <= int64(*copyEntireFileLimit) || *copyChunkSize <= 0 || shouldGzip(copySpec.SrcFile) {
+			err = h.copyEntireFile(ctx, jobRun, copySpec, srcFile, fileinfo, cl)
+			if err != nil {
+				return cl, err
+			}
+		} else if *compositeUploadThreshold > 0 && fileinfo.Size() >= *compositeUploadThreshold && copySpec.KmsKeyName == "" {
+			err = h.copyComposite(ctx, jobRun, copySpec, srcFile, fileinfo, cl)
 			if err != nil {
 				return cl, err
 			}
@@ -210,20 +467,59 @@ func (h *CopyHandler) handleCopySpec(ctx context.Context, copySpec *taskpb.CopyS
 		}
 	}
 	if resumedCopy {
-		err = h.copyResumableChunk(ctx, copySpec, srcFile, fileinfo, cl)
+		// The spec of a resumed copy may lag behind GCS, for example if the
+		// response to its last chunk was lost. There's nothing to reconcile
+		// for an upload which was just prepared.
+		if resumedFromSpec {
+			if err := h.reconcileResumableOffset(ctx, copySpec, srcFile, fileinfo); err != nil {
+				return cl, err
+			}
+		}
+		err = h.copyResumableChunk(ctx, jobRun, copySpec, srcFile, fileinfo, cl)
 		if err != nil {
 			return cl, err
 		}
 	}
 
 	// Now that data has been sent, check that the fileinfo stats haven't changed.
-	if err = h.checkFileStats(fileinfo, srcFile); err != nil {
+	if err = h.checkFileStats(jobRun, fileinfo, srcFile); err != nil {
 		return cl, err
 	}
 
 	return cl, nil
 }
 
+// copySymlink copies the target path of the symlink at osPath as the content
+// of the destination object.
+func (h *CopyHandler) copySymlink(ctx context.Context, jobRun string, c *taskpb.CopySpec, osPath string, fileinfo os.FileInfo, cl *taskpb.CopyLog) error {
+	target, err := os.Readlink(osPath)
+	if err != nil {
+		return err
+	}
+	cl.SrcBytes = int64(len(target))
+	cl.SrcMTime = fileinfo.ModTime().Unix()
+	if err := h.copyEntireFile(ctx, jobRun, c, strings.NewReader(target), fileinfo, cl); err != nil {
+		return err
+	}
+	cl.BytesCopied = cl.SrcBytes
+	return nil
+}
+
+// copyEmptyDirMarker copies the marker of the empty directory dir as a
+// zero-byte object.
+func (h *CopyHandler) copyEmptyDirMarker(ctx context.Context, jobRun string, c *taskpb.CopySpec, dir string, cl *taskpb.CopyLog) error {
+	fileinfo, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	cl.SrcMTime = fileinfo.ModTime().Unix()
+	if err := h.copyEntireFile(ctx, jobRun, c, strings.NewReader(""), fileinfo, cl); err != nil {
+		return err
+	}
+	cl.BytesCopied = 0 // Not the directory's size.
+	return nil
+}
+
 func isServiceInducedError(failureType taskpb.FailureType) bool {
 	switch failureType {
 	case taskpb.FailureType_UNKNOWN_FAILURE, taskpb.FailureType_HASH_MISMATCH_FAILURE:
@@ -246,6 +542,16 @@ func getBundleLogAndError(bs *taskpb.CopyBundleSpec) (*taskpb.CopyBundleLog, err
 			}
 			log.FilesFailed++
 			log.BytesFailed += bf.CopyLog.SrcBytes
+			switch bf.FailureType {
+			case taskpb.FailureType_HASH_MISMATCH_FAILURE:
+				log.FilesHashMismatch++
+			case taskpb.FailureType_FILE_NOT_FOUND_FAILURE:
+				log.FilesNotFound++
+			case taskpb.FailureType_FILE_MODIFIED_FAILURE:
+				log.FilesModified++
+			case taskpb.FailureType_PERMISSION_DENIED_FAILURE:
+				log.FilesPermissionDenied++
+			}
 			glog.Warningf("bundledFile %v, failed with err: %v", bf.CopySpec.SrcFile, bf.FailureMessage)
 		}
 	}
@@ -267,14 +573,20 @@ func shouldDoTimeAwareCopy(copySpec *taskpb.CopySpec, reqStart time.Time, jobRun
 	// Do a time aware copy iteration iff
 	// 1. The copy is resuamble.
 	// 2. There are bytes left to copy.
-	// 3. We haven't exceeded the work duration.
+	// 3. We haven't exceeded the job run's work duration, or the flag if it doesn't set one.
 	// 4. The JobRun is active (not paused).
-	return copySpec.ResumableUploadId != "" && copySpec.BytesCopied < copySpec.FileBytes && time.Now().Before(reqStart.Add(*copyWorkDuration)) && rate.IsJobRunActive(jobRunRelRsrcName)
+	workDur := rate.CopyWorkDuration(jobRunRelRsrcName, *copyWorkDuration)
+	return copySpec.ResumableUploadId != "" && copySpec.BytesCopied < copySpec.FileBytes && time.Now().Before(reqStart.Add(workDur)) && rate.IsJobRunActive(jobRunRelRsrcName)
 }
 
 func (h *CopyHandler) handleCopySpecTimeAware(ctx context.Context, copySpec *taskpb.CopySpec, reqStart time.Time, jobRunRelRsrcName string) (*taskpb.CopySpec, *taskpb.CopyLog, error) {
+	h.statsTracker.RecordCopyStart()
+	defer h.statsTracker.RecordCopyEnd()
+
 	// Perform the initial copy.
-	copyLog, err := h.handleCopySpec(ctx, copySpec) // Updates 'copySpec' in place.
+	copyStart := time.Now()
+	copyLog, err := h.handleCopySpec(ctx, jobRunRelRsrcName, copySpec) // Updates 'copySpec' in place.
+	logCopy(jobRunRelRsrcName, copySpec, copyLog, copyStart, err)
 	if err != nil {
 		return copySpec, copyLog, err
 	}
@@ -282,7 +594,9 @@ func (h *CopyHandler) handleCopySpecTimeAware(ctx context.Context, copySpec *tas
 	for shouldDoTimeAwareCopy(copySpec, reqStart, jobRunRelRsrcName) {
 		goodSpec := proto.Clone(copySpec).(*taskpb.CopySpec)
 		goodCopyLog := proto.Clone(copyLog).(*taskpb.CopyLog)
-		copyLog, err = h.handleCopySpec(ctx, copySpec)
+		copyStart = time.Now()
+		copyLog, err = h.handleCopySpec(ctx, jobRunRelRsrcName, copySpec)
+		logCopy(jobRunRelRsrcName, copySpec, copyLog, copyStart, err)
 		if err != nil {
 			// If we have a previously good state just return that.
 			return goodSpec, goodCopyLog, nil
@@ -291,15 +605,116 @@ func (h *CopyHandler) handleCopySpecTimeAware(ctx context.Context, copySpec *tas
 	return copySpec, copyLog, err
 }
 
+// logCopy logs the outcome of a single handleCopySpec call as a copy_success
+// or copy_failure event.
+func logCopy(jobRun string, c *taskpb.CopySpec, cl *taskpb.CopyLog, start time.Time, err error) {
+	fields := common.Fields{
+		"job_run":      jobRun,
+		"src_file":     c.SrcFile,
+		"dst_bucket":   c.DstBucket,
+		"dst_object":   c.DstObject,
+		"bytes_copied": cl.BytesCopied,
+		"src_bytes":    cl.SrcBytes,
+		"skipped":      cl.Skipped,
+		"duration_ms":  stats.DurMs(start),
+	}
+	if err != nil {
+		fields["failure_type"] = common.GetFailureTypeFromError(err).String()
+		fields["error"] = err
+		common.LogEvent("copy_failure", fields)
+		return
+	}
+	common.LogEvent("copy_success", fields)
+}
+
+// deleteSourceIfCopied removes the source file of a successful and complete
+// copy, if source deletion is enabled. A deletion failure doesn't fail the
+// copy; it's recorded in the copy log instead. Metadata-only copies don't
+// verify the object's content against the file, so they never delete it.
+func deleteSourceIfCopied(copySpec *taskpb.CopySpec, cl *taskpb.CopyLog) {
+	if !*deleteSource || cl.Skipped || cl.MetadataOnly {
+		return
+	}
+	if copySpec.ResumableUploadId != "" && copySpec.BytesCopied < copySpec.FileBytes {
+		return // The resumable copy is still in progress.
+	}
+	if src, _ := common.ObjectSourceFor(copySpec.SrcFile); src != nil {
+		cl.SrcDeleteError = "objects of this source aren't deleted"
+		return
+	}
+	if err := os.Remove(agentcommon.OSPath(copySpec.SrcFile)); err != nil {
+		glog.Warningf("Failed to delete source file %v, err: %v", copySpec.SrcFile, err)
+		cl.SrcDeleteError = err.Error()
+		return
+	}
+	cl.SrcDeleted = true
+}
+
+// bundleSems limits the concurrency of the files of a single copy bundle, see
+// the bundle-file-concurrency flag.
+type bundleSems struct {
+	files *semaphore.Weighted // Limits the bundle's files copied at once.
+	large *semaphore.Weighted // Limits the bundle's large files copied at once.
+}
+
+// newBundleSems returns the bundleSems of a bundle with n files, or nil if its
+// files should each take one of the concurrentCopySem slots.
+func newBundleSems(n int) *bundleSems {
+	if n <= 1 || *bundleFileConcurrency <= 0 {
+		return nil
+	}
+	return &bundleSems{
+		files: semaphore.NewWeighted(int64(*bundleFileConcurrency)),
+		large: semaphore.NewWeighted(1),
+	}
+}
+
+// acquireBundledFile acquires the concurrency limits of a bundled file, and
+// returns the func releasing them. Files of a bundle with bundleSems run within
+// the bundle's concurrentCopySem slot, and only files larger than a single
+// copy request are copied one at a time, so they can't oversubscribe the
+// network.
+func (h *CopyHandler) acquireBundledFile(ctx context.Context, sems *bundleSems, c *taskpb.CopySpec) (func(), error) {
+	if sems == nil {
+		if err := h.concurrentCopySem.Acquire(ctx, 1); err != nil {
+			return nil, err
+		}
+		return func() { h.concurrentCopySem.Release(1) }, nil
+	}
+	if err := sems.files.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	if c.FileBytes <= int64(*copyEntireFileLimit) {
+		return func() { sems.files.Release(1) }, nil
+	}
+	if err := sems.large.Acquire(ctx, 1); err != nil {
+		sems.files.Release(1)
+		return nil, err
+	}
+	return func() {
+		sems.large.Release(1)
+		sems.files.Release(1)
+	}, nil
+}
+
 func (h *CopyHandler) handleCopyBundleSpec(ctx context.Context, bundleSpec *taskpb.CopyBundleSpec, reqStart time.Time, jobRunRelRsrcName string) (*taskpb.CopyBundleLog, error) {
+	sems := newBundleSems(len(bundleSpec.BundledFiles))
+	if sems != nil {
+		// The bundle's files share a single slot.
+		if err := h.concurrentCopySem.Acquire(ctx, 1); err == nil {
+			defer h.concurrentCopySem.Release(1)
+		}
+	}
 	var wg sync.WaitGroup
 	for _, bf := range bundleSpec.BundledFiles {
 		wg.Add(1)
 		go func(bf *taskpb.BundledFile) {
 			if len(bundleSpec.BundledFiles) > 1 {
 				// Apply concurrency limiting to copy tasks with multiple bundled files.
-				h.concurrentCopySem.Acquire(ctx, 1)
-				defer h.concurrentCopySem.Release(1)
+				// If the context is done, the copy fails without holding a slot.
+				if release, err := h.acquireBundledFile(ctx, sems, bf.CopySpec); err == nil {
+					defer release()
+				}
 			}
 			defer wg.Done()
 			var err error
@@ -308,6 +723,7 @@ func (h *CopyHandler) handleCopyBundleSpec(ctx context.Context, bundleSpec *task
 			bf.FailureMessage = agentcommon.TaskFailureMsg(err)
 			if err == nil {
 				bf.Status = taskpb.Status_SUCCESS
+				deleteSourceIfCopied(bf.CopySpec, bf.CopyLog)
 			} else {
 				bf.Status = taskpb.Status_FAILED
 			}
@@ -322,10 +738,19 @@ func (h *CopyHandler) Do(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg, req
 	var log *taskpb.Log
 	var err error
 
+	ctx, span := tracing.StartSpan(ctx, "CopyHandler.Do",
+		attribute.String("task", taskReqMsg.TaskRelRsrcName),
+		attribute.String("job_run", taskReqMsg.JobrunRelRsrcName),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	if taskReqMsg.Spec.GetCopySpec() != nil {
 		var cl *taskpb.CopyLog
 		copySpec := proto.Clone(taskReqMsg.Spec.GetCopySpec()).(*taskpb.CopySpec)
 		copySpec, cl, err = h.handleCopySpecTimeAware(ctx, copySpec, reqStart, taskReqMsg.JobrunRelRsrcName)
+		if err == nil {
+			deleteSourceIfCopied(copySpec, cl)
+		}
 		respSpec = &taskpb.Spec{Spec: &taskpb.Spec_CopySpec{copySpec}}
 		log = &taskpb.Log{Log: &taskpb.Log_CopyLog{cl}}
 	} else if taskReqMsg.Spec.GetCopyBundleSpec() != nil {
@@ -334,45 +759,135 @@ func (h *CopyHandler) Do(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg, req
 		cbl, err = h.handleCopyBundleSpec(ctx, bundleSpec, reqStart, taskReqMsg.JobrunRelRsrcName)
 		respSpec = &taskpb.Spec{Spec: &taskpb.Spec_CopyBundleSpec{bundleSpec}}
 		log = &taskpb.Log{Log: &taskpb.Log_CopyBundleLog{cbl}}
+	} else if taskReqMsg.Spec.GetVerifySpec() != nil {
+		return h.verifyHandler.Do(ctx, taskReqMsg, reqStart)
 	} else {
-		err = errors.New("CopyHandler.Do taskReqMsg.Spec is neither CopySpec nor CopyBundleSpec")
+		err = errors.New("CopyHandler.Do taskReqMsg.Spec is not a CopySpec, CopyBundleSpec or VerifySpec")
 	}
 
 	return common.BuildTaskRespMsg(taskReqMsg, respSpec, log, err)
 }
 
-func (h *CopyHandler) copyEntireFile(ctx context.Context, c *taskpb.CopySpec, srcFile *os.File, fileinfo os.FileInfo, cl *taskpb.CopyLog) error {
-	w := h.gcs.NewWriterWithCondition(ctx, c.DstBucket, c.DstObject, common.GetGCSGenerationNumCondition(c.ExpectedGenerationNum))
+// objectMetadata returns the custom metadata to set on the GCS object copied
+// from the file described by fileinfo. The metadata requested by c is merged
+// in first, so it can't override the attributes set by the agent.
+func objectMetadata(c *taskpb.CopySpec, fileinfo os.FileInfo) map[string]string {
+	metadata := make(map[string]string)
+	for k, v := range c.ObjectMetadata {
+		metadata[k] = v
+	}
+	metadata[*mtimeAttrName] = strconv.FormatInt(fileinfo.ModTime().Unix(), 10)
+	if *preservePOSIX {
+		addPOSIXAttrs(metadata, fileinfo)
+	}
+	return metadata
+}
+
+// trustedCRC32C returns the CRC32C of the source file carried by c, and
+// whether it should be trusted rather than computed.
+func trustedCRC32C(c *taskpb.CopySpec) (uint32, bool) {
+	if !*trustSourceChecksum || c.SrcFileCrc32C == nil {
+		return 0, false
+	}
+	return c.SrcFileCrc32C.Value, true
+}
+
+func (h *CopyHandler) copyEntireFile(ctx context.Context, jobRun string, c *taskpb.CopySpec, srcFile io.Reader, fileinfo os.FileInfo, cl *taskpb.CopyLog) error {
+	gzipped := shouldGzip(c.SrcFile)
+	// The object content of a gzipped file doesn't match the source file's checksum.
+	trustedCRC, trusted := trustedCRC32C(c)
+	trusted = trusted && !gzipped
+	objContentType := c.ContentType // The writer detects the type if this is empty.
+	if gzipped && objContentType == "" {
+		var err error
+		if objContentType, srcFile, err = sniffContentType(srcFile); err != nil {
+			return err
+		}
+	}
+	var tmpName string
+	var w gcloud.WriteCloserWithError
+	if *uploadViaTempObject {
+		// The destination object's preconditions are checked when the
+		// temporary object is copied to it.
+		tmpName = tempObjectName(c.DstObject)
+		w = h.gcs.NewWriter(ctx, c.DstBucket, tmpName)
+	} else {
+		w = h.gcs.NewWriterWithCondition(ctx, c.DstBucket, c.DstObject, common.GetGCSGenerationNumCondition(expectedGeneration(c)))
+	}
 	if t, ok := w.(*storage.Writer); ok {
-		t.Metadata = map[string]string{MTIME_ATTR_NAME: strconv.FormatInt(fileinfo.ModTime().Unix(), 10)}
+		t.Metadata = objectMetadata(c, fileinfo)
+		t.StorageClass = c.StorageClass
+		t.KMSKeyName = c.KmsKeyName
+		t.ContentType = objContentType
+		t.PredefinedACL = c.PredefinedAcl
+		if gzipped {
+			t.ContentEncoding = "gzip"
+		}
+		if trusted {
+			// GCS rejects the upload if the content doesn't match the CRC32C.
+			t.CRC32C = trustedCRC
+			t.SendCRC32C = true
+		}
 	}
 
 	var srcCRC32C uint32
-	r := h.statsTracker.NewCopyByteTrackingReader(srcFile) // Wrap the srcFile with a CopyByteTrackingReader.
-	r = rate.NewRateLimitingReader(r)                      // Wrap with a RateLimitingReader.
-	r = NewCRC32UpdatingReader(r, &srcCRC32C)              // Wrap with a CRC32UpdatingReader.
-	tr := stats.NewTimingReader(r)                         // Wrap with a TimingReader.
+	var srcMD5 hash.Hash
+	if *verifyMD5 {
+		srcMD5 = md5.New()
+	}
+	r := h.statsTracker.NewCopyByteTrackingReader(jobRun, srcFile) // Wrap the srcFile with a CopyByteTrackingReader.
+	r = common.NewTaskBytesReader(ctx, r)                          // Wrap with a TaskBytesReader.
+	r = rate.NewRateLimitingReader(r)                              // Wrap with a RateLimitingReader.
+	var srcSHA256 hash.Hash
+	if *computeSHA256 {
+		srcSHA256 = sha256.New()
+		r = NewHashUpdatingReader(r, srcSHA256) // Wrap with a HashUpdatingReader.
+	}
+	if !gzipped {
+		// When gzipping, the hashes are computed over the compressed bytes instead.
+		if trusted {
+			srcCRC32C = trustedCRC
+		} else {
+			r = hashing.NewCRC32CUpdatingReader(r, &srcCRC32C) // Wrap with a CRC32CUpdatingReader.
+		}
+		if srcMD5 != nil {
+			r = NewHashUpdatingReader(r, srcMD5) // Wrap with a HashUpdatingReader.
+		}
+	}
+	tr := stats.NewTimingReader(r) // Wrap with a TimingReader.
 
 	// Copy the file using io.Copy. This allocates a small temp buffer and handles the Read+Write calls.
 	writeStart := time.Now()
-	_, err := io.Copy(w, tr)
-	h.statsTracker.RecordPulseStats(&stats.PulseStats{CopyWriteMs: stats.DurMs(writeStart.Add(tr.ReadDur()))})
+	var err error
+	if gzipped {
+		cl.CompressedBytes, srcCRC32C, err = gzipCopy(w, tr, srcMD5)
+	} else {
+		_, err = io.Copy(w, tr)
+	}
+	h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopyWriteMs: stats.DurMs(writeStart.Add(tr.ReadDur()))})
 	if err != nil {
 		w.CloseWithError(err)
-		return err
+		return objectExistsError(c, err)
 	}
 	if err := w.Close(); err != nil {
-		return err
+		return objectExistsError(c, err)
 	}
 
 	// Record some attributes.
 	dstAttrs := w.Attrs()
+	if tmpName != "" {
+		defer h.deleteTempObject(c.DstBucket, tmpName, dstAttrs.Generation)
+	}
 	cl.DstBytes = dstAttrs.Size
 	cl.DstCrc32C = dstAttrs.CRC32C
 	cl.DstMTime = dstAttrs.Updated.Unix()
 	cl.SrcCrc32C = srcCRC32C
 	cl.DstMd5 = base64.StdEncoding.EncodeToString(dstAttrs.MD5)
+	cl.DstMetadata = dstAttrs.Metadata
 	cl.BytesCopied = fileinfo.Size()
+	if srcSHA256 != nil {
+		cl.SrcSha256 = hex.EncodeToString(srcSHA256.Sum(nil))
+	}
 
 	// Verify the CRC32C.
 	if dstAttrs.CRC32C != srcCRC32C {
@@ -383,6 +898,33 @@ func (h *CopyHandler) copyEntireFile(ctx context.Context, c *taskpb.CopySpec, sr
 		}
 	}
 
+	// Verify the MD5, if requested.
+	if srcMD5 != nil {
+		if err := checkMD5(c, srcMD5.Sum(nil), dstAttrs.MD5); err != nil {
+			return err
+		}
+	}
+
+	if tmpName != "" {
+		if dstAttrs, err = h.finalizeTempObject(ctx, c, tmpName, dstAttrs); err != nil {
+			return err
+		}
+		cl.DstMTime = dstAttrs.Updated.Unix()
+		cl.DstMetadata = dstAttrs.Metadata
+	}
+	h.recordUpload(jobRun, c, fileinfo.Size(), srcCRC32C, dstAttrs.Generation, dstAttrs.ContentType)
+	return nil
+}
+
+// checkMD5 returns a HASH_MISMATCH_FAILURE AgentError if srcMD5 and dstMD5 differ.
+func checkMD5(c *taskpb.CopySpec, srcMD5, dstMD5 []byte) error {
+	if !bytes.Equal(srcMD5, dstMD5) {
+		return common.AgentError{
+			Msg: fmt.Sprintf("MD5 mismatch for file %s (%s) against object %s (%s)",
+				c.SrcFile, base64.StdEncoding.EncodeToString(srcMD5), c.DstObject, base64.StdEncoding.EncodeToString(dstMD5)),
+			FailureType: taskpb.FailureType_HASH_MISMATCH_FAILURE,
+		}
+	}
 	return nil
 }
 
@@ -399,22 +941,41 @@ func contentType(srcFile io.Reader) string {
 // prepareResumableCopy makes a request to GCS to begin a resumable copy. It
 // updates the copy spec (with the resuambleUploadId and other file metadata)
 // which will be sent to the DCP for future work on this resumable copy task.
-func (h *CopyHandler) prepareResumableCopy(ctx context.Context, c *taskpb.CopySpec, srcFile io.Reader, fileinfo os.FileInfo) error {
+func (h *CopyHandler) prepareResumableCopy(ctx context.Context, c *taskpb.CopySpec, srcFile io.Reader, fileinfo os.FileInfo) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "prepareResumableCopy",
+		attribute.String("dst_object", c.DstObject),
+		attribute.Int64("bytes", fileinfo.Size()),
+	)
+	defer func() { tracing.End(span, err) }()
+
 	// Create the request URL.
+	name := c.DstObject
 	urlParams := make(gensupport.URLParams)
-	urlParams.Set("ifGenerationMatch", fmt.Sprint(c.ExpectedGenerationNum))
+	if *uploadViaTempObject {
+		// The destination object's preconditions are checked when the
+		// temporary object is copied to it, once the upload is complete.
+		c.TmpDstObject = tempObjectName(c.DstObject)
+		name = c.TmpDstObject
+	} else {
+		urlParams.Set("ifGenerationMatch", fmt.Sprint(expectedGeneration(c)))
+	}
 	urlParams.Set("alt", "json")
 	urlParams.Set("uploadType", "resumable")
+	if c.KmsKeyName != "" {
+		urlParams.Set("kmsKeyName", c.KmsKeyName)
+	}
+	if c.PredefinedAcl != "" {
+		urlParams.Set("predefinedAcl", c.PredefinedAcl)
+	}
 	url := googleapi.ResolveRelative("https://www.googleapis.com/upload/storage/v1/", "b/{bucket}/o")
 	url += "?" + urlParams.Encode()
 
 	// Create the request body.
 	object := &raw.Object{
-		Name:   c.DstObject,
-		Bucket: c.DstBucket,
-		Metadata: map[string]string{
-			MTIME_ATTR_NAME: strconv.FormatInt(fileinfo.ModTime().Unix(), 10),
-		},
+		Name:         name,
+		Bucket:       c.DstBucket,
+		Metadata:     objectMetadata(c, fileinfo),
+		StorageClass: c.StorageClass,
 	}
 	body := new(bytes.Buffer)
 	if err := json.NewEncoder(body).Encode(object); err != nil {
@@ -432,7 +993,11 @@ func (h *CopyHandler) prepareResumableCopy(ctx context.Context, c *taskpb.CopySp
 	reqHeaders.Set("Content-Length", fmt.Sprint(body.Len()))
 	reqHeaders.Set("User-Agent", userAgentStr)
 	reqHeaders.Set("X-Upload-Content-Length", fmt.Sprint(fileinfo.Size()))
-	reqHeaders.Set("X-Upload-Content-Type", contentType(srcFile))
+	if c.ContentType != "" {
+		reqHeaders.Set("X-Upload-Content-Type", c.ContentType)
+	} else {
+		reqHeaders.Set("X-Upload-Content-Type", contentType(srcFile))
+	}
 
 	// Stitch all the pieces together into an HTTP request.
 	req, err := http.NewRequest("POST", url, body)
@@ -449,7 +1014,7 @@ func (h *CopyHandler) prepareResumableCopy(ctx context.Context, c *taskpb.CopySp
 	}
 	defer googleapi.CloseBody(resp)
 	if err = googleapi.CheckResponse(resp); err != nil {
-		return err
+		return objectExistsError(c, err)
 	}
 
 	// This function was successful, update the copy spec.
@@ -463,25 +1028,109 @@ func (h *CopyHandler) prepareResumableCopy(ctx context.Context, c *taskpb.CopySp
 	return nil
 }
 
+// EstimateMemory implements the tasks.MemoryEstimator interface. It estimates
+// the read buffers of the task's file copies, which dominate a copy's memory.
+func (h *CopyHandler) EstimateMemory(taskReqMsg *taskpb.TaskReqMsg) int64 {
+	// A ReadAheadReader allocates read-ahead-buffers read buffers.
+	buffers := int64(1)
+	if *readAheadBuffers > 1 {
+		buffers = int64(*readAheadBuffers)
+	}
+	if c := taskReqMsg.Spec.GetCopySpec(); c != nil {
+		return buffers * int64(readBufSize(chunkBytesEstimate(c)))
+	}
+	var n int64
+	for _, bf := range taskReqMsg.Spec.GetCopyBundleSpec().GetBundledFiles() {
+		n += buffers * int64(readBufSize(chunkBytesEstimate(bf.CopySpec)))
+	}
+	return n
+}
+
+// chunkBytesEstimate returns the number of bytes the next copy request of c
+// is expected to read.
+func chunkBytesEstimate(c *taskpb.CopySpec) int64 {
+	n := c.FileBytes - c.BytesCopied
+	chunk := chunkSize(c)
+	if n <= 0 {
+		// New copies don't know the file size.
+		n = chunk
+		if n <= 0 {
+			n = math.MaxInt64
+		}
+	} else if chunk > 0 && n > chunk {
+		n = chunk
+	}
+	return n
+}
+
+// readBufSize returns the size of the read buffer for reading n bytes of a
+// file. A buffer larger than n is never filled, so the buffer is n bytes,
+// rounded up to the next power of two and bounded between minFileReadBuf and
+// the file-read-buf flag. This avoids allocating the whole flag value for each
+// of many small concurrent copies, while large files get the full buffer.
+func readBufSize(n int64) int {
+	max := *fileReadBuf
+	if max < minFileReadBuf {
+		max = minFileReadBuf
+	}
+	size := minFileReadBuf
+	for int64(size) < n && size < max {
+		size *= 2
+	}
+	if size > max {
+		size = max
+	}
+	return size
+}
+
 // copyResumableChunk sends a chunk of the srcFile to GCS as part of a resumable
 // copy task. This function also updates the CopySpec and CopyLog, both of
 // which are sent to the DCP.
-func (h *CopyHandler) copyResumableChunk(ctx context.Context, c *taskpb.CopySpec, srcFile *os.File, fileinfo os.FileInfo, cl *taskpb.CopyLog) error {
+func (h *CopyHandler) copyResumableChunk(ctx context.Context, jobRun string, c *taskpb.CopySpec, srcFile *os.File, fileinfo os.FileInfo, cl *taskpb.CopyLog) (err error) {
 	final := false
-	bytesToCopy := int64(*copyChunkSize)
+	bytesToCopy := chunkSize(c)
 	if bytesToCopy <= 0 || bytesToCopy+c.BytesCopied >= fileinfo.Size() {
 		// bytesToCopy <= 0 indicates that the rest of the file should be copied.
 		bytesToCopy = fileinfo.Size() - c.BytesCopied
 		final = true
 	}
 
+	var backoff BackOff
+	ctx, span := tracing.StartSpan(ctx, "copyResumableChunk",
+		attribute.String("dst_object", c.DstObject),
+		attribute.Int64("offset", c.BytesCopied),
+		attribute.Int64("chunk_size", bytesToCopy),
+		attribute.Bool("final", final),
+	)
+	defer func() {
+		span.SetAttributes(attribute.Int("retries", backoff.retries))
+		tracing.End(span, err)
+	}()
+
 	var srcCRC32C uint32
+	// A trusted CRC32C is sent with the final request for GCS to verify. A
+	// chunk copied this way leaves c.Crc32C unchanged, so a later chunk copied
+	// without trusting the CRC32C fails with a hash mismatch and is retried.
+	trustedCRC, trusted := trustedCRC32C(c)
+	var sendCRC32C *uint32
+	if trusted && final {
+		sendCRC32C = &trustedCRC
+	}
+	// The MD5 can only be verified when the whole file is sent in this request.
+	md5Verifiable := *verifyMD5 && final && c.BytesCopied == 0
+	var srcMD5 hash.Hash
+	// Likewise, the SHA256 can only be computed over the whole file.
+	sha256Computable := *computeSHA256 && final && c.BytesCopied == 0
+	var srcSHA256 hash.Hash
+	fileLimiter := rate.NewFileLimiter() // Shared by all retries of this chunk.
 
 	// This loop will retry multiple times if the HTTP response returns a retryable error.
-	var backoff BackOff
 	var delay time.Duration
 	var resp *http.Response
-	var err error
+	var attemptDur time.Duration
+	var resentBytes int64 // Bytes sent by attempts which were retried.
+	cancelAttempt := func() {}
+	defer func() { cancelAttempt() }() // The last response is read after the loop.
 	for {
 		select {
 		case <-ctx.Done():
@@ -491,34 +1140,96 @@ func (h *CopyHandler) copyResumableChunk(ctx context.Context, c *taskpb.CopySpec
 
 		seekStart := time.Now()
 		_, err = srcFile.Seek(c.BytesCopied, 0)
-		h.statsTracker.RecordPulseStats(&stats.PulseStats{CopySeekMs: stats.DurMs(seekStart)})
+		h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopySeekMs: stats.DurMs(seekStart)})
 		if err != nil {
 			return err
 		}
-		r := h.statsTracker.NewCopyByteTrackingReader(srcFile) // Wrap the srcFile in a CopyByteTrackingReader.
-		r = io.LimitReader(r, bytesToCopy)                     // Wrap with a LimitReader.
-		r = NewSemAcquiringReader(r, ctx)                      // Wrap with a SemAcquiringReader.
-		r = bufio.NewReaderSize(r, *fileReadBuf)               // Wrap with a buffered reader.
-		r = rate.NewRateLimitingReader(r)                      // Wrap with a RateLimitingReader.
-		srcCRC32C = c.Crc32C                                   // Set the initial crc32.
-		r = NewCRC32UpdatingReader(r, &srcCRC32C)              // Wrap with a CRC32UpdatingReader.
-		tr := stats.NewTimingReader(r)                         // Wrap with a TimingReader.
+		r := h.statsTracker.NewCopyByteTrackingReader(jobRun, srcFile) // Wrap the srcFile in a CopyByteTrackingReader.
+		r = common.NewTaskBytesReader(ctx, r)                          // Wrap with a TaskBytesReader.
+		r = io.LimitReader(r, bytesToCopy)                             // Wrap with a LimitReader.
+		r = NewSemAcquiringReader(r, ctx)                              // Wrap with a SemAcquiringReader.
+		var rar *ReadAheadReader
+		if *readAheadBuffers > 0 {
+			rar = NewReadAheadReader(r, *readAheadBuffers, readBufSize(bytesToCopy)) // Wrap with a ReadAheadReader.
+			r = rar
+		} else {
+			r = bufio.NewReaderSize(r, readBufSize(bytesToCopy)) // Wrap with a buffered reader.
+		}
+		r = rate.NewFileRateLimitingReader(r, fileLimiter) // Wrap with a RateLimitingReader.
+		if trusted {
+			srcCRC32C = trustedCRC
+		} else {
+			srcCRC32C = c.Crc32C                               // Set the initial crc32.
+			r = hashing.NewCRC32CUpdatingReader(r, &srcCRC32C) // Wrap with a CRC32CUpdatingReader.
+		}
+		if md5Verifiable {
+			srcMD5 = md5.New()
+			r = NewHashUpdatingReader(r, srcMD5) // Wrap with a HashUpdatingReader.
+		}
+		if sha256Computable {
+			srcSHA256 = sha256.New()
+			r = NewHashUpdatingReader(r, srcSHA256) // Wrap with a HashUpdatingReader.
+		}
+		tr := stats.NewTimingReader(r) // Wrap with a TimingReader.
 
-		// Perform the copy!
+		// Perform the copy! Each attempt gets its own timeout.
+		cancelAttempt()
+		var attemptCtx context.Context
+		attemptCtx, cancelAttempt = chunkRequestContext(ctx)
 		writeStart := time.Now()
-		resp, err = h.resumedCopyRequest(ctx, c.ResumableUploadId, tr, c.BytesCopied, int64(bytesToCopy), final)
-		h.statsTracker.RecordPulseStats(&stats.PulseStats{CopyWriteMs: stats.DurMs(writeStart.Add(tr.ReadDur()))})
+		resp, err = h.resumedCopyRequest(attemptCtx, c.ResumableUploadId, tr, c.BytesCopied, int64(bytesToCopy), final, sendCRC32C)
+		attemptDur = time.Since(writeStart)
+		h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopyWriteMs: stats.DurMs(writeStart.Add(tr.ReadDur()))})
+		if rar != nil {
+			// Stop reading ahead before srcFile is seeked for a retry.
+			rar.Close()
+			h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopyReadOverlapMs: int64(rar.OverlapDur() / time.Millisecond)})
+		}
 
 		var status int
 		if resp != nil {
 			status = resp.StatusCode
 		}
 
+		// GCS returns HTTP 429 both when rate limited, which is worth
+		// retrying, and when a quota is exhausted, which isn't.
+		if status == http.StatusTooManyRequests {
+			if apiErr := googleapi.CheckResponse(resp); common.IsQuotaExceeded(apiErr) {
+				resp.Body.Close()
+				return apiErr
+			}
+		}
+
 		// Check if we should retry the request.
 		if shouldRetry(status, err) {
-			h.statsTracker.RecordPulseStats(&stats.PulseStats{CopyInternalRetries: 1})
+			h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopyInternalRetries: 1})
 			var retry bool
 			if delay, retry = backoff.GetDelay(); retry {
+				// Wait at least as long as GCS asked us to when throttling.
+				if d, ok := retryAfter(resp, time.Now()); ok {
+					delay, retry = backoff.WaitAtLeast(delay, d)
+				}
+			}
+			if retry {
+				// The bytes this attempt sent are sent again by the retry.
+				resentBytes += tr.ReadBytes()
+				h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopyResentBytes: tr.ReadBytes()})
+				fields := common.Fields{
+					"job_run":      jobRun,
+					"src_file":     c.SrcFile,
+					"status":       status,
+					"retry":        backoff.retries,
+					"delay_ms":     int64(delay / time.Millisecond),
+					"resent_bytes": tr.ReadBytes(),
+				}
+				if err != nil {
+					fields["error"] = err
+				}
+				common.LogEvent("copy_retry", fields)
+				span.AddEvent("retry", trace.WithAttributes(
+					attribute.Int("status", status),
+					attribute.Int64("delay_ms", int64(delay/time.Millisecond)),
+				))
 				if resp != nil && resp.Body != nil {
 					resp.Body.Close()
 				}
@@ -540,7 +1251,7 @@ func (h *CopyHandler) copyResumableChunk(ctx context.Context, c *taskpb.CopySpec
 		}
 	}
 	if err = googleapi.CheckResponse(resp); err != nil {
-		return err
+		return objectExistsError(c, err)
 	}
 
 	if final {
@@ -553,6 +1264,9 @@ func (h *CopyHandler) copyResumableChunk(ctx context.Context, c *taskpb.CopySpec
 		if err = gensupport.DecodeResponse(&obj, resp); err != nil {
 			return fmt.Errorf("gensupport.DecodeResponse err: %v", err)
 		}
+		if c.TmpDstObject != "" {
+			defer h.deleteTempObject(c.DstBucket, c.TmpDstObject, obj.Generation)
+		}
 		var dstCRC32C uint32
 		if dstCRC32C, err = decodeUint32(obj.Crc32c); err != nil {
 			return fmt.Errorf("decodeUint32 err: %v", err)
@@ -566,8 +1280,19 @@ func (h *CopyHandler) copyResumableChunk(ctx context.Context, c *taskpb.CopySpec
 				FailureType: taskpb.FailureType_HASH_MISMATCH_FAILURE,
 			}
 		}
+		// Check the MD5, if requested.
+		if srcMD5 != nil {
+			dstMD5, err := base64.StdEncoding.DecodeString(obj.Md5Hash)
+			if err != nil {
+				return fmt.Errorf("base64 decode of MD5 %q err: %v", obj.Md5Hash, err)
+			}
+			if err := checkMD5(c, srcMD5.Sum(nil), dstMD5); err != nil {
+				return err
+			}
+		}
 		cl.DstCrc32C = dstCRC32C
 		cl.DstMd5 = obj.Md5Hash
+		cl.DstMetadata = obj.Metadata
 		cl.DstBytes = int64(obj.Size)
 		var t time.Time
 		if err := t.UnmarshalText([]byte(obj.Updated)); err != nil {
@@ -575,16 +1300,53 @@ func (h *CopyHandler) copyResumableChunk(ctx context.Context, c *taskpb.CopySpec
 		}
 		cl.DstMTime = t.Unix()
 		cl.SrcCrc32C = srcCRC32C
-	} else {
+		if srcSHA256 != nil {
+			cl.SrcSha256 = hex.EncodeToString(srcSHA256.Sum(nil))
+		}
+		generation := obj.Generation
+		if c.TmpDstObject != "" {
+			tmp := &storage.ObjectAttrs{
+				Generation:      obj.Generation,
+				Metadata:        obj.Metadata,
+				StorageClass:    obj.StorageClass,
+				ContentType:     obj.ContentType,
+				ContentEncoding: obj.ContentEncoding,
+			}
+			dstAttrs, err := h.finalizeTempObject(ctx, c, c.TmpDstObject, tmp)
+			if err != nil {
+				return err
+			}
+			cl.DstMTime = dstAttrs.Updated.Unix()
+			cl.DstMetadata = dstAttrs.Metadata
+			generation = dstAttrs.Generation
+		}
+		h.recordUpload(jobRun, c, fileinfo.Size(), srcCRC32C, generation, obj.ContentType)
+	} else if !trusted {
 		c.Crc32C = srcCRC32C
 	}
 	c.BytesCopied += int64(bytesToCopy)
 	cl.BytesCopied = c.BytesCopied
+	if resentBytes > 0 {
+		glog.Infof("Sent %d bytes to copy the %d byte chunk of %s at offset %d, %d bytes were resent by %d retries", bytesToCopy+resentBytes, bytesToCopy, c.SrcFile, c.BytesCopied-bytesToCopy, resentBytes, backoff.retries)
+	}
+	if *adaptiveChunkSize && !final {
+		c.ChunkSize = nextChunkSize(bytesToCopy, attemptDur, backoff.retries)
+		glog.Infof("Copied %d byte chunk of %s in %v with %d retries, next chunk is %d bytes", bytesToCopy, c.SrcFile, attemptDur, backoff.retries, c.ChunkSize)
+	}
 
 	return nil
 }
 
-func (h *CopyHandler) resumedCopyRequest(ctx context.Context, URL string, data io.Reader, offset, size int64, final bool) (*http.Response, error) {
+// chunkRequestContext returns the context for a single resumable copy request,
+// which times out after the chunk-request-timeout if it's set.
+func chunkRequestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if *chunkRequestTimeout > 0 {
+		return context.WithTimeout(ctx, *chunkRequestTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+func (h *CopyHandler) resumedCopyRequest(ctx context.Context, URL string, data io.Reader, offset, size int64, final bool, crc32c *uint32) (*http.Response, error) {
 	req, err := http.NewRequest("PUT", URL, data)
 	if err != nil {
 		return nil, err
@@ -603,6 +1365,10 @@ func (h *CopyHandler) resumedCopyRequest(ctx context.Context, URL string, data i
 	}
 	req.Header.Set("Content-Range", contentRange)
 	req.Header.Set("Content-Length", fmt.Sprint(size))
+	if crc32c != nil {
+		// GCS verifies the whole object against this CRC32C.
+		req.Header.Set("X-Goog-Hash", "crc32c="+encodeUint32(*crc32c))
+	}
 
 	// Google's upload endpoint uses status code 308 for a
 	// different purpose than the "308 Permanent Redirect"
@@ -616,6 +1382,87 @@ func (h *CopyHandler) resumedCopyRequest(ctx context.Context, URL string, data i
 	return h.httpDoFunc(ctx, h.hc, req)
 }
 
+// resumableUploadOffset queries the status of the resumable upload at URL, and
+// returns the number of bytes GCS has committed. A completed upload has
+// committed all size bytes.
+func (h *CopyHandler) resumableUploadOffset(ctx context.Context, URL string, size int64) (int64, error) {
+	req, err := http.NewRequest("PUT", URL, http.NoBody)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Range", "bytes */*")
+	req.Header.Set("Content-Length", "0")
+	req.Header.Set("X-Guploader-No-308", "yes")
+	resp, err := h.httpDoFunc(ctx, h.hc, req)
+	if err != nil {
+		return 0, fmt.Errorf("resumable upload status err: %v", err)
+	}
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+	if resp.StatusCode == 410 {
+		return 0, common.AgentError{
+			Msg:         fmt.Sprintf("GCS HTTP 410 for uploadid %v", URL),
+			FailureType: taskpb.FailureType_GCS_RESUMABLE_ID_GONE_FAILURE,
+		}
+	}
+	if resp.StatusCode != 308 {
+		if err := googleapi.CheckResponse(resp); err != nil {
+			return 0, err
+		}
+		if !statusResumeIncomplete(resp) {
+			return size, nil
+		}
+	}
+	// The Range header holds the committed bytes, as "bytes=0-<last byte>".
+	// It's missing if no bytes have been committed.
+	rng := resp.Header.Get("Range")
+	if rng == "" {
+		return 0, nil
+	}
+	last, err := strconv.ParseInt(strings.TrimPrefix(rng, "bytes=0-"), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid resumable upload Range header %q: %v", rng, err)
+	}
+	return last + 1, nil
+}
+
+// reconcileResumableOffset updates the BytesCopied and Crc32C of c to match
+// the bytes GCS has committed to its resumable upload, reading the source
+// file to compute the CRC32C if they differ.
+func (h *CopyHandler) reconcileResumableOffset(ctx context.Context, c *taskpb.CopySpec, srcFile *os.File, fileinfo os.FileInfo) error {
+	offset, err := h.resumableUploadOffset(ctx, c.ResumableUploadId, fileinfo.Size())
+	if err != nil {
+		return err
+	}
+	if offset == c.BytesCopied {
+		return nil
+	}
+	if offset > fileinfo.Size() {
+		return fmt.Errorf("resumable upload of %s has %d bytes committed, more than the file's %d bytes", c.SrcFile, offset, fileinfo.Size())
+	}
+	glog.Warningf("Resumable upload of %s has %d bytes committed, but %d were recorded as copied", c.SrcFile, offset, c.BytesCopied)
+
+	// Only the bytes past BytesCopied need to be read, unless GCS has fewer.
+	start, crc := c.BytesCopied, c.Crc32C
+	if offset < c.BytesCopied {
+		start, crc = 0, 0
+	}
+	if _, err := srcFile.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
+	n, err := io.Copy(ioutil.Discard, hashing.NewCRC32CUpdatingReader(io.LimitReader(srcFile, offset-start), &crc))
+	if err != nil {
+		return err
+	}
+	if n != offset-start {
+		return fmt.Errorf("read %d bytes of %s to reconcile its resumable upload, want %d", n, c.SrcFile, offset-start)
+	}
+	c.BytesCopied = offset
+	c.Crc32C = crc
+	return nil
+}
+
 func statusResumeIncomplete(resp *http.Response) bool {
 	// This is how the server signals "status resume incomplete"
 	// when X-Guploader-No-308 is set to "yes":
@@ -655,6 +1502,9 @@ func shouldRetry(status int, err error) bool {
 	if err == io.ErrUnexpectedEOF {
 		return true
 	}
+	if err == context.DeadlineExceeded {
+		return true // The chunk-request-timeout expired.
+	}
 	if err, ok := err.(net.Error); ok {
 		return err.Temporary()
 	}
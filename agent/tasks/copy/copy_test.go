@@ -17,6 +17,7 @@ package copy
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/base64"
 	"encoding/json"
@@ -28,6 +29,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -35,11 +37,14 @@ import (
 	"cloud.google.com/go/storage"
 	agentcommon "github.com/GoogleCloudPlatform/cloud-ingest/agent/common"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/hashing"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/rate"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"golang.org/x/sync/semaphore"
+	"google.golang.org/api/googleapi"
 	raw "google.golang.org/api/storage/v1"
 
 	controlpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/control_go_proto"
@@ -54,6 +59,9 @@ const (
 	// echo -n "<content>" | openssl dgst -md5 -binary |  openssl enc -base64
 	testMD5  = "fZffxoPtxWX7SJhvXn+xpA==" // MD5 hash of testFileContent.
 	emptyMD5 = "1B2M2Y8AsgTpgAmY7PhCfg=="
+
+	// echo -n "<content>" | sha256sum
+	testSHA256 = "ada66070a21a4d5d5c6db58dc9d75abaaa92e24e3f58aadbbbc0c553dcdb203a" // SHA256 of testFileContent.
 )
 
 func testCopySpec(expGenNum, ccSize int64, ruID string) *taskpb.Spec {
@@ -90,6 +98,16 @@ func decodeBase64(s string) []byte {
 	return b
 }
 
+// testFsType returns the file system type copy logs report for the file at p.
+func testFsType(p string) string {
+	f, err := os.Open(p)
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+	return fsType(f)
+}
+
 func TestSourceNotFound(t *testing.T) {
 	h := CopyHandler{concurrentCopySem: semaphore.NewWeighted(1)}
 	taskReqMsg := testCopyTaskReqMsg()
@@ -125,6 +143,34 @@ func TestCRC32CMismtach(t *testing.T) {
 	}
 }
 
+func TestMD5Mismatch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	writer := common.NewStringWriteCloser(&storage.ObjectAttrs{
+		CRC32C: uint32(testCRC32C),
+		MD5:    decodeBase64(emptyMD5), // Incorrect MD5.
+	})
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	mockGCS.EXPECT().NewWriterWithCondition(context.Background(), "bucket", "object", gomock.Any()).Return(writer)
+
+	*verifyMD5 = true
+	defer func() { *verifyMD5 = false }()
+	h := CopyHandler{
+		gcs:               mockGCS,
+		concurrentCopySem: semaphore.NewWeighted(1),
+	}
+	taskReqMsg := testCopyTaskReqMsg()
+	taskReqMsg.Spec.GetCopySpec().SrcFile = tmpFile
+	taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+	if isValid, errMsg := common.IsValidFailureMsg("task", taskpb.FailureType_HASH_MISMATCH_FAILURE, taskRespMsg); !isValid {
+		t.Error(errMsg)
+	}
+}
+
 func TestCopyEntireFileSuccess(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -165,6 +211,7 @@ func TestCopyEntireFileSuccess(t *testing.T) {
 				SrcFile:   tmpFile,
 				SrcBytes:  int64(len(testFileContent)),
 				SrcMTime:  srcStats.ModTime().Unix(),
+				SrcFsType: testFsType(tmpFile),
 				SrcCrc32C: testCRC32C,
 
 				DstFile:   "bucket/object",
@@ -182,6 +229,481 @@ func TestCopyEntireFileSuccess(t *testing.T) {
 	}
 }
 
+func TestCopyEntireFileDstTransform(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	writer := common.NewStringWriteCloser(&storage.ObjectAttrs{
+		CRC32C: uint32(testCRC32C),
+		MD5:    decodeBase64(testMD5),
+		Size:   int64(len(testFileContent)),
+	})
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	mockGCS.EXPECT().NewWriterWithCondition(context.Background(), "bucket", "archive/data/object", gomock.Any()).Return(writer)
+
+	h := CopyHandler{
+		gcs:               mockGCS,
+		concurrentCopySem: semaphore.NewWeighted(1),
+	}
+	taskReqMsg := testCopyTaskReqMsg()
+	copySpec := taskReqMsg.Spec.GetCopySpec()
+	copySpec.SrcFile = tmpFile
+	copySpec.DstObject = "mnt/data/object"
+	copySpec.DstStripPrefix = "mnt/"
+	copySpec.DstAddPrefix = "archive/"
+	taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+	if isValid, errMsg := common.IsValidSuccessMsg("task", taskRespMsg); !isValid {
+		t.Error(errMsg)
+	}
+	if got, want := taskRespMsg.Log.GetCopyLog().DstFile, "bucket/archive/data/object"; got != want {
+		t.Errorf("DstFile = %q, want %q", got, want)
+	}
+	if got, want := taskRespMsg.RespSpec.GetCopySpec().DstObject, "archive/data/object"; got != want {
+		t.Errorf("RespSpec DstObject = %q, want %q", got, want)
+	}
+}
+
+func TestCopySkipUnchanged(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	srcStats, _ := os.Stat(tmpFile)
+
+	gcsModTime := time.Now()
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	mockGCS.EXPECT().GetAttrs(context.Background(), "bucket", "object").Return(&storage.ObjectAttrs{
+		Generation: 1234,
+		Size:       int64(len(testFileContent)),
+		CRC32C:     uint32(testCRC32C),
+		MD5:        decodeBase64(testMD5),
+		Updated:    gcsModTime,
+		Metadata:   map[string]string{MTIME_ATTR_NAME: fmt.Sprint(srcStats.ModTime().Unix())},
+	}, nil)
+
+	*skipUnchanged = true
+	defer func() { *skipUnchanged = false }()
+	h := CopyHandler{
+		gcs:               mockGCS,
+		concurrentCopySem: semaphore.NewWeighted(1),
+	}
+	taskReqMsg := &taskpb.TaskReqMsg{
+		TaskRelRsrcName: "task",
+		Spec:            testCopySpec(1234, 0, ""),
+	}
+	taskReqMsg.Spec.GetCopySpec().SrcFile = tmpFile
+	taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+	if isValid, errMsg := common.IsValidSuccessMsg("task", taskRespMsg); !isValid {
+		t.Error(errMsg)
+	}
+
+	wantLog := &taskpb.Log{
+		Log: &taskpb.Log_CopyLog{
+			CopyLog: &taskpb.CopyLog{
+				SrcFile:   tmpFile,
+				SrcBytes:  int64(len(testFileContent)),
+				SrcMTime:  srcStats.ModTime().Unix(),
+				SrcFsType: testFsType(tmpFile),
+
+				DstFile:   "bucket/object",
+				DstBytes:  int64(len(testFileContent)),
+				DstMTime:  gcsModTime.Unix(),
+				DstCrc32C: testCRC32C,
+				DstMd5:    testMD5,
+
+				Skipped: true,
+			},
+		},
+	}
+	if !proto.Equal(taskRespMsg.Log, wantLog) {
+		t.Errorf("log = %+v, want: %+v", taskRespMsg.Log, wantLog)
+	}
+}
+
+func TestIsUnchanged(t *testing.T) {
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	fileinfo, _ := os.Stat(tmpFile)
+	mtime := fmt.Sprint(fileinfo.ModTime().Unix())
+	size := int64(len(testFileContent))
+
+	tests := []struct {
+		desc     string
+		expGen   int64
+		attrs    *storage.ObjectAttrs
+		attrsErr error
+		want     bool
+		wantErr  bool
+	}{
+		{"Unchanged", 5, &storage.ObjectAttrs{Generation: 5, Size: size, Metadata: map[string]string{MTIME_ATTR_NAME: mtime}}, nil, true, false},
+		{"Object must not exist", 0, nil, nil, false, false},
+		{"Object not found", 5, nil, storage.ErrObjectNotExist, false, false},
+		{"GetAttrs error", 5, nil, errors.New("some error"), false, true},
+		{"Generation mismatch", 5, &storage.ObjectAttrs{Generation: 6, Size: size, Metadata: map[string]string{MTIME_ATTR_NAME: mtime}}, nil, false, false},
+		{"Size mismatch", 5, &storage.ObjectAttrs{Generation: 5, Size: size + 1, Metadata: map[string]string{MTIME_ATTR_NAME: mtime}}, nil, false, false},
+		{"MTime mismatch", 5, &storage.ObjectAttrs{Generation: 5, Size: size, Metadata: map[string]string{MTIME_ATTR_NAME: "1"}}, nil, false, false},
+		{"MTime missing", 5, &storage.ObjectAttrs{Generation: 5, Size: size}, nil, false, false},
+		{"Gzipped", 5, &storage.ObjectAttrs{Generation: 5, Size: size / 2, ContentEncoding: "gzip", Metadata: map[string]string{MTIME_ATTR_NAME: mtime}}, nil, true, false},
+		{"Gzipped mtime mismatch", 5, &storage.ObjectAttrs{Generation: 5, Size: size / 2, ContentEncoding: "gzip", Metadata: map[string]string{MTIME_ATTR_NAME: "1"}}, nil, false, false},
+	}
+	for _, tc := range tests {
+		mockCtrl := gomock.NewController(t)
+		mockGCS := gcloud.NewMockGCS(mockCtrl)
+		if tc.expGen != 0 {
+			mockGCS.EXPECT().GetAttrs(context.Background(), "bucket", "object").Return(tc.attrs, tc.attrsErr)
+		}
+		h := CopyHandler{gcs: mockGCS}
+		c := testCopySpec(tc.expGen, 0, "").GetCopySpec()
+		got, _, err := h.isUnchanged(context.Background(), c, fileinfo)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: isUnchanged got err %v, wantErr %v", tc.desc, err, tc.wantErr)
+		}
+		if got != tc.want {
+			t.Errorf("%s: isUnchanged got %v, want %v", tc.desc, got, tc.want)
+		}
+		mockCtrl.Finish()
+	}
+}
+
+func TestCopyEntireFileGzip(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	defer func(v string) { *gzipFiles = v }(*gzipFiles)
+	*gzipFiles = "test-agent*"
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	gz.Write([]byte(testFileContent))
+	gz.Close()
+	compressedCRC32C := hashing.CRC32C(compressed.Bytes())
+	writer := common.NewStringWriteCloser(&storage.ObjectAttrs{
+		CRC32C: compressedCRC32C,
+		Size:   int64(compressed.Len()),
+	})
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	mockGCS.EXPECT().NewWriterWithCondition(context.Background(), "bucket", "object", gomock.Any()).Return(writer)
+
+	h := CopyHandler{
+		gcs:               mockGCS,
+		concurrentCopySem: semaphore.NewWeighted(1),
+	}
+	taskReqMsg := testCopyTaskReqMsg()
+	taskReqMsg.Spec.GetCopySpec().SrcFile = tmpFile
+	taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+	if isValid, errMsg := common.IsValidSuccessMsg("task", taskRespMsg); !isValid {
+		t.Error(errMsg)
+	}
+	if writer.WrittenString() != compressed.String() {
+		t.Errorf("written string want %q, got %q", compressed.String(), writer.WrittenString())
+	}
+	cl := taskRespMsg.Log.GetCopyLog()
+	if cl.SrcBytes != int64(len(testFileContent)) || cl.CompressedBytes != int64(compressed.Len()) {
+		t.Errorf("got SrcBytes %d, CompressedBytes %d, want %d, %d",
+			cl.SrcBytes, cl.CompressedBytes, len(testFileContent), compressed.Len())
+	}
+	if cl.SrcCrc32C != compressedCRC32C {
+		t.Errorf("got SrcCrc32C %d, want %d", cl.SrcCrc32C, compressedCRC32C)
+	}
+}
+
+func TestCopyEntireFileSHA256(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	writer := common.NewStringWriteCloser(&storage.ObjectAttrs{
+		CRC32C: uint32(testCRC32C),
+		Size:   int64(len(testFileContent)),
+	})
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	mockGCS.EXPECT().NewWriterWithCondition(context.Background(), "bucket", "object", gomock.Any()).Return(writer)
+
+	*computeSHA256 = true
+	defer func() { *computeSHA256 = false }()
+	h := CopyHandler{
+		gcs:               mockGCS,
+		concurrentCopySem: semaphore.NewWeighted(1),
+	}
+	taskReqMsg := testCopyTaskReqMsg()
+	taskReqMsg.Spec.GetCopySpec().SrcFile = tmpFile
+	taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+	if isValid, errMsg := common.IsValidSuccessMsg("task", taskRespMsg); !isValid {
+		t.Error(errMsg)
+	}
+	if got := taskRespMsg.Log.GetCopyLog().SrcSha256; got != testSHA256 {
+		t.Errorf("SrcSha256 got %q, want %q", got, testSHA256)
+	}
+}
+
+func TestCopyEntireFileTrustSourceChecksum(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	// The trusted CRC32C doesn't match the file content, so the copy only
+	// succeeds if the agent doesn't compute it.
+	trustedCRC := uint32(testCRC32C) + 1
+	writer := common.NewStringWriteCloser(&storage.ObjectAttrs{
+		CRC32C: trustedCRC,
+		Size:   int64(len(testFileContent)),
+	})
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	mockGCS.EXPECT().NewWriterWithCondition(context.Background(), "bucket", "object", gomock.Any()).Return(writer)
+
+	*trustSourceChecksum = true
+	defer func() { *trustSourceChecksum = false }()
+	h := CopyHandler{
+		gcs:               mockGCS,
+		concurrentCopySem: semaphore.NewWeighted(1),
+	}
+	taskReqMsg := testCopyTaskReqMsg()
+	taskReqMsg.Spec.GetCopySpec().SrcFile = tmpFile
+	taskReqMsg.Spec.GetCopySpec().SrcFileCrc32C = &wrappers.UInt32Value{Value: trustedCRC}
+	taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+	if isValid, errMsg := common.IsValidSuccessMsg("task", taskRespMsg); !isValid {
+		t.Error(errMsg)
+	}
+	if got := taskRespMsg.Log.GetCopyLog().SrcCrc32C; got != trustedCRC {
+		t.Errorf("SrcCrc32C got %v, want %v", got, trustedCRC)
+	}
+}
+
+func TestTrustedCRC32C(t *testing.T) {
+	defer func() { *trustSourceChecksum = false }()
+	tests := []struct {
+		desc      string
+		trust     bool
+		crc       *wrappers.UInt32Value
+		wantCRC   uint32
+		wantTrust bool
+	}{
+		{"Not trusted", false, &wrappers.UInt32Value{Value: 5}, 0, false},
+		{"No checksum", true, nil, 0, false},
+		{"Trusted", true, &wrappers.UInt32Value{Value: 5}, 5, true},
+		{"Trusted zero", true, &wrappers.UInt32Value{}, 0, true},
+	}
+	for _, tc := range tests {
+		*trustSourceChecksum = tc.trust
+		gotCRC, gotTrust := trustedCRC32C(&taskpb.CopySpec{SrcFileCrc32C: tc.crc})
+		if gotCRC != tc.wantCRC || gotTrust != tc.wantTrust {
+			t.Errorf("%s: trustedCRC32C() = (%v, %v), want (%v, %v)", tc.desc, gotCRC, gotTrust, tc.wantCRC, tc.wantTrust)
+		}
+	}
+}
+
+func TestCopyEmptyDirMarker(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	writer := common.NewStringWriteCloser(&storage.ObjectAttrs{})
+
+	tmpDir := common.CreateTmpDir("", "test-agent")
+	defer os.RemoveAll(tmpDir)
+	common.SetEmptyDirMarker("/")
+	defer common.SetEmptyDirMarker("")
+
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	mockGCS.EXPECT().NewWriterWithCondition(context.Background(), "bucket", "dir/", gomock.Any()).Return(writer)
+	h := CopyHandler{
+		gcs:               mockGCS,
+		concurrentCopySem: semaphore.NewWeighted(1),
+	}
+	taskReqMsg := testCopyTaskReqMsg()
+	taskReqMsg.Spec.GetCopySpec().SrcFile = tmpDir + "/"
+	taskReqMsg.Spec.GetCopySpec().DstObject = "dir/"
+	taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+	if isValid, errMsg := common.IsValidSuccessMsg("task", taskRespMsg); !isValid {
+		t.Error(errMsg)
+	}
+	if got := writer.WrittenString(); got != "" {
+		t.Errorf("wrote %q, want an empty object", got)
+	}
+	if got := taskRespMsg.Log.GetCopyLog().BytesCopied; got != 0 {
+		t.Errorf("BytesCopied = %d, want 0", got)
+	}
+}
+
+func TestCopySymlinkAsObject(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	symlink := tmpFile + "-link"
+	if err := os.Symlink(tmpFile, symlink); err != nil {
+		t.Fatalf("os.Symlink(%q, %q) got err: %v", tmpFile, symlink, err)
+	}
+	defer os.Remove(symlink)
+
+	writer := common.NewStringWriteCloser(&storage.ObjectAttrs{
+		CRC32C: hashing.CRC32C([]byte(tmpFile)),
+		Size:   int64(len(tmpFile)),
+	})
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	mockGCS.EXPECT().NewWriterWithCondition(context.Background(), "bucket", "object", gomock.Any()).Return(writer)
+
+	if err := common.SetSymlinkPolicy(common.SymlinkPolicyCopyAsObject); err != nil {
+		t.Fatalf("SetSymlinkPolicy got err: %v", err)
+	}
+	defer common.SetSymlinkPolicy(common.SymlinkPolicySkip)
+	h := CopyHandler{
+		gcs:               mockGCS,
+		concurrentCopySem: semaphore.NewWeighted(1),
+	}
+	taskReqMsg := testCopyTaskReqMsg()
+	taskReqMsg.Spec.GetCopySpec().SrcFile = symlink
+	taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+	if isValid, errMsg := common.IsValidSuccessMsg("task", taskRespMsg); !isValid {
+		t.Error(errMsg)
+	}
+	if got := writer.WrittenString(); got != tmpFile {
+		t.Errorf("written string got %q, want %q", got, tmpFile)
+	}
+	if got := taskRespMsg.Log.GetCopyLog().BytesCopied; got != int64(len(tmpFile)) {
+		t.Errorf("BytesCopied got %d, want %d", got, len(tmpFile))
+	}
+}
+
+func TestCheckFileStability(t *testing.T) {
+	defer func(d time.Duration) { *minFileStabilityAge = d }(*minFileStabilityAge)
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	fileinfo, _ := os.Stat(tmpFile)
+	mtime := fileinfo.ModTime()
+
+	tests := []struct {
+		desc    string
+		minAge  time.Duration
+		now     time.Time
+		wantErr bool
+	}{
+		{"Disabled", 0, mtime, false},
+		{"Just modified", time.Minute, mtime.Add(time.Second), true},
+		{"Stable", time.Minute, mtime.Add(time.Minute), false},
+	}
+	for _, tc := range tests {
+		*minFileStabilityAge = tc.minAge
+		err := checkFileStability(fileinfo, tc.now)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: checkFileStability got err: %v, want err: %v", tc.desc, err, tc.wantErr)
+		}
+		if got := common.GetFailureTypeFromError(err); err != nil && got != taskpb.FailureType_FILE_NOT_STABLE_FAILURE {
+			t.Errorf("%s: checkFileStability got failure type %v, want FILE_NOT_STABLE_FAILURE", tc.desc, got)
+		}
+	}
+}
+
+func TestCopyUnstableFile(t *testing.T) {
+	defer func(d time.Duration) { *minFileStabilityAge = d }(*minFileStabilityAge)
+	*minFileStabilityAge = time.Hour
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	tmpFile := common.CreateTmpFile("", "test-agent", "")
+	defer os.Remove(tmpFile)
+
+	// Nothing is written to GCS.
+	h := CopyHandler{
+		gcs:               gcloud.NewMockGCS(mockCtrl),
+		concurrentCopySem: semaphore.NewWeighted(1),
+	}
+	taskReqMsg := testCopyTaskReqMsg()
+	taskReqMsg.Spec.GetCopySpec().SrcFile = tmpFile
+	taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+	if isValid, errMsg := common.IsValidFailureMsg("task", taskpb.FailureType_FILE_NOT_STABLE_FAILURE, taskRespMsg); !isValid {
+		t.Error(errMsg)
+	}
+}
+
+// preconditionFailedWriter fakes a storage.Writer whose object already exists.
+type preconditionFailedWriter struct {
+	*common.StringWriteCloser
+}
+
+func (w preconditionFailedWriter) Close() error {
+	return &googleapi.Error{Code: http.StatusPreconditionFailed, Message: "Precondition Failed"}
+}
+
+func TestObjectExistsError(t *testing.T) {
+	preconditionErr := &googleapi.Error{Code: http.StatusPreconditionFailed}
+	otherErr := &googleapi.Error{Code: http.StatusForbidden}
+	tests := []struct {
+		policy taskpb.OverwritePolicy
+		err    error
+		want   taskpb.FailureType
+	}{
+		{taskpb.OverwritePolicy_OVERWRITE, preconditionErr, taskpb.FailureType_PRECONDITION_FAILURE},
+		{taskpb.OverwritePolicy_FAIL_IF_EXISTS, preconditionErr, taskpb.FailureType_OBJECT_ALREADY_EXISTS_FAILURE},
+		{taskpb.OverwritePolicy_FAIL_IF_EXISTS, otherErr, taskpb.FailureType_PERMISSION_FAILURE},
+		{taskpb.OverwritePolicy_SKIP_IF_EXISTS, otherErr, taskpb.FailureType_PERMISSION_FAILURE},
+		{taskpb.OverwritePolicy_SKIP_IF_EXISTS, nil, taskpb.FailureType_UNSET_FAILURE_TYPE},
+	}
+	for _, tc := range tests {
+		c := &taskpb.CopySpec{DstBucket: "bucket", DstObject: "object", OverwritePolicy: tc.policy}
+		if got := common.GetFailureTypeFromError(objectExistsError(c, tc.err)); got != tc.want {
+			t.Errorf("objectExistsError(%v, %v) got failure type %v, want %v", tc.policy, tc.err, got, tc.want)
+		}
+	}
+	c := &taskpb.CopySpec{OverwritePolicy: taskpb.OverwritePolicy_SKIP_IF_EXISTS}
+	if got := objectExistsError(c, preconditionErr); got != errObjectExists {
+		t.Errorf("objectExistsError(%v, %v) = %v, want %v", c.OverwritePolicy, preconditionErr, got, errObjectExists)
+	}
+}
+
+func TestCopyOverwritePolicy(t *testing.T) {
+	tests := []struct {
+		policy          taskpb.OverwritePolicy
+		wantCond        storage.Conditions
+		wantFailureType taskpb.FailureType
+	}{
+		{taskpb.OverwritePolicy_OVERWRITE, storage.Conditions{GenerationMatch: 5}, taskpb.FailureType_PRECONDITION_FAILURE},
+		{taskpb.OverwritePolicy_SKIP_IF_EXISTS, storage.Conditions{DoesNotExist: true}, taskpb.FailureType_UNSET_FAILURE_TYPE},
+		{taskpb.OverwritePolicy_FAIL_IF_EXISTS, storage.Conditions{DoesNotExist: true}, taskpb.FailureType_OBJECT_ALREADY_EXISTS_FAILURE},
+	}
+	for _, tc := range tests {
+		mockCtrl := gomock.NewController(t)
+		tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+		defer os.Remove(tmpFile)
+
+		writer := preconditionFailedWriter{common.NewStringWriteCloser(nil)}
+		mockGCS := gcloud.NewMockGCS(mockCtrl)
+		mockGCS.EXPECT().NewWriterWithCondition(context.Background(), "bucket", "object", tc.wantCond).Return(writer)
+
+		h := CopyHandler{
+			gcs:               mockGCS,
+			concurrentCopySem: semaphore.NewWeighted(1),
+		}
+		taskReqMsg := &taskpb.TaskReqMsg{TaskRelRsrcName: "task", Spec: testCopySpec(5, 0, "")}
+		taskReqMsg.Spec.GetCopySpec().SrcFile = tmpFile
+		taskReqMsg.Spec.GetCopySpec().OverwritePolicy = tc.policy
+		taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+		if tc.wantFailureType == taskpb.FailureType_UNSET_FAILURE_TYPE {
+			if isValid, errMsg := common.IsValidSuccessMsg("task", taskRespMsg); !isValid {
+				t.Errorf("%v: %s", tc.policy, errMsg)
+			}
+			if !taskRespMsg.Log.GetCopyLog().Skipped {
+				t.Errorf("%v: CopyLog.Skipped = false, want true", tc.policy)
+			}
+		} else if isValid, errMsg := common.IsValidFailureMsg("task", tc.wantFailureType, taskRespMsg); !isValid {
+			t.Errorf("%v: %s", tc.policy, errMsg)
+		}
+		mockCtrl.Finish()
+	}
+}
+
 func TestCopyEntireFileEmpty(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -215,22 +737,122 @@ func TestCopyEntireFileEmpty(t *testing.T) {
 			"", writer.WrittenString())
 	}
 
-	srcStats, _ := os.Stat(tmpFile)
-	wantLog := &taskpb.Log{
-		Log: &taskpb.Log_CopyLog{
-			CopyLog: &taskpb.CopyLog{
-				SrcFile:  tmpFile,
-				SrcMTime: srcStats.ModTime().Unix(),
+	srcStats, _ := os.Stat(tmpFile)
+	wantLog := &taskpb.Log{
+		Log: &taskpb.Log_CopyLog{
+			CopyLog: &taskpb.CopyLog{
+				SrcFile:   tmpFile,
+				SrcMTime:  srcStats.ModTime().Unix(),
+				SrcFsType: testFsType(tmpFile),
+
+				DstFile:  "bucket/object",
+				DstMTime: gcsModTime.Unix(),
+
+				DstMd5: emptyMD5,
+			},
+		},
+	}
+	if !proto.Equal(taskRespMsg.Log, wantLog) {
+		t.Errorf("log = %+v, want: %+v", taskRespMsg.Log, wantLog)
+	}
+}
+
+func TestDeleteSourceIfCopied(t *testing.T) {
+	defer func() { *deleteSource = false }()
+	tests := []struct {
+		desc        string
+		enabled     bool
+		spec        *taskpb.CopySpec
+		skipped     bool
+		missingFile bool
+		wantDeleted bool
+		wantErr     bool
+	}{
+		{"Disabled", false, &taskpb.CopySpec{}, false, false, false, false},
+		{"Entire file copy", true, &taskpb.CopySpec{}, false, false, true, false},
+		{"Resumable copy done", true, &taskpb.CopySpec{ResumableUploadId: "id", FileBytes: 10, BytesCopied: 10}, false, false, true, false},
+		{"Resumable copy in progress", true, &taskpb.CopySpec{ResumableUploadId: "id", FileBytes: 10, BytesCopied: 5}, false, false, false, false},
+		{"Skipped copy", true, &taskpb.CopySpec{}, true, false, false, false},
+		{"Metadata-only copy", true, &taskpb.CopySpec{MetadataOnly: true}, false, false, false, false},
+		{"Delete fails", true, &taskpb.CopySpec{}, false, true, false, true},
+	}
+	for _, tc := range tests {
+		*deleteSource = tc.enabled
+		tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+		if tc.missingFile {
+			os.Remove(tmpFile)
+		}
+		tc.spec.SrcFile = tmpFile
+		cl := &taskpb.CopyLog{Skipped: tc.skipped, MetadataOnly: tc.spec.MetadataOnly}
+		deleteSourceIfCopied(tc.spec, cl)
+		if cl.SrcDeleted != tc.wantDeleted {
+			t.Errorf("%s: SrcDeleted got %v, want %v", tc.desc, cl.SrcDeleted, tc.wantDeleted)
+		}
+		if gotErr := cl.SrcDeleteError != ""; gotErr != tc.wantErr {
+			t.Errorf("%s: SrcDeleteError got %q, wantErr %v", tc.desc, cl.SrcDeleteError, tc.wantErr)
+		}
+		if _, err := os.Stat(tmpFile); os.IsNotExist(err) != (tc.wantDeleted || tc.missingFile) {
+			t.Errorf("%s: os.Stat(%q) got err %v, want deleted %v", tc.desc, tmpFile, err, tc.wantDeleted)
+		}
+		os.Remove(tmpFile)
+	}
+}
+
+func TestValidateMetadataKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantErr bool
+	}{
+		{MTIME_ATTR_NAME, false},
+		{"mtime", false},
+		{"", true},
+		{"has space", true},
+		{"has:colon", true},
+		{"non-ascii-\u00e9", true},
+	}
+	for _, tc := range tests {
+		if err := validateMetadataKey(tc.key); (err != nil) != tc.wantErr {
+			t.Errorf("validateMetadataKey(%q) got err %v, wantErr %v", tc.key, err, tc.wantErr)
+		}
+	}
+}
+
+func TestObjectMetadataMtimeAttrName(t *testing.T) {
+	defer func(v string) { *mtimeAttrName = v }(*mtimeAttrName)
+	*mtimeAttrName = "other-tool-mtime"
 
-				DstFile:  "bucket/object",
-				DstMTime: gcsModTime.Unix(),
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	fileinfo, _ := os.Stat(tmpFile)
 
-				DstMd5: emptyMD5,
-			},
-		},
+	metadata := objectMetadata(&taskpb.CopySpec{}, fileinfo)
+	if got, want := metadata["other-tool-mtime"], fmt.Sprint(fileinfo.ModTime().Unix()); got != want {
+		t.Errorf("objectMetadata got mtime %q, want %q", got, want)
 	}
-	if !proto.Equal(taskRespMsg.Log, wantLog) {
-		t.Errorf("log = %+v, want: %+v", taskRespMsg.Log, wantLog)
+	if _, ok := metadata[MTIME_ATTR_NAME]; ok {
+		t.Errorf("objectMetadata got %v, want no %q attr", metadata, MTIME_ATTR_NAME)
+	}
+}
+
+func TestObjectMetadataCustom(t *testing.T) {
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	fileinfo, _ := os.Stat(tmpFile)
+
+	c := &taskpb.CopySpec{ObjectMetadata: map[string]string{
+		"migration-batch": "2019Q3",
+		MTIME_ATTR_NAME:   "0", // Reserved, can't be overridden.
+	}}
+	metadata := objectMetadata(c, fileinfo)
+	want := map[string]string{
+		"migration-batch": "2019Q3",
+		MTIME_ATTR_NAME:   fmt.Sprint(fileinfo.ModTime().Unix()),
+	}
+	if !reflect.DeepEqual(metadata, want) {
+		t.Errorf("objectMetadata got %v, want %v", metadata, want)
+	}
+	if len(c.ObjectMetadata) != 2 || c.ObjectMetadata[MTIME_ATTR_NAME] != "0" {
+		t.Errorf("objectMetadata modified the spec's metadata, got %v", c.ObjectMetadata)
 	}
 }
 
@@ -318,10 +940,11 @@ func TestCopyBundle(t *testing.T) {
 			bundleStatus:  taskpb.Status_FAILED,
 			bundleFailure: taskpb.FailureType_UNKNOWN_FAILURE,
 			bundleLog: &taskpb.CopyBundleLog{
-				FilesCopied: 1,
-				BytesCopied: 18,
-				FilesFailed: 1,
-				BytesFailed: 19,
+				FilesCopied:       1,
+				BytesCopied:       18,
+				FilesFailed:       1,
+				BytesFailed:       19,
+				FilesHashMismatch: 1,
 			},
 		},
 	}
@@ -416,6 +1039,7 @@ func TestCopyBundle(t *testing.T) {
 				SrcFile:   file.fileName,
 				SrcBytes:  file.size,
 				SrcMTime:  srcStats.ModTime().Unix(),
+				SrcFsType: testFsType(file.fileName),
 				SrcCrc32C: file.crc,
 
 				DstFile:   fmt.Sprintf("%s/%s", file.bucket, file.object),
@@ -488,9 +1112,10 @@ func TestCopyHandlerDoResumable(t *testing.T) {
 	wantLog := &taskpb.Log{
 		Log: &taskpb.Log_CopyLog{
 			CopyLog: &taskpb.CopyLog{
-				SrcFile:  tmpFile,
-				SrcBytes: int64(len(testFileContent)),
-				SrcMTime: srcStats.ModTime().Unix(),
+				SrcFile:   tmpFile,
+				SrcBytes:  int64(len(testFileContent)),
+				SrcMTime:  srcStats.ModTime().Unix(),
+				SrcFsType: testFsType(tmpFile),
 
 				DstFile: "bucket/object",
 
@@ -620,6 +1245,94 @@ func TestPrepareResumableCopy(t *testing.T) {
 	}
 }
 
+func TestPrepareResumableCopyKMSKey(t *testing.T) {
+	h := CopyHandler{}
+	h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
+		if got := req.URL.Query().Get("kmsKeyName"); got != "projects/p/locations/l/keyRings/r/cryptoKeys/k" {
+			t.Errorf("want URL param kmsKeyName, got %q in %s", got, req.URL.String())
+		}
+		res := &http.Response{
+			StatusCode: 200,
+			Header:     make(map[string][]string),
+		}
+		res.Header.Add("Location", "testResumableUploadId")
+		return res, nil
+	}
+
+	copySpec := testCopySpec(77, 10, "").GetCopySpec()
+	copySpec.KmsKeyName = "projects/p/locations/l/keyRings/r/cryptoKeys/k"
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	srcFile, err := os.Open(tmpFile)
+	if err != nil {
+		t.Error("Couldn't open testing srcFile, err: ", err)
+	}
+	defer srcFile.Close()
+	var stats fakeStats
+
+	if err := h.prepareResumableCopy(context.Background(), copySpec, srcFile, stats); err != nil {
+		t.Error("got ", err)
+	}
+}
+
+func TestPrepareResumableCopyPredefinedACL(t *testing.T) {
+	h := CopyHandler{}
+	h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
+		if got := req.URL.Query().Get("predefinedAcl"); got != "publicRead" {
+			t.Errorf("want URL param predefinedAcl, got %q in %s", got, req.URL.String())
+		}
+		res := &http.Response{
+			StatusCode: 200,
+			Header:     make(map[string][]string),
+		}
+		res.Header.Add("Location", "testResumableUploadId")
+		return res, nil
+	}
+
+	copySpec := testCopySpec(77, 10, "").GetCopySpec()
+	copySpec.PredefinedAcl = "publicRead"
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	srcFile, err := os.Open(tmpFile)
+	if err != nil {
+		t.Error("Couldn't open testing srcFile, err: ", err)
+	}
+	defer srcFile.Close()
+	var stats fakeStats
+
+	if err := h.prepareResumableCopy(context.Background(), copySpec, srcFile, stats); err != nil {
+		t.Error("got ", err)
+	}
+}
+
+func TestPrepareResumableCopyContentType(t *testing.T) {
+	h := CopyHandler{}
+	h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
+		if got := req.Header.Get("X-Upload-Content-Type"); got != "application/x-custom" {
+			t.Errorf("want header X-Upload-Content-Type application/x-custom, got %q", got)
+		}
+		res := &http.Response{
+			StatusCode: 200,
+			Header:     make(map[string][]string),
+		}
+		res.Header.Add("Location", "testResumableUploadId")
+		return res, nil
+	}
+
+	copySpec := testCopySpec(77, 10, "").GetCopySpec()
+	copySpec.ContentType = "application/x-custom"
+	var b bytes.Buffer
+	srcFile := io.TeeReader(strings.NewReader(testFileContent), &b)
+	var stats fakeStats
+
+	if err := h.prepareResumableCopy(context.Background(), copySpec, srcFile, stats); err != nil {
+		t.Error("got ", err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("prepareResumableCopy read %d bytes from the source file, want 0", b.Len())
+	}
+}
+
 func TestCopyResumableChunkFinal(t *testing.T) {
 	h := CopyHandler{}
 	h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
@@ -631,12 +1344,13 @@ func TestCopyResumableChunkFinal(t *testing.T) {
 		}
 
 		object := &raw.Object{
-			Name:    "object",
-			Bucket:  "bucket",
-			Md5Hash: testMD5,
-			Crc32c:  encodeUint32(testCRC32C),
-			Size:    uint64(len(testFileContent)),
-			Updated: "2012-11-01T22:08:41+00:00",
+			Name:     "object",
+			Bucket:   "bucket",
+			Md5Hash:  testMD5,
+			Crc32c:   encodeUint32(testCRC32C),
+			Size:     uint64(len(testFileContent)),
+			Updated:  "2012-11-01T22:08:41+00:00",
+			Metadata: map[string]string{"migration-batch": "2019Q3"},
 		}
 		body := new(bytes.Buffer)
 		_ = json.NewEncoder(body).Encode(object)
@@ -668,7 +1382,7 @@ func TestCopyResumableChunkFinal(t *testing.T) {
 			CopyLog: &taskpb.CopyLog{},
 		},
 	}
-	err = h.copyResumableChunk(ctx, copySpec, srcFile, stats, log.GetCopyLog())
+	err = h.copyResumableChunk(ctx, "", copySpec, srcFile, stats, log.GetCopyLog())
 	if err != nil {
 		t.Error("got ", err)
 	}
@@ -686,6 +1400,7 @@ func TestCopyResumableChunkFinal(t *testing.T) {
 				DstCrc32C:   testCRC32C,
 				DstMd5:      testMD5,
 				DstMTime:    1351807721,
+				DstMetadata: map[string]string{"migration-batch": "2019Q3"},
 				BytesCopied: int64(len(testFileContent)),
 			},
 		},
@@ -695,6 +1410,170 @@ func TestCopyResumableChunkFinal(t *testing.T) {
 	}
 }
 
+func TestCopyResumableChunkFinalVerifyMD5(t *testing.T) {
+	*verifyMD5 = true
+	defer func() { *verifyMD5 = false }()
+
+	tests := []struct {
+		desc    string
+		dstMD5  string
+		wantErr bool
+	}{
+		{"Matching MD5", testMD5, false},
+		{"Mismatched MD5", emptyMD5, true},
+	}
+	for _, tc := range tests {
+		h := CopyHandler{}
+		h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
+			// Read the http.Request.Body to invoke the hash updating readers.
+			buf := make([]byte, 1024)
+			var err error
+			for err == nil {
+				_, err = req.Body.Read(buf)
+			}
+
+			object := &raw.Object{
+				Name:    "object",
+				Bucket:  "bucket",
+				Md5Hash: tc.dstMD5,
+				Crc32c:  encodeUint32(testCRC32C),
+				Size:    uint64(len(testFileContent)),
+				Updated: "2012-11-01T22:08:41+00:00",
+			}
+			body := new(bytes.Buffer)
+			_ = json.NewEncoder(body).Encode(object)
+			res := &http.Response{
+				StatusCode: 200,
+				Header:     make(map[string][]string),
+				Body:       ioutil.NopCloser(body),
+			}
+			return res, nil
+		}
+
+		tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+		defer os.Remove(tmpFile)
+		srcFile, err := os.Open(tmpFile)
+		if err != nil {
+			t.Fatal("Couldn't open testing srcFile, err: ", err)
+		}
+		defer srcFile.Close()
+		var stats fakeStats
+
+		copySpec := testCopySpec(77, 100, "ruID").GetCopySpec()
+		err = h.copyResumableChunk(context.Background(), "", copySpec, srcFile, stats, &taskpb.CopyLog{})
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%v: copyResumableChunk got err %v, want err: %v", tc.desc, err, tc.wantErr)
+		}
+		if tc.wantErr {
+			if ft := common.GetFailureTypeFromError(err); ft != taskpb.FailureType_HASH_MISMATCH_FAILURE {
+				t.Errorf("%v: got failure type %v, want %v", tc.desc, ft, taskpb.FailureType_HASH_MISMATCH_FAILURE)
+			}
+		}
+	}
+}
+
+func TestCopyResumableChunkRequestTimeout(t *testing.T) {
+	defer func(d time.Duration) { *chunkRequestTimeout = d }(*chunkRequestTimeout)
+	*chunkRequestTimeout = 10 * time.Millisecond
+	defer func(d time.Duration) { *minBackOffDelay = d }(*minBackOffDelay)
+	*minBackOffDelay = time.Millisecond
+
+	attempts := 0
+	h := CopyHandler{}
+	h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
+		attempts++
+		if attempts == 1 {
+			// Stall the first attempt until it times out.
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		buf := make([]byte, 1024)
+		var err error
+		for err == nil {
+			_, err = req.Body.Read(buf)
+		}
+		if ctx.Err() != nil {
+			t.Errorf("attempt %d ctx got err: %v, want a fresh timeout", attempts, ctx.Err())
+		}
+		res := &http.Response{
+			StatusCode: 200,
+			Header:     make(map[string][]string),
+			Body:       ioutil.NopCloser(new(bytes.Buffer)),
+		}
+		return res, nil
+	}
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	srcFile, err := os.Open(tmpFile)
+	if err != nil {
+		t.Fatal("Couldn't open testing srcFile, err: ", err)
+	}
+	defer srcFile.Close()
+	var stats fakeStats
+
+	copySpec := testCopySpec(77, 10, "ruID").GetCopySpec() // Not the final chunk.
+	if err := h.copyResumableChunk(context.Background(), "", copySpec, srcFile, stats, &taskpb.CopyLog{}); err != nil {
+		t.Errorf("copyResumableChunk got err: %v, want nil", err)
+	}
+	if attempts != 2 {
+		t.Errorf("copyResumableChunk made %d attempts, want 2", attempts)
+	}
+}
+
+func TestCopyResumableChunkTooManyRequests(t *testing.T) {
+	defer func(d time.Duration) { *minBackOffDelay = d }(*minBackOffDelay)
+	*minBackOffDelay = time.Millisecond
+
+	tests := []struct {
+		reason          string
+		wantAttempts    int
+		wantFailureType taskpb.FailureType
+	}{
+		{"rateLimitExceeded", 2, taskpb.FailureType_UNSET_FAILURE_TYPE},
+		{"quotaExceeded", 1, taskpb.FailureType_QUOTA_EXCEEDED_FAILURE},
+	}
+	for _, tc := range tests {
+		attempts := 0
+		h := CopyHandler{}
+		h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				body := fmt.Sprintf(`{"error":{"code":429,"message":"Too many requests.","errors":[{"reason":%q}]}}`, tc.reason)
+				return &http.Response{
+					StatusCode: http.StatusTooManyRequests,
+					Header:     make(map[string][]string),
+					Body:       ioutil.NopCloser(strings.NewReader(body)),
+				}, nil
+			}
+			res := &http.Response{
+				StatusCode: 200,
+				Header:     make(map[string][]string),
+				Body:       ioutil.NopCloser(new(bytes.Buffer)),
+			}
+			return res, nil
+		}
+
+		tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+		defer os.Remove(tmpFile)
+		srcFile, err := os.Open(tmpFile)
+		if err != nil {
+			t.Fatal("Couldn't open testing srcFile, err: ", err)
+		}
+		defer srcFile.Close()
+		var stats fakeStats
+
+		copySpec := testCopySpec(77, 10, "ruID").GetCopySpec() // Not the final chunk.
+		err = h.copyResumableChunk(context.Background(), "", copySpec, srcFile, stats, &taskpb.CopyLog{})
+		if got := common.GetFailureTypeFromError(err); got != tc.wantFailureType {
+			t.Errorf("%s: copyResumableChunk got err: %v, want failure type %v", tc.reason, err, tc.wantFailureType)
+		}
+		if attempts != tc.wantAttempts {
+			t.Errorf("%s: copyResumableChunk made %d attempts, want %d", tc.reason, attempts, tc.wantAttempts)
+		}
+	}
+}
+
 func TestCopyResumableChunkNotFinal(t *testing.T) {
 	h := CopyHandler{}
 	h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
@@ -743,7 +1622,7 @@ func TestCopyResumableChunkNotFinal(t *testing.T) {
 			CopyLog: &taskpb.CopyLog{},
 		},
 	}
-	err = h.copyResumableChunk(ctx, copySpec, srcFile, stats, log.GetCopyLog())
+	err = h.copyResumableChunk(ctx, "", copySpec, srcFile, stats, log.GetCopyLog())
 	if err != nil {
 		t.Error("got ", err)
 	}
@@ -805,7 +1684,7 @@ func TestResumedCopyRequest(t *testing.T) {
 		{true, 5, 0, "bytes */5", "0"},
 	}
 	for _, tc := range testCases {
-		res, err := h.resumedCopyRequest(ctx, "testURL", data, tc.offset, tc.size, tc.final)
+		res, err := h.resumedCopyRequest(ctx, "testURL", data, tc.offset, tc.size, tc.final, nil)
 		if err != nil {
 			t.Errorf("want err nil, got %v", err)
 		}
@@ -831,6 +1710,114 @@ func TestResumedCopyRequest(t *testing.T) {
 	}
 }
 
+func TestResumedCopyRequestCRC32C(t *testing.T) {
+	h := CopyHandler{}
+	var gotHash string
+	h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
+		gotHash = req.Header.Get("X-Goog-Hash")
+		return &http.Response{}, nil
+	}
+	crc := uint32(testCRC32C)
+	if _, err := h.resumedCopyRequest(context.Background(), "testURL", bytes.NewBufferString("0123456789"), 0, 10, true, &crc); err != nil {
+		t.Errorf("want err nil, got %v", err)
+	}
+	if want := "crc32c=" + encodeUint32(crc); gotHash != want {
+		t.Errorf("X-Goog-Hash = %q, want %q", gotHash, want)
+	}
+}
+
+func TestResumableUploadOffset(t *testing.T) {
+	tests := []struct {
+		desc       string
+		status     int
+		override   bool
+		rng        string
+		wantOffset int64
+		wantErr    bool
+	}{
+		{"Nothing committed", 200, true, "", 0, false},
+		{"Committed", 200, true, "bytes=0-9", 10, false},
+		{"Committed 308", 308, false, "bytes=0-19", 20, false},
+		{"Complete", 200, false, "", 45, false},
+		{"Invalid range", 200, true, "bytes=5-9", 0, true},
+		{"Gone", 410, false, "", 0, true},
+		{"Server error", 503, false, "", 0, true},
+	}
+	for _, tc := range tests {
+		h := CopyHandler{}
+		h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("Content-Range"); got != "bytes */*" {
+				t.Errorf("%s: Content-Range = %q, want %q", tc.desc, got, "bytes */*")
+			}
+			res := &http.Response{
+				StatusCode: tc.status,
+				Header:     make(map[string][]string),
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+			}
+			if tc.override {
+				res.Header.Set("X-Http-Status-Code-Override", "308")
+			}
+			if tc.rng != "" {
+				res.Header.Set("Range", tc.rng)
+			}
+			return res, nil
+		}
+		offset, err := h.resumableUploadOffset(context.Background(), "testURL", 45)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: resumableUploadOffset() got err: %v, want err: %v", tc.desc, err, tc.wantErr)
+		}
+		if err == nil && offset != tc.wantOffset {
+			t.Errorf("%s: resumableUploadOffset() = %d, want %d", tc.desc, offset, tc.wantOffset)
+		}
+	}
+}
+
+func TestReconcileResumableOffset(t *testing.T) {
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	srcFile, err := os.Open(tmpFile)
+	if err != nil {
+		t.Fatalf("os.Open(%q) got err: %v", tmpFile, err)
+	}
+	defer srcFile.Close()
+	fileinfo, err := srcFile.Stat()
+	if err != nil {
+		t.Fatalf("Stat() got err: %v", err)
+	}
+
+	tests := []struct {
+		desc        string
+		bytesCopied int64
+		crc32c      uint32
+		committed   string
+		wantErr     bool
+	}{
+		{"In sync", 10, testTenByteCRC32C, "bytes=0-9", false},
+		{"Lost ack", 0, 0, "bytes=0-9", false},
+		{"Fewer committed", 20, 1234, "bytes=0-9", false},
+		{"More than the file", 0, 0, "bytes=0-99", true},
+	}
+	for _, tc := range tests {
+		h := CopyHandler{}
+		h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
+			res := &http.Response{StatusCode: 200, Header: make(map[string][]string)}
+			res.Header.Set("X-Http-Status-Code-Override", "308")
+			res.Header.Set("Range", tc.committed)
+			return res, nil
+		}
+		c := testCopySpec(0, 10, "ruID").GetCopySpec()
+		c.BytesCopied = tc.bytesCopied
+		c.Crc32C = tc.crc32c
+		err := h.reconcileResumableOffset(context.Background(), c, srcFile, fileinfo)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: reconcileResumableOffset() got err: %v, want err: %v", tc.desc, err, tc.wantErr)
+		}
+		if err == nil && (c.BytesCopied != 10 || c.Crc32C != testTenByteCRC32C) {
+			t.Errorf("%s: reconcileResumableOffset() got BytesCopied %d, Crc32C %d, want 10, %d", tc.desc, c.BytesCopied, c.Crc32C, testTenByteCRC32C)
+		}
+	}
+}
+
 func TestStatusResumeIncomplete(t *testing.T) {
 	if statusResumeIncomplete(nil) != false {
 		t.Errorf("want false, got true")
@@ -857,6 +1844,67 @@ func TestCodecUint32(t *testing.T) {
 	}
 }
 
+func TestReadBufSize(t *testing.T) {
+	defer func(v int) { *fileReadBuf = v }(*fileReadBuf)
+	tests := []struct {
+		desc        string
+		fileReadBuf int
+		n           int64
+		want        int
+	}{
+		{"Empty file", 1024 * 1024, 0, minFileReadBuf},
+		{"Tiny file", 1024 * 1024, 10, minFileReadBuf},
+		{"Exact power of two", 1024 * 1024, 64 * 1024, 64 * 1024},
+		{"Rounded up", 1024 * 1024, 64*1024 + 1, 128 * 1024},
+		{"Capped by the flag", 1024 * 1024, 1024 * 1024 * 1024, 1024 * 1024},
+		{"Cap not a power of two", 100 * 1024, 1024 * 1024, 100 * 1024},
+		{"Flag below the min", 10, 1024 * 1024, minFileReadBuf},
+	}
+	for _, tc := range tests {
+		*fileReadBuf = tc.fileReadBuf
+		if got := readBufSize(tc.n); got != tc.want {
+			t.Errorf("%s: readBufSize(%d) with file-read-buf %d = %d, want %d", tc.desc, tc.n, tc.fileReadBuf, got, tc.want)
+		}
+	}
+}
+
+func TestEstimateMemory(t *testing.T) {
+	defer func(v int) { *fileReadBuf = v }(*fileReadBuf)
+	defer func(v int) { *copyChunkSize = v }(*copyChunkSize)
+	*fileReadBuf = 1024 * 1024
+	*copyChunkSize = 128 * 1024 * 1024
+
+	small := &taskpb.CopySpec{FileBytes: 10 * 1024, BytesCopied: 2 * 1024, ResumableUploadId: "ruID"}
+	newCopy := &taskpb.CopySpec{}
+	tests := []struct {
+		desc string
+		spec *taskpb.Spec
+		want int64
+	}{
+		{"Resumed copy", &taskpb.Spec{Spec: &taskpb.Spec_CopySpec{small}}, 8 * 1024},
+		{"New copy", &taskpb.Spec{Spec: &taskpb.Spec_CopySpec{newCopy}}, 1024 * 1024},
+		{"Bundle", &taskpb.Spec{Spec: &taskpb.Spec_CopyBundleSpec{&taskpb.CopyBundleSpec{
+			BundledFiles: []*taskpb.BundledFile{{CopySpec: small}, {CopySpec: newCopy}},
+		}}}, 8*1024 + 1024*1024},
+		{"Verify", &taskpb.Spec{Spec: &taskpb.Spec_VerifySpec{&taskpb.VerifySpec{}}}, 0},
+	}
+	h := &CopyHandler{}
+	for _, tc := range tests {
+		if got := h.EstimateMemory(&taskpb.TaskReqMsg{Spec: tc.spec}); got != tc.want {
+			t.Errorf("%s: EstimateMemory() = %d, want %d", tc.desc, got, tc.want)
+		}
+	}
+
+	// Each read-ahead buffer is counted.
+	defer func(n int) { *readAheadBuffers = n }(*readAheadBuffers)
+	*readAheadBuffers = 3
+	for _, tc := range tests {
+		if got := h.EstimateMemory(&taskpb.TaskReqMsg{Spec: tc.spec}); got != 3*tc.want {
+			t.Errorf("%s with 3 read-ahead buffers: EstimateMemory() = %d, want %d", tc.desc, got, 3*tc.want)
+		}
+	}
+}
+
 func TestShouldRetry(t *testing.T) {
 	testCases := []struct {
 		status int
@@ -874,6 +1922,8 @@ func TestShouldRetry(t *testing.T) {
 		{err: io.EOF, want: false},
 		{err: errors.New("random badness"), want: false},
 		{err: io.ErrUnexpectedEOF, want: true},
+		{err: context.DeadlineExceeded, want: true},
+		{err: context.Canceled, want: false},
 		{err: &net.AddrError{}, want: false},              // Not temporary.
 		{err: &net.DNSError{IsTimeout: true}, want: true}, // Temporary.
 	}
@@ -884,6 +1934,57 @@ func TestShouldRetry(t *testing.T) {
 	}
 }
 
+func TestAcquireBundledFile(t *testing.T) {
+	defer func(n int) { *bundleFileConcurrency = n }(*bundleFileConcurrency)
+	defer func(n int) { *copyEntireFileLimit = n }(*copyEntireFileLimit)
+	*copyEntireFileLimit = 10
+	small := &taskpb.CopySpec{FileBytes: 10}
+	large := &taskpb.CopySpec{FileBytes: 11}
+
+	// timedOut returns true if acquiring the bundled file's limits blocks.
+	timedOut := func(h *CopyHandler, sems *bundleSems, c *taskpb.CopySpec) bool {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := h.acquireBundledFile(ctx, sems, c)
+		return err != nil
+	}
+
+	*bundleFileConcurrency = 0
+	if sems := newBundleSems(5); sems != nil {
+		t.Errorf("newBundleSems(5) with bundle-file-concurrency 0 = %v, want nil", sems)
+	}
+	h := &CopyHandler{concurrentCopySem: semaphore.NewWeighted(2)}
+	for i := 0; i < 2; i++ {
+		if timedOut(h, nil, small) {
+			t.Fatalf("file %d without bundleSems timed out, want it to take a copy-files slot", i)
+		}
+	}
+	if !timedOut(h, nil, small) {
+		t.Errorf("file without bundleSems didn't time out, want it to wait for a copy-files slot")
+	}
+
+	*bundleFileConcurrency = 3
+	if sems := newBundleSems(1); sems != nil {
+		t.Errorf("newBundleSems(1) = %v, want nil", sems)
+	}
+	// The copy-files slots are all taken, bundled files only wait for the bundle's limits.
+	sems := newBundleSems(5)
+	if timedOut(h, sems, large) {
+		t.Fatalf("large file timed out, want it to take the bundle's large file slot")
+	}
+	if !timedOut(h, sems, large) {
+		t.Errorf("second large file didn't time out, want it to wait for the bundle's large file slot")
+	}
+	for i := 0; i < 2; i++ {
+		if timedOut(h, sems, small) {
+			t.Fatalf("small file %d timed out, want it to take a bundle file slot", i)
+		}
+	}
+	if !timedOut(h, sems, small) {
+		t.Errorf("small file didn't time out, want it to wait for a bundle file slot")
+	}
+}
+
 func TestGetBundleLogAndError(t *testing.T) {
 	testCases := []struct {
 		desc            string
@@ -933,8 +2034,9 @@ func TestGetBundleLogAndError(t *testing.T) {
 			wantStatus:      taskpb.Status_FAILED,
 			wantFailureType: taskpb.FailureType_UNKNOWN_FAILURE,
 			wantLog: &taskpb.CopyBundleLog{
-				FilesFailed: 1,
-				BytesFailed: 1,
+				FilesFailed:       1,
+				BytesFailed:       1,
+				FilesHashMismatch: 1,
 			},
 		},
 		{
@@ -957,8 +2059,9 @@ func TestGetBundleLogAndError(t *testing.T) {
 			wantStatus:      taskpb.Status_FAILED,
 			wantFailureType: taskpb.FailureType_NOT_SERVICE_INDUCED_UNKNOWN_FAILURE,
 			wantLog: &taskpb.CopyBundleLog{
-				FilesFailed: 1,
-				BytesFailed: 1,
+				FilesFailed:   1,
+				BytesFailed:   1,
+				FilesNotFound: 1,
 			},
 		},
 		{
@@ -992,8 +2095,10 @@ func TestGetBundleLogAndError(t *testing.T) {
 			wantStatus:      taskpb.Status_FAILED,
 			wantFailureType: taskpb.FailureType_UNKNOWN_FAILURE,
 			wantLog: &taskpb.CopyBundleLog{
-				FilesFailed: 2,
-				BytesFailed: 3,
+				FilesFailed:       2,
+				BytesFailed:       3,
+				FilesHashMismatch: 1,
+				FilesNotFound:     1,
 			},
 		},
 	}
@@ -1011,6 +2116,10 @@ func TestGetBundleLogAndError(t *testing.T) {
 				t.Errorf("test case: %s of getBundleLogAndError, got failureType: %+v, want: %+v", tc.desc, failureType, tc.wantFailureType)
 			}
 		}
+		if log.FilesHashMismatch != tc.wantLog.FilesHashMismatch || log.FilesNotFound != tc.wantLog.FilesNotFound ||
+			log.FilesModified != tc.wantLog.FilesModified || log.FilesPermissionDenied != tc.wantLog.FilesPermissionDenied {
+			t.Errorf("test case: %s of getBundleLogAndError, got failure breakdown: %+v, want: %+v", tc.desc, log, tc.wantLog)
+		}
 	}
 }
 
@@ -1095,6 +2204,34 @@ func TestShouldDoTimeAwareCopy(t *testing.T) {
 	}
 }
 
+func TestShouldDoTimeAwareCopyJobRunWorkDuration(t *testing.T) {
+	jobBWs := []*controlpb.JobRunBandwidth{
+		&controlpb.JobRunBandwidth{
+			JobrunRelRsrcName:  "jrRRN_short",
+			Bandwidth:          10 * 1024 * 1024,
+			CopyWorkDurationMs: 1000, // Much shorter than the copy-work-duration flag.
+		},
+		&controlpb.JobRunBandwidth{
+			JobrunRelRsrcName: "jrRRN_default",
+			Bandwidth:         10 * 1024 * 1024,
+		},
+	}
+	rate.ProcessJobRunBandwidths(jobBWs, nil)
+
+	copySpec := &taskpb.CopySpec{
+		ResumableUploadId: "id",
+		BytesCopied:       5,
+		FileBytes:         10,
+	}
+	reqStart := time.Now().Add(-5 * time.Second)
+	if shouldDoTimeAwareCopy(copySpec, reqStart, "jrRRN_short") {
+		t.Errorf("shouldDoTimeAwareCopy(...) with an exceeded job run work duration got true, want false")
+	}
+	if !shouldDoTimeAwareCopy(copySpec, reqStart, "jrRRN_default") {
+		t.Errorf("shouldDoTimeAwareCopy(...) within the flag work duration got false, want true")
+	}
+}
+
 func TestCopyEntireFileWithMountDirectorySuccess(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
@@ -1139,6 +2276,7 @@ func TestCopyEntireFileWithMountDirectorySuccess(t *testing.T) {
 				SrcFile:   strings.TrimPrefix(tmpFile, os.TempDir()),
 				SrcBytes:  int64(len(testFileContent)),
 				SrcMTime:  srcStats.ModTime().Unix(),
+				SrcFsType: testFsType(tmpFile),
 				SrcCrc32C: testCRC32C,
 
 				DstFile:   "bucket/object",
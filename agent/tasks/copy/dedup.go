@@ -0,0 +1,174 @@
+package copy
+
+import (
+	"container/list"
+	"context"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/stats"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/glog"
+	"google.golang.org/api/googleapi"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+var (
+	dedupCacheSize = flag.Int("dedup-cache-size", 0, "The number of uploaded objects, keyed by job run, size and CRC32C, that the agent remembers. A later file of the same job run with the same size and CRC32C is copied server-side from the remembered object instead of being read and uploaded. The CRC32C must be known before the copy, so this only applies with trust-source-checksum. If 0, files aren't deduplicated.")
+)
+
+// dedupKey identifies the content of a file within a job run.
+type dedupKey struct {
+	jobRun string
+	size   int64
+	crc32c uint32
+}
+
+// dedupObject is a GCS object holding content identified by a dedupKey.
+type dedupObject struct {
+	key         dedupKey
+	bucket      string
+	object      string
+	generation  int64
+	contentType string // Copied to duplicates whose CopySpec has no ContentType.
+}
+
+// dedupCache remembers the most recently uploaded objects by their content. A
+// nil dedupCache remembers nothing.
+type dedupCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // Of *dedupObject, most recently used first.
+	objects map[dedupKey]*list.Element
+}
+
+// newDedupCache returns a dedupCache holding at most size objects, or nil if
+// size isn't positive.
+func newDedupCache(size int) *dedupCache {
+	if size <= 0 {
+		return nil
+	}
+	return &dedupCache{size: size, order: list.New(), objects: make(map[dedupKey]*list.Element)}
+}
+
+// add remembers that the given object holds the content identified by key,
+// evicting the least recently used object if the cache is full.
+func (d *dedupCache) add(key dedupKey, bucket, object string, generation int64, contentType string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if e, ok := d.objects[key]; ok {
+		d.order.Remove(e)
+	}
+	d.objects[key] = d.order.PushFront(&dedupObject{key, bucket, object, generation, contentType})
+	if d.order.Len() > d.size {
+		oldest := d.order.Remove(d.order.Back()).(*dedupObject)
+		delete(d.objects, oldest.key)
+	}
+}
+
+// get returns the object remembered for key, if any.
+func (d *dedupCache) get(key dedupKey) (dedupObject, bool) {
+	if d == nil {
+		return dedupObject{}, false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	e, ok := d.objects[key]
+	if !ok {
+		return dedupObject{}, false
+	}
+	d.order.MoveToFront(e)
+	return *e.Value.(*dedupObject), true
+}
+
+// remove forgets the object remembered for key, if it's still obj.
+func (d *dedupCache) remove(obj dedupObject) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if e, ok := d.objects[obj.key]; ok && *e.Value.(*dedupObject) == obj {
+		d.order.Remove(e)
+		delete(d.objects, obj.key)
+	}
+}
+
+// recordUpload remembers the object uploaded for c, so later copies of the same
+// content can be deduplicated. Gzipped objects don't hold the source content.
+// contentType is the uploaded object's content type.
+func (h *CopyHandler) recordUpload(jobRun string, c *taskpb.CopySpec, size int64, crc32c uint32, generation int64, contentType string) {
+	if shouldGzip(c.SrcFile) {
+		return
+	}
+	h.dedup.add(dedupKey{jobRun, size, crc32c}, c.DstBucket, c.DstObject, generation, contentType)
+}
+
+// copyDuplicate copies c server-side from a previously uploaded object with the
+// same content, if there is one. It returns false if the file must be
+// uploaded instead.
+func (h *CopyHandler) copyDuplicate(ctx context.Context, jobRun string, c *taskpb.CopySpec, fileinfo os.FileInfo, cl *taskpb.CopyLog) (bool, error) {
+	crc32c, trusted := trustedCRC32C(c)
+	if h.dedup == nil || !trusted || shouldGzip(c.SrcFile) {
+		return false, nil
+	}
+	key := dedupKey{jobRun, fileinfo.Size(), crc32c}
+	src, ok := h.dedup.get(key)
+	if !ok || (src.bucket == c.DstBucket && src.object == c.DstObject) {
+		return false, nil
+	}
+
+	// The content type GCS detected for the remembered object applies to the
+	// duplicate too, the copy would otherwise have none.
+	contentType := c.ContentType
+	if contentType == "" {
+		contentType = src.contentType
+	}
+	attrs := &storage.ObjectAttrs{
+		Metadata:      objectMetadata(c, fileinfo),
+		StorageClass:  c.StorageClass,
+		ContentType:   contentType,
+		KMSKeyName:    c.KmsKeyName,
+		PredefinedACL: c.PredefinedAcl,
+	}
+	cond := common.GetGCSGenerationNumCondition(expectedGeneration(c))
+	dstAttrs, err := h.gcs.CopyObject(ctx, src.bucket, src.object, src.generation, c.DstBucket, c.DstObject, cond, attrs)
+	if err != nil {
+		if e, ok := err.(*googleapi.Error); (ok && e.Code == 404) || err == storage.ErrObjectNotExist {
+			// The remembered object was deleted or overwritten, upload the file.
+			h.dedup.remove(src)
+			return false, nil
+		}
+		return true, objectExistsError(c, err)
+	}
+	glog.Infof("Copied %v server-side from duplicate object %v/%v", c.SrcFile, src.bucket, src.object)
+	h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopyDedupHits: 1, CopyDedupBytes: fileinfo.Size()})
+
+	// Record some attributes.
+	cl.DstBytes = dstAttrs.Size
+	cl.DstCrc32C = dstAttrs.CRC32C
+	cl.DstMTime = dstAttrs.Updated.Unix()
+	cl.DstMd5 = base64.StdEncoding.EncodeToString(dstAttrs.MD5)
+	cl.DstMetadata = dstAttrs.Metadata
+	cl.SrcCrc32C = crc32c
+	cl.BytesCopied = fileinfo.Size()
+
+	// Verify the CRC32C, which also covers a stale cache entry.
+	if dstAttrs.CRC32C != crc32c || dstAttrs.Size != fileinfo.Size() {
+		h.dedup.remove(src)
+		return true, common.AgentError{
+			Msg: fmt.Sprintf("CRC32C mismatch for file %s (%d) against object %s (%d) copied from %s/%s",
+				c.SrcFile, crc32c, c.DstObject, dstAttrs.CRC32C, src.bucket, src.object),
+			FailureType: taskpb.FailureType_HASH_MISMATCH_FAILURE,
+		}
+	}
+	return true, nil
+}
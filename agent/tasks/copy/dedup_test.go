@@ -0,0 +1,207 @@
+package copy
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"golang.org/x/sync/semaphore"
+	"google.golang.org/api/googleapi"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestDedupCache(t *testing.T) {
+	var nilCache *dedupCache
+	nilCache.add(dedupKey{"jr", 1, 1}, "b", "o", 1, "")
+	if _, ok := nilCache.get(dedupKey{"jr", 1, 1}); ok {
+		t.Errorf("nil dedupCache get() got ok, want !ok")
+	}
+	if d := newDedupCache(0); d != nil {
+		t.Errorf("newDedupCache(0) = %v, want nil", d)
+	}
+
+	d := newDedupCache(2)
+	k1, k2, k3 := dedupKey{"jr", 1, 1}, dedupKey{"jr", 2, 2}, dedupKey{"other-jr", 1, 1}
+	d.add(k1, "b", "o1", 1, "")
+	d.add(k2, "b", "o2", 2, "")
+	d.get(k1)                   // k2 is now the least recently used.
+	d.add(k3, "b", "o3", 3, "") // Evicts k2.
+	tests := []struct {
+		key        dedupKey
+		wantOK     bool
+		wantObject string
+	}{
+		{k1, true, "o1"},
+		{k2, false, ""},
+		{k3, true, "o3"},
+		{dedupKey{"jr", 1, 2}, false, ""},
+	}
+	for _, tc := range tests {
+		obj, ok := d.get(tc.key)
+		if ok != tc.wantOK || obj.object != tc.wantObject {
+			t.Errorf("get(%v) = %v, %v, want object %q, %v", tc.key, obj, ok, tc.wantObject, tc.wantOK)
+		}
+	}
+
+	// Removing a stale object doesn't remove its replacement.
+	stale, _ := d.get(k1)
+	d.add(k1, "b", "o1-new", 4, "")
+	d.remove(stale)
+	if obj, ok := d.get(k1); !ok || obj.object != "o1-new" {
+		t.Errorf("get(%v) after removing stale object = %v, %v, want o1-new", k1, obj, ok)
+	}
+	d.remove(dedupObject{k1, "b", "o1-new", 4, ""})
+	if _, ok := d.get(k1); ok {
+		t.Errorf("get(%v) after remove got ok, want !ok", k1)
+	}
+}
+
+func TestCopyDuplicate(t *testing.T) {
+	*trustSourceChecksum = true
+	defer func() { *trustSourceChecksum = false }()
+	size := int64(len(testFileContent))
+	tests := []struct {
+		desc        string
+		cached      bool
+		copyAttrs   *storage.ObjectAttrs
+		copyErr     error
+		wantUpload  bool
+		wantSuccess bool
+		wantCached  bool
+	}{
+		{
+			desc:        "Not cached",
+			wantUpload:  true,
+			wantSuccess: true,
+			wantCached:  true,
+		},
+		{
+			desc:        "Copied server-side",
+			cached:      true,
+			copyAttrs:   &storage.ObjectAttrs{Size: size, CRC32C: testCRC32C},
+			wantSuccess: true,
+			wantCached:  true,
+		},
+		{
+			desc:        "Cached object is gone",
+			cached:      true,
+			copyErr:     &googleapi.Error{Code: 404},
+			wantUpload:  true,
+			wantSuccess: true,
+			wantCached:  true,
+		},
+		{
+			desc:       "Copy fails",
+			cached:     true,
+			copyErr:    &googleapi.Error{Code: 500},
+			wantCached: true,
+		},
+		{
+			desc:      "Copied content mismatch",
+			cached:    true,
+			copyAttrs: &storage.ObjectAttrs{Size: size, CRC32C: testCRC32C + 1},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+			defer os.Remove(tmpFile)
+
+			key := dedupKey{"jobrun", size, testCRC32C}
+			h := CopyHandler{
+				gcs:               gcloud.NewMockGCS(mockCtrl),
+				concurrentCopySem: semaphore.NewWeighted(1),
+				dedup:             newDedupCache(1),
+			}
+			mockGCS := h.gcs.(*gcloud.MockGCS)
+			if tc.cached {
+				h.dedup.add(key, "src-bucket", "src-object", 5, "")
+				mockGCS.EXPECT().CopyObject(context.Background(), "src-bucket", "src-object", int64(5), "bucket", "object", gomock.Any(), gomock.Any()).Return(tc.copyAttrs, tc.copyErr)
+			}
+			if tc.wantUpload {
+				writer := common.NewStringWriteCloser(&storage.ObjectAttrs{Size: size, CRC32C: testCRC32C, Generation: 7})
+				mockGCS.EXPECT().NewWriterWithCondition(context.Background(), "bucket", "object", gomock.Any()).Return(writer)
+			}
+
+			taskReqMsg := testCopyTaskReqMsg()
+			taskReqMsg.JobrunRelRsrcName = "jobrun"
+			taskReqMsg.Spec.GetCopySpec().SrcFile = tmpFile
+			taskReqMsg.Spec.GetCopySpec().SrcFileCrc32C = &wrappers.UInt32Value{Value: testCRC32C}
+			taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+			if got := taskRespMsg.Status == "SUCCESS"; got != tc.wantSuccess {
+				t.Errorf("Do() got status %v, failure %v, want success %v", taskRespMsg.Status, taskRespMsg.FailureMessage, tc.wantSuccess)
+			}
+			if _, ok := h.dedup.get(key); ok != tc.wantCached {
+				t.Errorf("dedup.get() got ok %v, want %v", ok, tc.wantCached)
+			}
+			if tc.wantSuccess {
+				if got := taskRespMsg.Log.GetCopyLog().BytesCopied; got != size {
+					t.Errorf("BytesCopied got %v, want %v", got, size)
+				}
+			}
+		})
+	}
+}
+
+func TestCopyDuplicateNeedsTrustedChecksum(t *testing.T) {
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	fileinfo, err := os.Stat(tmpFile)
+	if err != nil {
+		t.Fatalf("os.Stat(%q) got err: %v", tmpFile, err)
+	}
+	h := CopyHandler{dedup: newDedupCache(1)}
+	h.dedup.add(dedupKey{"jobrun", fileinfo.Size(), testCRC32C}, "bucket", "other", 1, "")
+	c := &taskpb.CopySpec{SrcFile: tmpFile, DstBucket: "bucket", DstObject: "object", SrcFileCrc32C: &wrappers.UInt32Value{Value: testCRC32C}}
+	// Without trust-source-checksum, the CRC32C isn't known before the copy.
+	if copied, err := h.copyDuplicate(context.Background(), "jobrun", c, fileinfo, &taskpb.CopyLog{}); copied || err != nil {
+		t.Errorf("copyDuplicate() = %v, %v, want false, nil", copied, err)
+	}
+}
+
+func TestCopyDuplicateContentType(t *testing.T) {
+	*trustSourceChecksum = true
+	defer func() { *trustSourceChecksum = false }()
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	fileinfo, err := os.Stat(tmpFile)
+	if err != nil {
+		t.Fatalf("os.Stat(%q) got err: %v", tmpFile, err)
+	}
+
+	tests := []struct {
+		specContentType string
+		want            string
+	}{
+		{"", "text/html"}, // The remembered object's content type.
+		{"application/json", "application/json"},
+	}
+	for _, tc := range tests {
+		mockCtrl := gomock.NewController(t)
+		mockGCS := gcloud.NewMockGCS(mockCtrl)
+		h := CopyHandler{gcs: mockGCS, dedup: newDedupCache(1)}
+		h.dedup.add(dedupKey{"jobrun", fileinfo.Size(), testCRC32C}, "bucket", "other", 1, "text/html")
+		dstAttrs := &storage.ObjectAttrs{Size: fileinfo.Size(), CRC32C: testCRC32C}
+		mockGCS.EXPECT().CopyObject(context.Background(), "bucket", "other", int64(1), "bucket", "object", gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _, _ string, _ int64, _, _ string, _ storage.Conditions, attrs *storage.ObjectAttrs) (*storage.ObjectAttrs, error) {
+				if attrs.ContentType != tc.want {
+					t.Errorf("CopyObject with spec content type %q got ContentType %q, want %q", tc.specContentType, attrs.ContentType, tc.want)
+				}
+				return dstAttrs, nil
+			})
+		c := &taskpb.CopySpec{SrcFile: tmpFile, DstBucket: "bucket", DstObject: "object", ContentType: tc.specContentType, SrcFileCrc32C: &wrappers.UInt32Value{Value: testCRC32C}}
+		if copied, err := h.copyDuplicate(context.Background(), "jobrun", c, fileinfo, &taskpb.CopyLog{}); !copied || err != nil {
+			t.Errorf("copyDuplicate() = %v, %v, want true, nil", copied, err)
+		}
+		mockCtrl.Finish()
+	}
+}
@@ -0,0 +1,46 @@
+//go:build linux
+// +build linux
+
+package copy
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// fsTypeNames are the names of the file system magic numbers reported by
+// statfs, see statfs(2).
+var fsTypeNames = map[uint32]string{
+	0x0000517b: "smb",
+	0x00c36400: "ceph",
+	0x01021994: "tmpfs",
+	0x0bd00bd0: "lustre",
+	0x2fc12fc1: "zfs",
+	0x47504653: "gpfs",
+	0x5346544e: "ntfs",
+	0x58465342: "xfs",
+	0x65735546: "fuse",
+	0x6969:     "nfs",
+	0x794c7630: "overlayfs",
+	0x858458f6: "ramfs",
+	0x9123683e: "btrfs",
+	0xef53:     "ext4", // Shared by ext2 and ext3.
+	0xfe534d42: "smb2",
+	0xff534d42: "cifs",
+}
+
+// fsType returns the type of the file system f is on, such as "nfs". Unknown
+// types are returned as their hex magic number, and "" is returned if the
+// type can't be detected.
+func fsType(f *os.File) string {
+	var st syscall.Statfs_t
+	if err := syscall.Fstatfs(int(f.Fd()), &st); err != nil {
+		return ""
+	}
+	magic := uint32(st.Type)
+	if name, ok := fsTypeNames[magic]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%x", magic)
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+// +build !linux
+
+package copy
+
+import (
+	"os"
+)
+
+// fsType returns "", since file system types are only detected on Linux.
+func fsType(f *os.File) string {
+	return ""
+}
@@ -0,0 +1,75 @@
+package copy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/hashing"
+)
+
+// shouldGzip returns true if the base name of srcFile matches one of the
+// comma separated glob patterns in the gzip-files flag.
+func shouldGzip(srcFile string) bool {
+	if *gzipFiles == "" {
+		return false
+	}
+	base := path.Base(filepath.ToSlash(srcFile))
+	for _, pattern := range strings.Split(*gzipFiles, ",") {
+		if matched, _ := filepath.Match(strings.TrimSpace(pattern), base); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// countingWriter is an io.Writer that counts the bytes written to it.
+type countingWriter struct {
+	n int64
+}
+
+// Write implements the io.Writer interface.
+func (cw *countingWriter) Write(buf []byte) (int, error) {
+	cw.n += int64(len(buf))
+	return len(buf), nil
+}
+
+// sniffContentType detects the content type of the uncompressed content read
+// from r, and returns it along with a reader returning all of r's content.
+// Gzipped objects need it set explicitly, since the writer would otherwise
+// detect the type of the compressed stream, and objects typed
+// application/x-gzip with Content-Encoding: gzip aren't transcoded by GCS.
+func sniffContentType(r io.Reader) (string, io.Reader, error) {
+	// 512 is the max needed by http.DetectContentType.
+	sniffBuf, err := ioutil.ReadAll(io.LimitReader(r, 512))
+	if err != nil {
+		return "", nil, err
+	}
+	return http.DetectContentType(sniffBuf), io.MultiReader(bytes.NewReader(sniffBuf), r), nil
+}
+
+// gzipCopy compresses all bytes read from r and writes them to w. It returns
+// the number of compressed bytes written and their CRC32C. If h is not nil the
+// compressed bytes are also written into it.
+func gzipCopy(w io.Writer, r io.Reader, h hash.Hash) (int64, uint32, error) {
+	crc := hashing.NewCRC32C()
+	cw := &countingWriter{}
+	writers := []io.Writer{w, crc, cw}
+	if h != nil {
+		writers = append(writers, h)
+	}
+	gz := gzip.NewWriter(io.MultiWriter(writers...))
+	if _, err := io.Copy(gz, r); err != nil {
+		return 0, 0, err
+	}
+	if err := gz.Close(); err != nil {
+		return 0, 0, err
+	}
+	return cw.n, crc.Sum32(), nil
+}
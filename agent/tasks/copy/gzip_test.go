@@ -0,0 +1,91 @@
+package copy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/md5"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/hashing"
+)
+
+func TestShouldGzip(t *testing.T) {
+	defer func(v string) { *gzipFiles = v }(*gzipFiles)
+	tests := []struct {
+		patterns string
+		srcFile  string
+		want     bool
+	}{
+		{"", "/dir/file.log", false},
+		{"*.log", "/dir/file.log", true},
+		{"*.log", "/dir/file.txt", false},
+		{"*.csv, *.log", "/dir/file.log", true},
+		{"*.log", "/dir.log/file", false},
+		{"access-*", "/dir/access-2019.txt", true},
+	}
+	for _, tc := range tests {
+		*gzipFiles = tc.patterns
+		if got := shouldGzip(tc.srcFile); got != tc.want {
+			t.Errorf("shouldGzip(%q) with patterns %q got %v, want %v", tc.srcFile, tc.patterns, got, tc.want)
+		}
+	}
+}
+
+func TestGzipCopy(t *testing.T) {
+	var buf bytes.Buffer
+	h := md5.New()
+	n, crc, err := gzipCopy(&buf, strings.NewReader(testFileContent), h)
+	if err != nil {
+		t.Fatalf("gzipCopy got err: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Errorf("gzipCopy got %d bytes, want %d", n, buf.Len())
+	}
+	if want := hashing.CRC32C(buf.Bytes()); crc != want {
+		t.Errorf("gzipCopy got crc32c %d, want %d", crc, want)
+	}
+	if want := md5.Sum(buf.Bytes()); !bytes.Equal(h.Sum(nil), want[:]) {
+		t.Errorf("gzipCopy got md5 %x, want %x", h.Sum(nil), want)
+	}
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip.NewReader got err: %v", err)
+	}
+	got, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatalf("ioutil.ReadAll got err: %v", err)
+	}
+	if string(got) != testFileContent {
+		t.Errorf("decompressed content got %q, want %q", got, testFileContent)
+	}
+}
+
+func TestSniffContentType(t *testing.T) {
+	content := "<html><body>" + strings.Repeat("x", 1000) + "</body></html>"
+	ct, r, err := sniffContentType(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("sniffContentType got err: %v", err)
+	}
+	if ct != "text/html; charset=utf-8" {
+		t.Errorf("sniffContentType got content type %q, want %q", ct, "text/html; charset=utf-8")
+	}
+
+	// The content type of the gzipped object must be that of the uncompressed content.
+	var buf bytes.Buffer
+	if _, _, err := gzipCopy(&buf, r, nil); err != nil {
+		t.Fatalf("gzipCopy got err: %v", err)
+	}
+	if compressed := http.DetectContentType(buf.Bytes()); ct == compressed || ct == "application/x-gzip" {
+		t.Errorf("sniffContentType got the compressed content type %q", ct)
+	}
+	gz, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip.NewReader got err: %v", err)
+	}
+	if got, err := ioutil.ReadAll(gz); err != nil || string(got) != content {
+		t.Errorf("decompressed content got %q, err %v, want %q", got, err, content)
+	}
+}
@@ -0,0 +1,28 @@
+package copy
+
+import (
+	"hash"
+	"io"
+)
+
+// HashUpdatingReader is an io.Reader that wraps another io.Reader and a
+// hash.Hash. This reader writes the bytes into the hash as they are read.
+type HashUpdatingReader struct {
+	reader io.Reader
+	hash   hash.Hash
+}
+
+// NewHashUpdatingReader returns a HashUpdatingReader. 'h' will be updated with
+// every byte read through the returned reader.
+func NewHashUpdatingReader(r io.Reader, h hash.Hash) io.Reader {
+	return &HashUpdatingReader{reader: r, hash: h}
+}
+
+// Read implements the io.Reader interface.
+func (hr *HashUpdatingReader) Read(buf []byte) (n int, err error) {
+	if n, err = hr.reader.Read(buf); err != nil {
+		return 0, err
+	}
+	hr.hash.Write(buf[:n]) // Never returns an error, see hash.Hash.
+	return n, nil
+}
@@ -0,0 +1,35 @@
+package copy
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestHashUpdatingReader(t *testing.T) {
+	tests := []struct {
+		desc  string
+		input string
+		want  string
+	}{
+		{"Empty", "", emptyMD5},
+		{"Basic", testFileContent, testMD5},
+	}
+	for _, tc := range tests {
+		var r io.Reader = strings.NewReader(tc.input)
+		h := md5.New()
+		r = NewHashUpdatingReader(r, h)
+
+		buf := make([]byte, 8)
+		var err error
+		for err == nil {
+			_, err = r.Read(buf)
+		}
+
+		if got := base64.StdEncoding.EncodeToString(h.Sum(nil)); got != tc.want {
+			t.Errorf("%v: got md5 %v, want %v", tc.desc, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package copy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/stats"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+
+	agentcommon "github.com/GoogleCloudPlatform/cloud-ingest/agent/common"
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+// updateMetadataOnly handles a metadata-only copy, see CopySpec.metadata_only.
+// It sets the metadata of the existing destination object, including its mtime
+// attribute, from the current stats of the source file without uploading any
+// content. The object must have the spec's expected generation, and the size of
+// the source file, otherwise the file must be copied again.
+func (h *CopyHandler) updateMetadataOnly(ctx context.Context, jobRun string, c *taskpb.CopySpec, cl *taskpb.CopyLog) error {
+	cl.MetadataOnly = true
+	statStart := time.Now()
+	fileinfo, err := os.Stat(agentcommon.OSPath(c.SrcFile))
+	h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopyStatMs: stats.DurMs(statStart)})
+	if err != nil {
+		return err
+	}
+	cl.SrcBytes = fileinfo.Size()
+	cl.SrcMTime = fileinfo.ModTime().Unix()
+
+	attrs, err := h.gcs.GetAttrs(ctx, c.DstBucket, c.DstObject)
+	if err == storage.ErrObjectNotExist {
+		return common.AgentError{
+			Msg:         fmt.Sprintf("metadata-only copy of file %s, object %s doesn't exist in bucket %s", c.SrcFile, c.DstObject, c.DstBucket),
+			FailureType: taskpb.FailureType_PRECONDITION_FAILURE,
+		}
+	} else if err != nil {
+		return err
+	}
+	if attrs.Generation != c.ExpectedGenerationNum {
+		return common.AgentError{
+			Msg:         fmt.Sprintf("metadata-only copy of file %s, object %s has generation %d, want %d", c.SrcFile, c.DstObject, attrs.Generation, c.ExpectedGenerationNum),
+			FailureType: taskpb.FailureType_PRECONDITION_FAILURE,
+		}
+	}
+	if attrs.Size != fileinfo.Size() {
+		return common.AgentError{
+			Msg:         fmt.Sprintf("metadata-only copy of file %s (%d bytes), object %s has %d bytes", c.SrcFile, fileinfo.Size(), c.DstObject, attrs.Size),
+			FailureType: taskpb.FailureType_FILE_MODIFIED_FAILURE,
+		}
+	}
+
+	// The metageneration condition fails the update if the object's metadata
+	// changed since it was read.
+	cond := storage.Conditions{GenerationMatch: attrs.Generation, MetagenerationMatch: attrs.Metageneration}
+	dstAttrs, err := h.gcs.UpdateAttrs(ctx, c.DstBucket, c.DstObject, cond, storage.ObjectAttrsToUpdate{Metadata: objectMetadata(c, fileinfo)})
+	if err != nil {
+		return err
+	}
+	cl.DstBytes = dstAttrs.Size
+	cl.DstCrc32C = dstAttrs.CRC32C
+	cl.DstMTime = dstAttrs.Updated.Unix()
+	cl.DstMetadata = dstAttrs.Metadata
+	return nil
+}
@@ -0,0 +1,69 @@
+package copy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/mock/gomock"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestUpdateMetadataOnly(t *testing.T) {
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	fileinfo, err := os.Stat(tmpFile)
+	if err != nil {
+		t.Fatalf("Stat(%q) got err: %v", tmpFile, err)
+	}
+	size := fileinfo.Size()
+	wantMetadata := map[string]string{*mtimeAttrName: strconv.FormatInt(fileinfo.ModTime().Unix(), 10)}
+
+	tests := []struct {
+		desc            string
+		attrs           *storage.ObjectAttrs
+		attrsErr        error
+		updateErr       error
+		wantUpdate      bool
+		wantFailureType taskpb.FailureType
+	}{
+		{"Updated", &storage.ObjectAttrs{Generation: 7, Metageneration: 2, Size: size}, nil, nil, true, taskpb.FailureType_UNSET_FAILURE_TYPE},
+		{"Object not found", nil, storage.ErrObjectNotExist, nil, false, taskpb.FailureType_PRECONDITION_FAILURE},
+		{"Generation mismatch", &storage.ObjectAttrs{Generation: 8, Metageneration: 2, Size: size}, nil, nil, false, taskpb.FailureType_PRECONDITION_FAILURE},
+		{"Size mismatch", &storage.ObjectAttrs{Generation: 7, Metageneration: 2, Size: size + 1}, nil, nil, false, taskpb.FailureType_FILE_MODIFIED_FAILURE},
+		{"Update fails", &storage.ObjectAttrs{Generation: 7, Metageneration: 2, Size: size}, nil, errors.New("update failed"), true, taskpb.FailureType_UNKNOWN_FAILURE},
+	}
+	for _, tc := range tests {
+		mockCtrl := gomock.NewController(t)
+		mockGCS := gcloud.NewMockGCS(mockCtrl)
+		mockGCS.EXPECT().GetAttrs(gomock.Any(), "bucket", "object").Return(tc.attrs, tc.attrsErr)
+		gcsModTime := time.Now()
+		if tc.wantUpdate {
+			cond := storage.Conditions{GenerationMatch: 7, MetagenerationMatch: 2}
+			mockGCS.EXPECT().UpdateAttrs(gomock.Any(), "bucket", "object", cond, storage.ObjectAttrsToUpdate{Metadata: wantMetadata}).Return(
+				&storage.ObjectAttrs{Generation: 7, Size: size, Metadata: wantMetadata, Updated: gcsModTime}, tc.updateErr)
+		}
+
+		h := CopyHandler{gcs: mockGCS}
+		c := &taskpb.CopySpec{SrcFile: tmpFile, DstBucket: "bucket", DstObject: "object", ExpectedGenerationNum: 7, MetadataOnly: true}
+		cl := &taskpb.CopyLog{}
+		err := h.updateMetadataOnly(context.Background(), "", c, cl)
+		if got := common.GetFailureTypeFromError(err); got != tc.wantFailureType {
+			t.Errorf("%s: updateMetadataOnly got failure type %v (err: %v), want %v", tc.desc, got, err, tc.wantFailureType)
+		}
+		if !cl.MetadataOnly || cl.BytesCopied != 0 {
+			t.Errorf("%s: updateMetadataOnly got MetadataOnly %v, BytesCopied %d, want true, 0", tc.desc, cl.MetadataOnly, cl.BytesCopied)
+		}
+		if err == nil && (cl.DstMTime != gcsModTime.Unix() || cl.DstMetadata[*mtimeAttrName] != wantMetadata[*mtimeAttrName]) {
+			t.Errorf("%s: updateMetadataOnly got log %+v", tc.desc, cl)
+		}
+		mockCtrl.Finish()
+	}
+}
@@ -0,0 +1,92 @@
+package copy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+// Object name length policies, see the object-name-length-policy flag.
+const (
+	objectNameLengthPolicyFail       = "fail"
+	objectNameLengthPolicyHashSuffix = "hash-suffix"
+)
+
+const (
+	// maxObjectNameBytes is the longest GCS object name, in UTF-8 bytes.
+	maxObjectNameBytes = 1024
+
+	// objectNameHashLen is the number of hex digits of the hash suffix of
+	// truncated object names.
+	objectNameHashLen = 16
+)
+
+var (
+	objectNameLengthPolicy = flag.String("object-name-length-policy", objectNameLengthPolicyFail, "How copies whose destination object name is longer than GCS's limit of 1024 bytes, less the longest suffix of the temporary objects the copy may upload, are handled. \"fail\" fails them with OBJECT_NAME_TOO_LONG_FAILURE before anything is uploaded, and \"hash-suffix\" truncates the name and appends a hash of the full name, so truncated names stay unique. The copy log's dst_file records the final name.")
+)
+
+// validateObjectNameLengthPolicy returns an error if policy is not a known
+// object name length policy.
+func validateObjectNameLengthPolicy(policy string) error {
+	if policy != objectNameLengthPolicyFail && policy != objectNameLengthPolicyHashSuffix {
+		return fmt.Errorf("invalid object name length policy %q, want %q or %q", policy, objectNameLengthPolicyFail, objectNameLengthPolicyHashSuffix)
+	}
+	return nil
+}
+
+// maxDstObjectBytes returns the longest DstObject whose temporary object names
+// still fit within maxObjectNameBytes. The temporary objects of the
+// upload-via-temp-object flag, and the components of composite uploads, are
+// named by suffixing the DstObject.
+func maxDstObjectBytes() int {
+	var suffix int
+	if *uploadViaTempObject {
+		suffix = len(tempObjectName(""))
+	}
+	if *compositeUploadThreshold > 0 {
+		token := strings.Repeat("0", componentTokenLen)
+		if n := len(componentName("", token, maxComposeComponents-1)); n > suffix {
+			suffix = n
+		}
+	}
+	return maxObjectNameBytes - suffix
+}
+
+// checkDstObjectLength applies the object-name-length-policy flag to a
+// CopySpec whose DstObject is longer than maxDstObjectBytes, either failing
+// with an OBJECT_NAME_TOO_LONG_FAILURE or shortening its DstObject. Names
+// within the limit, including shortened ones, are left unchanged.
+func checkDstObjectLength(c *taskpb.CopySpec) error {
+	max := maxDstObjectBytes()
+	if len(c.DstObject) <= max {
+		return nil
+	}
+	if *objectNameLengthPolicy != objectNameLengthPolicyHashSuffix {
+		return common.AgentError{
+			Msg:         fmt.Sprintf("DstObject for file %s is %d bytes long, more than the %d bytes GCS allows along with the temporary object suffixes", c.SrcFile, len(c.DstObject), max),
+			FailureType: taskpb.FailureType_OBJECT_NAME_TOO_LONG_FAILURE,
+		}
+	}
+	c.DstObject = hashSuffixedName(c.DstObject, max)
+	return nil
+}
+
+// hashSuffixedName returns name truncated to fit max bytes along with a "~"
+// and objectNameHashLen hex digits of its SHA256. The name is truncated at a
+// UTF-8 character boundary.
+func hashSuffixedName(name string, max int) string {
+	sum := sha256.Sum256([]byte(name))
+	suffix := "~" + hex.EncodeToString(sum[:])[:objectNameHashLen]
+	n := max - len(suffix)
+	for n > 0 && !utf8.RuneStart(name[n]) {
+		n--
+	}
+	return name[:n] + suffix
+}
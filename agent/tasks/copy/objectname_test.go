@@ -0,0 +1,100 @@
+package copy
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestCheckDstObjectLength(t *testing.T) {
+	defer func(p string) { *objectNameLengthPolicy = p }(*objectNameLengthPolicy)
+	defer func(b bool) { *uploadViaTempObject = b }(*uploadViaTempObject)
+
+	long := strings.Repeat("a", 2000)
+	tests := []struct {
+		desc            string
+		policy          string
+		name            string
+		wantFailureType taskpb.FailureType
+		wantTruncated   bool
+		tempObjects     bool
+	}{
+		{"Short name", objectNameLengthPolicyFail, "dir/file", taskpb.FailureType_UNSET_FAILURE_TYPE, false, false},
+		{"Longest name", objectNameLengthPolicyFail, long[:maxObjectNameBytes], taskpb.FailureType_UNSET_FAILURE_TYPE, false, false},
+		{"Too long", objectNameLengthPolicyFail, long, taskpb.FailureType_OBJECT_NAME_TOO_LONG_FAILURE, false, false},
+		{"Too long hash suffixed", objectNameLengthPolicyHashSuffix, long, taskpb.FailureType_UNSET_FAILURE_TYPE, true, false},
+		{"Multi-byte characters", objectNameLengthPolicyHashSuffix, strings.Repeat("é", 600), taskpb.FailureType_UNSET_FAILURE_TYPE, true, false},
+		{"Too long with temp objects", objectNameLengthPolicyFail, long[:maxObjectNameBytes], taskpb.FailureType_OBJECT_NAME_TOO_LONG_FAILURE, false, true},
+		{"Too long with temp objects hash suffixed", objectNameLengthPolicyHashSuffix, long[:maxObjectNameBytes], taskpb.FailureType_UNSET_FAILURE_TYPE, true, true},
+	}
+	for _, tc := range tests {
+		*objectNameLengthPolicy = tc.policy
+		*uploadViaTempObject = tc.tempObjects
+		c := &taskpb.CopySpec{SrcFile: "file", DstObject: tc.name}
+		err := checkDstObjectLength(c)
+		if got := common.GetFailureTypeFromError(err); got != tc.wantFailureType {
+			t.Errorf("%s: checkDstObjectLength got failure type %v (err: %v), want %v", tc.desc, got, err, tc.wantFailureType)
+		}
+		if !tc.wantTruncated {
+			if c.DstObject != tc.name {
+				t.Errorf("%s: checkDstObjectLength changed DstObject to %q", tc.desc, c.DstObject)
+			}
+			continue
+		}
+		if max := maxDstObjectBytes(); len(c.DstObject) > max || !utf8.ValidString(c.DstObject) {
+			t.Errorf("%s: checkDstObjectLength got %d byte DstObject %q, want at most %d valid UTF-8 bytes", tc.desc, len(c.DstObject), c.DstObject, max)
+		}
+		if want := hashSuffixedName(tc.name, maxDstObjectBytes()); c.DstObject != want {
+			t.Errorf("%s: checkDstObjectLength got DstObject %q, want %q", tc.desc, c.DstObject, want)
+		}
+		// A second check, as when a copy is resumed, keeps the shortened name.
+		name := c.DstObject
+		if err := checkDstObjectLength(c); err != nil || c.DstObject != name {
+			t.Errorf("%s: second checkDstObjectLength got DstObject %q, err %v, want %q, nil", tc.desc, c.DstObject, err, name)
+		}
+	}
+}
+
+func TestMaxDstObjectBytes(t *testing.T) {
+	defer func(b bool) { *uploadViaTempObject = b }(*uploadViaTempObject)
+	defer func(n int64) { *compositeUploadThreshold = n }(*compositeUploadThreshold)
+
+	tests := []struct {
+		tempObjects bool
+		threshold   int64
+		want        int
+	}{
+		{false, 0, maxObjectNameBytes},
+		{true, 0, maxObjectNameBytes - len(".cloud-ingest-tmp")},
+		{false, 1, maxObjectNameBytes - len(".cloud-ingest-component-0123456789abcdef-31")},
+		{true, 1, maxObjectNameBytes - len(".cloud-ingest-component-0123456789abcdef-31")},
+	}
+	for _, tc := range tests {
+		*uploadViaTempObject, *compositeUploadThreshold = tc.tempObjects, tc.threshold
+		if got := maxDstObjectBytes(); got != tc.want {
+			t.Errorf("maxDstObjectBytes() with upload-via-temp-object %v, composite-upload-threshold %d = %d, want %d", tc.tempObjects, tc.threshold, got, tc.want)
+		}
+	}
+}
+
+func TestHashSuffixedNameUnique(t *testing.T) {
+	prefix := strings.Repeat("a", 2000)
+	if a, b := hashSuffixedName(prefix+"1", maxObjectNameBytes), hashSuffixedName(prefix+"2", maxObjectNameBytes); a == b {
+		t.Errorf("hashSuffixedName got %q for both names, want different names", a)
+	}
+}
+
+func TestValidateObjectNameLengthPolicy(t *testing.T) {
+	for _, policy := range []string{objectNameLengthPolicyFail, objectNameLengthPolicyHashSuffix} {
+		if err := validateObjectNameLengthPolicy(policy); err != nil {
+			t.Errorf("validateObjectNameLengthPolicy(%q) got err: %v", policy, err)
+		}
+	}
+	if err := validateObjectNameLengthPolicy("truncate"); err == nil {
+		t.Errorf("validateObjectNameLengthPolicy(%q) got nil err, want err", "truncate")
+	}
+}
@@ -0,0 +1,58 @@
+package copy
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/stats"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+// copyFromObjectSource copies the object at c.SrcFile from src, such as an
+// S3-compatible store. Objects are always copied in a single request, which
+// the GCS writer splits into chunks as needed, since they can't be resumed
+// across tasks.
+func (h *CopyHandler) copyFromObjectSource(ctx context.Context, jobRun string, c *taskpb.CopySpec, src common.ObjectSource, cl *taskpb.CopyLog) error {
+	statStart := time.Now()
+	info, err := src.Stat(ctx, c.SrcFile)
+	h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopyStatMs: stats.DurMs(statStart)})
+	if err != nil {
+		return err
+	}
+	cl.SrcBytes = info.Size()
+	cl.SrcMTime = info.ModTime().Unix()
+	if err := checkFileStability(info, time.Now()); err != nil {
+		return err
+	}
+	h.statsTracker.RecordFileSize(info.Size())
+
+	if *skipUnchanged {
+		unchanged, dstAttrs, err := h.isUnchanged(ctx, c, info)
+		if err != nil {
+			return err
+		}
+		if unchanged {
+			cl.DstBytes = dstAttrs.Size
+			cl.DstCrc32C = dstAttrs.CRC32C
+			cl.DstMTime = dstAttrs.Updated.Unix()
+			cl.DstMd5 = base64.StdEncoding.EncodeToString(dstAttrs.MD5)
+			cl.Skipped = true
+			return nil
+		}
+	}
+	if copied, err := h.copyDuplicate(ctx, jobRun, c, info, cl); copied || err != nil {
+		return err
+	}
+
+	openStart := time.Now()
+	r, err := src.NewReader(ctx, c.SrcFile)
+	h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{CopyOpenMs: stats.DurMs(openStart)})
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return h.copyEntireFile(ctx, jobRun, c, r, info, cl)
+}
@@ -0,0 +1,74 @@
+package copy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/mock/gomock"
+	"golang.org/x/sync/semaphore"
+)
+
+func TestCopyFromObjectSource(t *testing.T) {
+	mtime := time.Unix(1500000000, 0)
+	common.RegisterObjectSource(common.S3Scheme, &common.FakeObjectSource{
+		Objects: map[string]string{"s3://src-bucket/key": testFileContent},
+		MTime:   mtime,
+	})
+	defer common.RegisterObjectSource(common.S3Scheme, nil)
+
+	tests := []struct {
+		desc        string
+		srcFile     string
+		wantSuccess bool
+	}{
+		{"Copied", "s3://src-bucket/key", true},
+		{"Not found", "s3://src-bucket/missing", false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			writer := common.NewStringWriteCloser(&storage.ObjectAttrs{
+				CRC32C: testCRC32C,
+				Size:   int64(len(testFileContent)),
+			})
+			mockGCS := gcloud.NewMockGCS(mockCtrl)
+			if tc.wantSuccess {
+				mockGCS.EXPECT().NewWriterWithCondition(context.Background(), "bucket", "object", gomock.Any()).Return(writer)
+			}
+			h := CopyHandler{
+				gcs:               mockGCS,
+				concurrentCopySem: semaphore.NewWeighted(1),
+			}
+			taskReqMsg := testCopyTaskReqMsg()
+			taskReqMsg.Spec.GetCopySpec().SrcFile = tc.srcFile
+			taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+			if got := taskRespMsg.Status == "SUCCESS"; got != tc.wantSuccess {
+				t.Fatalf("Do() got status %v, failure %v, want success %v", taskRespMsg.Status, taskRespMsg.FailureMessage, tc.wantSuccess)
+			}
+			if !tc.wantSuccess {
+				return
+			}
+			if got := writer.WrittenString(); got != testFileContent {
+				t.Errorf("written content got %q, want %q", got, testFileContent)
+			}
+			cl := taskRespMsg.Log.GetCopyLog()
+			if cl.SrcBytes != int64(len(testFileContent)) || cl.SrcMTime != mtime.Unix() || cl.SrcCrc32C != testCRC32C {
+				t.Errorf("copy log got SrcBytes %d, SrcMTime %d, SrcCrc32C %d, want %d, %d, %d", cl.SrcBytes, cl.SrcMTime, cl.SrcCrc32C, len(testFileContent), mtime.Unix(), testCRC32C)
+			}
+		})
+	}
+}
+
+func TestCopyFromUnregisteredObjectSource(t *testing.T) {
+	h := CopyHandler{concurrentCopySem: semaphore.NewWeighted(1)}
+	taskReqMsg := testCopyTaskReqMsg()
+	taskReqMsg.Spec.GetCopySpec().SrcFile = "s3://src-bucket/key"
+	if taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now()); taskRespMsg.Status != "FAILURE" {
+		t.Errorf("Do() got status %v, want FAILURE", taskRespMsg.Status)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build !windows
+// +build !windows
+
+package copy
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+)
+
+const (
+	POSIX_UID_ATTR_NAME  = "goog-reserved-posix-uid"
+	POSIX_GID_ATTR_NAME  = "goog-reserved-posix-gid"
+	POSIX_MODE_ATTR_NAME = "goog-reserved-posix-mode"
+)
+
+// addPOSIXAttrs adds the uid, gid and permission mode of the file to the
+// metadata map. The file's mode is written as an octal string (e.g. "644"). If
+// the fileinfo doesn't carry a syscall.Stat_t, the metadata is left untouched.
+func addPOSIXAttrs(metadata map[string]string, fileinfo os.FileInfo) {
+	st, ok := fileinfo.Sys().(*syscall.Stat_t)
+	if !ok {
+		return
+	}
+	metadata[POSIX_UID_ATTR_NAME] = strconv.FormatUint(uint64(st.Uid), 10)
+	metadata[POSIX_GID_ATTR_NAME] = strconv.FormatUint(uint64(st.Gid), 10)
+	metadata[POSIX_MODE_ATTR_NAME] = strconv.FormatUint(uint64(st.Mode)&0777, 8)
+}
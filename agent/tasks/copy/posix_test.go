@@ -0,0 +1,50 @@
+//go:build !windows
+// +build !windows
+
+package copy
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestObjectMetadataPreservePOSIX(t *testing.T) {
+	defer func(v bool) { *preservePOSIX = v }(*preservePOSIX)
+
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	if err := os.Chmod(tmpFile, 0640); err != nil {
+		t.Fatalf("os.Chmod(%q, 0640) got err: %v", tmpFile, err)
+	}
+	fileinfo, err := os.Stat(tmpFile)
+	if err != nil {
+		t.Fatalf("os.Stat(%q) got err: %v", tmpFile, err)
+	}
+
+	*preservePOSIX = false
+	metadata := objectMetadata(&taskpb.CopySpec{}, fileinfo)
+	if len(metadata) != 1 {
+		t.Errorf("objectMetadata got %v, want only the mtime attr", metadata)
+	}
+
+	*preservePOSIX = true
+	metadata = objectMetadata(&taskpb.CopySpec{}, fileinfo)
+	want := map[string]string{
+		POSIX_UID_ATTR_NAME:  fmt.Sprint(os.Getuid()),
+		POSIX_GID_ATTR_NAME:  fmt.Sprint(os.Getgid()),
+		POSIX_MODE_ATTR_NAME: "640",
+	}
+	for k, v := range want {
+		if metadata[k] != v {
+			t.Errorf("objectMetadata[%q] got %q, want %q", k, metadata[k], v)
+		}
+	}
+	if _, ok := metadata[MTIME_ATTR_NAME]; !ok {
+		t.Errorf("objectMetadata got %v, missing %q", metadata, MTIME_ATTR_NAME)
+	}
+}
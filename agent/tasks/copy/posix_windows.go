@@ -0,0 +1,8 @@
+package copy
+
+import (
+	"os"
+)
+
+// addPOSIXAttrs is a no-op on Windows, which has no POSIX file attributes.
+func addPOSIXAttrs(metadata map[string]string, fileinfo os.FileInfo) {}
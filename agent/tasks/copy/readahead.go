@@ -0,0 +1,120 @@
+package copy
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var errReadAheadClosed = errors.New("read from closed ReadAheadReader")
+
+// readAheadChunk is a buffer filled by a single Read of the wrapped reader.
+type readAheadChunk struct {
+	buf []byte
+	n   int
+	err error
+}
+
+// ReadAheadReader is an io.Reader that wraps another io.Reader, and reads from
+// it in a separate goroutine into a bounded number of buffers. This overlaps
+// reads of the wrapped reader with whatever the caller does with the bytes,
+// such as sending them over the network. Close must be called once the caller
+// is done reading, which stops the goroutine.
+type ReadAheadReader struct {
+	reader io.Reader
+	free   chan []byte         // Buffers ready to be filled.
+	full   chan readAheadChunk // Filled buffers, in read order.
+	done   chan struct{}
+	wg     sync.WaitGroup
+
+	chunk readAheadChunk // The chunk being consumed.
+	off   int            // Offset of the unread bytes in chunk.buf.
+
+	// Accessed atomically, since an HTTP transport may still call Read while
+	// the caller closes the reader.
+	readNs int64 // Time spent reading the wrapped reader.
+	waitNs int64 // Time Read spent waiting for a filled buffer.
+}
+
+// NewReadAheadReader returns a ReadAheadReader reading ahead up to n buffers
+// of size bytes each.
+func NewReadAheadReader(r io.Reader, n, size int) *ReadAheadReader {
+	rar := &ReadAheadReader{
+		reader: r,
+		free:   make(chan []byte, n),
+		full:   make(chan readAheadChunk, n),
+		done:   make(chan struct{}),
+	}
+	for i := 0; i < n; i++ {
+		rar.free <- make([]byte, size)
+	}
+	rar.wg.Add(1)
+	go rar.readAhead()
+	return rar
+}
+
+func (rar *ReadAheadReader) readAhead() {
+	defer rar.wg.Done()
+	for {
+		var buf []byte
+		select {
+		case buf = <-rar.free:
+		case <-rar.done:
+			return
+		}
+		start := time.Now()
+		n, err := rar.reader.Read(buf)
+		atomic.AddInt64(&rar.readNs, int64(time.Since(start)))
+		select {
+		case rar.full <- readAheadChunk{buf, n, err}:
+		case <-rar.done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Read implements the io.Reader interface.
+func (rar *ReadAheadReader) Read(buf []byte) (n int, err error) {
+	for rar.off >= rar.chunk.n {
+		if rar.chunk.err != nil {
+			return 0, rar.chunk.err
+		}
+		if rar.chunk.buf != nil {
+			rar.free <- rar.chunk.buf // Never blocks, free holds all the buffers.
+		}
+		start := time.Now()
+		select {
+		case rar.chunk = <-rar.full:
+		case <-rar.done:
+			return 0, errReadAheadClosed
+		}
+		atomic.AddInt64(&rar.waitNs, int64(time.Since(start)))
+		rar.off = 0
+	}
+	n = copy(buf, rar.chunk.buf[rar.off:rar.chunk.n])
+	rar.off += n
+	return n, nil
+}
+
+// Close stops reading ahead, and waits until the wrapped reader is no longer
+// being read. It must be called exactly once.
+func (rar *ReadAheadReader) Close() error {
+	close(rar.done)
+	rar.wg.Wait()
+	return nil
+}
+
+// OverlapDur returns how much of the time spent reading the wrapped reader
+// overlapped with the caller's work, rather than blocking Read. It's only
+// valid after Close.
+func (rar *ReadAheadReader) OverlapDur() time.Duration {
+	if d := atomic.LoadInt64(&rar.readNs) - atomic.LoadInt64(&rar.waitNs); d > 0 {
+		return time.Duration(d)
+	}
+	return 0
+}
@@ -0,0 +1,118 @@
+package copy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	raw "google.golang.org/api/storage/v1"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestReadAheadReader(t *testing.T) {
+	input := strings.Repeat("this is some data", 100)
+	tests := []struct {
+		desc    string
+		n, size int
+		oneByte bool
+	}{
+		{"One buffer", 1, 16, false},
+		{"Several buffers", 4, 7, false},
+		{"Buffers larger than input", 2, 4096, false},
+		{"One byte reads", 3, 10, true},
+	}
+	for _, tc := range tests {
+		var r io.Reader = strings.NewReader(input)
+		if tc.oneByte {
+			r = iotest.OneByteReader(r)
+		}
+		rar := NewReadAheadReader(r, tc.n, tc.size)
+		got, err := ioutil.ReadAll(rar)
+		rar.Close()
+		if err != nil {
+			t.Errorf("%s: ReadAll got err: %v", tc.desc, err)
+		}
+		if string(got) != input {
+			t.Errorf("%s: ReadAll got %d bytes, want %d bytes %q", tc.desc, len(got), len(input), input[:16])
+		}
+	}
+}
+
+func TestReadAheadReaderError(t *testing.T) {
+	rar := NewReadAheadReader(iotest.TimeoutReader(strings.NewReader("some data")), 2, 4)
+	defer rar.Close()
+	buf := make([]byte, 16)
+	n, err := rar.Read(buf)
+	if err != nil || string(buf[:n]) != "some" {
+		t.Errorf("Read() = %d, %v, want 4, nil", n, err)
+	}
+	if _, err := rar.Read(buf); err != iotest.ErrTimeout {
+		t.Errorf("Read() got err %v, want %v", err, iotest.ErrTimeout)
+	}
+}
+
+func TestReadAheadReaderClose(t *testing.T) {
+	rar := NewReadAheadReader(strings.NewReader(strings.Repeat("x", 1000)), 2, 10)
+	buf := make([]byte, 10)
+	if _, err := rar.Read(buf); err != nil {
+		t.Fatalf("Read() got err: %v", err)
+	}
+	rar.Close()
+
+	// Reads after Close either drain an already filled buffer, or fail.
+	for i := 0; i < 10; i++ {
+		if _, err := rar.Read(buf); err != nil {
+			if err != errReadAheadClosed {
+				t.Errorf("Read() after Close got err %v, want %v", err, errReadAheadClosed)
+			}
+			return
+		}
+	}
+	t.Errorf("Read() after Close kept returning data")
+}
+
+func TestCopyResumableChunkReadAhead(t *testing.T) {
+	defer func(n int) { *readAheadBuffers = n }(*readAheadBuffers)
+	*readAheadBuffers = 2
+
+	h := CopyHandler{}
+	h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
+		if _, err := ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		body := new(bytes.Buffer)
+		_ = json.NewEncoder(body).Encode(&raw.Object{Name: "object", Bucket: "bucket"})
+		res := &http.Response{
+			StatusCode: 200,
+			Header:     make(map[string][]string),
+			Body:       ioutil.NopCloser(body),
+		}
+		return res, nil
+	}
+
+	copySpec := testCopySpec(77, 10, "ruID").GetCopySpec()
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	srcFile, err := os.Open(tmpFile)
+	if err != nil {
+		t.Fatalf("os.Open(%q) got err: %v", tmpFile, err)
+	}
+	defer srcFile.Close()
+
+	cl := &taskpb.CopyLog{}
+	if err := h.copyResumableChunk(context.Background(), "", copySpec, srcFile, fakeStats{}, cl); err != nil {
+		t.Fatalf("copyResumableChunk() got err: %v", err)
+	}
+	if copySpec.BytesCopied != 10 || copySpec.Crc32C != testTenByteCRC32C {
+		t.Errorf("copySpec got BytesCopied %d, Crc32C %d, want 10, %d", copySpec.BytesCopied, copySpec.Crc32C, testTenByteCRC32C)
+	}
+}
@@ -1,12 +1,115 @@
 package copy
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	agentcommon "github.com/GoogleCloudPlatform/cloud-ingest/agent/common"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
 
 	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
 )
 
+// validStorageClasses are the GCS storage classes that may be set in a CopySpec.
+var validStorageClasses = map[string]bool{
+	"STANDARD":                     true,
+	"NEARLINE":                     true,
+	"COLDLINE":                     true,
+	"ARCHIVE":                      true,
+	"MULTI_REGIONAL":               true,
+	"REGIONAL":                     true,
+	"DURABLE_REDUCED_AVAILABILITY": true,
+}
+
+// validPredefinedACLs are the GCS predefined object ACLs that may be set in a
+// CopySpec.
+var validPredefinedACLs = map[string]bool{
+	"authenticatedRead":      true,
+	"bucketOwnerFullControl": true,
+	"bucketOwnerRead":        true,
+	"private":                true,
+	"projectPrivate":         true,
+	"publicRead":             true,
+}
+
+// transformDstObject applies the CopySpec's destination prefix transform to its
+// DstObject, and clears the transform so it's only applied once. It fails if
+// nothing is left of the DstObject after stripping the prefix.
+func transformDstObject(c *taskpb.CopySpec) error {
+	if c.DstStripPrefix == "" && c.DstAddPrefix == "" {
+		return nil
+	}
+	obj := strings.TrimPrefix(c.DstObject, c.DstStripPrefix)
+	if obj == "" {
+		return common.AgentError{
+			Msg:         fmt.Sprintf("DstObject %q is empty after stripping DstStripPrefix %q", c.DstObject, c.DstStripPrefix),
+			FailureType: taskpb.FailureType_INVALID_FILE_NAME,
+		}
+	}
+	c.DstObject = c.DstAddPrefix + obj
+	c.DstStripPrefix = ""
+	c.DstAddPrefix = ""
+	return nil
+}
+
+// checkDstNameCollision returns an error if the name of srcFile, or of any of
+// the directories in its path, collides with the name of another entry in its
+// parent directory once normalized. A parent directory is only read if
+// normalizing changes the name, since names that are already normalized are
+// copied unchanged.
+func checkDstNameCollision(ctx context.Context, srcFile string) error {
+	for p := srcFile; ; {
+		dir, name := filepath.Split(p)
+		if name == "" {
+			return nil
+		}
+		if err := checkEntryNameCollision(ctx, srcFile, dir, name); err != nil {
+			return err
+		}
+		if dir == "" {
+			return nil
+		}
+		p = filepath.Clean(dir)
+	}
+}
+
+// checkEntryNameCollision returns an error if name collides with the name of
+// another entry of dir once normalized, which makes srcFile's destination name
+// ambiguous. The directory is held open within the max-open-files budget.
+func checkEntryNameCollision(ctx context.Context, srcFile, dir, name string) error {
+	normalized := common.NormalizeDstName(name)
+	if normalized == name {
+		return nil
+	}
+	releaseOpenFile, err := common.AcquireOpenFile(ctx)
+	if err != nil {
+		return err
+	}
+	defer releaseOpenFile()
+	f, err := os.Open(agentcommon.OSPath(dir))
+	if err != nil {
+		return err
+	}
+	names, err := f.Readdirnames(-1)
+	f.Close()
+	if err != nil {
+		return err
+	}
+	for _, n := range names {
+		if n != name && common.NormalizeDstName(n) == normalized {
+			return common.AgentError{
+				Msg:         fmt.Sprintf("copying file %q, %q and %q both have the destination name %q once normalized", srcFile, filepath.Join(dir, name), filepath.Join(dir, n), normalized),
+				FailureType: taskpb.FailureType_NAME_COLLISION_FAILURE,
+			}
+		}
+	}
+	return nil
+}
+
 func checkCopyTaskSpec(c *taskpb.CopySpec) (resumedCopy bool, err error) {
 	if c.SrcFile == "" {
 		return false, errors.New("empty SrcFile")
@@ -16,6 +119,14 @@ func checkCopyTaskSpec(c *taskpb.CopySpec) (resumedCopy bool, err error) {
 		return false, errors.New("empty DstObject")
 	} else if c.ExpectedGenerationNum < 0 {
 		return false, fmt.Errorf("invalid ExpectedGen'Num: %v", c.ExpectedGenerationNum)
+	} else if c.StorageClass != "" && !validStorageClasses[c.StorageClass] {
+		return false, fmt.Errorf("invalid StorageClass: %q", c.StorageClass)
+	} else if c.PredefinedAcl != "" && !validPredefinedACLs[c.PredefinedAcl] {
+		return false, fmt.Errorf("invalid PredefinedAcl: %q, want one of authenticatedRead, bucketOwnerFullControl, bucketOwnerRead, private, projectPrivate or publicRead", c.PredefinedAcl)
+	} else if c.MetadataOnly && c.ExpectedGenerationNum == 0 {
+		return false, errors.New("MetadataOnly with zero ExpectedGenerationNum")
+	} else if c.MetadataOnly && c.ResumableUploadId != "" {
+		return false, errors.New("MetadataOnly with a ResumableUploadId")
 	}
 
 	if c.FileBytes != 0 || c.FileMTime != 0 || c.BytesCopied != 0 || c.Crc32C != 0 || c.ResumableUploadId != "" {
@@ -1,9 +1,15 @@
 package copy
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+
 	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
 )
 
@@ -21,6 +27,21 @@ func tCopySpec(sf, db, do string, egn, fb, fm, bc int64, crc uint32, ruID string
 	}
 }
 
+func withStorageClass(c *taskpb.CopySpec, storageClass string) *taskpb.CopySpec {
+	c.StorageClass = storageClass
+	return c
+}
+
+func withPredefinedACL(c *taskpb.CopySpec, acl string) *taskpb.CopySpec {
+	c.PredefinedAcl = acl
+	return c
+}
+
+func withMetadataOnly(c *taskpb.CopySpec) *taskpb.CopySpec {
+	c.MetadataOnly = true
+	return c
+}
+
 func TestCheckCopyTaskSpec(t *testing.T) {
 	type w struct {
 		resumedCopy bool
@@ -36,6 +57,14 @@ func TestCheckCopyTaskSpec(t *testing.T) {
 		{tCopySpec("f", "", "o", 0, 0, 0, 0, 0, ""), w{false, "empty DstBucket"}},
 		{tCopySpec("f", "b", "", 0, 0, 0, 0, 0, ""), w{false, "empty DstObject"}},
 		{tCopySpec("f", "b", "o", -1, 0, 0, 0, 0, ""), w{false, "invalid ExpectedGen"}},
+		{withStorageClass(tCopySpec("f", "b", "o", 0, 0, 0, 0, 0, ""), "COLDLINE"), w{false, ""}},
+		{withStorageClass(tCopySpec("f", "b", "o", 0, 0, 0, 0, 0, ""), "coldline"), w{false, "invalid StorageClass"}},
+		{withStorageClass(tCopySpec("f", "b", "o", 0, 0, 0, 0, 0, ""), "FROZEN"), w{false, "invalid StorageClass"}},
+		{withPredefinedACL(tCopySpec("f", "b", "o", 0, 0, 0, 0, 0, ""), "publicRead"), w{false, ""}},
+		{withPredefinedACL(tCopySpec("f", "b", "o", 0, 0, 0, 0, 0, ""), "public-read"), w{false, "invalid PredefinedAcl"}},
+		{withMetadataOnly(tCopySpec("f", "b", "o", 7, 0, 0, 0, 0, "")), w{false, ""}},
+		{withMetadataOnly(tCopySpec("f", "b", "o", 0, 0, 0, 0, 0, "")), w{false, "MetadataOnly with zero ExpectedGenerationNum"}},
+		{withMetadataOnly(tCopySpec("f", "b", "o", 7, 20, 1, 10, 99, "ruID")), w{false, "MetadataOnly with a ResumableUploadId"}},
 
 		// Resumed copy.
 		{tCopySpec("f", "b", "o", 0, 20, 1, 10, 99, "ruID"), w{true, ""}},
@@ -66,3 +95,78 @@ func TestCheckCopyTaskSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestTransformDstObject(t *testing.T) {
+	tests := []struct {
+		desc        string
+		dstObject   string
+		stripPrefix string
+		addPrefix   string
+		want        string
+		wantErr     bool
+	}{
+		{"No transform", "a/b/c", "", "", "a/b/c", false},
+		{"Strip", "a/b/c", "a/", "", "b/c", false},
+		{"Add", "a/b/c", "", "2019-09-01/", "2019-09-01/a/b/c", false},
+		{"Strip and add", "mnt/nfs/b/c", "mnt/nfs/", "archive/", "archive/b/c", false},
+		{"Strip prefix not present", "a/b/c", "x/", "y/", "y/a/b/c", false},
+		{"Empty after strip", "a/", "a/", "", "a/", true},
+		{"Empty after strip with add", "a/", "a/", "y/", "a/", true},
+	}
+	for _, tc := range tests {
+		c := &taskpb.CopySpec{DstObject: tc.dstObject, DstStripPrefix: tc.stripPrefix, DstAddPrefix: tc.addPrefix}
+		err := transformDstObject(c)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: transformDstObject() got err: %v, want err: %v", tc.desc, err, tc.wantErr)
+		}
+		if c.DstObject != tc.want {
+			t.Errorf("%s: transformDstObject() got DstObject %q, want %q", tc.desc, c.DstObject, tc.want)
+		}
+		if err == nil && (c.DstStripPrefix != "" || c.DstAddPrefix != "") {
+			t.Errorf("%s: transformDstObject() didn't clear the transform: %v", tc.desc, c)
+		}
+	}
+}
+
+func TestCheckDstNameCollision(t *testing.T) {
+	tmpDir := common.CreateTmpDir("", "test-agent")
+	defer os.RemoveAll(tmpDir)
+	for _, dir := range []string{"Docs", "docs", "Files"} {
+		if err := os.Mkdir(filepath.Join(tmpDir, dir), 0755); err != nil {
+			t.Fatalf("Mkdir(%q) got err: %v", dir, err)
+		}
+	}
+	for _, name := range []string{"caf\u00e9", "cafe\u0301", "Report", "notes", "Docs/notes", "Files/notes"} {
+		if err := ioutil.WriteFile(filepath.Join(tmpDir, name), []byte("content"), 0644); err != nil {
+			t.Fatalf("WriteFile(%q) got err: %v", name, err)
+		}
+	}
+	defer common.SetDstNameNormalization("")
+	if err := common.SetDstNameNormalization("nfc,lower"); err != nil {
+		t.Fatalf("SetDstNameNormalization() got err: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		wantErr bool
+	}{
+		// Names which are already normalized are copied as is.
+		{"caf\u00e9", false},
+		{"notes", false},
+		{"cafe\u0301", true},
+		// Normalized, but doesn't collide.
+		{"Report", false},
+		// The file's name is normalized, but one of its directories collides.
+		{"Docs/notes", true},
+		{"Files/notes", false},
+	}
+	for _, tc := range tests {
+		err := checkDstNameCollision(context.Background(), filepath.Join(tmpDir, tc.name))
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("checkDstNameCollision(%q) got err: %v, want err: %v", tc.name, err, tc.wantErr)
+		}
+		if err != nil && common.GetFailureTypeFromError(err) != taskpb.FailureType_NAME_COLLISION_FAILURE {
+			t.Errorf("checkDstNameCollision(%q) got failure type %v, want %v", tc.name, common.GetFailureTypeFromError(err), taskpb.FailureType_NAME_COLLISION_FAILURE)
+		}
+	}
+}
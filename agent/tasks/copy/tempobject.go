@@ -0,0 +1,54 @@
+package copy
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/glog"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+var (
+	uploadViaTempObject = flag.Bool("upload-via-temp-object", false, "Upload each file to a temporary object next to its destination object, and copy it to the destination object server-side only once its CRC32C is verified. Objects which fail verification are then never visible at their destination name, at the cost of a server-side copy per file.")
+)
+
+// tempObjectName returns the name of the temporary object a file is uploaded
+// to before being copied to dstObject, see the upload-via-temp-object flag.
+func tempObjectName(dstObject string) string {
+	return fmt.Sprintf("%s.cloud-ingest-tmp", dstObject)
+}
+
+// finalizeTempObject copies the verified temporary object tmpName of c, whose
+// attributes are tmp, to the destination object server-side. The caller
+// deletes the temporary object. It returns the attributes of the destination
+// object.
+func (h *CopyHandler) finalizeTempObject(ctx context.Context, c *taskpb.CopySpec, tmpName string, tmp *storage.ObjectAttrs) (*storage.ObjectAttrs, error) {
+	attrs := &storage.ObjectAttrs{
+		Metadata:        tmp.Metadata,
+		StorageClass:    tmp.StorageClass,
+		ContentType:     tmp.ContentType,
+		ContentEncoding: tmp.ContentEncoding,
+		KMSKeyName:      c.KmsKeyName,
+		PredefinedACL:   c.PredefinedAcl,
+	}
+	cond := common.GetGCSGenerationNumCondition(expectedGeneration(c))
+	dstAttrs, err := h.gcs.CopyObject(ctx, c.DstBucket, tmpName, tmp.Generation, c.DstBucket, c.DstObject, cond, attrs)
+	if err != nil {
+		return nil, objectExistsError(c, err)
+	}
+	return dstAttrs, nil
+}
+
+// deleteTempObject deletes a temporary object once it has been copied to its
+// destination object, or failed verification. Failures are logged, since they
+// don't affect the destination object.
+func (h *CopyHandler) deleteTempObject(bucket, name string, generation int64) {
+	// Use a fresh context so cleanup still happens if the copy was cancelled.
+	if err := h.gcs.DeleteObject(context.Background(), bucket, name, generation); err != nil {
+		glog.Warningf("Failed to delete temporary object %v/%v, err: %v", bucket, name, err)
+	}
+}
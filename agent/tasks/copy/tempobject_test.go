@@ -0,0 +1,101 @@
+package copy
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/mock/gomock"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestCopyEntireFileViaTempObject(t *testing.T) {
+	defer func(b bool) { *uploadViaTempObject = b }(*uploadViaTempObject)
+	*uploadViaTempObject = true
+
+	tests := []struct {
+		desc            string
+		tmpCRC32C       uint32
+		wantFailureType taskpb.FailureType
+	}{
+		{"Verified", testCRC32C, taskpb.FailureType_UNSET_FAILURE_TYPE},
+		{"CRC32C mismatch", testCRC32C + 1, taskpb.FailureType_HASH_MISMATCH_FAILURE},
+	}
+	for _, tc := range tests {
+		mockCtrl := gomock.NewController(t)
+		tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+		srcFile, _ := os.Open(tmpFile)
+		fileinfo, _ := srcFile.Stat()
+
+		gcsModTime := time.Now()
+		mockGCS := gcloud.NewMockGCS(mockCtrl)
+		writer := common.NewStringWriteCloser(&storage.ObjectAttrs{CRC32C: tc.tmpCRC32C, Generation: 5})
+		mockGCS.EXPECT().NewWriter(gomock.Any(), "bucket", "object.cloud-ingest-tmp").Return(writer)
+		if tc.wantFailureType == taskpb.FailureType_UNSET_FAILURE_TYPE {
+			// The destination object's preconditions apply to the server-side copy.
+			mockGCS.EXPECT().CopyObject(gomock.Any(), "bucket", "object.cloud-ingest-tmp", int64(5), "bucket", "object", storage.Conditions{GenerationMatch: 77}, gomock.Any()).Return(
+				&storage.ObjectAttrs{CRC32C: testCRC32C, Generation: 6, Updated: gcsModTime}, nil)
+		}
+		mockGCS.EXPECT().DeleteObject(gomock.Any(), "bucket", "object.cloud-ingest-tmp", int64(5)).Return(nil)
+
+		h := CopyHandler{gcs: mockGCS}
+		c := testCopySpec(77, 0, "").GetCopySpec()
+		cl := &taskpb.CopyLog{}
+		err := h.copyEntireFile(context.Background(), "", c, srcFile, fileinfo, cl)
+		if got := common.GetFailureTypeFromError(err); got != tc.wantFailureType {
+			t.Errorf("%s: copyEntireFile got failure type %v (err: %v), want %v", tc.desc, got, err, tc.wantFailureType)
+		}
+		if err == nil && cl.DstMTime != gcsModTime.Unix() {
+			t.Errorf("%s: copyEntireFile got DstMTime %d, want %d", tc.desc, cl.DstMTime, gcsModTime.Unix())
+		}
+
+		srcFile.Close()
+		os.Remove(tmpFile)
+		mockCtrl.Finish()
+	}
+}
+
+func TestPrepareResumableCopyViaTempObject(t *testing.T) {
+	defer func(b bool) { *uploadViaTempObject = b }(*uploadViaTempObject)
+	*uploadViaTempObject = true
+
+	h := CopyHandler{}
+	h.httpDoFunc = func(ctx context.Context, h *http.Client, req *http.Request) (*http.Response, error) {
+		if got := req.URL.Query().Get("ifGenerationMatch"); got != "" {
+			t.Errorf("want no URL param ifGenerationMatch, got %q in %s", got, req.URL.String())
+		}
+		res := &http.Response{
+			StatusCode: 200,
+			Header:     make(map[string][]string),
+		}
+		res.Header.Add("Location", "testResumableUploadId")
+		return res, nil
+	}
+
+	copySpec := testCopySpec(77, 10, "").GetCopySpec()
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	srcFile, err := os.Open(tmpFile)
+	if err != nil {
+		t.Error("Couldn't open testing srcFile, err: ", err)
+	}
+	defer srcFile.Close()
+	var stats fakeStats
+
+	if err := h.prepareResumableCopy(context.Background(), copySpec, srcFile, stats); err != nil {
+		t.Error("got ", err)
+	}
+	if want := "object.cloud-ingest-tmp"; copySpec.TmpDstObject != want {
+		t.Errorf("got TmpDstObject %q, want %q", copySpec.TmpDstObject, want)
+	}
+	if !strings.HasSuffix(copySpec.ResumableUploadId, "testResumableUploadId") {
+		t.Errorf("got ResumableUploadId %q", copySpec.ResumableUploadId)
+	}
+}
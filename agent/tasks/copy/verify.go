@@ -0,0 +1,120 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package copy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"cloud.google.com/go/storage"
+	agentcommon "github.com/GoogleCloudPlatform/cloud-ingest/agent/common"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/hashing"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/rate"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/stats"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+// VerifyHandler is responsible for handling verify tasks. A verify task reads
+// a source file, computes its CRC32C, and compares it against the CRC32C GCS
+// has for the destination object. It never writes to GCS, so it can be used to
+// audit the integrity of an earlier copy cheaply. Objects written with
+// --gzip-files can't be verified, as GCS has the CRC32C of the compressed
+// content; they're reported with gzip_encoded set instead.
+type VerifyHandler struct {
+	gcs          gcloud.GCS
+	statsTracker *stats.Tracker // For tracking bytes read.
+}
+
+// NewVerifyHandler creates a VerifyHandler with storage.Client and
+// stats.Tracker.
+func NewVerifyHandler(storageClient *storage.Client, st *stats.Tracker) *VerifyHandler {
+	return &VerifyHandler{gcs: gcloud.NewGCSClient(storageClient), statsTracker: st}
+}
+
+// contextReader is an io.Reader which stops reading once its context is done.
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+// Read implements the io.Reader interface.
+func (cr *contextReader) Read(buf []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.reader.Read(buf)
+}
+
+func (h *VerifyHandler) handleVerifySpec(ctx context.Context, jobRun string, vs *taskpb.VerifySpec) (*taskpb.VerifyLog, error) {
+	vl := &taskpb.VerifyLog{
+		SrcFile: vs.SrcFile,
+		DstFile: fmt.Sprintf("%s/%s", vs.DstBucket, vs.DstObject),
+	}
+
+	// The object is checked first, so the source isn't read needlessly.
+	attrs, err := h.gcs.GetAttrs(ctx, vs.DstBucket, vs.DstObject)
+	if err != nil {
+		return vl, err
+	}
+	vl.DstBytes = attrs.Size
+	vl.DstCrc32C = attrs.CRC32C
+	if attrs.ContentEncoding == "gzip" {
+		vl.GzipEncoded = true
+		return vl, nil
+	}
+
+	releaseOpenFile, err := common.AcquireOpenFile(ctx)
+	if err != nil {
+		return vl, err
+	}
+	defer releaseOpenFile()
+	srcFile, err := os.Open(agentcommon.OSPath(vs.SrcFile))
+	if err != nil {
+		return vl, err
+	}
+	defer srcFile.Close()
+	r := h.statsTracker.NewCopyByteTrackingReader(jobRun, srcFile) // Wrap the srcFile with a CopyByteTrackingReader.
+	r = common.NewTaskBytesReader(ctx, r)                          // Wrap with a TaskBytesReader.
+	r = rate.NewRateLimitingReader(r)                              // Wrap with a RateLimitingReader.
+	r = &contextReader{ctx: ctx, reader: r}                        // Wrap with a contextReader.
+	r = hashing.NewCRC32CUpdatingReader(r, &vl.SrcCrc32C)          // Wrap with a CRC32CUpdatingReader.
+	if vl.SrcBytes, err = io.Copy(ioutil.Discard, r); err != nil {
+		return vl, err
+	}
+	vl.Match = vl.SrcBytes == vl.DstBytes && vl.SrcCrc32C == vl.DstCrc32C
+	return vl, nil
+}
+
+// Do handles a verify task. A mismatch is reported in the VerifyLog and
+// doesn't fail the task; only errors reading the file or the object do.
+func (h *VerifyHandler) Do(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg, reqStart time.Time) *taskpb.TaskRespMsg {
+	vs := taskReqMsg.Spec.GetVerifySpec()
+	if vs == nil {
+		err := errors.New("VerifyHandler.Do taskReqMsg.Spec is not a VerifySpec")
+		return common.BuildTaskRespMsg(taskReqMsg, nil, nil, err)
+	}
+	vl, err := h.handleVerifySpec(ctx, taskReqMsg.JobrunRelRsrcName, vs)
+	log := &taskpb.Log{Log: &taskpb.Log_VerifyLog{vl}}
+	return common.BuildTaskRespMsg(taskReqMsg, taskReqMsg.Spec, log, err)
+}
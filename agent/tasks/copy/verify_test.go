@@ -0,0 +1,124 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package copy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestVerify(t *testing.T) {
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+	size := int64(len(testFileContent))
+
+	tests := []struct {
+		desc            string
+		srcFile         string
+		attrs           *storage.ObjectAttrs
+		attrsErr        error
+		wantFailureType taskpb.FailureType
+		wantMatch       bool
+	}{
+		{"Match", tmpFile, &storage.ObjectAttrs{Size: size, CRC32C: testCRC32C}, nil, taskpb.FailureType_UNSET_FAILURE_TYPE, true},
+		{"CRC32C mismatch", tmpFile, &storage.ObjectAttrs{Size: size, CRC32C: testCRC32C + 1}, nil, taskpb.FailureType_UNSET_FAILURE_TYPE, false},
+		{"Size mismatch", tmpFile, &storage.ObjectAttrs{Size: size + 1, CRC32C: testCRC32C}, nil, taskpb.FailureType_UNSET_FAILURE_TYPE, false},
+		{"Object not found", tmpFile, nil, storage.ErrObjectNotExist, taskpb.FailureType_UNKNOWN_FAILURE, false},
+		{"GetAttrs error", tmpFile, nil, errors.New("some error"), taskpb.FailureType_UNKNOWN_FAILURE, false},
+		{"File not found", tmpFile + "-missing", &storage.ObjectAttrs{Size: size, CRC32C: testCRC32C}, nil, taskpb.FailureType_FILE_NOT_FOUND_FAILURE, false},
+		{"Gzip-encoded object", tmpFile + "-missing", &storage.ObjectAttrs{Size: size, CRC32C: testCRC32C, ContentEncoding: "gzip"}, nil, taskpb.FailureType_UNSET_FAILURE_TYPE, false},
+	}
+	for _, tc := range tests {
+		mockCtrl := gomock.NewController(t)
+		mockGCS := gcloud.NewMockGCS(mockCtrl)
+		if tc.attrs != nil || tc.attrsErr != nil {
+			mockGCS.EXPECT().GetAttrs(context.Background(), "bucket", "object").Return(tc.attrs, tc.attrsErr)
+		}
+		// Verify tasks are sent on the copy subscription, so exercise the routing too.
+		h := CopyHandler{verifyHandler: &VerifyHandler{gcs: mockGCS}}
+		taskReqMsg := &taskpb.TaskReqMsg{
+			TaskRelRsrcName: "task",
+			Spec: &taskpb.Spec{Spec: &taskpb.Spec_VerifySpec{&taskpb.VerifySpec{
+				SrcFile:   tc.srcFile,
+				DstBucket: "bucket",
+				DstObject: "object",
+			}}},
+		}
+		taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+		if tc.wantFailureType != taskpb.FailureType_UNSET_FAILURE_TYPE {
+			if isValid, errMsg := common.IsValidFailureMsg("task", tc.wantFailureType, taskRespMsg); !isValid {
+				t.Errorf("%s: %s", tc.desc, errMsg)
+			}
+			mockCtrl.Finish()
+			continue
+		}
+		if isValid, errMsg := common.IsValidSuccessMsg("task", taskRespMsg); !isValid {
+			t.Errorf("%s: %s", tc.desc, errMsg)
+		}
+		wantVl := &taskpb.VerifyLog{
+			SrcFile:   tc.srcFile,
+			SrcBytes:  size,
+			SrcCrc32C: testCRC32C,
+			DstFile:   "bucket/object",
+			DstBytes:  tc.attrs.Size,
+			DstCrc32C: tc.attrs.CRC32C,
+			Match:     tc.wantMatch,
+		}
+		if tc.attrs.ContentEncoding == "gzip" {
+			// The source file isn't read.
+			wantVl.SrcBytes, wantVl.SrcCrc32C, wantVl.GzipEncoded = 0, 0, true
+		}
+		wantLog := &taskpb.Log{Log: &taskpb.Log_VerifyLog{wantVl}}
+		if !proto.Equal(taskRespMsg.Log, wantLog) {
+			t.Errorf("%s: log = %+v, want: %+v", tc.desc, taskRespMsg.Log, wantLog)
+		}
+		mockCtrl.Finish()
+	}
+}
+
+func TestVerifyCtxCanceled(t *testing.T) {
+	tmpFile := common.CreateTmpFile("", "test-agent", testFileContent)
+	defer os.Remove(tmpFile)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx, cancel := context.WithCancel(context.Background())
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	mockGCS.EXPECT().GetAttrs(ctx, "bucket", "object").DoAndReturn(func(context.Context, string, string) (*storage.ObjectAttrs, error) {
+		cancel() // Cancel the task before the source file is read.
+		return &storage.ObjectAttrs{Size: int64(len(testFileContent)), CRC32C: testCRC32C}, nil
+	})
+	h := &VerifyHandler{gcs: mockGCS}
+	vs := &taskpb.VerifySpec{SrcFile: tmpFile, DstBucket: "bucket", DstObject: "object"}
+	vl, err := h.handleVerifySpec(ctx, "jobrun", vs)
+	if err != context.Canceled {
+		t.Errorf("handleVerifySpec got err %v, want %v", err, context.Canceled)
+	}
+	if vl.SrcBytes != 0 || vl.Match {
+		t.Errorf("handleVerifySpec got %+v, want no bytes read and no match", vl)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/glog"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+var (
+	taskDeadline = flag.Duration("task-deadline", 0, "The longest a task may run before the agent gives up on it and nacks it, so it's retried, possibly by another agent. This aborts tasks stuck on a hung source, such as a dead NFS mount. If 0, tasks have no deadline.")
+)
+
+// doWithDeadline runs handler.Do with a context whose deadline is deadline
+// after reqStart. Do runs in its own goroutine, so the deadline is honored
+// even when the handler is blocked in a syscall that doesn't observe the
+// context, such as an os.Open or Read on a hung mount. In that case the
+// goroutine is abandoned, and returns whenever the syscall does.
+// onReturn is called once Do returns, which for an abandoned goroutine is
+// after doWithDeadline has returned, so what the handler holds is only
+// released once it's actually done with it.
+// If deadline isn't positive, Do is called directly.
+func doWithDeadline(ctx context.Context, handler TaskHandler, taskReqMsg *taskpb.TaskReqMsg, reqStart time.Time, deadline time.Duration, onReturn func()) *taskpb.TaskRespMsg {
+	if deadline <= 0 {
+		defer onReturn()
+		return handler.Do(ctx, taskReqMsg, reqStart)
+	}
+	dctx, cancel := context.WithDeadline(ctx, reqStart.Add(deadline))
+	defer cancel()
+
+	var abandoned int32 // Accessed atomically.
+	respChan := make(chan *taskpb.TaskRespMsg, 1)
+	go func() {
+		resp := handler.Do(dctx, taskReqMsg, reqStart)
+		onReturn()
+		if atomic.LoadInt32(&abandoned) == 1 {
+			glog.Warningf("Abandoned task %v returned %v after %v", taskReqMsg.TaskRelRsrcName, resp.Status, time.Since(reqStart))
+		}
+		respChan <- resp
+	}()
+	var resp *taskpb.TaskRespMsg
+	select {
+	case resp = <-respChan:
+		if resp.Status == "SUCCESS" || dctx.Err() != context.DeadlineExceeded {
+			return resp
+		}
+		// The handler observed the deadline and failed, report that the
+		// deadline was the cause.
+	case <-dctx.Done():
+		if dctx.Err() != context.DeadlineExceeded {
+			// The parent context was cancelled, the response won't be sent.
+			return common.BuildTaskRespMsg(taskReqMsg, nil, nil, dctx.Err())
+		}
+		glog.Warningf("Task %v didn't return within its task-deadline of %v, abandoning it", taskReqMsg.TaskRelRsrcName, deadline)
+		atomic.StoreInt32(&abandoned, 1)
+	}
+	return common.BuildTaskRespMsg(taskReqMsg, nil, nil, common.AgentError{
+		Msg:         fmt.Sprintf("task %v exceeded the task-deadline of %v", taskReqMsg.TaskRelRsrcName, deadline),
+		FailureType: taskpb.FailureType_DEADLINE_EXCEEDED_FAILURE,
+	})
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+// funcTaskHandler is a TaskHandler which calls do.
+type funcTaskHandler struct {
+	do func(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg) *taskpb.TaskRespMsg
+}
+
+// Do handles the TaskReqMsg and returns a TaskRespMsg.
+func (h *funcTaskHandler) Do(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg, reqStart time.Time) *taskpb.TaskRespMsg {
+	return h.do(ctx, taskReqMsg)
+}
+
+func TestDoWithDeadline(t *testing.T) {
+	hung := make(chan struct{})
+	defer close(hung)
+	tests := []struct {
+		desc            string
+		deadline        time.Duration
+		do              func(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg) *taskpb.TaskRespMsg
+		wantStatus      string
+		wantFailureType taskpb.FailureType
+	}{
+		{
+			desc:     "No deadline",
+			deadline: 0,
+			do: func(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg) *taskpb.TaskRespMsg {
+				if _, ok := ctx.Deadline(); ok {
+					t.Errorf("Do got ctx with a deadline, want none")
+				}
+				return common.BuildTaskRespMsg(taskReqMsg, nil, nil, nil)
+			},
+			wantStatus: "SUCCESS",
+		},
+		{
+			desc:     "Finishes within the deadline",
+			deadline: time.Minute,
+			do: func(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg) *taskpb.TaskRespMsg {
+				return common.BuildTaskRespMsg(taskReqMsg, nil, nil, nil)
+			},
+			wantStatus: "SUCCESS",
+		},
+		{
+			desc:     "Fails within the deadline",
+			deadline: time.Minute,
+			do: func(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg) *taskpb.TaskRespMsg {
+				return common.BuildTaskRespMsg(taskReqMsg, nil, nil, common.AgentError{FailureType: taskpb.FailureType_FILE_NOT_FOUND_FAILURE})
+			},
+			wantStatus:      "FAILURE",
+			wantFailureType: taskpb.FailureType_FILE_NOT_FOUND_FAILURE,
+		},
+		{
+			desc:     "Observes the deadline",
+			deadline: 10 * time.Millisecond,
+			do: func(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg) *taskpb.TaskRespMsg {
+				<-ctx.Done()
+				return common.BuildTaskRespMsg(taskReqMsg, nil, nil, ctx.Err())
+			},
+			wantStatus:      "FAILURE",
+			wantFailureType: taskpb.FailureType_DEADLINE_EXCEEDED_FAILURE,
+		},
+		{
+			desc:     "Hung past the deadline",
+			deadline: 10 * time.Millisecond,
+			do: func(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg) *taskpb.TaskRespMsg {
+				<-hung // Like a syscall which ignores ctx.
+				return common.BuildTaskRespMsg(taskReqMsg, nil, nil, nil)
+			},
+			wantStatus:      "FAILURE",
+			wantFailureType: taskpb.FailureType_DEADLINE_EXCEEDED_FAILURE,
+		},
+	}
+	for _, tc := range tests {
+		taskReqMsg := &taskpb.TaskReqMsg{TaskRelRsrcName: "task", Spec: &taskpb.Spec{}}
+		resp := doWithDeadline(context.Background(), &funcTaskHandler{tc.do}, taskReqMsg, time.Now(), tc.deadline, func() {})
+		if resp.Status != tc.wantStatus || resp.FailureType != tc.wantFailureType {
+			t.Errorf("%s: doWithDeadline got status %q, failure type %v, want %q, %v", tc.desc, resp.Status, resp.FailureType, tc.wantStatus, tc.wantFailureType)
+		}
+		if resp.ReqSpec != taskReqMsg.Spec {
+			t.Errorf("%s: doWithDeadline got ReqSpec %v, want %v", tc.desc, resp.ReqSpec, taskReqMsg.Spec)
+		}
+	}
+}
+
+func TestDoWithDeadlineOnReturn(t *testing.T) {
+	hung := make(chan struct{})
+	returned := make(chan struct{})
+	do := func(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg) *taskpb.TaskRespMsg {
+		<-hung // Like a syscall which ignores ctx.
+		return common.BuildTaskRespMsg(taskReqMsg, nil, nil, nil)
+	}
+	taskReqMsg := &taskpb.TaskReqMsg{TaskRelRsrcName: "task", Spec: &taskpb.Spec{}}
+	resp := doWithDeadline(context.Background(), &funcTaskHandler{do}, taskReqMsg, time.Now(), 10*time.Millisecond, func() { close(returned) })
+	if resp.FailureType != taskpb.FailureType_DEADLINE_EXCEEDED_FAILURE {
+		t.Errorf("doWithDeadline got failure type %v, want %v", resp.FailureType, taskpb.FailureType_DEADLINE_EXCEEDED_FAILURE)
+	}
+	select {
+	case <-returned:
+		t.Fatalf("onReturn was called before the abandoned handler returned")
+	default:
+	}
+
+	close(hung)
+	select {
+	case <-returned:
+	case <-time.After(time.Second):
+		t.Errorf("onReturn wasn't called after the abandoned handler returned")
+	}
+}
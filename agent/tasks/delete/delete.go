@@ -76,7 +76,7 @@ func (h *DeleteHandler) Do(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg, _
 	if taskReqMsg.Spec.GetDeleteBundleSpec() != nil {
 		var dbl *taskpb.DeleteBundleLog
 		bundleSpec := proto.Clone(taskReqMsg.Spec.GetDeleteBundleSpec()).(*taskpb.DeleteBundleSpec)
-		dbl, err = h.handleDeleteBundleSpec(ctx, bundleSpec)
+		dbl, err = h.handleDeleteBundleSpec(ctx, taskReqMsg.JobrunRelRsrcName, bundleSpec)
 		respSpec = &taskpb.Spec{Spec: &taskpb.Spec_DeleteBundleSpec{bundleSpec}}
 		log = &taskpb.Log{Log: &taskpb.Log_DeleteBundleLog{dbl}}
 	} else {
@@ -86,7 +86,7 @@ func (h *DeleteHandler) Do(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg, _
 	return common.BuildTaskRespMsg(taskReqMsg, respSpec, log, err)
 }
 
-func (h *DeleteHandler) handleDeleteBundleSpec(ctx context.Context, bundleSpec *taskpb.DeleteBundleSpec) (*taskpb.DeleteBundleLog, error) {
+func (h *DeleteHandler) handleDeleteBundleSpec(ctx context.Context, jobRun string, bundleSpec *taskpb.DeleteBundleSpec) (*taskpb.DeleteBundleLog, error) {
 	var wg sync.WaitGroup
 	for _, bo := range bundleSpec.BundledObjects {
 		// In case of end to end retries we do not want to retry successes and permanent failures.
@@ -94,7 +94,7 @@ func (h *DeleteHandler) handleDeleteBundleSpec(ctx context.Context, bundleSpec *
 			wg.Add(1)
 			go func(bo *taskpb.BundledObject) {
 				defer wg.Done()
-				bo.BundledObjectLog = h.handleDeleteObjectSpec(ctx, bo.DeleteObjectSpec)
+				bo.BundledObjectLog = h.handleDeleteObjectSpec(ctx, jobRun, bo.DeleteObjectSpec)
 				bo.FailureType = bo.BundledObjectLog.FailureType
 				bo.FailureMessage = bo.BundledObjectLog.FailureMessage
 				bo.Status = bo.BundledObjectLog.Status
@@ -128,7 +128,7 @@ func getBundleLogAndError(bs *taskpb.DeleteBundleSpec) (*taskpb.DeleteBundleLog,
 	return &log, err
 }
 
-func (h *DeleteHandler) handleDeleteObjectSpec(ctx context.Context, deleteSpec *taskpb.DeleteObjectSpec) *taskpb.BundledObjectLog {
+func (h *DeleteHandler) handleDeleteObjectSpec(ctx context.Context, jobRun string, deleteSpec *taskpb.DeleteObjectSpec) *taskpb.BundledObjectLog {
 	h.concurrentDeleteSem.Acquire(ctx, 1)
 	defer h.concurrentDeleteSem.Release(1)
 
@@ -144,7 +144,7 @@ func (h *DeleteHandler) handleDeleteObjectSpec(ctx context.Context, deleteSpec *
 		if err == nil || !isRetryableError(err) {
 			break
 		}
-		h.statsTracker.RecordPulseStats(&stats.PulseStats{DeleteInternalRetries: 1})
+		h.statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{DeleteInternalRetries: 1})
 	}
 
 	dl := &taskpb.BundledObjectLog{
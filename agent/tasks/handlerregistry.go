@@ -35,16 +35,17 @@ func NewHandlerRegistry(majorVersionToHandlers map[uint64]TaskHandler) *HandlerR
 func (h *HandlerRegistry) HandlerForTaskReqMsg(taskReqMsg *taskpb.TaskReqMsg) (TaskHandler, *common.AgentError) {
 	jobRunVersion, err := versions.VersionFromString(taskReqMsg.JobRunVersion)
 	if err != nil {
-		glog.Errorf("Failed to parse job run version for task request message %v with err: %v", taskReqMsg, err)
+		// A version this agent can't parse is most likely from a newer DCP.
+		glog.Errorf("Unsupported task version: failed to parse job run version %q of task %v with err: %v", taskReqMsg.JobRunVersion, taskReqMsg.TaskRelRsrcName, err)
 		return nil, &common.AgentError{
-			fmt.Sprintf("Failed to parse task request message job run version %v.", taskReqMsg.JobRunVersion),
-			taskpb.FailureType_UNKNOWN_FAILURE,
+			fmt.Sprintf("Agent (version %v) failed to parse the job run version %q of task %v.", versions.AgentVersion(), taskReqMsg.JobRunVersion, taskReqMsg.TaskRelRsrcName),
+			taskpb.FailureType_AGENT_UNSUPPORTED_VERSION,
 		}
 	}
 
 	handler, exists := h.handlers[jobRunVersion.Major]
-	if !exists {
-		glog.Errorf("Handler does not exist for job run major version %d required for task request message %v", jobRunVersion.Major, taskReqMsg)
+	if !exists || handler == nil {
+		glog.Errorf("Unsupported task version: no handler for job run version %v (major %d) of task %v, agent version %v", taskReqMsg.JobRunVersion, jobRunVersion.Major, taskReqMsg.TaskRelRsrcName, versions.AgentVersion())
 		return nil, &common.AgentError{
 			fmt.Sprintf("Agent (version %v) does not support job run major version %v for task request message %v.", versions.AgentVersion(), jobRunVersion.Major, taskReqMsg.String()),
 			taskpb.FailureType_AGENT_UNSUPPORTED_VERSION,
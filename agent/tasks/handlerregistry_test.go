@@ -0,0 +1,50 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestHandlerForTaskReqMsg(t *testing.T) {
+	handler := &TestTaskHandler{}
+	r := NewHandlerRegistry(map[uint64]TaskHandler{3: handler})
+	tests := []struct {
+		desc            string
+		jobRunVersion   string
+		wantHandler     bool
+		wantFailureType taskpb.FailureType
+	}{
+		{"Supported", "3.1.0", true, taskpb.FailureType_UNSET_FAILURE_TYPE},
+		{"Unsupported major version", "9.0.0", false, taskpb.FailureType_AGENT_UNSUPPORTED_VERSION},
+		{"Unparseable version", "not-a-version", false, taskpb.FailureType_AGENT_UNSUPPORTED_VERSION},
+	}
+	for _, tc := range tests {
+		req := &taskpb.TaskReqMsg{TaskRelRsrcName: "task", JobRunVersion: tc.jobRunVersion}
+		h, agentErr := r.HandlerForTaskReqMsg(req)
+		if tc.wantHandler {
+			if h != handler || agentErr != nil {
+				t.Errorf("%s: HandlerForTaskReqMsg(%v) = %v, %v, want the handler and nil err", tc.desc, req, h, agentErr)
+			}
+			continue
+		}
+		if h != nil || agentErr == nil || agentErr.FailureType != tc.wantFailureType {
+			t.Errorf("%s: HandlerForTaskReqMsg(%v) = %v, %v, want nil handler and %v", tc.desc, req, h, agentErr, tc.wantFailureType)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/stats"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/glog"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+// InFlightTask describes a task being processed.
+type InFlightTask struct {
+	Task   string // The task's relative resource name.
+	JobRun string
+	Start  time.Time
+	Bytes  int64 // The bytes copied so far, only counted for copy tasks.
+}
+
+// inFlightTask is an entry of InFlightTasks.
+type inFlightTask struct {
+	jobRun    string
+	start     time.Time
+	bytes     int64 // Accessed atomically.
+	cancel    context.CancelFunc
+	cancelled bool
+}
+
+// InFlightTasks is a registry of the tasks an agent is processing, shared by
+// its TaskProcessors. Individual tasks can be listed and cancelled through its
+// HTTP handler, so a single wedged task can be dealt with without restarting
+// the agent. A nil *InFlightTasks doesn't track anything.
+type InFlightTasks struct {
+	mu    sync.Mutex
+	tasks map[string]*inFlightTask // Keyed by task relative resource name.
+}
+
+// NewInFlightTasks returns an empty InFlightTasks.
+func NewInFlightTasks() *InFlightTasks {
+	return &InFlightTasks{tasks: make(map[string]*inFlightTask)}
+}
+
+// start registers taskReqMsg and returns the context to process it with,
+// which is cancelled by Cancel. The returned done func must be called once
+// the task's handler has returned, it unregisters the task. The returned
+// cancelled func reports whether the task was cancelled by Cancel.
+func (r *InFlightTasks) start(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg) (context.Context, func(), func() bool) {
+	if r == nil {
+		return ctx, func() {}, func() bool { return false }
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	t := &inFlightTask{jobRun: taskReqMsg.JobrunRelRsrcName, start: time.Now(), cancel: cancel}
+	ctx = common.WithTaskBytes(ctx, &t.bytes)
+	name := taskReqMsg.TaskRelRsrcName
+	r.mu.Lock()
+	r.tasks[name] = t
+	r.mu.Unlock()
+	done := func() {
+		cancel()
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.tasks[name] == t {
+			delete(r.tasks, name)
+		}
+	}
+	cancelled := func() bool {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return t.cancelled
+	}
+	return ctx, done, cancelled
+}
+
+// List returns the in-flight tasks, oldest first.
+func (r *InFlightTasks) List() []InFlightTask {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tasks := make([]InFlightTask, 0, len(r.tasks))
+	for name, t := range r.tasks {
+		tasks = append(tasks, InFlightTask{Task: name, JobRun: t.jobRun, Start: t.start, Bytes: atomic.LoadInt64(&t.bytes)})
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].Start.Before(tasks[j].Start) })
+	return tasks
+}
+
+// Cancel cancels the context of the named in-flight task. The task's message
+// is nacked, so it's redelivered, possibly to another agent. Returns false if
+// no such task is in flight.
+func (r *InFlightTasks) Cancel(task string) bool {
+	if r == nil {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t, ok := r.tasks[task]
+	if !ok {
+		return false
+	}
+	glog.Warningf("Cancelling in-flight task %v", task)
+	t.cancelled = true
+	t.cancel()
+	return true
+}
+
+// ServeHTTP implements the http.Handler interface. GET responds with the
+// in-flight tasks encoded as JSON, and POST with a "cancel" query parameter
+// cancels the task with that relative resource name.
+func (r *InFlightTasks) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(r.List()); err != nil {
+			glog.Warningf("Failed to write the in-flight tasks response, err: %v", err)
+		}
+	case http.MethodPost:
+		task := req.URL.Query().Get("cancel")
+		if task == "" {
+			http.Error(w, "missing cancel parameter", http.StatusBadRequest)
+			return
+		}
+		if !r.Cancel(task) {
+			http.Error(w, "task not in flight", http.StatusNotFound)
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// ServeInFlightTasks serves the in-flight tasks at "/tasks" on addr, until ctx
+// is cancelled.
+func (r *InFlightTasks) ServeInFlightTasks(ctx context.Context, addr string) {
+	stats.Serve(ctx, addr, "/tasks", r)
+}
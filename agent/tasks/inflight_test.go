@@ -0,0 +1,107 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestInFlightTasks(t *testing.T) {
+	r := NewInFlightTasks()
+	req := &taskpb.TaskReqMsg{TaskRelRsrcName: "task", JobrunRelRsrcName: "jobrun"}
+	ctx, done, cancelled := r.start(context.Background(), req)
+
+	if _, err := io.Copy(ioutil.Discard, common.NewTaskBytesReader(ctx, strings.NewReader("12345"))); err != nil {
+		t.Fatalf("io.Copy got err: %v", err)
+	}
+	tasks := r.List()
+	if len(tasks) != 1 || tasks[0].Task != "task" || tasks[0].JobRun != "jobrun" || tasks[0].Bytes != 5 {
+		t.Errorf("List() = %+v, want task with 5 bytes", tasks)
+	}
+
+	if r.Cancel("other") {
+		t.Errorf("Cancel(%q) = true, want false", "other")
+	}
+	if !r.Cancel("task") {
+		t.Errorf("Cancel(%q) = false, want true", "task")
+	}
+	if ctx.Err() != context.Canceled {
+		t.Errorf("ctx.Err() = %v, want %v", ctx.Err(), context.Canceled)
+	}
+	done()
+	if !cancelled() {
+		t.Errorf("cancelled() = false, want true")
+	}
+	if tasks := r.List(); len(tasks) != 0 {
+		t.Errorf("List() after done = %+v, want none", tasks)
+	}
+}
+
+func TestInFlightTasksNotCancelled(t *testing.T) {
+	var nilRegistry *InFlightTasks
+	for _, r := range []*InFlightTasks{nilRegistry, NewInFlightTasks()} {
+		_, done, cancelled := r.start(context.Background(), &taskpb.TaskReqMsg{TaskRelRsrcName: "task"})
+		done()
+		if cancelled() {
+			t.Errorf("cancelled() = true, want false")
+		}
+	}
+}
+
+func TestInFlightTasksServeHTTP(t *testing.T) {
+	r := NewInFlightTasks()
+	_, done, _ := r.start(context.Background(), &taskpb.TaskReqMsg{TaskRelRsrcName: "task"})
+	defer done()
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks", nil))
+	var tasks []InFlightTask
+	if err := json.Unmarshal(rec.Body.Bytes(), &tasks); err != nil {
+		t.Fatalf("json.Unmarshal(%q) got err: %v", rec.Body.String(), err)
+	}
+	if len(tasks) != 1 || tasks[0].Task != "task" {
+		t.Errorf("GET /tasks got %+v, want task", tasks)
+	}
+
+	tests := []struct {
+		method   string
+		target   string
+		wantCode int
+	}{
+		{http.MethodPost, "/tasks", http.StatusBadRequest},
+		{http.MethodPost, "/tasks?cancel=other", http.StatusNotFound},
+		{http.MethodPost, "/tasks?cancel=task", http.StatusOK},
+		{http.MethodDelete, "/tasks", http.StatusMethodNotAllowed},
+	}
+	for _, tc := range tests {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.target, nil))
+		if rec.Code != tc.wantCode {
+			t.Errorf("%s %s got code %d, want %d", tc.method, tc.target, rec.Code, tc.wantCode)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"context"
+	"flag"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/hashing"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/stats"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/ptypes/wrappers"
+
+	listfilepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
+)
+
+var (
+	listFileChecksums = flag.Bool("list-file-checksums", false, "Store the CRC32C of every listed file in its list file entry. Local files are read while listing to compute it, which makes listing much slower, but lets copies by agents run with trust-source-checksum read each file only once.")
+)
+
+// setFileCRC32C sets the CRC32C of the file listed by a fileInfoEntry.
+func setFileCRC32C(entry *listfilepb.ListFileEntry, crc uint32) {
+	entry.GetFileInfo().Crc32C = &wrappers.UInt32Value{Value: crc}
+}
+
+// addFileChecksum reads the file at osPath to compute the CRC32C of its
+// fileInfoEntry, if the list-file-checksums flag is set and listMD doesn't
+// skip checksums. The time spent is
+// recorded in listMD. Files which can't be read, including when ctx is done
+// while waiting for the max-open-files budget, are logged and left without a
+// CRC32C, their copies compute it instead.
+func addFileChecksum(ctx context.Context, entry *listfilepb.ListFileEntry, osPath string, listMD *listingFileMetadata) {
+	if !*listFileChecksums || listMD.skipChecksums {
+		return
+	}
+	start := time.Now()
+	crc, n, err := fileCRC32C(ctx, osPath)
+	listMD.checksumReadMs += stats.DurMs(start)
+	listMD.checksumBytesRead += n
+	if err != nil {
+		glog.Warningf("not storing the CRC32C of %q, which couldn't be read: %v", osPath, err)
+		return
+	}
+	setFileCRC32C(entry, crc)
+}
+
+// fileCRC32C returns the CRC32C of the file at osPath, and the number of bytes
+// read to compute it. The file is held open within the max-open-files budget.
+func fileCRC32C(ctx context.Context, osPath string) (uint32, int64, error) {
+	releaseOpenFile, err := common.AcquireOpenFile(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer releaseOpenFile()
+	f, err := os.Open(osPath)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	var crc uint32
+	n, err := io.Copy(ioutil.Discard, hashing.NewCRC32CUpdatingReader(f, &crc))
+	return crc, n, err
+}
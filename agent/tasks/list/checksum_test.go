@@ -0,0 +1,76 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/hashing"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+)
+
+func TestProcessDirFileChecksums(t *testing.T) {
+	defer func(b bool) { *listFileChecksums = b }(*listFileChecksums)
+
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	p := filepath.Join(tmpDir, "file")
+	if err := ioutil.WriteFile(p, []byte(fileContent), 0644); err != nil {
+		t.Fatalf("WriteFile(%q) got err: %v", p, err)
+	}
+
+	for _, tc := range []struct{ checksums, skip bool }{{false, false}, {true, false}, {true, true}} {
+		*listFileChecksums = tc.checksums
+		checksums := tc.checksums && !tc.skip
+		listMD := &listingFileMetadata{skipChecksums: tc.skip}
+		entries, err := processDir(context.Background(), tmpDir, NewDirectoryInfoStore(), listMD, false, nil, 0, "", nil)
+		if err != nil {
+			t.Fatalf("processDir(%q) got err: %v", tmpDir, err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("processDir(%q) got %d entries, want 1", tmpDir, len(entries))
+		}
+		crc := entries[0].GetFileInfo().GetCrc32C()
+		if !checksums {
+			if crc != nil || listMD.checksumBytesRead != 0 {
+				t.Errorf("processDir(%q) without checksums got CRC32C %v, %d bytes read, want nil, 0", tmpDir, crc, listMD.checksumBytesRead)
+			}
+			continue
+		}
+		if want := hashing.CRC32C([]byte(fileContent)); crc.GetValue() != want {
+			t.Errorf("processDir(%q) got CRC32C %v, want %d", tmpDir, crc, want)
+		}
+		if listMD.checksumBytesRead != int64(len(fileContent)) {
+			t.Errorf("processDir(%q) got %d checksum bytes read, want %d", tmpDir, listMD.checksumBytesRead, len(fileContent))
+		}
+	}
+}
+
+func TestAddFileChecksumUnreadable(t *testing.T) {
+	defer func(b bool) { *listFileChecksums = b }(*listFileChecksums)
+	*listFileChecksums = true
+
+	p := filepath.Join(os.TempDir(), "test-list-agent-does-not-exist")
+	entry := fileInfoEntry(p, 0, 0)
+	addFileChecksum(context.Background(), entry, p, &listingFileMetadata{})
+	if crc := entry.GetFileInfo().GetCrc32C(); crc != nil {
+		t.Errorf("addFileChecksum(%q) got CRC32C %v, want nil", p, crc)
+	}
+}
@@ -18,11 +18,12 @@ package list
 import (
 	"context"
 	"errors"
-	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -45,11 +46,21 @@ type DepthFirstListHandler struct {
 	resumableChunkSize    int
 	listFileSizeThreshold int
 	allowedDirBytes       int
-	statsTracker          *stats.Tracker // For tracking bytes sent/copied.
+	statsTracker          *stats.Tracker    // For tracking bytes sent/copied.
+	reconcileHandler      *ReconcileHandler // Handles the reconcile tasks sent on the list subscription.
 }
 
 // NewDepthFirstListHandler returns a new DepthFirstListHandler.
 func NewDepthFirstListHandler(storageClient *storage.Client, st *stats.Tracker) *DepthFirstListHandler {
+	if err := common.ValidateSymlinkPolicy(common.SymlinkPolicy()); err != nil {
+		glog.Fatalf("Invalid symlink-policy flag: %v", err)
+	}
+	if err := validateListFileFormat(*listFileFormat); err != nil {
+		glog.Fatalf("Invalid list-file-format flag: %v", err)
+	}
+	if err := validateRestatPolicy(*listRestatPolicy); err != nil {
+		glog.Fatalf("Invalid list-restat-policy flag: %v", err)
+	}
 	// Convert maxMemoryForListingDirectories to bytes and divide it equally between
 	// the list task processing threads.
 	allowedDirBytes := *maxMemoryForListingDirectories * 1024 * 1024 / *NumberConcurrentListTasks
@@ -58,6 +69,7 @@ func NewDepthFirstListHandler(storageClient *storage.Client, st *stats.Tracker)
 		resumableChunkSize:    *listTaskChunkSize,
 		listFileSizeThreshold: *listFileSizeThreshold,
 		allowedDirBytes:       allowedDirBytes,
+		reconcileHandler:      NewReconcileHandler(storageClient),
 		statsTracker:          st,
 	}
 }
@@ -66,70 +78,182 @@ func NewDepthFirstListHandler(storageClient *storage.Client, st *stats.Tracker)
 // given dirStore.
 // It returns the discovered files (and directories if writeDirs is true) sorted in case sensitive
 // alphabetical order by path. The given listMD is updated with the number of files/dirs found.
-func processDir(dir string, dirStore *DirectoryInfoStore, listMD *listingFileMetadata, writeDirs bool, statsTracker *stats.Tracker) ([]*listfilepb.ListFileEntry, error) {
+// Files and directories skipped by the filter are neither returned nor counted. If minMTime is
+// non-zero, files modified before it are skipped and counted in listMD.filesSkippedByMTime.
+// If an empty directory marker is configured and dir is empty, a zero-byte marker file is returned
+// for it.
+// Entries whose destination names collide once normalized are recorded in listMD.nameCollisions.
+// The directory is held open within the max-open-files budget, waiting for it until ctx is done.
+func processDir(ctx context.Context, dir string, dirStore *DirectoryInfoStore, listMD *listingFileMetadata, writeDirs bool, filter *globFilter, minMTime int64, jobRun string, statsTracker *stats.Tracker) ([]*listfilepb.ListFileEntry, error) {
+	entries, _, err := processPartialDir(ctx, dir, "", 0, dirStore, listMD, writeDirs, filter, minMTime, jobRun, statsTracker)
+	return entries, err
+}
+
+// dirProgress describes how far a directory which was only partly listed got.
+type dirProgress struct {
+	lastListed string // The name of the last entry listed.
+	listed     int64  // The number of entries listed.
+	remaining  int64  // The number of entries sorted after lastListed.
+}
+
+// dirWindow sorts osFileInfos by name and returns those sorted after resumeAfter, at most
+// maxEntries of them if maxEntries is positive. It also returns the number of entries left after
+// the returned ones.
+func dirWindow(osFileInfos []os.FileInfo, resumeAfter string, maxEntries int) ([]os.FileInfo, int) {
+	sort.Slice(osFileInfos, func(i, j int) bool { return osFileInfos[i].Name() < osFileInfos[j].Name() })
+	start := sort.Search(len(osFileInfos), func(i int) bool { return osFileInfos[i].Name() > resumeAfter })
+	window := osFileInfos[start:]
+	if maxEntries > 0 && len(window) > maxEntries {
+		return window[:maxEntries], len(window) - maxEntries
+	}
+	return window, 0
+}
+
+// processPartialDir is like processDir, but only lists the entries of dir sorted by name after
+// resumeAfter, and at most maxEntries of them if maxEntries is positive. If entries are left
+// unlisted, it returns the progress to resume from.
+func processPartialDir(ctx context.Context, dir, resumeAfter string, maxEntries int, dirStore *DirectoryInfoStore, listMD *listingFileMetadata, writeDirs bool, filter *globFilter, minMTime int64, jobRun string, statsTracker *stats.Tracker) ([]*listfilepb.ListFileEntry, *dirProgress, error) {
+	// The budget is released once the directory is closed, before any of its files are opened
+	// to compute their checksums.
+	releaseOpenFile, err := common.AcquireOpenFile(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 	openStart := time.Now()
 	osDir := agentcommon.OSPath(dir)
 	f, err := os.Open(osDir)
-	statsTracker.RecordPulseStats(&stats.PulseStats{ListDirOpenMs: stats.DurMs(openStart)})
+	statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{ListDirOpenMs: stats.DurMs(openStart)})
 	if err != nil {
-		return nil, err
+		releaseOpenFile()
+		return nil, nil, err
+	}
+	closeDir := func() {
+		f.Close()
+		releaseOpenFile()
 	}
 	readStart := time.Now()
 	osFileInfos, err := f.Readdir(-1)
-	statsTracker.RecordPulseStats(&stats.PulseStats{ListDirReadMs: stats.DurMs(readStart)})
-	f.Close()
+	statsTracker.RecordPulseStats(jobRun, &stats.PulseStats{ListDirReadMs: stats.DurMs(readStart)})
 	if err != nil {
-		return nil, err
+		closeDir()
+		return nil, nil, err
+	}
+	if len(osFileInfos) == 0 && common.EmptyDirMarker() != "" {
+		dirInfo, err := f.Stat()
+		closeDir()
+		if err != nil {
+			return nil, nil, err
+		}
+		if dirInfo.ModTime().Unix() < minMTime {
+			listMD.filesSkippedByMTime++
+			return nil, nil, nil
+		}
+		listMD.addFile(0)
+		return []*listfilepb.ListFileEntry{fileInfoEntry(dir+common.EmptyDirMarker(), dirInfo.ModTime().Unix(), 0)}, nil, nil
+	}
+	closeDir()
+	// Collisions are only recorded once, when listing the start of the directory.
+	if common.DstNamesNormalized() && resumeAfter == "" {
+		names := make([]string, len(osFileInfos))
+		for i, osFileInfo := range osFileInfos {
+			names[i] = osFileInfo.Name()
+		}
+		if collisions := nameCollisions(dir, names); len(collisions) > 0 {
+			glog.Warningf("%d entries of %q have colliding destination names: %q", len(collisions), dir, collisions)
+			listMD.nameCollisions = append(listMD.nameCollisions, collisions...)
+		}
+	}
+
+	var progress *dirProgress
+	if resumeAfter != "" || maxEntries > 0 {
+		var remaining int
+		osFileInfos, remaining = dirWindow(osFileInfos, resumeAfter, maxEntries)
+		if remaining > 0 {
+			progress = &dirProgress{
+				lastListed: osFileInfos[len(osFileInfos)-1].Name(),
+				listed:     int64(len(osFileInfos)),
+				remaining:  int64(remaining),
+			}
+		}
 	}
 
 	var symlinksSkipped int
+	policy := symlinkPolicy()
 	var entries []*listfilepb.ListFileEntry
 	for _, osFileInfo := range osFileInfos {
-		if strings.Contains(osFileInfo.Name(), "\n") {
-			fileOrDir := "file"
-			if osFileInfo.IsDir() {
-				fileOrDir = "directory"
-			}
-			return nil, fmt.Errorf("the listing contains a %s with newlines in the name: %q", fileOrDir, osFileInfo.Name())
-		}
+		// Names may contain any bytes, including newlines, since list file entries are length
+		// prefixed protobufs.
 		path := filepath.Join(dir, osFileInfo.Name())
 		osPath := agentcommon.OSPath(path)
-		isSymlinkToDir := false
 		if osFileInfo.Mode()&os.ModeSymlink != 0 {
-			if !*followSymlinks {
-				symlinksSkipped++
+			switch policy {
+			case common.SymlinkPolicyFollow:
+				target, err := os.Stat(osPath) // Resolves chained symlinks.
+				if err != nil {
+					glog.Warningf("skipping symlink, Stat(%q) got err: %v", osPath, err)
+					symlinksSkipped++
+					continue
+				}
+				if target.IsDir() && pointsToAncestor(osDir, target) {
+					glog.Warningf("skipping symlink %q, which points to one of its ancestor directories", osPath)
+					symlinksSkipped++
+					continue
+				}
+				osFileInfo = target
+				listMD.symlinksFollowed++
+			case common.SymlinkPolicyCopyAsObject:
+				if filter.skipFile(path) {
+					continue
+				}
+				if osFileInfo.ModTime().Unix() < minMTime {
+					listMD.filesSkippedByMTime++
+					continue
+				}
+				// The object content is the symlink target path, so the
+				// symlink's own size (the length of the target path) is used.
+				entries = append(entries, fileInfoEntry(path, osFileInfo.ModTime().Unix(), osFileInfo.Size()))
+				listMD.addFile(osFileInfo.Size())
 				continue
-			}
-			isSymlinkToDir, err = doesSymlinkPointToDir(osDir, osPath)
-			if err != nil {
-				glog.Warningf("skipping symlink, isSymlinkToDir(%q) got err: %v", osPath, err)
+			default:
 				symlinksSkipped++
 				continue
 			}
 		}
-		if osFileInfo.IsDir() || isSymlinkToDir {
+		if osFileInfo.IsDir() {
+			if filter.skipDir(path) {
+				continue
+			}
 			dirInfo := listfilepb.DirectoryInfo{Path: path}
 			err := dirStore.Add(dirInfo)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			listMD.dirsDiscovered++
 			if writeDirs {
 				entries = append(entries, &listfilepb.ListFileEntry{Entry: &listfilepb.ListFileEntry_DirectoryInfo{DirectoryInfo: &dirInfo}})
 			}
 		} else {
+			if filter.skipFile(path) {
+				continue
+			}
+			if osFileInfo.ModTime().Unix() < minMTime {
+				listMD.filesSkippedByMTime++
+				continue
+			}
 			size := osFileInfo.Size()
-			entries = append(entries, fileInfoEntry(path, osFileInfo.ModTime().Unix(), size))
-			listMD.files++
-			listMD.bytes += size
+			entry := fileInfoEntry(path, osFileInfo.ModTime().Unix(), size)
+			addFileChecksum(ctx, entry, osPath, listMD)
+			entries = append(entries, entry)
+			listMD.addFile(size)
 		}
 	}
 	if symlinksSkipped > 0 {
 		glog.Infof("skipped %v symlinks when listing %q", symlinksSkipped, dir)
+		listMD.symlinksSkipped += int64(symlinksSkipped)
 	}
 
 	err = sortListFileEntries(entries)
-	return entries, err
+	return entries, progress, err
 }
 
 // writeDirectories writes all of the directories stored in dirStore to the given writer
@@ -137,57 +261,213 @@ func processDir(dir string, dirStore *DirectoryInfoStore, listMD *listingFileMet
 func writeDirectories(w io.Writer, dirStore *DirectoryInfoStore) error {
 	for _, dirInfo := range dirStore.DirectoryInfos() {
 		entry := listfilepb.ListFileEntry{Entry: &listfilepb.ListFileEntry_DirectoryInfo{DirectoryInfo: &dirInfo}}
-		if err := writeProtobuf(w, &entry); err != nil {
+		if err := writeListFileEntry(w, &entry); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// listedDir holds the result of listing a single directory. Directories listed in parallel each
+// get their own dirStore and listMD, which are merged in order once the directory's turn comes.
+type listedDir struct {
+	dirInfo  *listfilepb.DirectoryInfo
+	entries  []*listfilepb.ListFileEntry
+	progress *dirProgress // Non-nil if the directory was only partly listed.
+	modTime  int64        // The directory's mtime before it was listed, if it's indexed.
+	dirStore *DirectoryInfoStore
+	listMD   *listingFileMetadata
+	err      error
+}
+
+// listDirs lists the given directories using up to parallelism goroutines, and returns their
+// results in the same order.
+func listDirs(ctx context.Context, gcs gcloud.GCS, dirs []*listfilepb.DirectoryInfo, parallelism int, settings listSettings, listSpec taskpb.ListSpec, filter *globFilter, statsTracker *stats.Tracker) []*listedDir {
+	results := make([]*listedDir, len(dirs))
+	dirChan := make(chan int, len(dirs))
+	for i, dirInfo := range dirs {
+		results[i] = &listedDir{dirInfo: dirInfo, dirStore: NewDirectoryInfoStore(), listMD: &listingFileMetadata{}}
+		dirChan <- i
+	}
+	close(dirChan)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism && i < len(dirs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range dirChan {
+				r := results[i]
+				src, srcErr := common.ObjectSourceFor(r.dirInfo.Path)
+				if isGCSPath(r.dirInfo.Path) {
+					r.entries, r.err = processGCSDir(ctx, gcs, r.dirInfo.Path, r.dirStore, r.listMD, settings.includeDirs, filter, listSpec.MinMtime)
+				} else if srcErr != nil {
+					r.err = srcErr
+				} else if src != nil {
+					r.entries, r.err = processObjectSourceDir(ctx, src, r.dirInfo.Path, r.dirStore, r.listMD, settings.includeDirs, filter, listSpec.MinMtime)
+				} else {
+					var resumeAfter string
+					if r.dirInfo.Path == listSpec.ResumeDir {
+						resumeAfter = listSpec.ResumeAfter
+					}
+					if d := settings.index.lookup(r.dirInfo.Path); d != nil && resumeAfter == "" {
+						r.modTime = d.ModTime
+						r.entries, r.err = d.replay(r.dirStore, r.listMD)
+					} else {
+						if settings.index != nil {
+							// A directory which can't be stat'ed isn't indexed, and fails to be listed below.
+							r.modTime, _ = dirModTime(r.dirInfo.Path)
+						}
+						r.entries, r.progress, r.err = processPartialDir(ctx, r.dirInfo.Path, resumeAfter, settings.dirMaxEntries, r.dirStore, r.listMD, settings.includeDirs, filter, listSpec.MinMtime, settings.jobRun, statsTracker)
+					}
+					if r.err == nil {
+						var skipped int
+						// A directory missing skipped files must not be replayed from the index.
+						if r.entries, skipped = restatEntries(ctx, r.entries, r.listMD); skipped > 0 {
+							r.modTime = 0
+						}
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
 // processDirectories lists directories until it has hit the list file size threshold or it has
 // used too much memory. For each directory it processes, it writes any files to the list file and
 // adds any directories to the list of directories to be listed. If includeDirs is true, both files
 // and directories are written to the list file.
+// Discovered directories deeper than the list spec's max depth are not listed, and are returned
+// to dirStore once listing is done. Directories with a "gs://bucket/prefix" path are listed from
+// GCS using the given gcs, and directories of a registered common.ObjectSource, such as
+// "s3://bucket/prefix" paths, are listed from that source. Directories which can't be read are recorded in the returned metadata
+// and skipped, see handleErroredDir.
+// Up to settings.dirParallelism directories are listed at once. Their results are merged in
+// order, and directories whose results would exceed the limits are returned to dirStore unlisted,
+// so the limits hold just as when listing one directory at a time.
+// Listing stops once a directory is only partly listed because it has more than
+// settings.dirMaxEntries entries left. The returned metadata records where to resume it.
+// Local directories listed whole are recorded in settings.index, and unmodified directories already
+// in it are replayed from it instead of being listed again.
+// Local files are stat'ed again before their entries are written, see restatEntries.
 // processDirectories returns listing file metadata gathered while processing directories.
-func processDirectories(w io.Writer, dirStore *DirectoryInfoStore, settings listSettings, listSpec taskpb.ListSpec, statsTracker *stats.Tracker) (*listingFileMetadata, error) {
+func processDirectories(ctx context.Context, gcs gcloud.GCS, w io.Writer, dirStore *DirectoryInfoStore, settings listSettings, listSpec taskpb.ListSpec, statsTracker *stats.Tracker) (*listingFileMetadata, error) {
 	totalEntries := 0
 	listMD := &listingFileMetadata{}
+	filter := newGlobFilter(listSpec.RootDirectory)
+	deferredDirs := NewDirectoryInfoStore()
+	parallelism := settings.dirParallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	// Directories taken from dirStore but not yet merged still count against the limits.
+	withinLimits := func(pending []*listfilepb.DirectoryInfo) bool {
+		pendingSize := 0
+		for _, dirInfo := range pending {
+			pendingSize += approximateSizeOfDirInfo(*dirInfo)
+		}
+		return dirStore.Size()+deferredDirs.Size()+pendingSize < settings.maxDirBytes && totalEntries+dirStore.Len()+deferredDirs.Len()+len(pending) < settings.listFileSizeThreshold
+	}
 
 	// Ensure that at least one directory is listed. Without the firstTime flag, the initial list
 	// of directories could exceed the memory limit, resulting in no directories being listed.
-	for firstTime := true; firstTime || (dirStore.Size() < settings.maxDirBytes && totalEntries+dirStore.Len() < settings.listFileSizeThreshold); {
-		dirToProcess := dirStore.RemoveFirst()
-		if dirToProcess == nil {
+	yielded := false
+	for firstTime := true; !yielded && (firstTime || withinLimits(nil)); {
+		var batch []*listfilepb.DirectoryInfo
+		for len(batch) < parallelism {
+			dirToProcess := dirStore.RemoveFirst()
+			if dirToProcess == nil {
+				break
+			}
+			if exceedsMaxDepth(dirToProcess.Path, listSpec) && !isListedInSpec(dirToProcess.Path, listSpec) {
+				if err := deferredDirs.Add(*dirToProcess); err != nil {
+					return nil, err
+				}
+				listMD.dirsDeferredByDepth++
+				continue
+			}
+			batch = append(batch, dirToProcess)
+		}
+		if len(batch) == 0 {
 			break
 		}
-		entries, err := processDir(dirToProcess.Path, dirStore, listMD, settings.includeDirs, statsTracker)
-		if err != nil {
-			if listSpec.RootDirectory != "" && os.IsNotExist(err) {
-				if err := handleNotFoundDir(dirToProcess.Path, listSpec, listMD); err == nil {
-					// Successfully handled the not found dir, continue listing
-					continue
+		results := listDirs(ctx, gcs, batch, parallelism, settings, listSpec, filter, statsTracker)
+		for i, r := range results {
+			if yielded || (!firstTime && !withinLimits(batch[i:])) {
+				for _, unlisted := range batch[i:] {
+					if err := dirStore.Add(*unlisted); err != nil {
+						return nil, err
+					}
 				}
+				break
 			}
-			return nil, err
-		}
-		if settings.includeDirHeader {
-			if err := writeProtobuf(w, dirHeaderEntry(dirToProcess.Path, int64(len(entries)))); err != nil {
+			if err := r.err; err != nil {
+				if listSpec.RootDirectory != "" && os.IsNotExist(err) {
+					if err := handleNotFoundDir(r.dirInfo.Path, listSpec, listMD); err == nil {
+						// Successfully handled the not found dir, continue listing
+						continue
+					}
+				}
+				if err := handleErroredDir(r.dirInfo.Path, err, listSpec, listMD); err == nil {
+					// The dir couldn't be read, but its siblings can still be listed.
+					continue
+				}
 				return nil, err
 			}
-		}
-		for _, entry := range entries {
-			if err := writeProtobuf(w, entry); err != nil {
-				return nil, err
+			for _, dirInfo := range r.dirStore.DirectoryInfos() {
+				if err := dirStore.Add(dirInfo); err != nil {
+					return nil, err
+				}
+			}
+			listMD.add(r.listMD)
+			if settings.includeDirHeader {
+				if err := writeListFileEntry(w, dirHeaderEntry(r.dirInfo.Path, int64(len(r.entries)))); err != nil {
+					return nil, err
+				}
+			}
+			for _, entry := range r.entries {
+				if err := writeListFileEntry(w, entry); err != nil {
+					return nil, err
+				}
+			}
+			totalEntries += len(r.entries)
+			firstTime = false
+			listMD.dirsListed++
+			if p := r.progress; p != nil {
+				listMD.partialDir = r.dirInfo.Path
+				listMD.partialDirResumeAfter = p.lastListed
+				listMD.partialDirEntriesListed = p.listed
+				listMD.partialDirEntriesRemaining = p.remaining
+				yielded = true
+			} else if r.modTime != 0 {
+				settings.index.add(r, r.modTime)
 			}
 		}
-		totalEntries += len(entries)
-		firstTime = false
-		listMD.dirsListed++
+	}
+	for _, dirInfo := range deferredDirs.DirectoryInfos() {
+		if err := dirStore.Add(dirInfo); err != nil {
+			return nil, err
+		}
 	}
 	listMD.dirsNotListed = int64(dirStore.Len())
 	return listMD, nil
 }
 
+// exceedsMaxDepth returns true if dir is deeper than the list spec's max depth below its root
+// directory, where the root directory has depth 0.
+func exceedsMaxDepth(dir string, spec taskpb.ListSpec) bool {
+	if spec.MaxDepth == nil || spec.RootDirectory == "" {
+		return false
+	}
+	rel, err := filepath.Rel(spec.RootDirectory, dir)
+	if err != nil || rel == "." {
+		return false
+	}
+	depth := int64(len(strings.Split(rel, string(filepath.Separator))))
+	return depth > spec.MaxDepth.Value
+}
+
 // handleNotFoundDir checks if the job's root dir can be found. If the job's root dir cannot
 // be found, it's likely that the agent was misconfigured, so an error is returned. Otherwise, the
 // notFoundDir has likely been deleted, so the given listMD is adjusted accordingly.
@@ -212,6 +492,31 @@ func handleNotFoundDir(notFoundDir string, listSpec taskpb.ListSpec, listMD *lis
 	return nil
 }
 
+// handleErroredDir checks whether listing can continue after dir could not be opened or read.
+// Errors listing the job's root directory, or a list spec src directory of a job without a root
+// directory, are returned since they likely mean the agent was misconfigured. Otherwise, dir is
+// recorded in the given listMD and nil is returned.
+func handleErroredDir(dir string, dirErr error, listSpec taskpb.ListSpec, listMD *listingFileMetadata) error {
+	if _, ok := dirErr.(*os.PathError); !ok {
+		return dirErr
+	}
+	if listSpec.RootDirectory == "" {
+		if isListedInSpec(dir, listSpec) {
+			return dirErr
+		}
+	} else {
+		if dir == listSpec.RootDirectory {
+			return dirErr
+		}
+		if _, err := os.Stat(listSpec.RootDirectory); err != nil {
+			return err
+		}
+	}
+	glog.Warningf("skipping directory %q, which could not be listed: %v", dir, dirErr)
+	listMD.dirsErrored = append(listMD.dirsErrored, &taskpb.DirError{Path: dir, Reason: dirErr.Error()})
+	return nil
+}
+
 func isListedInSpec(dir string, spec taskpb.ListSpec) bool {
 	for _, srcDir := range spec.SrcDirectories {
 		if dir == srcDir {
@@ -228,7 +533,12 @@ func isListedInSpec(dir string, spec taskpb.ListSpec) bool {
 // file. Otherwise, just files are written.
 // Unlisted directories (any directories that were found or included in the list spec but weren't
 // listed) are stored in the returned directory info store.
-func listDirectoriesAndWriteResults(w io.Writer, listSpec *taskpb.ListSpec, settings listSettings, statsTracker *stats.Tracker) (*listingFileMetadata, *DirectoryInfoStore, error) {
+// If the list spec's src_directories are manifests, the manifests are listed instead, see
+// listManifestsAndWriteResults.
+func listDirectoriesAndWriteResults(ctx context.Context, gcs gcloud.GCS, w io.Writer, listSpec *taskpb.ListSpec, settings listSettings, statsTracker *stats.Tracker) (*listingFileMetadata, *DirectoryInfoStore, error) {
+	if listSpec.SrcIsManifest {
+		return listManifestsAndWriteResults(ctx, gcs, w, listSpec, settings)
+	}
 	// Add directories from list spec into the DirStore.
 	// Directories will be explored in alphabetical, depth first order.
 	dirStore := NewDirectoryInfoStore()
@@ -238,7 +548,7 @@ func listDirectoriesAndWriteResults(w io.Writer, listSpec *taskpb.ListSpec, sett
 		}
 	}
 
-	listMD, err := processDirectories(w, dirStore, settings, *listSpec, statsTracker)
+	listMD, err := processDirectories(ctx, gcs, w, dirStore, settings, *listSpec, statsTracker)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -247,6 +557,9 @@ func listDirectoriesAndWriteResults(w io.Writer, listSpec *taskpb.ListSpec, sett
 }
 
 func (h *DepthFirstListHandler) Do(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg, reqStart time.Time) *taskpb.TaskRespMsg {
+	if taskReqMsg.Spec.GetReconcileSpec() != nil {
+		return h.reconcileHandler.Do(ctx, taskReqMsg, reqStart)
+	}
 	listSpec := taskReqMsg.Spec.GetListSpec()
 	if listSpec == nil {
 		err := errors.New("ListHandler.Do taskReqMsg.Spec is not ListSpec")
@@ -257,20 +570,24 @@ func (h *DepthFirstListHandler) Do(ctx context.Context, taskReqMsg *taskpb.TaskR
 		Log: &taskpb.Log_ListLog{ListLog: &taskpb.ListLog{}},
 	}
 
-	w := gcsWriterWithCondition(ctx, h.gcs, listSpec.DstListResultBucket, listSpec.DstListResultObject, listSpec.ExpectedGenerationNum, h.resumableChunkSize)
+	w := listFileWriter(ctx, h.gcs, listSpec.DstListResultBucket, listSpec.DstListResultObject, listSpec.ExpectedGenerationNum, h.resumableChunkSize)
 
-	fileWriter := h.statsTracker.NewListByteTrackingWriter(w, true)
+	fileWriter := h.statsTracker.NewListByteTrackingWriter(taskReqMsg.JobrunRelRsrcName, w, true)
 	settings := listSettings{
 		listFileSizeThreshold: h.listFileSizeThreshold,
 		maxDirBytes:           h.allowedDirBytes,
+		dirParallelism:        *listDirParallelism,
+		jobRun:                taskReqMsg.JobrunRelRsrcName,
 	}
-	listMD, unlistedDirs, err := listDirectoriesAndWriteResults(fileWriter, listSpec, settings, h.statsTracker)
+	settings.index = openListIndex(taskReqMsg.TaskRelRsrcName, listSpec, settings)
+	defer settings.index.close()
+	listMD, unlistedDirs, err := listDirectoriesAndWriteResults(ctx, h.gcs, fileWriter, listSpec, settings, h.statsTracker)
 	if err != nil {
 		w.CloseWithError(err)
 		return common.BuildTaskRespMsg(taskReqMsg, nil, log, err)
 	}
 
-	dirWriter := h.statsTracker.NewListByteTrackingWriter(w, false)
+	dirWriter := h.statsTracker.NewListByteTrackingWriter(taskReqMsg.JobrunRelRsrcName, w, false)
 	if err = writeDirectories(dirWriter, unlistedDirs); err != nil {
 		w.CloseWithError(err)
 		return common.BuildTaskRespMsg(taskReqMsg, nil, log, err)
@@ -281,6 +598,7 @@ func (h *DepthFirstListHandler) Do(ctx context.Context, taskReqMsg *taskpb.TaskR
 	}
 
 	setListLog(log, listMD)
+	settings.index.remove()
 
 	return common.BuildTaskRespMsg(taskReqMsg, nil, log, nil)
 }
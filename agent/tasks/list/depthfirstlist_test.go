@@ -18,8 +18,13 @@ package list
 import (
 	"bytes"
 	"context"
+	"errors"
 	"flag"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"reflect"
 	"sort"
 	"strings"
 	"testing"
@@ -31,6 +36,7 @@ import (
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
 
 	listpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
 	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
@@ -150,9 +156,10 @@ func TestDepthFirstListSuccessFlatDir(t *testing.T) {
 	wantLog := &taskpb.Log{
 		Log: &taskpb.Log_ListLog{
 			ListLog: &taskpb.ListLog{
-				FilesFound: 10,
-				BytesFound: 100,
-				DirsListed: 1,
+				FilesFound:   10,
+				BytesFound:   100,
+				MaxFileBytes: 10,
+				DirsListed:   1,
 			},
 		},
 	}
@@ -161,7 +168,7 @@ func TestDepthFirstListSuccessFlatDir(t *testing.T) {
 	}
 }
 
-func TestDepthFirstListFailsFileWithNewline(t *testing.T) {
+func TestDepthFirstListSuccessFileWithNewline(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 
@@ -199,11 +206,10 @@ func TestDepthFirstListFailsFileWithNewline(t *testing.T) {
 	h := DepthFirstListHandler{gcs: mockGCS, listFileSizeThreshold: 10000, allowedDirBytes: 5 * 1024 * 1024, statsTracker: st}
 	taskReqParams := testDepthFirstListTaskReqMsg(taskRelRsrcName, []string{tmpDir})
 	taskRespMsg := h.Do(context.Background(), taskReqParams, time.Now())
-	// TODO(b/111502687): Failing with UNKNOWN_FAILURE is temporary. In the long
-	// term, we will escape file with newlines.
-	CheckFailureWithType(taskRelRsrcName, taskpb.FailureType_UNKNOWN_FAILURE, taskRespMsg, t)
-	if writer.WrittenString() != "" {
-		t.Errorf("expected nothing written but found: %s", writer.WrittenString())
+	CheckSuccessMsg(taskRelRsrcName, taskRespMsg, t)
+	if writer.WrittenString() != expectedListResult.String() {
+		t.Errorf("expected to write \"%s\", found: \"%s\"",
+			expectedListResult.String(), writer.WrittenString())
 	}
 }
 
@@ -268,6 +274,7 @@ func TestDepthFirstListSuccessNestedDirSmallListFile(t *testing.T) {
 			ListLog: &taskpb.ListLog{
 				FilesFound:    10,
 				BytesFound:    100,
+				MaxFileBytes:  10,
 				DirsFound:     2,
 				DirsListed:    1,
 				DirsNotListed: 2,
@@ -344,10 +351,11 @@ func TestDepthFirstListSuccessNestedDirLargeListFile(t *testing.T) {
 	wantLog := &taskpb.Log{
 		Log: &taskpb.Log_ListLog{
 			ListLog: &taskpb.ListLog{
-				FilesFound: 20,
-				BytesFound: 200,
-				DirsFound:  2,
-				DirsListed: 3,
+				FilesFound:   20,
+				BytesFound:   200,
+				MaxFileBytes: 10,
+				DirsFound:    2,
+				DirsListed:   3,
 			},
 		},
 	}
@@ -402,10 +410,11 @@ func TestDepthFirstListMakesProgressWhenSrcDirsExceedsMemDirLimit(t *testing.T)
 	wantLog := &taskpb.Log{
 		Log: &taskpb.Log_ListLog{
 			ListLog: &taskpb.ListLog{
-				FilesFound: 10,
-				BytesFound: 100,
-				DirsFound:  0,
-				DirsListed: 1,
+				FilesFound:   10,
+				BytesFound:   100,
+				MaxFileBytes: 10,
+				DirsFound:    0,
+				DirsListed:   1,
 			},
 		},
 	}
@@ -492,6 +501,7 @@ func TestDepthFirstListSuccessNestedDirSmallMemoryLimitListFile(t *testing.T) {
 			ListLog: &taskpb.ListLog{
 				FilesFound:    20,
 				BytesFound:    200,
+				MaxFileBytes:  10,
 				DirsFound:     3,
 				DirsListed:    2,
 				DirsNotListed: 2,
@@ -554,9 +564,10 @@ func TestDepthFirstListWithMountDirSuccess(t *testing.T) {
 	wantLog := &taskpb.Log{
 		Log: &taskpb.Log_ListLog{
 			ListLog: &taskpb.ListLog{
-				FilesFound: 10,
-				BytesFound: 100,
-				DirsListed: 1,
+				FilesFound:   10,
+				BytesFound:   100,
+				MaxFileBytes: 10,
+				DirsListed:   1,
 			},
 		},
 	}
@@ -564,3 +575,330 @@ func TestDepthFirstListWithMountDirSuccess(t *testing.T) {
 		t.Errorf("log = %+v, want: %+v", taskRespMsg.Log, wantLog)
 	}
 }
+
+func TestProcessDirSymlinkPolicy(t *testing.T) {
+	defer common.SetSymlinkPolicy(common.SymlinkPolicySkip)
+
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	filePath := common.CreateTmpFile(tmpDir, "file", "0123456789")
+	nestedDir := common.CreateTmpDir(tmpDir, "nestedDir")
+	fileLink := filepath.Join(tmpDir, "fileLink")
+	dirLink := filepath.Join(tmpDir, "dirLink")
+	loopLink := filepath.Join(nestedDir, "loopLink")
+	for target, link := range map[string]string{filePath: fileLink, nestedDir: dirLink, tmpDir: loopLink} {
+		if err := os.Symlink(target, link); err != nil {
+			t.Fatalf("os.Symlink(%q, %q) got err: %v", target, link, err)
+		}
+	}
+
+	tests := []struct {
+		policy      string
+		dir         string
+		wantPaths   []string
+		wantFiles   int64
+		wantBytes   int64
+		wantSkipped int64
+		wantFollow  int64
+	}{
+		{common.SymlinkPolicySkip, tmpDir, []string{filePath, nestedDir}, 1, 10, 2, 0},
+		{common.SymlinkPolicyFollow, tmpDir, []string{dirLink, filePath, fileLink, nestedDir}, 2, 20, 0, 2},
+		{common.SymlinkPolicyFollow, nestedDir, nil, 0, 0, 1, 0},
+		{common.SymlinkPolicyCopyAsObject, tmpDir, []string{dirLink, filePath, fileLink, nestedDir}, 3, int64(10 + len(filePath) + len(nestedDir)), 0, 0},
+	}
+	for _, tc := range tests {
+		if err := common.SetSymlinkPolicy(tc.policy); err != nil {
+			t.Fatalf("SetSymlinkPolicy(%q) got err: %v", tc.policy, err)
+		}
+		listMD := &listingFileMetadata{}
+		entries, err := processDir(context.Background(), tc.dir, NewDirectoryInfoStore(), listMD, true, nil, 0, "", nil)
+		if err != nil {
+			t.Fatalf("%s: processDir(%q) got err: %v", tc.policy, tc.dir, err)
+		}
+		var gotPaths []string
+		for _, e := range entries {
+			p, err := getPath(e)
+			if err != nil {
+				t.Fatalf("%s: getPath(%v) got err: %v", tc.policy, e, err)
+			}
+			gotPaths = append(gotPaths, p)
+		}
+		if !reflect.DeepEqual(gotPaths, tc.wantPaths) {
+			t.Errorf("%s: processDir(%q) got paths %v, want %v", tc.policy, tc.dir, gotPaths, tc.wantPaths)
+		}
+		if listMD.files != tc.wantFiles || listMD.bytes != tc.wantBytes {
+			t.Errorf("%s: processDir(%q) got files %d, bytes %d, want files %d, bytes %d", tc.policy, tc.dir, listMD.files, listMD.bytes, tc.wantFiles, tc.wantBytes)
+		}
+		if listMD.symlinksSkipped != tc.wantSkipped || listMD.symlinksFollowed != tc.wantFollow {
+			t.Errorf("%s: processDir(%q) got symlinks skipped %d, followed %d, want skipped %d, followed %d", tc.policy, tc.dir, listMD.symlinksSkipped, listMD.symlinksFollowed, tc.wantSkipped, tc.wantFollow)
+		}
+	}
+}
+
+func TestExceedsMaxDepth(t *testing.T) {
+	tests := []struct {
+		desc     string
+		rootDir  string
+		maxDepth *wrappers.Int64Value
+		dir      string
+		want     bool
+	}{
+		{"No max depth", "/root", nil, "/root/a/b/c", false},
+		{"No root dir", "", &wrappers.Int64Value{Value: 0}, "/root/a", false},
+		{"Root dir", "/root", &wrappers.Int64Value{Value: 0}, "/root", false},
+		{"Depth 0 child", "/root", &wrappers.Int64Value{Value: 0}, "/root/a", true},
+		{"Depth 1 child", "/root", &wrappers.Int64Value{Value: 1}, "/root/a", false},
+		{"Depth 1 grandchild", "/root", &wrappers.Int64Value{Value: 1}, "/root/a/b", true},
+	}
+	for _, tc := range tests {
+		spec := taskpb.ListSpec{RootDirectory: tc.rootDir, MaxDepth: tc.maxDepth}
+		if got := exceedsMaxDepth(tc.dir, spec); got != tc.want {
+			t.Errorf("%s: exceedsMaxDepth(%q) = %v, want %v", tc.desc, tc.dir, got, tc.want)
+		}
+	}
+}
+
+func TestHandleErroredDir(t *testing.T) {
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	subDir := filepath.Join(tmpDir, "sub")
+	permErr := &os.PathError{Op: "open", Path: subDir, Err: os.ErrPermission}
+	tests := []struct {
+		desc    string
+		rootDir string
+		srcDirs []string
+		dir     string
+		err     error
+		wantErr bool
+	}{
+		{"Discovered dir", "", nil, subDir, permErr, false},
+		{"Src dir without root dir", "", []string{subDir}, subDir, permErr, true},
+		{"Src dir with root dir", tmpDir, []string{subDir}, subDir, permErr, false},
+		{"Root dir", tmpDir, []string{tmpDir}, tmpDir, permErr, true},
+		{"Root dir not found", filepath.Join(tmpDir, "missing"), nil, subDir, permErr, true},
+		{"Not a PathError", "", nil, subDir, errors.New("store full"), true},
+	}
+	for _, tc := range tests {
+		listMD := &listingFileMetadata{}
+		spec := taskpb.ListSpec{RootDirectory: tc.rootDir, SrcDirectories: tc.srcDirs}
+		err := handleErroredDir(tc.dir, tc.err, spec, listMD)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("%s: handleErroredDir(%q) got err: %v, want err: %v", tc.desc, tc.dir, err, tc.wantErr)
+		}
+		var want []*taskpb.DirError
+		if !tc.wantErr {
+			want = []*taskpb.DirError{{Path: tc.dir, Reason: tc.err.Error()}}
+		}
+		if !reflect.DeepEqual(listMD.dirsErrored, want) {
+			t.Errorf("%s: handleErroredDir(%q) got dirsErrored %v, want %v", tc.desc, tc.dir, listMD.dirsErrored, want)
+		}
+	}
+}
+
+func TestProcessDirectoriesSkipsErroredDir(t *testing.T) {
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	deletedDir := filepath.Join(tmpDir, "a")
+	siblingDir := filepath.Join(tmpDir, "b")
+	if err := os.Mkdir(siblingDir, 0755); err != nil {
+		t.Fatalf("Mkdir(%q) got err: %v", siblingDir, err)
+	}
+	file := createFile(t, siblingDir, "file", fileContent)
+
+	// The deleted dir was discovered by a previous list task, but is not in the list spec.
+	dirStore := NewDirectoryInfoStore()
+	for _, d := range []string{deletedDir, siblingDir} {
+		if err := dirStore.Add(listpb.DirectoryInfo{Path: d}); err != nil {
+			t.Fatalf("DirectoryInfoStore.Add(%q) got err: %v", d, err)
+		}
+	}
+	var w bytes.Buffer
+	spec := taskpb.ListSpec{SrcDirectories: []string{tmpDir}}
+	listMD, err := processDirectories(context.Background(), nil, &w, dirStore, listSettings{listFileSizeThreshold: 10000, maxDirBytes: 500000}, spec, nil)
+	if err != nil {
+		t.Fatalf("processDirectories() got err: %v", err)
+	}
+	if listMD.dirsListed != 1 || listMD.files != 1 {
+		t.Errorf("processDirectories() got dirsListed %d, files %d, want 1, 1", listMD.dirsListed, listMD.files)
+	}
+	if len(listMD.dirsErrored) != 1 || listMD.dirsErrored[0].Path != deletedDir {
+		t.Errorf("processDirectories() got dirsErrored %v, want only %q", listMD.dirsErrored, deletedDir)
+	}
+	var want bytes.Buffer
+	writeEntry(t, &want, file)
+	if w.String() != want.String() {
+		t.Errorf("processDirectories() wrote %q, want %q", w.String(), want.String())
+	}
+}
+
+func TestProcessDirectoriesParallel(t *testing.T) {
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	for i := 0; i < 5; i++ {
+		subDir := filepath.Join(tmpDir, fmt.Sprintf("d%d", i), "sub")
+		if err := os.MkdirAll(subDir, 0755); err != nil {
+			t.Fatalf("MkdirAll(%q) got err: %v", subDir, err)
+		}
+		createFile(t, filepath.Dir(subDir), "file", fileContent)
+		createFile(t, subDir, "file", fileContent)
+	}
+
+	tests := []struct {
+		desc          string
+		threshold     int
+		parallelism   int
+		wantDirs      int64
+		wantFiles     int64
+		wantNotListed int64
+	}{
+		{"Sequential", 10000, 1, 11, 10, 0},
+		{"Parallel", 10000, 4, 11, 10, 0},
+		{"More workers than dirs", 10000, 20, 11, 10, 0},
+		// The root directory discovers 5 dirs, exceeding the threshold.
+		{"Sequential limited", 5, 1, 1, 0, 5},
+		{"Parallel limited", 5, 4, 1, 0, 5},
+		// The threshold is hit once d0, d1 and their subdirs, and d2 are listed.
+		{"Sequential partly listed", 8, 1, 6, 5, 3},
+		// d0 to d3 are listed in parallel, merging d3 would exceed the threshold.
+		{"Parallel partly listed", 8, 4, 4, 3, 5},
+	}
+	for _, tc := range tests {
+		dirStore := NewDirectoryInfoStore()
+		if err := dirStore.Add(listpb.DirectoryInfo{Path: tmpDir}); err != nil {
+			t.Fatalf("DirectoryInfoStore.Add(%q) got err: %v", tmpDir, err)
+		}
+		var w bytes.Buffer
+		settings := listSettings{listFileSizeThreshold: tc.threshold, maxDirBytes: 500000, dirParallelism: tc.parallelism}
+		listMD, err := processDirectories(context.Background(), nil, &w, dirStore, settings, taskpb.ListSpec{}, nil)
+		if err != nil {
+			t.Fatalf("%s: processDirectories() got err: %v", tc.desc, err)
+		}
+		if listMD.dirsListed != tc.wantDirs || listMD.files != tc.wantFiles || listMD.dirsNotListed != tc.wantNotListed {
+			t.Errorf("%s: processDirectories() got dirsListed %d, files %d, dirsNotListed %d, want %d, %d, %d",
+				tc.desc, listMD.dirsListed, listMD.files, listMD.dirsNotListed, tc.wantDirs, tc.wantFiles, tc.wantNotListed)
+		}
+		if listMD.bytes != tc.wantFiles*int64(len(fileContent)) {
+			t.Errorf("%s: processDirectories() got bytes %d, want %d", tc.desc, listMD.bytes, tc.wantFiles*int64(len(fileContent)))
+		}
+	}
+}
+
+func TestDirWindow(t *testing.T) {
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	for _, name := range []string{"c", "a", "e", "b", "d"} {
+		if err := ioutil.WriteFile(filepath.Join(tmpDir, name), nil, 0644); err != nil {
+			t.Fatalf("WriteFile(%q) got err: %v", name, err)
+		}
+	}
+	tests := []struct {
+		resumeAfter   string
+		maxEntries    int
+		want          string
+		wantRemaining int
+	}{
+		{"", 0, "abcde", 0},
+		{"", 2, "ab", 3},
+		{"b", 2, "cd", 1},
+		{"bb", 2, "cd", 1},
+		{"c", 5, "de", 0},
+		{"e", 2, "", 0},
+	}
+	for _, tc := range tests {
+		f, err := os.Open(tmpDir)
+		if err != nil {
+			t.Fatalf("os.Open(%q) got err: %v", tmpDir, err)
+		}
+		osFileInfos, err := f.Readdir(-1)
+		f.Close()
+		if err != nil {
+			t.Fatalf("Readdir() got err: %v", err)
+		}
+		window, remaining := dirWindow(osFileInfos, tc.resumeAfter, tc.maxEntries)
+		var got string
+		for _, fi := range window {
+			got += fi.Name()
+		}
+		if got != tc.want || remaining != tc.wantRemaining {
+			t.Errorf("dirWindow(%q, %d) = %q, %d, want %q, %d", tc.resumeAfter, tc.maxEntries, got, remaining, tc.want, tc.wantRemaining)
+		}
+	}
+}
+
+func TestProcessDirEmptyDirMarker(t *testing.T) {
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	emptyDir := filepath.Join(tmpDir, "empty")
+	if err := os.Mkdir(emptyDir, 0755); err != nil {
+		t.Fatalf("Mkdir(%q) got err: %v", emptyDir, err)
+	}
+	dirInfo, err := os.Stat(emptyDir)
+	if err != nil {
+		t.Fatalf("Stat(%q) got err: %v", emptyDir, err)
+	}
+	defer common.SetEmptyDirMarker("")
+
+	tests := []struct {
+		marker string
+		dir    string
+		want   []*listpb.ListFileEntry
+	}{
+		{"", emptyDir, nil},
+		{"/", emptyDir, []*listpb.ListFileEntry{fileInfoEntry(emptyDir+"/", dirInfo.ModTime().Unix(), 0)}},
+		// Directories with subdirectories aren't empty.
+		{"/", tmpDir, nil},
+	}
+	for _, tc := range tests {
+		common.SetEmptyDirMarker(tc.marker)
+		listMD := &listingFileMetadata{}
+		entries, err := processDir(context.Background(), tc.dir, NewDirectoryInfoStore(), listMD, false, nil, 0, "", nil)
+		if err != nil {
+			t.Fatalf("processDir(%q) with marker %q got err: %v", tc.dir, tc.marker, err)
+		}
+		if len(entries) != len(tc.want) {
+			t.Fatalf("processDir(%q) with marker %q got %v, want %v", tc.dir, tc.marker, entries, tc.want)
+		}
+		for i := range entries {
+			if !proto.Equal(entries[i], tc.want[i]) {
+				t.Errorf("processDir(%q) with marker %q got entry %v, want %v", tc.dir, tc.marker, entries[i], tc.want[i])
+			}
+		}
+		if got, want := listMD.files, int64(len(tc.want)); got != want {
+			t.Errorf("processDir(%q) with marker %q got files %d, want %d", tc.dir, tc.marker, got, want)
+		}
+	}
+}
+
+func TestProcessDirOpenFileBudget(t *testing.T) {
+	defer func(b bool) { *listFileChecksums = b }(*listFileChecksums)
+	*listFileChecksums = true
+	defer common.SetMaxOpenFiles(0)
+	common.SetMaxOpenFiles(1)
+
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	common.CreateTmpFile(tmpDir, "file", "content")
+
+	// The directory is closed before its file is read, so a single open file
+	// is enough.
+	listMD := &listingFileMetadata{}
+	entries, err := processDir(context.Background(), tmpDir, NewDirectoryInfoStore(), listMD, false, nil, 0, "", nil)
+	if err != nil {
+		t.Fatalf("processDir(%q) got err: %v", tmpDir, err)
+	}
+	if len(entries) != 1 || entries[0].GetFileInfo().GetCrc32C() == nil {
+		t.Errorf("processDir(%q) got entries %v, want a single file with a CRC32C", tmpDir, entries)
+	}
+
+	// While the budget is used up, listing waits until ctx is done.
+	release, err := common.AcquireOpenFile(context.Background())
+	if err != nil {
+		t.Fatalf("AcquireOpenFile got err: %v", err)
+	}
+	defer release()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := processDir(ctx, tmpDir, NewDirectoryInfoStore(), &listingFileMetadata{}, false, nil, 0, "", nil); err != context.DeadlineExceeded {
+		t.Errorf("processDir(%q) with the budget used up got err: %v, want %v", tmpDir, err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"context"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"google.golang.org/api/iterator"
+
+	listfilepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
+)
+
+const gcsScheme = common.GCSScheme
+
+// isGCSPath returns true if p is a "gs://bucket/prefix" path.
+func isGCSPath(p string) bool {
+	return strings.HasPrefix(p, gcsScheme)
+}
+
+// parseGCSPath splits a "gs://bucket/prefix" path into its bucket and object
+// prefix. A non-empty prefix always ends with "/", so that only the objects
+// "within" the prefix directory are listed.
+func parseGCSPath(p string) (bucket, prefix string) {
+	parts := strings.SplitN(strings.TrimPrefix(p, gcsScheme), "/", 2)
+	bucket = parts[0]
+	if len(parts) == 2 && parts[1] != "" {
+		prefix = strings.TrimSuffix(parts[1], "/") + "/"
+	}
+	return bucket, prefix
+}
+
+// processGCSDir is the processDir equivalent for a "gs://bucket/prefix" dir.
+// Objects directly within the prefix are listed as files, and the prefixes
+// nested one level below it are listed as directories.
+func processGCSDir(ctx context.Context, gcs gcloud.GCS, dir string, dirStore *DirectoryInfoStore, listMD *listingFileMetadata, writeDirs bool, filter *globFilter, minMTime int64) ([]*listfilepb.ListFileEntry, error) {
+	bucket, prefix := parseGCSPath(dir)
+	it := gcs.ListObjects(ctx, bucket, &storage.Query{Prefix: prefix, Delimiter: "/"})
+	var entries []*listfilepb.ListFileEntry
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if attrs.Prefix != "" {
+			path := gcsScheme + bucket + "/" + strings.TrimSuffix(attrs.Prefix, "/")
+			if filter.skipDir(path) {
+				continue
+			}
+			dirInfo := listfilepb.DirectoryInfo{Path: path}
+			if err := dirStore.Add(dirInfo); err != nil {
+				return nil, err
+			}
+			listMD.dirsDiscovered++
+			if writeDirs {
+				entries = append(entries, &listfilepb.ListFileEntry{Entry: &listfilepb.ListFileEntry_DirectoryInfo{DirectoryInfo: &dirInfo}})
+			}
+			continue
+		}
+		if attrs.Name == prefix {
+			// Skip the placeholder object some tools create for the directory itself.
+			continue
+		}
+		path := gcsScheme + bucket + "/" + attrs.Name
+		if filter.skipFile(path) {
+			continue
+		}
+		mtime := common.ObjectMTime(attrs)
+		if mtime < minMTime {
+			listMD.filesSkippedByMTime++
+			continue
+		}
+		entry := fileInfoEntry(path, mtime, attrs.Size)
+		if *listFileChecksums {
+			// GCS already checksummed the object, so nothing is read.
+			setFileCRC32C(entry, attrs.CRC32C)
+		}
+		entries = append(entries, entry)
+		listMD.addFile(attrs.Size)
+	}
+
+	err := sortListFileEntries(entries)
+	return entries, err
+}
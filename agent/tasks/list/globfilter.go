@@ -0,0 +1,113 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	includeGlobs globList
+	excludeGlobs globList
+)
+
+func init() {
+	flag.Var(&includeGlobs, "include-glob", "Only list files matching this glob. May be repeated, files matching any of the globs are listed. Globs without a \"/\" are matched against the file name, other globs against the path relative to the job's root directory.")
+	flag.Var(&excludeGlobs, "exclude-glob", "Don't list files or directories matching this glob, excluded directories are not descended into. May be repeated. Globs are matched like include-glob.")
+}
+
+// globList is a flag.Value holding the globs of a repeatable flag.
+type globList []string
+
+// String implements the flag.Value interface.
+func (g *globList) String() string {
+	return strings.Join(*g, ",")
+}
+
+// Set implements the flag.Value interface.
+func (g *globList) Set(glob string) error {
+	if _, err := path.Match(glob, ""); err != nil {
+		return fmt.Errorf("invalid glob %q: %v", glob, err)
+	}
+	*g = append(*g, glob)
+	return nil
+}
+
+// globFilter decides which listed files and directories are written to the
+// list file. A nil globFilter doesn't filter anything.
+type globFilter struct {
+	rootDir          string
+	include, exclude []string
+}
+
+// newGlobFilter returns a globFilter matching paths relative to rootDir, or
+// nil if no globs are configured.
+func newGlobFilter(rootDir string) *globFilter {
+	if len(includeGlobs) == 0 && len(excludeGlobs) == 0 {
+		return nil
+	}
+	return &globFilter{rootDir: rootDir, include: includeGlobs, exclude: excludeGlobs}
+}
+
+// skipDir returns true if the directory at p should not be listed.
+func (f *globFilter) skipDir(p string) bool {
+	if f == nil {
+		return false
+	}
+	return f.matchesAny(f.exclude, p)
+}
+
+// skipFile returns true if the file at p should not be written to the list
+// file.
+func (f *globFilter) skipFile(p string) bool {
+	if f == nil {
+		return false
+	}
+	if f.matchesAny(f.exclude, p) {
+		return true
+	}
+	return len(f.include) > 0 && !f.matchesAny(f.include, p)
+}
+
+// matchesAny returns true if p matches any of the globs. Globs containing a
+// "/" are matched against p relative to the root directory, so they behave the
+// same regardless of where the root directory is mounted. Other globs are
+// matched against the base name of p.
+func (f *globFilter) matchesAny(globs []string, p string) bool {
+	relPath := p
+	if f.rootDir != "" {
+		if r, err := filepath.Rel(f.rootDir, p); err == nil {
+			relPath = r
+		}
+	}
+	relPath = filepath.ToSlash(relPath)
+	base := path.Base(relPath)
+	for _, glob := range globs {
+		name := base
+		if strings.Contains(glob, "/") {
+			name = relPath
+		}
+		// The globs are validated when the flags are set.
+		if ok, _ := path.Match(glob, name); ok {
+			return true
+		}
+	}
+	return false
+}
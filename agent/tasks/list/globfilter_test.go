@@ -0,0 +1,108 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+)
+
+func TestGlobListSet(t *testing.T) {
+	var g globList
+	if err := g.Set("*.parquet"); err != nil {
+		t.Errorf("Set(%q) got err: %v", "*.parquet", err)
+	}
+	if err := g.Set("[a-"); err == nil {
+		t.Errorf("Set(%q) got nil err, want err", "[a-")
+	}
+	if got, want := g.String(), "*.parquet"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestGlobFilter(t *testing.T) {
+	root := "/mnt/root"
+	tests := []struct {
+		desc         string
+		include      []string
+		exclude      []string
+		path         string
+		wantSkipFile bool
+		wantSkipDir  bool
+	}{
+		{"No globs", nil, nil, "/mnt/root/a/b.tmp", false, false},
+		{"Include base name", []string{"*.parquet"}, nil, "/mnt/root/a/b.parquet", false, false},
+		{"Not included", []string{"*.parquet"}, nil, "/mnt/root/a/b.tmp", true, false},
+		{"Include doesn't apply to dirs", []string{"*.parquet"}, nil, "/mnt/root/a", true, false},
+		{"Exclude base name", nil, []string{"*.tmp"}, "/mnt/root/a/b.tmp", true, true},
+		{"Exclude dir", nil, []string{".snapshot"}, "/mnt/root/a/.snapshot", true, true},
+		{"Exclude wins", []string{"*.tmp"}, []string{"*.tmp"}, "/mnt/root/b.tmp", true, true},
+		{"Relative path", nil, []string{"a/*.tmp"}, "/mnt/root/a/b.tmp", true, true},
+		{"Relative path no match", nil, []string{"a/*.tmp"}, "/mnt/root/x/a/b.tmp", false, false},
+		{"Absolute glob doesn't match", nil, []string{"/mnt/root/a/*.tmp"}, "/mnt/root/a/b.tmp", false, false},
+	}
+	for _, tc := range tests {
+		f := &globFilter{rootDir: root, include: tc.include, exclude: tc.exclude}
+		if got := f.skipFile(tc.path); got != tc.wantSkipFile {
+			t.Errorf("%s: skipFile(%q) = %v, want %v", tc.desc, tc.path, got, tc.wantSkipFile)
+		}
+		if got := f.skipDir(tc.path); got != tc.wantSkipDir {
+			t.Errorf("%s: skipDir(%q) = %v, want %v", tc.desc, tc.path, got, tc.wantSkipDir)
+		}
+	}
+}
+
+func TestProcessDirGlobFilter(t *testing.T) {
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	keptFile := filepath.Join(tmpDir, "kept.parquet")
+	for _, p := range []string{keptFile, filepath.Join(tmpDir, "skipped.tmp")} {
+		if err := ioutil.WriteFile(p, []byte(fileContent), 0644); err != nil {
+			t.Fatalf("WriteFile(%q) got err: %v", p, err)
+		}
+	}
+	keptDir := filepath.Join(tmpDir, "data")
+	for _, d := range []string{keptDir, filepath.Join(tmpDir, ".snapshot")} {
+		if err := os.Mkdir(d, 0755); err != nil {
+			t.Fatalf("Mkdir(%q) got err: %v", d, err)
+		}
+	}
+
+	filter := &globFilter{rootDir: tmpDir, include: []string{"*.parquet"}, exclude: []string{".snapshot"}}
+	dirStore := NewDirectoryInfoStore()
+	listMD := &listingFileMetadata{}
+	entries, err := processDir(context.Background(), tmpDir, dirStore, listMD, false, filter, 0, "", nil)
+	if err != nil {
+		t.Fatalf("processDir(%q) got err: %v", tmpDir, err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("processDir(%q) got %d entries, want 1", tmpDir, len(entries))
+	}
+	if p, _ := getPath(entries[0]); p != keptFile {
+		t.Errorf("processDir(%q) got entry %q, want %q", tmpDir, p, keptFile)
+	}
+	if listMD.files != 1 || listMD.bytes != int64(len(fileContent)) || listMD.dirsDiscovered != 1 {
+		t.Errorf("processDir(%q) got files %d, bytes %d, dirs %d, want 1, %d, 1", tmpDir, listMD.files, listMD.bytes, listMD.dirsDiscovered, len(fileContent))
+	}
+	if d := dirStore.RemoveFirst(); d == nil || d.Path != keptDir {
+		t.Errorf("dirStore.RemoveFirst() = %v, want %q", d, keptDir)
+	}
+}
@@ -16,10 +16,10 @@ limitations under the License.
 package list
 
 import (
+	"compress/gzip"
 	"context"
 	"errors"
 	"flag"
-	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
@@ -39,13 +39,74 @@ var (
 	listFileSizeThreshold          = flag.Int("list-file-size-threshold", 50000, "List tasks will keep listing directories until the number of listed files and directories exceeds this threshold, or until there are no more files/directories to list")
 	listTaskChunkSize              = flag.Int("list-task-chunk-size", 8*1024*1024, "The resumable upload chunk size used for list tasks, defaults to 8MiB.")
 	maxMemoryForListingDirectories = flag.Int("max-memory-for-listing-directories", 20, "Maximum amount of memory agent will use in total (not per task) to store directories before writing them to a list file. Value is in MiB.")
+	gzipListFiles                  = flag.Bool("gzip-list-files", false, "Gzip list files and unexplored dirs files before uploading them, and store them with Content-Encoding: gzip.")
+	listDirMaxEntries              = flag.Int("list-dir-max-entries", 0, "The maximum number of entries of a single directory each list task lists. A list task reaching it yields, and returns where it stopped in its response spec so that a re-queued task continues the directory. Only honored for job run version 3. If 0, directories are always listed whole.")
+	listDirParallelism             = flag.Int("list-dir-parallelism", 1, "The number of directories each list task reads in parallel. Raising this speeds up listing wide trees on fast storage.")
 
-	followSymlinks = flag.Bool("follow-symlinks", false, "If true symlinks will be followed, if false symlinks will be ignored. BEWARE: there is no cycle protection!")
+	followSymlinks = flag.Bool("follow-symlinks", false, "Deprecated, use symlink-policy=follow instead. If true symlinks will be followed.")
 )
 
 type listingFileMetadata struct {
 	bytes, files, dirsDiscovered, dirsListed, dirsNotListed int64
+	maxFileBytes, dirsFromIndex                             int64
+	symlinksSkipped, symlinksFollowed, filesSkippedByMTime  int64
+	dirsDeferredByDepth                                     int64
+	checksumReadMs, checksumBytesRead                       int64
+	filesChangedDuringList                                  int64
 	dirsNotFound                                            []string
+	dirsErrored                                             []*taskpb.DirError
+	manifestFilesNotFound                                   []string
+	nameCollisions                                          []string
+
+	// The directory listing yielded partway through, see ListSpec.resume_dir.
+	partialDir, partialDirResumeAfter                   string
+	partialDirEntriesListed, partialDirEntriesRemaining int64
+
+	// Set by walks which don't need the files' CRC32Cs, so addFileChecksum
+	// doesn't read them even with list-file-checksums.
+	skipChecksums bool
+}
+
+// add adds the counts and lists of md2 to md.
+func (md *listingFileMetadata) add(md2 *listingFileMetadata) {
+	md.bytes += md2.bytes
+	md.files += md2.files
+	md.dirsDiscovered += md2.dirsDiscovered
+	md.dirsListed += md2.dirsListed
+	md.dirsNotListed += md2.dirsNotListed
+	md.symlinksSkipped += md2.symlinksSkipped
+	md.symlinksFollowed += md2.symlinksFollowed
+	md.filesSkippedByMTime += md2.filesSkippedByMTime
+	md.dirsDeferredByDepth += md2.dirsDeferredByDepth
+	md.dirsNotFound = append(md.dirsNotFound, md2.dirsNotFound...)
+	md.dirsErrored = append(md.dirsErrored, md2.dirsErrored...)
+	md.manifestFilesNotFound = append(md.manifestFilesNotFound, md2.manifestFilesNotFound...)
+	md.nameCollisions = append(md.nameCollisions, md2.nameCollisions...)
+	md.dirsFromIndex += md2.dirsFromIndex
+	md.checksumReadMs += md2.checksumReadMs
+	md.checksumBytesRead += md2.checksumBytesRead
+	md.filesChangedDuringList += md2.filesChangedDuringList
+	if md2.maxFileBytes > md.maxFileBytes {
+		md.maxFileBytes = md2.maxFileBytes
+	}
+}
+
+// addFile counts a listed file of the given size.
+func (md *listingFileMetadata) addFile(size int64) {
+	md.files++
+	md.bytes += size
+	if size > md.maxFileBytes {
+		md.maxFileBytes = size
+	}
+}
+
+// symlinkPolicy returns the symlink policy for listing, honoring the
+// deprecated follow-symlinks flag.
+func symlinkPolicy() string {
+	if *followSymlinks && common.SymlinkPolicy() == common.SymlinkPolicySkip {
+		return common.SymlinkPolicyFollow
+	}
+	return common.SymlinkPolicy()
 }
 
 type listSettings struct {
@@ -58,6 +119,14 @@ type listSettings struct {
 	// includeDirHeader determines whether a header including the path of the directory being listed
 	// is written to the list file before its contents.
 	includeDirHeader bool
+	// dirParallelism is the number of directories listed at once, at least 1.
+	dirParallelism int
+	// dirMaxEntries is the maximum number of entries of a single directory listed, if positive.
+	dirMaxEntries int
+	// jobRun is the relative resource name of the job run being listed, used to attribute stats.
+	jobRun string
+	// index checkpoints the directories listed whole, see listIndex. It may be nil.
+	index *listIndex
 }
 
 func dirInfoEntry(path string) *listfilepb.ListFileEntry {
@@ -97,10 +166,60 @@ func setListLog(log *taskpb.Log, listMD *listingFileMetadata) {
 	ll := log.GetListLog()
 	ll.FilesFound = listMD.files
 	ll.BytesFound = listMD.bytes
+	ll.MaxFileBytes = listMD.maxFileBytes
 	ll.DirsFound = listMD.dirsDiscovered
 	ll.DirsListed = listMD.dirsListed
 	ll.DirsNotListed = listMD.dirsNotListed
 	ll.DirsNotFound = listMD.dirsNotFound
+	ll.SymlinksSkipped = listMD.symlinksSkipped
+	ll.SymlinksFollowed = listMD.symlinksFollowed
+	ll.FilesSkippedByMtime = listMD.filesSkippedByMTime
+	ll.DirsDeferredByDepth = listMD.dirsDeferredByDepth
+	ll.DirsErrored = listMD.dirsErrored
+	ll.ManifestFilesNotFound = listMD.manifestFilesNotFound
+	ll.NameCollisions = listMD.nameCollisions
+	ll.PartialDir = listMD.partialDir
+	ll.PartialDirEntriesListed = listMD.partialDirEntriesListed
+	ll.PartialDirEntriesRemaining = listMD.partialDirEntriesRemaining
+	ll.DirsListedFromIndex = listMD.dirsFromIndex
+	ll.ChecksumReadMs = listMD.checksumReadMs
+	ll.ChecksumBytesRead = listMD.checksumBytesRead
+	ll.FilesChangedDuringList = listMD.filesChangedDuringList
+}
+
+// gzipWriter is a gcloud.WriteCloserWithError which gzips the bytes written to the wrapped GCS
+// object writer.
+type gzipWriter struct {
+	gcloud.WriteCloserWithError
+	zw *gzip.Writer
+}
+
+// Write implements the io.Writer interface.
+func (w *gzipWriter) Write(p []byte) (int, error) {
+	return w.zw.Write(p)
+}
+
+// Close flushes the compressed bytes, then closes the wrapped writer.
+func (w *gzipWriter) Close() error {
+	if err := w.zw.Close(); err != nil {
+		w.WriteCloserWithError.CloseWithError(err)
+		return err
+	}
+	return w.WriteCloserWithError.Close()
+}
+
+// listFileWriter returns a writer for a list file or unexplored dirs object, which is gzipped
+// and stored with Content-Encoding: gzip if the gzip-list-files flag is set. Like any object
+// written with gcsWriterWithCondition, the object must match generationNum.
+func listFileWriter(ctx context.Context, gcs gcloud.GCS, bucket, object string, generationNum int64, resumableChunkSize int) gcloud.WriteCloserWithError {
+	w := gcsWriterWithCondition(ctx, gcs, bucket, object, generationNum, resumableChunkSize)
+	if !*gzipListFiles {
+		return w
+	}
+	if t, ok := w.(*storage.Writer); ok {
+		t.ContentEncoding = "gzip"
+	}
+	return &gzipWriter{WriteCloserWithError: w, zw: gzip.NewWriter(w)}
 }
 
 func gcsWriterWithCondition(ctx context.Context, gcs gcloud.GCS, bucket, object string, generationNum int64, resumableChunkSize int) gcloud.WriteCloserWithError {
@@ -140,15 +259,41 @@ func getPath(entry *listfilepb.ListFileEntry) (string, error) {
 	}
 }
 
-func doesSymlinkPointToDir(dir, path string) (bool, error) {
-	realPath, err := os.Readlink(path) // Resolves chained symlinks.
-	if err != nil {
-		return false, fmt.Errorf("Readlink(%v) got err %v\n", path, err)
+// nameCollisions returns the paths of the entries of dir whose destination
+// names collide with another entry's once normalized, sorted by path.
+func nameCollisions(dir string, names []string) []string {
+	byNormalized := make(map[string][]string)
+	for _, name := range names {
+		n := common.NormalizeDstName(name)
+		byNormalized[n] = append(byNormalized[n], name)
 	}
-	realPath = filepath.Join(dir, realPath)
-	fi, err := os.Stat(realPath)
-	if err != nil {
-		return false, fmt.Errorf("Stat(%q) got err %v\n", realPath, err)
+	var paths []string
+	for _, colliding := range byNormalized {
+		if len(colliding) < 2 {
+			continue
+		}
+		for _, name := range colliding {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// pointsToAncestor returns true if target, the resolved target of a symlink
+// within dir, is dir itself or one of its ancestors. Following such a symlink
+// would list the same directories forever. Directories are compared by their
+// resolved device and inode (see os.SameFile), so cycles through other
+// followed symlinks are also detected.
+func pointsToAncestor(dir string, target os.FileInfo) bool {
+	for d := filepath.Clean(dir); ; {
+		if fi, err := os.Stat(d); err == nil && os.SameFile(fi, target) {
+			return true
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return false
+		}
+		d = parent
 	}
-	return fi.IsDir(), nil
 }
@@ -1,12 +1,23 @@
 package list
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
 
+	listfilepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
 	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
 )
 
@@ -33,39 +44,155 @@ func CheckSuccessMsg(taskRelRsrcName string, taskRespMsg *taskpb.TaskRespMsg, t
 	}
 }
 
-func TestDoesSymlinkPointToDir(t *testing.T) {
-	// Create temp dirs and file.
+func TestPointsToAncestor(t *testing.T) {
 	tmpDir := common.CreateTmpDir("", "dir")
 	defer os.RemoveAll(tmpDir)
-	tmpNestedDir := common.CreateTmpDir(tmpDir, "nestedDir")
-	tmpFile := common.CreateTmpFile(tmpDir, "tmpfile", "dummyFileContent")
+	nestedDir := common.CreateTmpDir(tmpDir, "nestedDir")
+	siblingDir := common.CreateTmpDir(tmpDir, "siblingDir")
 
-	// Create up the symlinks.
-	dirSymlink := filepath.Join(tmpDir, "dirSymlink")
-	err := os.Symlink(tmpNestedDir, dirSymlink)
-	if err != nil {
-		t.Fatalf("os.Symlink(%q, %q) got err: %v", tmpNestedDir, dirSymlink, err)
+	tests := []struct {
+		desc   string
+		dir    string
+		target string
+		want   bool
+	}{
+		{"Self", nestedDir, nestedDir, true},
+		{"Parent", nestedDir, tmpDir, true},
+		{"Sibling", nestedDir, siblingDir, false},
+		{"Child", tmpDir, nestedDir, false},
 	}
-	fileSymlink := filepath.Join(tmpDir, "fileSymlink")
-	err = os.Symlink(tmpFile, fileSymlink)
-	if err != nil {
-		t.Fatalf("os.Symlink(%q, %q) got err: %v", tmpFile, fileSymlink, err)
+	for _, tc := range tests {
+		target, err := os.Stat(tc.target)
+		if err != nil {
+			t.Fatalf("os.Stat(%q) got err: %v", tc.target, err)
+		}
+		if got := pointsToAncestor(tc.dir, target); got != tc.want {
+			t.Errorf("%s: pointsToAncestor(%q, %q) = %v, want %v", tc.desc, tc.dir, tc.target, got, tc.want)
+		}
 	}
 
-	// Test doesSymlinkPointToDir.
-	gotDirSymlink, err := doesSymlinkPointToDir("", dirSymlink)
-	if err != nil {
-		t.Errorf("doesSymlinkPointToDir(\"\", %q) got err: %v", dirSymlink, err)
+	// An ancestor reached through a symlink is detected by inode.
+	dirSymlink := filepath.Join(siblingDir, "link")
+	if err := os.Symlink(nestedDir, dirSymlink); err != nil {
+		t.Fatalf("os.Symlink(%q, %q) got err: %v", nestedDir, dirSymlink, err)
 	}
-	if gotDirSymlink == false {
-		t.Errorf("doesSymlinkPointToDir(\"\", %q) = false, want true", dirSymlink)
+	target, _ := os.Stat(siblingDir)
+	if !pointsToAncestor(dirSymlink, target) {
+		t.Errorf("pointsToAncestor(%q, %q) = false, want true", dirSymlink, siblingDir)
+	}
+}
+
+func TestSortListFileEntriesWithNewline(t *testing.T) {
+	entries := []*listfilepb.ListFileEntry{
+		fileInfoEntry("dir/file\nwith newline", 1, 10),
+		dirInfoEntry("dir/\n"),
+		fileInfoEntry("dir/file", 2, 20),
+	}
+	if err := sortListFileEntries(entries); err != nil {
+		t.Fatalf("sortListFileEntries got err: %v", err)
+	}
+	wantPaths := []string{"dir/\n", "dir/file", "dir/file\nwith newline"}
+	var buf bytes.Buffer
+	for i, entry := range entries {
+		if p, _ := getPath(entry); p != wantPaths[i] {
+			t.Errorf("sorted entry %d got path %q, want %q", i, p, wantPaths[i])
+		}
+		if err := writeProtobuf(&buf, entry); err != nil {
+			t.Fatalf("writeProtobuf(%v) got err: %v", entry, err)
+		}
 	}
 
-	gotFileSymlink, err := doesSymlinkPointToDir("", fileSymlink)
-	if err != nil {
-		t.Errorf("doesSymlinkPointToDir(\"\", %q) got err: %v", fileSymlink, err)
+	// The raw names survive a round trip through the list file.
+	for _, want := range entries {
+		var got listfilepb.ListFileEntry
+		if err := parseProtobuf(&buf, &got); err != nil {
+			t.Fatalf("parseProtobuf got err: %v", err)
+		}
+		if !proto.Equal(&got, want) {
+			t.Errorf("parseProtobuf got %v, want %v", &got, want)
+		}
 	}
-	if gotFileSymlink == true {
-		t.Errorf("doesSymlinkPointToDir(\"\", %q) = true, want false", fileSymlink)
+}
+
+func TestNameCollisions(t *testing.T) {
+	defer common.SetDstNameNormalization("")
+	if err := common.SetDstNameNormalization("nfc,lower"); err != nil {
+		t.Fatalf("SetDstNameNormalization() got err: %v", err)
+	}
+	names := []string{"caf\u00e9", "cafe\u0301", "Data", "data", "unique"}
+	want := []string{"/d/Data", "/d/cafe\u0301", "/d/caf\u00e9", "/d/data"}
+	if got := nameCollisions("/d", names); !reflect.DeepEqual(got, want) {
+		t.Errorf("nameCollisions(%q) = %q, want %q", names, got, want)
+	}
+}
+
+func TestListingFileMetadataMaxFileBytes(t *testing.T) {
+	md := &listingFileMetadata{}
+	md.addFile(10)
+	md.addFile(30)
+	md.addFile(0)
+	md2 := &listingFileMetadata{}
+	md2.addFile(20)
+	md.add(md2)
+	if md.files != 4 || md.bytes != 60 || md.maxFileBytes != 30 {
+		t.Errorf("got files %d, bytes %d, maxFileBytes %d, want 4, 60, 30", md.files, md.bytes, md.maxFileBytes)
+	}
+	md3 := &listingFileMetadata{}
+	md3.addFile(50)
+	md.add(md3)
+	if md.maxFileBytes != 50 {
+		t.Errorf("got maxFileBytes %d, want 50", md.maxFileBytes)
+	}
+}
+
+func TestListFileWriterGzip(t *testing.T) {
+	defer func(b bool) { *gzipListFiles = b }(*gzipListFiles)
+	entries := []*listfilepb.ListFileEntry{
+		fileInfoEntry("dir/file", 123456, 5),
+		{Entry: &listfilepb.ListFileEntry_DirectoryInfo{DirectoryInfo: &listfilepb.DirectoryInfo{Path: "dir/subdir"}}},
+	}
+	for _, gzipped := range []bool{false, true} {
+		*gzipListFiles = gzipped
+		mockCtrl := gomock.NewController(t)
+		mockGCS := gcloud.NewMockGCS(mockCtrl)
+		objectW := &common.StringWriteCloser{}
+		mockGCS.EXPECT().NewWriterWithCondition(context.Background(), "bucket", "object", gomock.Any()).Return(objectW)
+
+		w := listFileWriter(context.Background(), mockGCS, "bucket", "object", 0, 0)
+		for _, entry := range entries {
+			if err := writeListFileEntry(w, entry); err != nil {
+				t.Fatalf("gzip %v: writeListFileEntry(%v) got err: %v", gzipped, entry, err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("gzip %v: Close() got err: %v", gzipped, err)
+		}
+		mockCtrl.Finish()
+
+		written := objectW.WrittenString()
+		if gzipped {
+			zr, err := gzip.NewReader(strings.NewReader(written))
+			if err != nil {
+				t.Fatalf("gzip.NewReader() got err: %v", err)
+			}
+			if _, err := ioutil.ReadAll(zr); err != nil {
+				t.Errorf("reading the gzipped list file got err: %v", err)
+			}
+		}
+
+		// The reader detects and decompresses gzipped list files.
+		lr := newListFileReader(strings.NewReader(written))
+		for _, want := range entries {
+			got := &listfilepb.ListFileEntry{}
+			if err := lr.next(got); err != nil {
+				t.Fatalf("gzip %v: next() got err: %v", gzipped, err)
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("gzip %v: next() got %v, want %v", gzipped, got, want)
+			}
+		}
+		if err := lr.next(&listfilepb.ListFileEntry{}); err != io.EOF {
+			t.Errorf("gzip %v: next() at the end got err %v, want io.EOF", gzipped, err)
+		}
 	}
 }
@@ -0,0 +1,266 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	agentcommon "github.com/GoogleCloudPlatform/cloud-ingest/agent/common"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+
+	listfilepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+var (
+	listIndexDir = flag.String("list-index-dir", "", "A local directory list tasks checkpoint the directories they've listed to. A redelivered list task, for example one which timed out near completion, reads the directories it already listed from its checkpoint instead of listing them again, unless they've been modified since. If empty, list tasks aren't checkpointed.")
+	listIndexTTL = flag.Duration("list-index-ttl", 1*time.Hour, "How long after its last update the checkpoint of a list task in list-index-dir is used by a redelivered task.")
+)
+
+// listIndexHeader is the first record of a list index file.
+type listIndexHeader struct {
+	// Fingerprint identifies the list spec and the agent settings the listing
+	// depends on. An index with a different fingerprint is discarded.
+	Fingerprint string
+}
+
+// indexedDir is a directory which was listed whole, recorded in a list index.
+// The counts are those of the directory's listingFileMetadata.
+type indexedDir struct {
+	Path    string
+	ModTime int64    // The directory's mtime in nanoseconds, before it was listed.
+	Entries [][]byte // The directory's marshaled list file entries.
+	Dirs    []string // The directories discovered in the directory.
+
+	Files, Bytes, MaxFileBytes, DirsDiscovered int64
+	SymlinksSkipped, SymlinksFollowed          int64
+	FilesSkippedByMTime                        int64
+	NameCollisions                             []string
+}
+
+// listIndex is a local checkpoint of a list task, which records each directory
+// the task listed whole. If the task is redelivered, the directories indexed
+// by the previous attempt whose mtime hasn't changed are replayed from the
+// index, instead of being listed from the file system. A directory's mtime
+// changes when entries are added to, removed from or renamed within it, but
+// not when a file in it is modified in place. Such a file is listed with its
+// old size and mtime, which its copy task detects.
+//
+// A nil listIndex indexes nothing. lookup may be called concurrently, but add,
+// close and remove must only be called from a single goroutine.
+type listIndex struct {
+	path string
+	prev map[string]*indexedDir // The directories indexed by the previous attempt.
+	f    *os.File
+	enc  *gob.Encoder
+}
+
+// listIndexFingerprint returns the fingerprint of a list task's index.
+func listIndexFingerprint(listSpec *taskpb.ListSpec, settings listSettings) (string, error) {
+	specBytes, err := proto.Marshal(listSpec)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write(specBytes)
+	fmt.Fprintf(h, "%q %q %q %q %v %v", includeGlobs, excludeGlobs, symlinkPolicy(), common.EmptyDirMarker(), common.DstNamesNormalized(), settings.includeDirs)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// openListIndex reads the index of the list task named taskName left by a
+// previous attempt, if it's recent and matches the task, and starts a new
+// index for this attempt. It returns nil if list-index-dir isn't set. Since
+// the index is only an optimization, failures are logged and nil is returned.
+func openListIndex(taskName string, listSpec *taskpb.ListSpec, settings listSettings) *listIndex {
+	if *listIndexDir == "" || taskName == "" || listSpec.SrcIsManifest {
+		return nil
+	}
+	fingerprint, err := listIndexFingerprint(listSpec, settings)
+	if err != nil {
+		glog.Warningf("Not indexing list task %v, fingerprint err: %v", taskName, err)
+		return nil
+	}
+	name := sha256.Sum256([]byte(taskName))
+	x := &listIndex{path: filepath.Join(*listIndexDir, hex.EncodeToString(name[:])+".idx")}
+	x.prev = readListIndex(x.path, fingerprint)
+	if len(x.prev) > 0 {
+		glog.Infof("Resuming list task %v from %d directories in its index %v", taskName, len(x.prev), x.path)
+	}
+
+	if x.f, err = os.Create(x.path); err != nil {
+		glog.Warningf("Not indexing list task %v, err: %v", taskName, err)
+		return &listIndex{prev: x.prev}
+	}
+	x.enc = gob.NewEncoder(x.f)
+	if err := x.enc.Encode(&listIndexHeader{Fingerprint: fingerprint}); err != nil {
+		glog.Warningf("Not indexing list task %v, err: %v", taskName, err)
+		x.close()
+	}
+	return x
+}
+
+// readListIndex returns the directories of the index at path, or nil if there
+// is no index, it's older than the list-index-ttl, or its fingerprint doesn't
+// match. An index whose last record was cut short, for example because the
+// agent was killed, returns the directories before that record.
+func readListIndex(path, fingerprint string) map[string]*indexedDir {
+	fi, err := os.Stat(path)
+	if err != nil || time.Since(fi.ModTime()) > *listIndexTTL {
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		glog.Warningf("Failed to open list index %v, err: %v", path, err)
+		return nil
+	}
+	defer f.Close()
+	dec := gob.NewDecoder(f)
+	var header listIndexHeader
+	if err := dec.Decode(&header); err != nil || header.Fingerprint != fingerprint {
+		return nil
+	}
+	dirs := make(map[string]*indexedDir)
+	for {
+		d := &indexedDir{}
+		if err := dec.Decode(d); err != nil {
+			return dirs
+		}
+		dirs[d.Path] = d
+	}
+}
+
+// dirModTime returns the mtime of dir in nanoseconds.
+func dirModTime(dir string) (int64, error) {
+	fi, err := os.Stat(agentcommon.OSPath(dir))
+	if err != nil {
+		return 0, err
+	}
+	return fi.ModTime().UnixNano(), nil
+}
+
+// lookup returns the directory dir indexed by the previous attempt, if dir
+// still has the same mtime. Otherwise it returns nil.
+func (x *listIndex) lookup(dir string) *indexedDir {
+	if x == nil {
+		return nil
+	}
+	d, ok := x.prev[dir]
+	if !ok {
+		return nil
+	}
+	if modTime, err := dirModTime(dir); err != nil || modTime != d.ModTime {
+		return nil
+	}
+	return d
+}
+
+// add indexes r, a directory listed whole whose mtime was modTime before it
+// was listed. Failures are logged, and stop the indexing.
+func (x *listIndex) add(r *listedDir, modTime int64) {
+	if x == nil || x.enc == nil {
+		return
+	}
+	d := &indexedDir{
+		Path:                r.dirInfo.Path,
+		ModTime:             modTime,
+		Files:               r.listMD.files,
+		Bytes:               r.listMD.bytes,
+		MaxFileBytes:        r.listMD.maxFileBytes,
+		DirsDiscovered:      r.listMD.dirsDiscovered,
+		SymlinksSkipped:     r.listMD.symlinksSkipped,
+		SymlinksFollowed:    r.listMD.symlinksFollowed,
+		FilesSkippedByMTime: r.listMD.filesSkippedByMTime,
+		NameCollisions:      r.listMD.nameCollisions,
+	}
+	for _, entry := range r.entries {
+		b, err := proto.Marshal(entry)
+		if err != nil {
+			glog.Warningf("Failed to index directory %v, err: %v", d.Path, err)
+			x.close()
+			return
+		}
+		d.Entries = append(d.Entries, b)
+	}
+	for _, dirInfo := range r.dirStore.DirectoryInfos() {
+		d.Dirs = append(d.Dirs, dirInfo.Path)
+	}
+	if err := x.enc.Encode(d); err != nil {
+		glog.Warningf("Failed to index directory %v, err: %v", d.Path, err)
+		x.close()
+	}
+}
+
+// replay returns the list file entries of d, and adds its discovered
+// directories to dirStore and its counts to listMD, as listing it would.
+func (d *indexedDir) replay(dirStore *DirectoryInfoStore, listMD *listingFileMetadata) ([]*listfilepb.ListFileEntry, error) {
+	entries := make([]*listfilepb.ListFileEntry, 0, len(d.Entries))
+	for _, b := range d.Entries {
+		entry := &listfilepb.ListFileEntry{}
+		if err := proto.Unmarshal(b, entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	for _, dir := range d.Dirs {
+		if err := dirStore.Add(listfilepb.DirectoryInfo{Path: dir}); err != nil {
+			return nil, err
+		}
+	}
+	listMD.add(&listingFileMetadata{
+		files:               d.Files,
+		bytes:               d.Bytes,
+		maxFileBytes:        d.MaxFileBytes,
+		dirsDiscovered:      d.DirsDiscovered,
+		symlinksSkipped:     d.SymlinksSkipped,
+		symlinksFollowed:    d.SymlinksFollowed,
+		filesSkippedByMTime: d.FilesSkippedByMTime,
+		nameCollisions:      d.NameCollisions,
+		dirsFromIndex:       1,
+	})
+	return entries, nil
+}
+
+// close stops indexing, keeping the index for a redelivery of the task.
+func (x *listIndex) close() {
+	if x == nil || x.f == nil {
+		return
+	}
+	if err := x.f.Close(); err != nil {
+		glog.Warningf("Failed to close list index %v, err: %v", x.path, err)
+	}
+	x.f = nil
+	x.enc = nil
+}
+
+// remove stops indexing and deletes the index, once the task succeeded.
+func (x *listIndex) remove() {
+	if x == nil || x.path == "" {
+		return
+	}
+	x.close()
+	if err := os.Remove(x.path); err != nil && !os.IsNotExist(err) {
+		glog.Warningf("Failed to remove list index %v, err: %v", x.path, err)
+	}
+}
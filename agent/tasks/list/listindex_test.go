@@ -0,0 +1,151 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+
+	listfilepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+// listWithIndex lists spec using the index of the task, as an attempt which
+// fails after listing would, and returns the list file and metadata.
+func listWithIndex(t *testing.T, spec *taskpb.ListSpec) (string, *listingFileMetadata) {
+	settings := listSettings{listFileSizeThreshold: 10000, maxDirBytes: 500000, includeDirs: true}
+	settings.index = openListIndex("task", spec, settings)
+	defer settings.index.close()
+	dirStore := NewDirectoryInfoStore()
+	for _, dir := range spec.SrcDirectories {
+		if err := dirStore.Add(listfilepb.DirectoryInfo{Path: dir}); err != nil {
+			t.Fatalf("dirStore.Add(%q) got err: %v", dir, err)
+		}
+	}
+	var w bytes.Buffer
+	listMD, err := processDirectories(context.Background(), nil, &w, dirStore, settings, *spec, nil)
+	if err != nil {
+		t.Fatalf("processDirectories() got err: %v", err)
+	}
+	return w.String(), listMD
+}
+
+func TestListIndexResume(t *testing.T) {
+	defer func(dir string) { *listIndexDir = dir }(*listIndexDir)
+	*listIndexDir = common.CreateTmpDir("", "test-list-index-")
+	defer os.RemoveAll(*listIndexDir)
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	subDir := filepath.Join(tmpDir, "sub")
+	if err := os.Mkdir(subDir, 0755); err != nil {
+		t.Fatalf("Mkdir(%q) got err: %v", subDir, err)
+	}
+	for _, p := range []string{filepath.Join(tmpDir, "a"), filepath.Join(subDir, "b")} {
+		if err := ioutil.WriteFile(p, []byte(fileContent), 0644); err != nil {
+			t.Fatalf("WriteFile(%q) got err: %v", p, err)
+		}
+	}
+	spec := &taskpb.ListSpec{SrcDirectories: []string{tmpDir}, RootDirectory: tmpDir}
+
+	wantList, wantMD := listWithIndex(t, spec)
+	if wantMD.dirsFromIndex != 0 || wantMD.dirsListed != 2 {
+		t.Fatalf("first attempt got dirsFromIndex %d, dirsListed %d, want 0, 2", wantMD.dirsFromIndex, wantMD.dirsListed)
+	}
+
+	// The redelivered task replays both directories.
+	gotList, gotMD := listWithIndex(t, spec)
+	if gotMD.dirsFromIndex != 2 {
+		t.Errorf("second attempt got dirsFromIndex %d, want 2", gotMD.dirsFromIndex)
+	}
+	if gotList != wantList {
+		t.Errorf("second attempt wrote %q, want %q", gotList, wantList)
+	}
+	gotMD.dirsFromIndex = 0
+	if !(gotMD.files == wantMD.files && gotMD.bytes == wantMD.bytes && gotMD.maxFileBytes == wantMD.maxFileBytes &&
+		gotMD.dirsDiscovered == wantMD.dirsDiscovered && gotMD.dirsListed == wantMD.dirsListed) {
+		t.Errorf("second attempt got listMD %+v, want %+v", gotMD, wantMD)
+	}
+
+	// Adding a file to sub invalidates only sub.
+	newFile := filepath.Join(subDir, "c")
+	if err := ioutil.WriteFile(newFile, []byte(fileContent), 0644); err != nil {
+		t.Fatalf("WriteFile(%q) got err: %v", newFile, err)
+	}
+	future := time.Now().Add(time.Hour)
+	if err := os.Chtimes(subDir, future, future); err != nil {
+		t.Fatalf("Chtimes(%q) got err: %v", subDir, err)
+	}
+	_, gotMD = listWithIndex(t, spec)
+	if gotMD.dirsFromIndex != 1 || gotMD.files != wantMD.files+1 {
+		t.Errorf("third attempt got dirsFromIndex %d, files %d, want 1, %d", gotMD.dirsFromIndex, gotMD.files, wantMD.files+1)
+	}
+
+	// A different spec doesn't use the index.
+	otherSpec := &taskpb.ListSpec{SrcDirectories: []string{tmpDir}, RootDirectory: tmpDir, MinMtime: 1}
+	if _, gotMD = listWithIndex(t, otherSpec); gotMD.dirsFromIndex != 0 {
+		t.Errorf("different spec got dirsFromIndex %d, want 0", gotMD.dirsFromIndex)
+	}
+}
+
+func TestListIndexTTL(t *testing.T) {
+	defer func(dir string, ttl time.Duration) { *listIndexDir, *listIndexTTL = dir, ttl }(*listIndexDir, *listIndexTTL)
+	*listIndexDir = common.CreateTmpDir("", "test-list-index-")
+	defer os.RemoveAll(*listIndexDir)
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	spec := &taskpb.ListSpec{SrcDirectories: []string{tmpDir}, RootDirectory: tmpDir}
+
+	listWithIndex(t, spec)
+	*listIndexTTL = -time.Second
+	if _, gotMD := listWithIndex(t, spec); gotMD.dirsFromIndex != 0 {
+		t.Errorf("expired index got dirsFromIndex %d, want 0", gotMD.dirsFromIndex)
+	}
+}
+
+func TestListIndexRemove(t *testing.T) {
+	defer func(dir string) { *listIndexDir = dir }(*listIndexDir)
+	*listIndexDir = common.CreateTmpDir("", "test-list-index-")
+	defer os.RemoveAll(*listIndexDir)
+
+	x := openListIndex("task", &taskpb.ListSpec{}, listSettings{})
+	if x == nil {
+		t.Fatal("openListIndex() = nil, want an index")
+	}
+	x.remove()
+	if files, _ := ioutil.ReadDir(*listIndexDir); len(files) != 0 {
+		t.Errorf("remove() left %d files in the index dir, want 0", len(files))
+	}
+
+	// A nil index is safe to use.
+	*listIndexDir = ""
+	x = openListIndex("task", &taskpb.ListSpec{}, listSettings{})
+	if x != nil {
+		t.Errorf("openListIndex() = %v, want nil", x)
+	}
+	if d := x.lookup("/dir"); d != nil {
+		t.Errorf("lookup() = %v, want nil", d)
+	}
+	x.add(&listedDir{}, 1)
+	x.close()
+	x.remove()
+}
@@ -25,6 +25,9 @@ import (
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/stats"
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
+
 	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
 )
 
@@ -39,11 +42,21 @@ type ListHandlerV3 struct {
 	resumableChunkSize    int
 	listFileSizeThreshold int
 	allowedDirBytes       int
-	statsTracker          *stats.Tracker // For tracking bytes sent/copied.
+	statsTracker          *stats.Tracker    // For tracking bytes sent/copied.
+	reconcileHandler      *ReconcileHandler // Handles the reconcile tasks sent on the list subscription.
 }
 
 // NewListHandlerV3 returns a new ListHandlerV3.
 func NewListHandlerV3(storageClient *storage.Client, st *stats.Tracker) *ListHandlerV3 {
+	if err := common.ValidateSymlinkPolicy(common.SymlinkPolicy()); err != nil {
+		glog.Fatalf("Invalid symlink-policy flag: %v", err)
+	}
+	if err := validateListFileFormat(*listFileFormat); err != nil {
+		glog.Fatalf("Invalid list-file-format flag: %v", err)
+	}
+	if err := validateRestatPolicy(*listRestatPolicy); err != nil {
+		glog.Fatalf("Invalid list-restat-policy flag: %v", err)
+	}
 	// Convert maxMemoryForListingDirectories to bytes and divide it equally between
 	// the list task processing threads.
 	allowedDirBytes := *maxMemoryForListingDirectories * 1024 * 1024 / *NumberConcurrentListTasks
@@ -52,11 +65,15 @@ func NewListHandlerV3(storageClient *storage.Client, st *stats.Tracker) *ListHan
 		resumableChunkSize:    *listTaskChunkSize,
 		listFileSizeThreshold: *listFileSizeThreshold,
 		allowedDirBytes:       allowedDirBytes,
+		reconcileHandler:      NewReconcileHandler(storageClient),
 		statsTracker:          st,
 	}
 }
 
-func (h *ListHandlerV3) Do(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg, _ time.Time) *taskpb.TaskRespMsg {
+func (h *ListHandlerV3) Do(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg, reqStart time.Time) *taskpb.TaskRespMsg {
+	if taskReqMsg.Spec.GetReconcileSpec() != nil {
+		return h.reconcileHandler.Do(ctx, taskReqMsg, reqStart)
+	}
 	listSpec := taskReqMsg.Spec.GetListSpec()
 	if listSpec == nil {
 		err := errors.New("ListHandlerV3.Do taskReqMsg.Spec is not ListSpec")
@@ -69,16 +86,21 @@ func (h *ListHandlerV3) Do(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg, _
 
 	// Write list file BEFORE the unexplored dirs file. This ordering is important to ensure that if
 	// two agents are processing the same task, one will succeed and the other will fail.
-	listFileW := gcsWriterWithCondition(ctx, h.gcs, listSpec.DstListResultBucket, listSpec.DstListResultObject, listSpec.ListResultExpectedGenerationNum, h.resumableChunkSize)
-	listBtw := h.statsTracker.NewListByteTrackingWriter(listFileW, true)
+	listFileW := listFileWriter(ctx, h.gcs, listSpec.DstListResultBucket, listSpec.DstListResultObject, listSpec.ListResultExpectedGenerationNum, h.resumableChunkSize)
+	listBtw := h.statsTracker.NewListByteTrackingWriter(taskReqMsg.JobrunRelRsrcName, listFileW, true)
 
 	settings := listSettings{
 		listFileSizeThreshold: h.listFileSizeThreshold,
 		maxDirBytes:           h.allowedDirBytes,
 		includeDirs:           true,
 		includeDirHeader:      true,
+		dirParallelism:        *listDirParallelism,
+		dirMaxEntries:         *listDirMaxEntries,
+		jobRun:                taskReqMsg.JobrunRelRsrcName,
 	}
-	listMD, unlistedDirs, err := listDirectoriesAndWriteResults(listBtw, listSpec, settings, h.statsTracker)
+	settings.index = openListIndex(taskReqMsg.TaskRelRsrcName, listSpec, settings)
+	defer settings.index.close()
+	listMD, unlistedDirs, err := listDirectoriesAndWriteResults(ctx, h.gcs, listBtw, listSpec, settings, h.statsTracker)
 	if err != nil {
 		listFileW.CloseWithError(err)
 		if os.IsNotExist(err) {
@@ -93,8 +115,8 @@ func (h *ListHandlerV3) Do(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg, _
 		return common.BuildTaskRespMsg(taskReqMsg, nil, log, err)
 	}
 
-	unexploredDirsW := gcsWriterWithCondition(ctx, h.gcs, listSpec.DstListResultBucket, listSpec.DstUnexploredDirsObject, listSpec.UnexploredDirsExpectedGenerationNum, h.resumableChunkSize)
-	unexploredBtw := h.statsTracker.NewListByteTrackingWriter(unexploredDirsW, false)
+	unexploredDirsW := listFileWriter(ctx, h.gcs, listSpec.DstListResultBucket, listSpec.DstUnexploredDirsObject, listSpec.UnexploredDirsExpectedGenerationNum, h.resumableChunkSize)
+	unexploredBtw := h.statsTracker.NewListByteTrackingWriter(taskReqMsg.JobrunRelRsrcName, unexploredDirsW, false)
 	if err = writeDirectories(unexploredBtw, unlistedDirs); err != nil {
 		unexploredDirsW.CloseWithError(err)
 		return common.BuildTaskRespMsg(taskReqMsg, nil, log, err)
@@ -105,6 +127,22 @@ func (h *ListHandlerV3) Do(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg, _
 	}
 
 	setListLog(log, listMD)
+	settings.index.remove()
+
+	return common.BuildTaskRespMsg(taskReqMsg, resumeListSpec(taskReqMsg.Spec, listMD), log, nil)
+}
 
-	return common.BuildTaskRespMsg(taskReqMsg, nil, log, nil)
+// resumeListSpec returns the spec of the task continuing the directory the listing yielded
+// partway through, or nil if every listed directory was listed whole. The discovered directories
+// are in the unexplored dirs file, so the continuing task only lists the partial directory.
+func resumeListSpec(spec *taskpb.Spec, listMD *listingFileMetadata) *taskpb.Spec {
+	if listMD.partialDir == "" {
+		return nil
+	}
+	respSpec := proto.Clone(spec).(*taskpb.Spec)
+	listSpec := respSpec.GetListSpec()
+	listSpec.SrcDirectories = []string{listMD.partialDir}
+	listSpec.ResumeDir = listMD.partialDir
+	listSpec.ResumeAfter = listMD.partialDirResumeAfter
+	return respSpec
 }
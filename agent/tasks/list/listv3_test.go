@@ -28,6 +28,7 @@ import (
 	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
 	"github.com/golang/mock/gomock"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
 
 	listfilepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
 	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
@@ -164,7 +165,7 @@ func TestListV3DirFoundThenDeleted(t *testing.T) {
 		t.Fatalf("DirectoryInfoStore.Add() got error: %v", err)
 	}
 	// Have to test helper method to avoid race conditions.
-	listMD, err := processDirectories(listWriter, dirStore, listSettings{listFileSizeThreshold: 10000, maxDirBytes: 500000, includeDirs: true, includeDirHeader: true}, *taskReqMsg.Spec.GetListSpec(), nil)
+	listMD, err := processDirectories(context.Background(), nil, listWriter, dirStore, listSettings{listFileSizeThreshold: 10000, maxDirBytes: 500000, includeDirs: true, includeDirHeader: true}, *taskReqMsg.Spec.GetListSpec(), nil)
 	if err != nil {
 		t.Errorf("processDirectories() got error %v", err)
 	}
@@ -262,9 +263,10 @@ func TestListV3SuccessFlatDir(t *testing.T) {
 	wantLog := &taskpb.Log{
 		Log: &taskpb.Log_ListLog{
 			ListLog: &taskpb.ListLog{
-				FilesFound: 10,
-				BytesFound: 100,
-				DirsListed: 1,
+				FilesFound:   10,
+				BytesFound:   100,
+				MaxFileBytes: 10,
+				DirsListed:   1,
 			},
 		},
 	}
@@ -273,33 +275,223 @@ func TestListV3SuccessFlatDir(t *testing.T) {
 	}
 }
 
-func TestListV3FailsFileWithNewline(t *testing.T) {
+func TestListV3YieldsPartwayThroughDir(t *testing.T) {
+	defer func(n int) { *listDirMaxEntries = n }(*listDirMaxEntries)
+	*listDirMaxEntries = 2
+
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	for i := 0; i < 5; i++ {
+		createFile(t, tmpDir, "test-file-", fileContent)
+	}
+
+	ctx := context.Background()
+	st := stats.NewTracker(ctx)
+	taskRelRsrcName := "projects/project_A/jobConfigs/config_B/jobRuns/run_C/tasks/task_D"
+	taskReqMsg := testListV3TaskReqMsg(taskRelRsrcName, []string{tmpDir}, tmpDir)
+	wantRemaining := []int64{3, 1, 0}
+	var files int64
+	for i, remaining := range wantRemaining {
+		mockCtrl := gomock.NewController(t)
+		mockGCS := gcloud.NewMockGCS(mockCtrl)
+		gomock.InOrder(
+			mockGCS.EXPECT().NewWriterWithCondition(
+				context.Background(), testBucket, testObject, gomock.Any()).Return(&common.StringWriteCloser{}),
+			mockGCS.EXPECT().NewWriterWithCondition(
+				context.Background(), testBucket, unexplored, gomock.Any()).Return(&common.StringWriteCloser{}),
+		)
+		h := ListHandlerV3{gcs: mockGCS, listFileSizeThreshold: 10000, allowedDirBytes: 5 * 1024 * 1024, statsTracker: st}
+		taskRespMsg := h.Do(ctx, taskReqMsg, time.Now())
+		mockCtrl.Finish()
+		CheckSuccessMsg(taskRelRsrcName, taskRespMsg, t)
+
+		ll := taskRespMsg.Log.GetListLog()
+		files += ll.FilesFound
+		if ll.PartialDirEntriesRemaining != remaining {
+			t.Errorf("task %d: got PartialDirEntriesRemaining %d, want %d", i, ll.PartialDirEntriesRemaining, remaining)
+		}
+		if remaining == 0 {
+			if taskRespMsg.RespSpec != nil || ll.PartialDir != "" {
+				t.Errorf("task %d: got RespSpec %v, PartialDir %q, want nil, \"\"", i, taskRespMsg.RespSpec, ll.PartialDir)
+			}
+			break
+		}
+		listSpec := taskRespMsg.RespSpec.GetListSpec()
+		if ll.PartialDir != tmpDir || ll.PartialDirEntriesListed != 2 || listSpec.GetResumeDir() != tmpDir || listSpec.GetResumeAfter() == "" {
+			t.Fatalf("task %d: got PartialDir %q, PartialDirEntriesListed %d, RespSpec %v, want %q, 2 and a resume cursor", i, ll.PartialDir, ll.PartialDirEntriesListed, taskRespMsg.RespSpec, tmpDir)
+		}
+		taskReqMsg = proto.Clone(taskReqMsg).(*taskpb.TaskReqMsg)
+		taskReqMsg.Spec = taskRespMsg.RespSpec
+	}
+	if files != 5 {
+		t.Errorf("got %d files found by all tasks, want 5", files)
+	}
+}
+
+func TestListV3SkipsFilesBeforeMinMTime(t *testing.T) {
+	var expectedListResult, expectedDirsResult bytes.Buffer
+
 	tmpDir := common.CreateTmpDir("", "test-list-agent-")
 	defer os.RemoveAll(tmpDir)
+	minMTime := time.Now().Add(-time.Hour)
+	oldMTime := minMTime.Add(-time.Hour)
 
+	// Old directories are still listed, so new files within them are found.
+	nestedTmpDir := common.CreateTmpDir(tmpDir, "sub-dir-")
+	if err := os.Chtimes(nestedTmpDir, oldMTime, oldMTime); err != nil {
+		t.Fatalf("Chtimes(%q) got err: %v", nestedTmpDir, err)
+	}
+	dirEntries := []*listfilepb.ListFileEntry{dirInfoEntry(nestedTmpDir)}
+	for i := 0; i < 2; i++ {
+		dirEntries = append(dirEntries, createFile(t, tmpDir, "new-file-", fileContent))
+		oldFile := common.CreateTmpFile(tmpDir, "old-file-", fileContent)
+		if err := os.Chtimes(oldFile, oldMTime, oldMTime); err != nil {
+			t.Fatalf("Chtimes(%q) got err: %v", oldFile, err)
+		}
+	}
+	writeEntry(t, &expectedListResult, dirHeaderEntry(tmpDir, int64(len(dirEntries))))
+	sortAndWriteEntries(t, &expectedListResult, dirEntries)
+	sortAndWriteEntries(t, &expectedDirsResult, []*listfilepb.ListFileEntry{dirInfoEntry(nestedTmpDir)})
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	listWriter := &common.StringWriteCloser{}
+	dirsWriter := &common.StringWriteCloser{}
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	gomock.InOrder(
+		mockGCS.EXPECT().NewWriterWithCondition(
+			context.Background(), testBucket, testObject, gomock.Any()).Return(listWriter),
+		mockGCS.EXPECT().NewWriterWithCondition(
+			context.Background(), testBucket, unexplored, gomock.Any()).Return(dirsWriter),
+	)
+	ctx := context.Background()
+	st := stats.NewTracker(ctx)
+	h := ListHandlerV3{gcs: mockGCS, listFileSizeThreshold: 1, allowedDirBytes: 5 * 1024 * 1024, statsTracker: st}
+	taskRelRsrcName := "projects/project_A/jobConfigs/config_B/jobRuns/run_C/tasks/task_D"
+	taskReqMsg := testListV3TaskReqMsg(taskRelRsrcName, []string{tmpDir}, tmpDir)
+	taskReqMsg.Spec.GetListSpec().MinMtime = minMTime.Unix()
+	taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+	CheckSuccessMsg(taskRelRsrcName, taskRespMsg, t)
+	if listWriter.WrittenString() != expectedListResult.String() {
+		t.Errorf("got list file: \"%s\", want: \"%s\"",
+			listWriter.WrittenString(), expectedListResult.String())
+	}
+	if dirsWriter.WrittenString() != expectedDirsResult.String() {
+		t.Errorf("got unexplored dirs file: \"%s\", want: \"%s\"",
+			dirsWriter.WrittenString(), expectedDirsResult.String())
+	}
+
+	wantLog := &taskpb.Log{
+		Log: &taskpb.Log_ListLog{
+			ListLog: &taskpb.ListLog{
+				FilesFound:          2,
+				BytesFound:          20,
+				MaxFileBytes:        10,
+				DirsFound:           1,
+				DirsListed:          1,
+				DirsNotListed:       1,
+				FilesSkippedByMtime: 2,
+			},
+		},
+	}
+	if !proto.Equal(taskRespMsg.Log, wantLog) {
+		t.Errorf("log = %+v, want: %+v", taskRespMsg.Log, wantLog)
+	}
+}
+
+func TestListV3DefersDirsBeyondMaxDepth(t *testing.T) {
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	nestedTmpDir := common.CreateTmpDir(tmpDir, "sub-dir-")
+	nestedTmpDir2 := common.CreateTmpDir(nestedTmpDir, "sub-dir-")
+
+	tests := []struct {
+		maxDepth     int64
+		wantDeferred string
+		wantListed   int64
+	}{
+		{0, nestedTmpDir, 1},
+		{1, nestedTmpDir2, 2},
+	}
+	for _, tc := range tests {
+		var expectedDirsResult bytes.Buffer
+		writeEntry(t, &expectedDirsResult, dirInfoEntry(tc.wantDeferred))
+
+		mockCtrl := gomock.NewController(t)
+		listWriter := &common.StringWriteCloser{}
+		dirsWriter := &common.StringWriteCloser{}
+		mockGCS := gcloud.NewMockGCS(mockCtrl)
+		gomock.InOrder(
+			mockGCS.EXPECT().NewWriterWithCondition(
+				context.Background(), testBucket, testObject, gomock.Any()).Return(listWriter),
+			mockGCS.EXPECT().NewWriterWithCondition(
+				context.Background(), testBucket, unexplored, gomock.Any()).Return(dirsWriter),
+		)
+		ctx := context.Background()
+		st := stats.NewTracker(ctx)
+		h := ListHandlerV3{gcs: mockGCS, listFileSizeThreshold: 10000, allowedDirBytes: 5 * 1024 * 1024, statsTracker: st}
+		taskRelRsrcName := "projects/project_A/jobConfigs/config_B/jobRuns/run_C/tasks/task_D"
+		taskReqMsg := testListV3TaskReqMsg(taskRelRsrcName, []string{tmpDir}, tmpDir)
+		taskReqMsg.Spec.GetListSpec().MaxDepth = &wrappers.Int64Value{Value: tc.maxDepth}
+		taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+		CheckSuccessMsg(taskRelRsrcName, taskRespMsg, t)
+		if dirsWriter.WrittenString() != expectedDirsResult.String() {
+			t.Errorf("maxDepth %d: got unexplored dirs file: \"%s\", want: \"%s\"",
+				tc.maxDepth, dirsWriter.WrittenString(), expectedDirsResult.String())
+		}
+
+		wantLog := &taskpb.Log{
+			Log: &taskpb.Log_ListLog{
+				ListLog: &taskpb.ListLog{
+					DirsFound:           tc.wantListed,
+					DirsListed:          tc.wantListed,
+					DirsNotListed:       1,
+					DirsDeferredByDepth: 1,
+				},
+			},
+		}
+		if !proto.Equal(taskRespMsg.Log, wantLog) {
+			t.Errorf("maxDepth %d: log = %+v, want: %+v", tc.maxDepth, taskRespMsg.Log, wantLog)
+		}
+		mockCtrl.Finish()
+	}
+}
+
+func TestListV3SuccessFileWithNewline(t *testing.T) {
+	var expectedListResult bytes.Buffer
+
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+
+	var dirEntries []*listfilepb.ListFileEntry
 	for i := 0; i < 10; i++ {
-		common.CreateTmpFile(tmpDir, "test-file-", fileContent)
+		dirEntries = append(dirEntries, createFile(t, tmpDir, "test-file-", fileContent))
 	}
-	common.CreateTmpFile(tmpDir, "test-file-with-\n-newline", fileContent)
+	dirEntries = append(dirEntries, createFile(t, tmpDir, "test-file-with-\n-newline", fileContent))
+	writeEntry(t, &expectedListResult, dirHeaderEntry(tmpDir, int64(len(dirEntries))))
+	sortAndWriteEntries(t, &expectedListResult, dirEntries)
 
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	writer := &common.StringWriteCloser{}
+	dirsWriter := &common.StringWriteCloser{}
 	mockGCS := gcloud.NewMockGCS(mockCtrl)
-	mockGCS.EXPECT().NewWriterWithCondition(
-		context.Background(), testBucket, testObject, gomock.Any()).Return(writer)
+	gomock.InOrder(
+		mockGCS.EXPECT().NewWriterWithCondition(
+			context.Background(), testBucket, testObject, gomock.Any()).Return(writer),
+		mockGCS.EXPECT().NewWriterWithCondition(
+			context.Background(), testBucket, unexplored, gomock.Any()).Return(dirsWriter),
+	)
 	ctx := context.Background()
 	st := stats.NewTracker(ctx)
 	h := ListHandlerV3{gcs: mockGCS, listFileSizeThreshold: 10000, allowedDirBytes: 5 * 1024 * 1024, statsTracker: st}
 	taskRelRsrcName := "projects/project_A/jobConfigs/config_B/jobRuns/run_C/tasks/task_D"
 	taskReqMsg := testListV3TaskReqMsg(taskRelRsrcName, []string{tmpDir}, tmpDir)
 	taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
-	// TODO(b/111502687): Failing with UNKNOWN_FAILURE is temporary. In the long
-	// term, we will escape file with newlines.
-	CheckFailureWithType(taskRelRsrcName, taskpb.FailureType_UNKNOWN_FAILURE, taskRespMsg, t)
-	if writer.WrittenString() != "" {
+	CheckSuccessMsg(taskRelRsrcName, taskRespMsg, t)
+	if writer.WrittenString() != expectedListResult.String() {
 		t.Errorf("got list file: \"%s\", want: \"%s\"",
-			writer.WrittenString(), "")
+			writer.WrittenString(), expectedListResult.String())
 	}
 }
 
@@ -361,6 +553,7 @@ func TestListV3SuccessNestedDirSmallListFile(t *testing.T) {
 			ListLog: &taskpb.ListLog{
 				FilesFound:    10,
 				BytesFound:    100,
+				MaxFileBytes:  10,
 				DirsFound:     2,
 				DirsListed:    1,
 				DirsNotListed: 2,
@@ -431,10 +624,11 @@ func TestListV3SuccessNestedDirLargeListFile(t *testing.T) {
 	wantLog := &taskpb.Log{
 		Log: &taskpb.Log_ListLog{
 			ListLog: &taskpb.ListLog{
-				FilesFound: 20,
-				BytesFound: 200,
-				DirsFound:  2,
-				DirsListed: 3,
+				FilesFound:   20,
+				BytesFound:   200,
+				MaxFileBytes: 10,
+				DirsFound:    2,
+				DirsListed:   3,
 			},
 		},
 	}
@@ -486,10 +680,11 @@ func TestListV3MakesProgressWhenSrcDirsExceedsMemDirLimit(t *testing.T) {
 	wantLog := &taskpb.Log{
 		Log: &taskpb.Log_ListLog{
 			ListLog: &taskpb.ListLog{
-				FilesFound: 10,
-				BytesFound: 100,
-				DirsFound:  0,
-				DirsListed: 1,
+				FilesFound:   10,
+				BytesFound:   100,
+				MaxFileBytes: 10,
+				DirsFound:    0,
+				DirsListed:   1,
 			},
 		},
 	}
@@ -567,6 +762,7 @@ func TestListV3SuccessNestedDirSmallMemoryLimitListFile(t *testing.T) {
 			ListLog: &taskpb.ListLog{
 				FilesFound:    20,
 				BytesFound:    200,
+				MaxFileBytes:  10,
 				DirsFound:     3,
 				DirsListed:    2,
 				DirsNotListed: 2,
@@ -0,0 +1,153 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	agentcommon "github.com/GoogleCloudPlatform/cloud-ingest/agent/common"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/golang/glog"
+
+	listfilepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+// openManifest opens a manifest, which is either an On-Premises file or a "gs://bucket/object"
+// object.
+func openManifest(ctx context.Context, gcs gcloud.GCS, manifest string) (io.ReadCloser, error) {
+	if !isGCSPath(manifest) {
+		return os.Open(agentcommon.OSPath(manifest))
+	}
+	parts := strings.SplitN(strings.TrimPrefix(manifest, gcsScheme), "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("manifest %q is not a gs://bucket/object path", manifest)
+	}
+	return gcs.NewRangeReader(ctx, parts[0], parts[1], 0, -1)
+}
+
+// readManifest returns the paths listed in a manifest, one per line. Empty lines are ignored,
+// and relative paths are relative to rootDir.
+func readManifest(ctx context.Context, gcs gcloud.GCS, manifest, rootDir string) ([]string, error) {
+	r, err := openManifest(ctx, gcs, manifest)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	var paths []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		p := strings.TrimSuffix(scanner.Text(), "\r")
+		if p == "" {
+			continue
+		}
+		if !filepath.IsAbs(p) && rootDir != "" {
+			p = filepath.Join(rootDir, p)
+		}
+		paths = append(paths, p)
+	}
+	return paths, scanner.Err()
+}
+
+// processManifest is the processDir equivalent for a manifest. It returns an entry for each file
+// in the manifest, sorted by path. Directories in the manifest are added to the given dirStore
+// rather than listed. Files in the manifest that don't exist are recorded in listMD.
+func processManifest(ctx context.Context, gcs gcloud.GCS, manifest string, dirStore *DirectoryInfoStore, listMD *listingFileMetadata, writeDirs bool, filter *globFilter, listSpec *taskpb.ListSpec) ([]*listfilepb.ListFileEntry, error) {
+	paths, err := readManifest(ctx, gcs, manifest, listSpec.RootDirectory)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var entries []*listfilepb.ListFileEntry
+	for _, path := range paths {
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		fileInfo, err := os.Stat(agentcommon.OSPath(path))
+		if os.IsNotExist(err) {
+			glog.Warningf("skipping %q from manifest %q, which was not found", path, manifest)
+			listMD.manifestFilesNotFound = append(listMD.manifestFilesNotFound, path)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if fileInfo.IsDir() {
+			if filter.skipDir(path) {
+				continue
+			}
+			dirInfo := listfilepb.DirectoryInfo{Path: path}
+			if err := dirStore.Add(dirInfo); err != nil {
+				return nil, err
+			}
+			listMD.dirsDiscovered++
+			if writeDirs {
+				entries = append(entries, &listfilepb.ListFileEntry{Entry: &listfilepb.ListFileEntry_DirectoryInfo{DirectoryInfo: &dirInfo}})
+			}
+			continue
+		}
+		if filter.skipFile(path) {
+			continue
+		}
+		if fileInfo.ModTime().Unix() < listSpec.MinMtime {
+			listMD.filesSkippedByMTime++
+			continue
+		}
+		entry := fileInfoEntry(path, fileInfo.ModTime().Unix(), fileInfo.Size())
+		addFileChecksum(ctx, entry, agentcommon.OSPath(path), listMD)
+		entries = append(entries, entry)
+		listMD.addFile(fileInfo.Size())
+	}
+	err = sortListFileEntries(entries)
+	return entries, err
+}
+
+// listManifestsAndWriteResults is the listDirectoriesAndWriteResults equivalent for a list spec
+// whose src_directories are manifests. Every file in the manifests is written using the given
+// writer, regardless of the list file size threshold. When includeDirHeader is set, each
+// manifest's entries are preceded by a header with the manifest's path.
+// Directories found in the manifests are not listed, and are stored in the returned directory
+// info store.
+func listManifestsAndWriteResults(ctx context.Context, gcs gcloud.GCS, w io.Writer, listSpec *taskpb.ListSpec, settings listSettings) (*listingFileMetadata, *DirectoryInfoStore, error) {
+	listMD := &listingFileMetadata{}
+	dirStore := NewDirectoryInfoStore()
+	filter := newGlobFilter(listSpec.RootDirectory)
+	for _, manifest := range listSpec.SrcDirectories {
+		entries, err := processManifest(ctx, gcs, manifest, dirStore, listMD, settings.includeDirs, filter, listSpec)
+		if err != nil {
+			return nil, nil, err
+		}
+		if settings.includeDirHeader {
+			if err := writeListFileEntry(w, dirHeaderEntry(manifest, int64(len(entries)))); err != nil {
+				return nil, nil, err
+			}
+		}
+		for _, entry := range entries {
+			if err := writeListFileEntry(w, entry); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	listMD.dirsNotListed = int64(dirStore.Len())
+	return listMD, dirStore, nil
+}
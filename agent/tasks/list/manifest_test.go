@@ -0,0 +1,98 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/mock/gomock"
+
+	listfilepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestListManifests(t *testing.T) {
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	fileA := createFile(t, tmpDir, "a", fileContent)
+	fileB := createFile(t, tmpDir, "b", fileContent)
+	subDir := filepath.Join(tmpDir, "sub")
+	if err := os.Mkdir(subDir, 0755); err != nil {
+		t.Fatalf("Mkdir(%q) got err: %v", subDir, err)
+	}
+	missing := filepath.Join(tmpDir, "missing")
+	pathA := fileA.GetFileInfo().Path
+	pathB := fileB.GetFileInfo().Path
+
+	// The manifest lists b relative to the root dir, and a twice.
+	manifest := filepath.Join(tmpDir, "manifest")
+	lines := []string{pathA, filepath.Base(pathB), "", missing, subDir, pathA}
+	if err := ioutil.WriteFile(manifest, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatalf("WriteFile(%q) got err: %v", manifest, err)
+	}
+
+	var w bytes.Buffer
+	spec := &taskpb.ListSpec{SrcDirectories: []string{manifest}, RootDirectory: tmpDir, SrcIsManifest: true}
+	settings := listSettings{listFileSizeThreshold: 1, maxDirBytes: 500000, includeDirHeader: true}
+	listMD, dirStore, err := listDirectoriesAndWriteResults(context.Background(), nil, &w, spec, settings, nil)
+	if err != nil {
+		t.Fatalf("listDirectoriesAndWriteResults() got err: %v", err)
+	}
+
+	var want bytes.Buffer
+	writeEntry(t, &want, dirHeaderEntry(manifest, 2))
+	sortAndWriteEntries(t, &want, []*listfilepb.ListFileEntry{fileA, fileB})
+	if w.String() != want.String() {
+		t.Errorf("listDirectoriesAndWriteResults() wrote %q, want %q", w.String(), want.String())
+	}
+	if listMD.files != 2 || listMD.bytes != 2*int64(len(fileContent)) || listMD.dirsDiscovered != 1 || listMD.dirsNotListed != 1 {
+		t.Errorf("listDirectoriesAndWriteResults() got listMD %+v, want 2 files, %d bytes, 1 dir discovered and not listed", listMD, 2*len(fileContent))
+	}
+	if want := []string{missing}; !reflect.DeepEqual(listMD.manifestFilesNotFound, want) {
+		t.Errorf("listDirectoriesAndWriteResults() got manifestFilesNotFound %v, want %v", listMD.manifestFilesNotFound, want)
+	}
+	if d := dirStore.RemoveFirst(); d == nil || d.Path != subDir {
+		t.Errorf("dirStore.RemoveFirst() = %v, want %q", d, subDir)
+	}
+}
+
+func TestReadManifestGCS(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	mockGCS.EXPECT().NewRangeReader(gomock.Any(), "bucket", "dir/manifest", int64(0), int64(-1)).Return(common.NewStringReadCloser("/a\r\n/b\n"), nil)
+
+	paths, err := readManifest(context.Background(), mockGCS, "gs://bucket/dir/manifest", "")
+	if err != nil {
+		t.Fatalf("readManifest() got err: %v", err)
+	}
+	if want := []string{"/a", "/b"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("readManifest() = %v, want %v", paths, want)
+	}
+
+	if _, err := readManifest(context.Background(), mockGCS, "gs://bucket", ""); err == nil {
+		t.Errorf("readManifest(%q) got nil err, want err", "gs://bucket")
+	}
+}
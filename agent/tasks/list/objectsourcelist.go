@@ -0,0 +1,65 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+
+	listfilepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
+)
+
+// processObjectSourceDir is the processDir equivalent for a dir of an
+// ObjectSource, such as an "s3://bucket/prefix" dir. Objects directly within
+// the dir are listed as files, and the dirs nested one level below it are
+// listed as directories.
+func processObjectSourceDir(ctx context.Context, src common.ObjectSource, dir string, dirStore *DirectoryInfoStore, listMD *listingFileMetadata, writeDirs bool, filter *globFilter, minMTime int64) ([]*listfilepb.ListFileEntry, error) {
+	var entries []*listfilepb.ListFileEntry
+	err := src.List(ctx, dir, func(info *common.ObjectInfo) error {
+		if info.IsDir() {
+			if filter.skipDir(info.Path) {
+				return nil
+			}
+			dirInfo := listfilepb.DirectoryInfo{Path: info.Path}
+			if err := dirStore.Add(dirInfo); err != nil {
+				return err
+			}
+			listMD.dirsDiscovered++
+			if writeDirs {
+				entries = append(entries, &listfilepb.ListFileEntry{Entry: &listfilepb.ListFileEntry_DirectoryInfo{DirectoryInfo: &dirInfo}})
+			}
+			return nil
+		}
+		if filter.skipFile(info.Path) {
+			return nil
+		}
+		mtime := info.ModTime().Unix()
+		if mtime < minMTime {
+			listMD.filesSkippedByMTime++
+			return nil
+		}
+		entries = append(entries, fileInfoEntry(info.Path, mtime, info.Size()))
+		listMD.addFile(info.Size())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = sortListFileEntries(entries)
+	return entries, err
+}
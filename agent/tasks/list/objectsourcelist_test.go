@@ -0,0 +1,114 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/stats"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+
+	listfilepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestListV3SuccessObjectSourceDir(t *testing.T) {
+	var expectedListResult, expectedDirsResult bytes.Buffer
+
+	srcDir := "s3://src-bucket/data"
+	mtime := time.Unix(2000, 0)
+	common.RegisterObjectSource(common.S3Scheme, &common.FakeObjectSource{
+		Objects: map[string]string{
+			"s3://src-bucket/data/a":           "0123456789",
+			"s3://src-bucket/data/b":           "01234567890123456789",
+			"s3://src-bucket/data/nested/c":    "0",
+			"s3://src-bucket/other/not-listed": "0",
+		},
+		MTime: mtime,
+	})
+	defer common.RegisterObjectSource(common.S3Scheme, nil)
+	dirEntries := []*listfilepb.ListFileEntry{
+		dirInfoEntry("s3://src-bucket/data/nested"),
+		fileInfoEntry("s3://src-bucket/data/a", 2000, 10),
+		fileInfoEntry("s3://src-bucket/data/b", 2000, 20),
+	}
+	writeEntry(t, &expectedListResult, dirHeaderEntry(srcDir, int64(len(dirEntries))))
+	sortAndWriteEntries(t, &expectedListResult, dirEntries)
+	writeEntry(t, &expectedDirsResult, dirInfoEntry("s3://src-bucket/data/nested"))
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	listWriter := &common.StringWriteCloser{}
+	dirsWriter := &common.StringWriteCloser{}
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	gomock.InOrder(
+		mockGCS.EXPECT().NewWriterWithCondition(
+			context.Background(), testBucket, testObject, gomock.Any()).Return(listWriter),
+		mockGCS.EXPECT().NewWriterWithCondition(
+			context.Background(), testBucket, unexplored, gomock.Any()).Return(dirsWriter),
+	)
+	ctx := context.Background()
+	st := stats.NewTracker(ctx)
+	// Only the first directory is listed with a threshold of 1.
+	h := ListHandlerV3{gcs: mockGCS, listFileSizeThreshold: 1, allowedDirBytes: 5 * 1024 * 1024, statsTracker: st}
+	taskRelRsrcName := "projects/project_A/jobConfigs/config_B/jobRuns/run_C/tasks/task_D"
+	taskReqMsg := testListV3TaskReqMsg(taskRelRsrcName, []string{srcDir}, "s3://src-bucket")
+	taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now())
+	CheckSuccessMsg(taskRelRsrcName, taskRespMsg, t)
+	if listWriter.WrittenString() != expectedListResult.String() {
+		t.Errorf("got list file: \"%s\", want: \"%s\"",
+			listWriter.WrittenString(), expectedListResult.String())
+	}
+	if dirsWriter.WrittenString() != expectedDirsResult.String() {
+		t.Errorf("got unexplored dirs file: \"%s\", want: \"%s\"",
+			dirsWriter.WrittenString(), expectedDirsResult.String())
+	}
+
+	wantLog := &taskpb.Log{
+		Log: &taskpb.Log_ListLog{
+			ListLog: &taskpb.ListLog{
+				FilesFound:    2,
+				BytesFound:    30,
+				MaxFileBytes:  20,
+				DirsFound:     1,
+				DirsListed:    1,
+				DirsNotListed: 1,
+			},
+		},
+	}
+	if !proto.Equal(taskRespMsg.Log, wantLog) {
+		t.Errorf("log = %+v, want: %+v", taskRespMsg.Log, wantLog)
+	}
+}
+
+func TestListV3UnregisteredObjectSourceDir(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	mockGCS.EXPECT().NewWriterWithCondition(context.Background(), testBucket, testObject, gomock.Any()).Return(&common.StringWriteCloser{})
+	h := ListHandlerV3{gcs: mockGCS, listFileSizeThreshold: 1, allowedDirBytes: 5 * 1024 * 1024}
+	taskRelRsrcName := "projects/project_A/jobConfigs/config_B/jobRuns/run_C/tasks/task_D"
+	taskReqMsg := testListV3TaskReqMsg(taskRelRsrcName, []string{"s3://src-bucket/data"}, "s3://src-bucket")
+	if taskRespMsg := h.Do(context.Background(), taskReqMsg, time.Now()); taskRespMsg.Status != "FAILURE" {
+		t.Errorf("Do() got status %v, want FAILURE", taskRespMsg.Status)
+	}
+}
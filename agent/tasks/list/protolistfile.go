@@ -16,15 +16,115 @@ limitations under the License.
 package list
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/binary"
 	"errors"
+	"flag"
 	"fmt"
 	"io"
 
 	"github.com/golang/glog"
+	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/proto"
 )
 
+const (
+	listFileFormatProtobuf = "protobuf"
+	listFileFormatNDJSON   = "ndjson"
+
+	// The first bytes of gzipped data.
+	gzipID1 = 0x1f
+	gzipID2 = 0x8b
+)
+
+var listFileFormat = flag.String("list-file-format", listFileFormatProtobuf, "The format list tasks write list files in, one of: protobuf (length-prefixed text protobufs), ndjson (one JSON object per line, readable without custom tooling).")
+
+// validateListFileFormat returns an error if format is not a known list file format.
+func validateListFileFormat(format string) error {
+	if format != listFileFormatProtobuf && format != listFileFormatNDJSON {
+		return fmt.Errorf("invalid list file format %q, want %q or %q", format, listFileFormatProtobuf, listFileFormatNDJSON)
+	}
+	return nil
+}
+
+// writeListFileEntry writes the given protobuf message using the given writer, in the format
+// set by the list-file-format flag.
+func writeListFileEntry(w io.Writer, pb proto.Message) error {
+	if *listFileFormat == listFileFormatNDJSON {
+		return writeNDJSON(w, pb)
+	}
+	return writeProtobuf(w, pb)
+}
+
+// writeNDJSON writes the given protobuf message as a single line of JSON using the given writer.
+func writeNDJSON(w io.Writer, pb proto.Message) error {
+	var buf bytes.Buffer
+	if err := (&jsonpb.Marshaler{}).Marshal(&buf, pb); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		glog.Errorf("failed to write protobuf %v with error %v", pb, err)
+		return err
+	}
+	return nil
+}
+
+// listFileReader parses the protobufs of a list file written in either list file format. The
+// format is detected from the first byte: NDJSON starts with "{", whereas the big-endian length
+// prefix of a protobuf entry starts with 0 since entries are smaller than 16MiB. Gzipped list
+// files, see the gzip-list-files flag, are detected by the gzip magic number and decompressed.
+type listFileReader struct {
+	r        *bufio.Reader
+	detected bool // True once the format is detected.
+	ndjson   bool
+}
+
+// newListFileReader returns a listFileReader reading from r.
+func newListFileReader(r io.Reader) *listFileReader {
+	return &listFileReader{r: bufio.NewReader(r)}
+}
+
+// next parses the next protobuf from the list file and uses pb to hold the parsed data. It
+// returns io.EOF once the list file has been read.
+func (lr *listFileReader) next(pb proto.Message) error {
+	if !lr.detected {
+		if b, err := lr.r.Peek(2); err == nil && b[0] == gzipID1 && b[1] == gzipID2 {
+			zr, err := gzip.NewReader(lr.r)
+			if err != nil {
+				return err
+			}
+			lr.r = bufio.NewReader(zr)
+		}
+		b, err := lr.r.Peek(1)
+		if err != nil {
+			return err
+		}
+		lr.ndjson = b[0] == '{'
+		lr.detected = true
+	}
+	if !lr.ndjson {
+		if _, err := lr.r.Peek(1); err != nil {
+			return err
+		}
+		return parseProtobuf(lr.r, pb)
+	}
+	line, err := lr.r.ReadBytes('\n')
+	if err == io.EOF && len(line) > 0 {
+		err = nil // The last line needn't end in a newline.
+	}
+	if err != nil {
+		return err
+	}
+	if err := jsonpb.Unmarshal(bytes.NewReader(line), pb); err != nil {
+		glog.Errorf("failed to unmarshal protobuf message with error %v", err)
+		return err
+	}
+	return nil
+}
+
 // writeProtobuf writes the given protobuf message using the given writer.
 func writeProtobuf(w io.Writer, pb proto.Message) error {
 	pbStr := proto.MarshalTextString(pb)
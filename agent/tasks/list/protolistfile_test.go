@@ -17,6 +17,8 @@ package list
 
 import (
 	"bytes"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/golang/protobuf/proto"
@@ -99,3 +101,53 @@ func TestWriteAndReadProtobufsIncompleteRead(t *testing.T) {
 		t.Errorf("Expected %v, actual %v", dirInfo, readDirInfo)
 	}
 }
+
+func TestListFileFormats(t *testing.T) {
+	defer func(f string) { *listFileFormat = f }(*listFileFormat)
+	entries := []*listpb.ListFileEntry{
+		fileInfoEntry("Path/to/file\nwith newline", 123456, 5),
+		{Entry: &listpb.ListFileEntry_DirectoryInfo{DirectoryInfo: &listpb.DirectoryInfo{Path: "directoryName"}}},
+	}
+	for _, format := range []string{listFileFormatProtobuf, listFileFormatNDJSON} {
+		*listFileFormat = format
+		var buf bytes.Buffer
+		for _, entry := range entries {
+			if err := writeListFileEntry(&buf, entry); err != nil {
+				t.Fatalf("%s: writeListFileEntry(%v) got err: %v", format, entry, err)
+			}
+		}
+		if format == listFileFormatNDJSON && strings.Count(buf.String(), "\n") != len(entries) {
+			t.Errorf("%s: wrote %q, want one line per entry", format, buf.String())
+		}
+
+		lr := newListFileReader(&buf)
+		for _, want := range entries {
+			got := &listpb.ListFileEntry{}
+			if err := lr.next(got); err != nil {
+				t.Fatalf("%s: next() got err: %v", format, err)
+			}
+			if !proto.Equal(got, want) {
+				t.Errorf("%s: next() got %v, want %v", format, got, want)
+			}
+		}
+		if err := lr.next(&listpb.ListFileEntry{}); err != io.EOF {
+			t.Errorf("%s: next() at the end got err %v, want io.EOF", format, err)
+		}
+	}
+}
+
+func TestValidateListFileFormat(t *testing.T) {
+	for _, tc := range []struct {
+		format  string
+		wantErr bool
+	}{
+		{"protobuf", false},
+		{"ndjson", false},
+		{"json", true},
+		{"", true},
+	} {
+		if err := validateListFileFormat(tc.format); (err != nil) != tc.wantErr {
+			t.Errorf("validateListFileFormat(%q) got err %v, want err %v", tc.format, err, tc.wantErr)
+		}
+	}
+}
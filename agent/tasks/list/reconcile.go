@@ -0,0 +1,219 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"google.golang.org/api/iterator"
+
+	listfilepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+// The kinds of differences in a reconcile report.
+const (
+	diffMissingObject = "MISSING_OBJECT"
+	diffExtraObject   = "EXTRA_OBJECT"
+	diffSizeMismatch  = "SIZE_MISMATCH"
+)
+
+// ReconcileHandler is responsible for handling reconcile tasks. A reconcile
+// task lists a source directory and the objects it was copied to, and writes
+// a CSV report of the missing objects, extra objects and size mismatches. It
+// doesn't copy anything, so it can be used to audit a finished migration.
+// Every file and object path is held in memory while they're compared, so a
+// task needs memory proportional to the number of files in its directory;
+// large directory trees should be split across several tasks.
+type ReconcileHandler struct {
+	gcs                gcloud.GCS
+	resumableChunkSize int
+}
+
+// NewReconcileHandler creates a ReconcileHandler with storage.Client.
+func NewReconcileHandler(storageClient *storage.Client) *ReconcileHandler {
+	return &ReconcileHandler{gcs: gcloud.NewGCSClient(storageClient), resumableChunkSize: *listTaskChunkSize}
+}
+
+// reconcileDiff is a single difference between the source and destination.
+type reconcileDiff struct {
+	kind               string
+	path               string // Relative to the source directory and destination prefix.
+	srcBytes, dstBytes int64  // -1 if there's no file or object.
+}
+
+// listSrcFiles returns the size of every file within dir, keyed by the path
+// relative to dir. Files are walked like a list task does, so the list
+// filters, symlink policy and empty directory markers apply, but the files
+// are never read to compute their checksums. Stops with ctx's error once ctx
+// is done.
+func listSrcFiles(ctx context.Context, dir string) (map[string]int64, error) {
+	files := make(map[string]int64)
+	dirStore := NewDirectoryInfoStore()
+	if err := dirStore.Add(listfilepb.DirectoryInfo{Path: dir}); err != nil {
+		return nil, err
+	}
+	filter := newGlobFilter(dir)
+	listMD := &listingFileMetadata{skipChecksums: true}
+	for d := dirStore.RemoveFirst(); d != nil; d = dirStore.RemoveFirst() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		entries, err := processDir(ctx, d.Path, dirStore, listMD, false, filter, 0, "", nil)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			fi := e.GetFileInfo()
+			rel, err := filepath.Rel(dir, fi.Path)
+			if err != nil {
+				return nil, err
+			}
+			files[dstName(filepath.ToSlash(rel))] = fi.Size
+		}
+	}
+	return files, nil
+}
+
+// dstName returns the name a file's relative path has relative to the
+// destination prefix.
+func dstName(rel string) string {
+	if common.DstNamesNormalized() {
+		return common.NormalizeDstName(rel)
+	}
+	return rel
+}
+
+// listDstObjects returns the size of every object under prefix, keyed by the
+// name relative to prefix. Placeholder objects for directories are skipped.
+func (h *ReconcileHandler) listDstObjects(ctx context.Context, bucket, prefix string) (map[string]int64, error) {
+	objects := make(map[string]int64)
+	it := h.gcs.ListObjects(ctx, bucket, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasSuffix(attrs.Name, "/") {
+			continue
+		}
+		objects[strings.TrimPrefix(attrs.Name, prefix)] = attrs.Size
+	}
+	return objects, nil
+}
+
+// reconcile returns the differences between the files and objects, sorted by
+// path.
+func reconcile(files, objects map[string]int64) []reconcileDiff {
+	var diffs []reconcileDiff
+	for path, srcBytes := range files {
+		dstBytes, ok := objects[path]
+		if !ok {
+			diffs = append(diffs, reconcileDiff{diffMissingObject, path, srcBytes, -1})
+		} else if dstBytes != srcBytes {
+			diffs = append(diffs, reconcileDiff{diffSizeMismatch, path, srcBytes, dstBytes})
+		}
+	}
+	for path, dstBytes := range objects {
+		if _, ok := files[path]; !ok {
+			diffs = append(diffs, reconcileDiff{diffExtraObject, path, -1, dstBytes})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].path < diffs[j].path })
+	return diffs
+}
+
+// writeReconcileReport writes the diffs as CSV, with a header row. Missing
+// sizes are left empty.
+func writeReconcileReport(w *csv.Writer, diffs []reconcileDiff) error {
+	size := func(n int64) string {
+		if n < 0 {
+			return ""
+		}
+		return strconv.FormatInt(n, 10)
+	}
+	if err := w.Write([]string{"difference", "path", "src_bytes", "dst_bytes"}); err != nil {
+		return err
+	}
+	for _, d := range diffs {
+		if err := w.Write([]string{d.kind, d.path, size(d.srcBytes), size(d.dstBytes)}); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func (h *ReconcileHandler) handleReconcileSpec(ctx context.Context, rs *taskpb.ReconcileSpec) (*taskpb.ReconcileLog, error) {
+	rl := &taskpb.ReconcileLog{}
+	files, err := listSrcFiles(ctx, rs.SrcDirectory)
+	if err != nil {
+		return rl, err
+	}
+	rl.FilesFound = int64(len(files))
+	objects, err := h.listDstObjects(ctx, rs.DstBucket, rs.DstPrefix)
+	if err != nil {
+		return rl, err
+	}
+	rl.ObjectsFound = int64(len(objects))
+
+	diffs := reconcile(files, objects)
+	for _, d := range diffs {
+		switch d.kind {
+		case diffMissingObject:
+			rl.MissingObjects++
+		case diffExtraObject:
+			rl.ExtraObjects++
+		case diffSizeMismatch:
+			rl.SizeMismatches++
+		}
+	}
+
+	w := gcsWriterWithCondition(ctx, h.gcs, rs.DstReportBucket, rs.DstReportObject, rs.ReportExpectedGenerationNum, h.resumableChunkSize)
+	if err := writeReconcileReport(csv.NewWriter(w), diffs); err != nil {
+		w.CloseWithError(err)
+		return rl, fmt.Errorf("writing the reconcile report got err: %v", err)
+	}
+	return rl, w.Close()
+}
+
+// Do handles a reconcile task. Differences are reported and don't fail the
+// task; only errors listing the directory or the objects, or writing the
+// report, do.
+func (h *ReconcileHandler) Do(ctx context.Context, taskReqMsg *taskpb.TaskReqMsg, reqStart time.Time) *taskpb.TaskRespMsg {
+	rs := taskReqMsg.Spec.GetReconcileSpec()
+	if rs == nil {
+		err := errors.New("ReconcileHandler.Do taskReqMsg.Spec is not a ReconcileSpec")
+		return common.BuildTaskRespMsg(taskReqMsg, nil, nil, err)
+	}
+	rl, err := h.handleReconcileSpec(ctx, rs)
+	log := &taskpb.Log{Log: &taskpb.Log_ReconcileLog{rl}}
+	return common.BuildTaskRespMsg(taskReqMsg, taskReqMsg.Spec, log, err)
+}
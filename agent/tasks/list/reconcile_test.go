@@ -0,0 +1,128 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/gcloud"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+func TestReconcile(t *testing.T) {
+	files := map[string]int64{"a": 1, "b": 2, "c/d": 3}
+	objects := map[string]int64{"a": 1, "b": 20, "e": 5}
+	want := []reconcileDiff{
+		{diffSizeMismatch, "b", 2, 20},
+		{diffMissingObject, "c/d", 3, -1},
+		{diffExtraObject, "e", -1, 5},
+	}
+	if got := reconcile(files, objects); !reflect.DeepEqual(got, want) {
+		t.Errorf("reconcile() = %v, want %v", got, want)
+	}
+	if got := reconcile(files, files); len(got) != 0 {
+		t.Errorf("reconcile() of identical maps = %v, want no diffs", got)
+	}
+}
+
+func TestWriteReconcileReport(t *testing.T) {
+	var buf bytes.Buffer
+	diffs := []reconcileDiff{
+		{diffSizeMismatch, "b", 2, 20},
+		{diffMissingObject, "c,d", 3, -1},
+	}
+	if err := writeReconcileReport(csv.NewWriter(&buf), diffs); err != nil {
+		t.Fatalf("writeReconcileReport got err: %v", err)
+	}
+	want := "difference,path,src_bytes,dst_bytes\nSIZE_MISMATCH,b,2,20\nMISSING_OBJECT,\"c,d\",3,\n"
+	if got := buf.String(); got != want {
+		t.Errorf("writeReconcileReport wrote %q, want %q", got, want)
+	}
+}
+
+func TestReconcileHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	tmpDir := common.CreateTmpDir("", "test-reconcile-agent-")
+	defer os.RemoveAll(tmpDir)
+	if err := os.Mkdir(filepath.Join(tmpDir, "sub"), 0755); err != nil {
+		t.Fatalf("Mkdir got err: %v", err)
+	}
+	for name, content := range map[string]string{"a": "0123456789", "sub/b": "abc", "c": "c"} {
+		if err := ioutil.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile(%q) got err: %v", name, err)
+		}
+	}
+
+	reportWriter := &common.StringWriteCloser{}
+	mockGCS := gcloud.NewMockGCS(mockCtrl)
+	mockGCS.EXPECT().ListObjects(gomock.Any(), "bucket", &storage.Query{Prefix: "dst/"}).Return(gcloud.NewObjectIterator(
+		&storage.ObjectAttrs{Name: "dst/a", Size: 10},
+		&storage.ObjectAttrs{Name: "dst/sub/", Size: 0}, // A directory placeholder.
+		&storage.ObjectAttrs{Name: "dst/sub/b", Size: 5},
+		&storage.ObjectAttrs{Name: "dst/extra", Size: 1},
+	))
+	mockGCS.EXPECT().NewWriterWithCondition(gomock.Any(), "report-bucket", "report", gomock.Any()).Return(reportWriter)
+
+	taskReqMsg := &taskpb.TaskReqMsg{
+		TaskRelRsrcName: "task",
+		Spec: &taskpb.Spec{Spec: &taskpb.Spec_ReconcileSpec{ReconcileSpec: &taskpb.ReconcileSpec{
+			SrcDirectory:    tmpDir,
+			DstBucket:       "bucket",
+			DstPrefix:       "dst/",
+			DstReportBucket: "report-bucket",
+			DstReportObject: "report",
+		}}},
+	}
+	// Reconcile tasks are sent on the list subscription.
+	h := ListHandlerV3{reconcileHandler: &ReconcileHandler{gcs: mockGCS}}
+	resp := h.Do(context.Background(), taskReqMsg, time.Now())
+	CheckSuccessMsg("task", resp, t)
+
+	wantLog := &taskpb.ReconcileLog{FilesFound: 3, ObjectsFound: 3, MissingObjects: 1, ExtraObjects: 1, SizeMismatches: 1}
+	if got := resp.Log.GetReconcileLog(); !proto.Equal(got, wantLog) {
+		t.Errorf("Do got log %v, want %v", got, wantLog)
+	}
+	wantReport := "difference,path,src_bytes,dst_bytes\nMISSING_OBJECT,c,1,\nEXTRA_OBJECT,extra,,1\nSIZE_MISMATCH,sub/b,3,5\n"
+	if got := reportWriter.WrittenString(); got != wantReport {
+		t.Errorf("Do wrote report %q, want %q", got, wantReport)
+	}
+}
+
+func TestListSrcFilesCtxCanceled(t *testing.T) {
+	tmpDir := common.CreateTmpDir("", "test-reconcile-agent-")
+	defer os.RemoveAll(tmpDir)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := listSrcFiles(ctx, tmpDir); err != context.Canceled {
+		t.Errorf("listSrcFiles(%q) got err %v, want %v", tmpDir, err, context.Canceled)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	agentcommon "github.com/GoogleCloudPlatform/cloud-ingest/agent/common"
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+	"github.com/golang/glog"
+
+	listfilepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
+)
+
+// Re-stat policies, see the list-restat-policy flag.
+const (
+	restatPolicyOff     = "off"
+	restatPolicySkip    = "skip"
+	restatPolicyInclude = "include"
+)
+
+var (
+	listRestatPolicy = flag.String("list-restat-policy", restatPolicyOff, "How local files which changed while being listed are handled. Files are stat'ed again just before their list file entries are written. \"off\" doesn't re-stat files, \"skip\" leaves changed files out of the list file, and \"include\" lists them with their current size and mtime. Changed files are counted in the list log's files_changed_during_list. Skipping them avoids copies failing with FILE_MODIFIED_FAILURE on live source trees, the files are listed again by the next job run.")
+	listRestatWindow = flag.Duration("list-restat-window", 0, "With list-restat-policy, files modified less than this long before being stat'ed again are also treated as changed, since they are likely still being written.")
+)
+
+// validateRestatPolicy returns an error if policy is not a known re-stat policy.
+func validateRestatPolicy(policy string) error {
+	switch policy {
+	case restatPolicyOff, restatPolicySkip, restatPolicyInclude:
+		return nil
+	default:
+		return fmt.Errorf("invalid re-stat policy %q, want one of %q, %q or %q", policy, restatPolicyOff, restatPolicySkip, restatPolicyInclude)
+	}
+}
+
+// restatEntries stats the local files listed by entries again, and handles the
+// files which changed since they were listed according to the
+// list-restat-policy flag. It returns the entries to write, and the number of
+// file entries which were left out. The counts in listMD are adjusted to match.
+func restatEntries(ctx context.Context, entries []*listfilepb.ListFileEntry, listMD *listingFileMetadata) ([]*listfilepb.ListFileEntry, int) {
+	policy := *listRestatPolicy
+	if policy == restatPolicyOff {
+		return entries, 0
+	}
+	stat := os.Stat
+	if symlinkPolicy() == common.SymlinkPolicyCopyAsObject {
+		stat = os.Lstat // Symlinks are listed with their own size.
+	}
+	kept := entries[:0]
+	for _, entry := range entries {
+		fi := entry.GetFileInfo()
+		if fi == nil {
+			kept = append(kept, entry)
+			continue
+		}
+		osPath := agentcommon.OSPath(fi.Path)
+		osFileInfo, err := stat(osPath)
+		if os.IsNotExist(err) {
+			if _, ok := common.EmptyDirOfMarker(fi.Path); ok {
+				kept = append(kept, entry)
+				continue
+			}
+			// Deleted files can't be listed with their current stats either.
+			glog.Warningf("skipping %q, which was deleted while being listed", fi.Path)
+			listMD.filesChangedDuringList++
+			listMD.files--
+			listMD.bytes -= fi.Size
+			continue
+		}
+		if err != nil {
+			glog.Warningf("keeping %q as listed, Stat got err: %v", fi.Path, err)
+			kept = append(kept, entry)
+			continue
+		}
+		mtime := osFileInfo.ModTime()
+		changed := osFileInfo.Size() != fi.Size || mtime.Unix() != fi.LastModifiedTime
+		if !changed && (*listRestatWindow <= 0 || time.Since(mtime) >= *listRestatWindow) {
+			kept = append(kept, entry)
+			continue
+		}
+		listMD.filesChangedDuringList++
+		if policy == restatPolicySkip {
+			listMD.files--
+			listMD.bytes -= fi.Size
+			continue
+		}
+		if changed {
+			listMD.bytes += osFileInfo.Size() - fi.Size
+			if osFileInfo.Size() > listMD.maxFileBytes {
+				listMD.maxFileBytes = osFileInfo.Size()
+			}
+			fi.Size = osFileInfo.Size()
+			fi.LastModifiedTime = mtime.Unix()
+			if fi.Crc32C != nil {
+				// The listed checksum is stale.
+				fi.Crc32C = nil
+				addFileChecksum(ctx, entry, osPath, listMD)
+			}
+		}
+		kept = append(kept, entry)
+	}
+	return kept, len(entries) - len(kept)
+}
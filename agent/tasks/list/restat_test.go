@@ -0,0 +1,94 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/cloud-ingest/agent/tasks/common"
+
+	listfilepb "github.com/GoogleCloudPlatform/cloud-ingest/proto/listfile_go_proto"
+)
+
+func TestRestatEntries(t *testing.T) {
+	defer func(p string, w time.Duration) { *listRestatPolicy, *listRestatWindow = p, w }(*listRestatPolicy, *listRestatWindow)
+
+	tmpDir := common.CreateTmpDir("", "test-list-agent-")
+	defer os.RemoveAll(tmpDir)
+	p := filepath.Join(tmpDir, "file")
+	if err := ioutil.WriteFile(p, []byte(fileContent), 0644); err != nil {
+		t.Fatalf("WriteFile(%q) got err: %v", p, err)
+	}
+	mtime := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(p, mtime, mtime); err != nil {
+		t.Fatalf("Chtimes(%q) got err: %v", p, err)
+	}
+	size := int64(len(fileContent))
+
+	tests := []struct {
+		desc        string
+		policy      string
+		window      time.Duration
+		path        string
+		listedSize  int64
+		wantEntries int
+		wantSize    int64
+		wantChanged int64
+	}{
+		{"Off", restatPolicyOff, 0, p, 1, 1, 1, 0},
+		{"Unchanged", restatPolicySkip, 0, p, size, 1, size, 0},
+		{"Changed skipped", restatPolicySkip, 0, p, 1, 0, 0, 1},
+		{"Changed included", restatPolicyInclude, 0, p, 1, 1, size, 1},
+		{"Deleted", restatPolicyInclude, 0, p + "-deleted", 1, 0, 0, 1},
+		{"Modified within window", restatPolicySkip, 2 * time.Hour, p, size, 0, 0, 1},
+		{"Modified before window", restatPolicySkip, time.Minute, p, size, 1, size, 0},
+	}
+	for _, tc := range tests {
+		*listRestatPolicy, *listRestatWindow = tc.policy, tc.window
+		entries := []*listfilepb.ListFileEntry{dirHeaderEntry(tmpDir, 1), fileInfoEntry(tc.path, mtime.Unix(), tc.listedSize)}
+		listMD := &listingFileMetadata{}
+		listMD.addFile(tc.listedSize)
+		got, skipped := restatEntries(context.Background(), entries, listMD)
+		if len(got) != tc.wantEntries+1 || skipped != 1-tc.wantEntries {
+			t.Errorf("%s: restatEntries got %d entries, %d skipped, want %d file entries", tc.desc, len(got), skipped, tc.wantEntries)
+			continue
+		}
+		if tc.wantEntries == 1 {
+			if s := got[1].GetFileInfo().Size; s != tc.wantSize {
+				t.Errorf("%s: restatEntries got size %d, want %d", tc.desc, s, tc.wantSize)
+			}
+		}
+		if listMD.files != int64(tc.wantEntries) || listMD.bytes != tc.wantSize || listMD.filesChangedDuringList != tc.wantChanged {
+			t.Errorf("%s: restatEntries got files %d, bytes %d, changed %d, want %d, %d, %d", tc.desc, listMD.files, listMD.bytes, listMD.filesChangedDuringList, tc.wantEntries, tc.wantSize, tc.wantChanged)
+		}
+	}
+}
+
+func TestValidateRestatPolicy(t *testing.T) {
+	for _, policy := range []string{restatPolicyOff, restatPolicySkip, restatPolicyInclude} {
+		if err := validateRestatPolicy(policy); err != nil {
+			t.Errorf("validateRestatPolicy(%q) got err: %v", policy, err)
+		}
+	}
+	if err := validateRestatPolicy("sometimes"); err == nil {
+		t.Errorf("validateRestatPolicy(%q) got nil err, want err", "sometimes")
+	}
+}
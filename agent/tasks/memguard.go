@@ -0,0 +1,75 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"flag"
+
+	"golang.org/x/sync/semaphore"
+
+	taskpb "github.com/GoogleCloudPlatform/cloud-ingest/proto/task_go_proto"
+)
+
+var (
+	maxInFlightTaskBytes = flag.Int64("max-in-flight-task-bytes", 0, "The estimated memory, in bytes, that the buffers of in-flight copy tasks may use. New copy tasks wait while this would be exceeded, and a task that exceeds it on its own runs alone. If 0, only the number of in-flight tasks is limited.")
+)
+
+// MemoryEstimator is an optional interface of a TaskHandler, which estimates
+// the memory a task uses while it's in flight, such as its read buffers.
+type MemoryEstimator interface {
+	EstimateMemory(taskReqMsg *taskpb.TaskReqMsg) int64
+}
+
+// estimateMemory returns the handler's memory estimate for the task, or 0 if
+// the handler doesn't implement MemoryEstimator.
+func estimateMemory(h TaskHandler, taskReqMsg *taskpb.TaskReqMsg) int64 {
+	if e, ok := h.(MemoryEstimator); ok {
+		return e.EstimateMemory(taskReqMsg)
+	}
+	return 0
+}
+
+// memoryGuard bounds the estimated memory of in-flight tasks. A nil memoryGuard
+// doesn't bound anything.
+type memoryGuard struct {
+	max int64
+	sem *semaphore.Weighted
+}
+
+// newMemoryGuard returns a memoryGuard for at most max bytes, or nil if max
+// is not positive.
+func newMemoryGuard(max int64) *memoryGuard {
+	if max <= 0 {
+		return nil
+	}
+	return &memoryGuard{max: max, sem: semaphore.NewWeighted(max)}
+}
+
+// acquire blocks until n bytes are available or ctx is done, and returns a
+// func that releases them. Requests larger than the guard wait for all of it.
+func (g *memoryGuard) acquire(ctx context.Context, n int64) (release func(), err error) {
+	if g == nil || n <= 0 {
+		return func() {}, nil
+	}
+	if n > g.max {
+		n = g.max
+	}
+	if err := g.sem.Acquire(ctx, n); err != nil {
+		return nil, err
+	}
+	return func() { g.sem.Release(n) }, nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryGuardNil(t *testing.T) {
+	if g := newMemoryGuard(0); g != nil {
+		t.Errorf("newMemoryGuard(0) = %v, want nil", g)
+	}
+	var g *memoryGuard
+	release, err := g.acquire(context.Background(), 1<<40)
+	if err != nil {
+		t.Fatalf("nil guard acquire() got err: %v", err)
+	}
+	release()
+}
+
+func TestMemoryGuardAcquire(t *testing.T) {
+	g := newMemoryGuard(100)
+	ctx := context.Background()
+	release60, err := g.acquire(ctx, 60)
+	if err != nil {
+		t.Fatalf("acquire(60) got err: %v", err)
+	}
+
+	// A second 60 byte task doesn't fit until the first is released.
+	acquired := make(chan func())
+	go func() {
+		release, err := g.acquire(ctx, 60)
+		if err != nil {
+			t.Errorf("acquire(60) got err: %v", err)
+		}
+		acquired <- release
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("acquire(60) didn't wait for the first 60 bytes to be released")
+	case <-time.After(50 * time.Millisecond):
+	}
+	release60()
+	select {
+	case release := <-acquired:
+		release()
+	case <-time.After(time.Second):
+		t.Fatal("acquire(60) still waiting after the first 60 bytes were released")
+	}
+
+	// A task larger than the guard runs alone rather than waiting forever.
+	release, err := g.acquire(ctx, 1000)
+	if err != nil {
+		t.Fatalf("acquire(1000) got err: %v", err)
+	}
+	cctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := g.acquire(cctx, 1); err == nil {
+		t.Errorf("acquire(1) while a larger task is in flight got nil err, want the context error")
+	}
+	release()
+}
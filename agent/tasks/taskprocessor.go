@@ -50,6 +50,9 @@ type TaskProcessor struct {
 	ProgressTopic *pubsub.Topic
 	Handlers      *HandlerRegistry
 	StatsTracker  *stats.Tracker
+	InFlight      *InFlightTasks // Tracks the tasks being processed, may be nil.
+
+	memGuard *memoryGuard // Bounds the memory of in-flight tasks, may be nil.
 }
 
 // NewListProcessor returns a TaskProcessor for handling List tasks.
@@ -84,6 +87,7 @@ func NewCopyProcessor(sc *storage.Client, hc *http.Client, sub *pubsub.Subscript
 			4: copyHandler,
 		}),
 		StatsTracker: st,
+		memGuard:     newMemoryGuard(*maxInFlightTaskBytes),
 	}
 }
 
@@ -102,9 +106,13 @@ func NewDeleteProcessor(sc *storage.Client, sub *pubsub.Subscription, topic *pub
 }
 
 // Process handles taskReqMsgs sent by the DCP for the given PubSub subscription and handler.
+// New messages are pulled until ctx is done, while tasks are processed with workCtx. This lets
+// in-flight tasks finish after ctx is done, and Process returns once they have.
 // This is a blocking function.
-func (tp *TaskProcessor) Process(ctx context.Context) {
-	err := tp.TaskSub.Receive(ctx, tp.processMessage)
+func (tp *TaskProcessor) Process(ctx, workCtx context.Context) {
+	err := tp.TaskSub.Receive(ctx, func(_ context.Context, msg *pubsub.Message) {
+		tp.processMessage(workCtx, msg)
+	})
 	if err != nil && ctx.Err() == nil {
 		glog.Fatalf("%s.Receive() got err: %v", tp.TaskSub.String(), err)
 	}
@@ -132,6 +140,22 @@ func addTaskTimestamps(resp *taskpb.TaskRespMsg, reqStart, msgPublishTime time.T
 	resp.RespPublishTime = respPublishTime
 }
 
+// logTaskFinish logs the outcome of a task handled by this agent as a
+// task_finish event.
+func logTaskFinish(req *taskpb.TaskReqMsg, resp *taskpb.TaskRespMsg, reqStart time.Time) {
+	fields := common.Fields{
+		"task":        resp.TaskRelRsrcName,
+		"job_run":     req.JobrunRelRsrcName,
+		"status":      resp.Status,
+		"duration_ms": stats.DurMs(reqStart),
+	}
+	if resp.FailureType != taskpb.FailureType_UNSET_FAILURE_TYPE {
+		fields["failure_type"] = resp.FailureType.String()
+		fields["failure_message"] = resp.FailureMessage
+	}
+	common.LogEvent("task_finish", fields)
+}
+
 func (tp *TaskProcessor) processMessage(ctx context.Context, msg *pubsub.Message) {
 	var taskReqMsg taskpb.TaskReqMsg
 	if err := proto.Unmarshal(msg.Data, &taskReqMsg); err != nil {
@@ -148,8 +172,48 @@ func (tp *TaskProcessor) processMessage(ctx context.Context, msg *pubsub.Message
 		if agentErr != nil {
 			taskRespMsg = common.BuildTaskRespMsg(&taskReqMsg, nil, nil, *agentErr)
 		} else {
-			taskRespMsg = handler.Do(ctx, &taskReqMsg, reqStart)
+			release, err := tp.memGuard.acquire(ctx, estimateMemory(handler, &taskReqMsg))
+			if err != nil {
+				// The context was cancelled while waiting for memory. The task
+				// remains on PubSub for another worker, as below.
+				glog.Errorf("Context is canceled, not processing taskReqMsg: %v", taskReqMsg.TaskRelRsrcName)
+				return
+			}
+			tp.StatsTracker.RecordTaskStart(&taskReqMsg)
+			common.LogEvent("task_start", common.Fields{
+				"task":    taskReqMsg.TaskRelRsrcName,
+				"job_run": taskReqMsg.JobrunRelRsrcName,
+			})
+			taskCtx, done, cancelled := tp.InFlight.start(ctx, &taskReqMsg)
+			taskRespMsg = doWithDeadline(taskCtx, handler, &taskReqMsg, reqStart, *taskDeadline, func() {
+				// A task abandoned at its deadline keeps its memory and stays in flight
+				// until its handler returns.
+				done()
+				release()
+			})
 			tp.StatsTracker.RecordTaskResp(taskRespMsg)
+			// The Pub/Sub client extends the lease of a message for as long as it's held, so
+			// long held tasks indicate a slow source, or chunks which are too large.
+			tp.StatsTracker.RecordPulseStats(taskReqMsg.JobrunRelRsrcName, &stats.PulseStats{TaskHeldMs: stats.DurMs(reqStart)})
+			logTaskFinish(&taskReqMsg, taskRespMsg, reqStart)
+			if taskRespMsg.FailureType == taskpb.FailureType_DEADLINE_EXCEEDED_FAILURE {
+				// The task may be stuck on this agent's view of the source, nack it so
+				// it's redelivered, possibly to another agent.
+				msg.Nack()
+				return
+			}
+			if taskRespMsg.FailureType == taskpb.FailureType_FILE_NOT_STABLE_FAILURE {
+				// The file may still be being written, nack the task so it's
+				// redelivered once the file has had time to become stable.
+				msg.Nack()
+				return
+			}
+			if cancelled() {
+				// The task was cancelled through InFlightTasks, nack it so it's
+				// redelivered, possibly to another agent.
+				msg.Nack()
+				return
+			}
 		}
 	} else {
 		taskRespMsg = common.BuildTaskRespMsg(&taskReqMsg, nil, nil, common.AgentError{
@@ -311,3 +311,64 @@ func TestTaskProcessorProcessMessageNoHandler(t *testing.T) {
 		t.Errorf("wp.processMessage(%v) = %v, want %v", taskReqMsg, taskRespMsg, want)
 	}
 }
+
+func TestTaskProcessorProcessMessageFileNotStable(t *testing.T) {
+	ctx := context.Background()
+	client, cleanUp := fakePubSubClient(ctx, t)
+	defer cleanUp()
+
+	progressTopic := createTopic(ctx, t, client, "progress")
+	progressSub := createSubscription(ctx, t, client, progressTopic, "progressSub")
+
+	workTopic := createTopic(ctx, t, client, "work")
+	workSub := createSubscription(ctx, t, client, workTopic, "workSub")
+
+	taskReqMsg := &taskpb.TaskReqMsg{
+		TaskRelRsrcName:   "taskid",
+		JobrunRelRsrcName: "jobrunid",
+		JobRunVersion:     "0.0.0",
+	}
+	rate.ProcessJobRunBandwidths([]*controlpb.JobRunBandwidth{
+		&controlpb.JobRunBandwidth{JobrunRelRsrcName: taskReqMsg.JobrunRelRsrcName, Bandwidth: 1},
+	}, nil)
+
+	data, err := proto.Marshal(taskReqMsg)
+	if err != nil {
+		t.Fatalf("error marshalling task req message %v", err)
+	}
+
+	// Publish and receive task request message
+	res := workTopic.Publish(ctx, &pubsub.Message{Data: data})
+	res.Get(ctx)
+	workMsgs := make(chan *pubsub.Message)
+	receiveMessages(ctx, workMsgs, workSub)
+	psTaskReqMsg := getMessageOrTimeout(t, workMsgs)
+
+	wp := TaskProcessor{
+		TaskSub:       workSub,
+		ProgressTopic: progressTopic,
+		Handlers: &HandlerRegistry{map[uint64]TaskHandler{
+			0: &TestTaskHandler{map[string]*taskpb.TaskRespMsg{
+				taskReqMsg.TaskRelRsrcName: &taskpb.TaskRespMsg{
+					TaskRelRsrcName: taskReqMsg.TaskRelRsrcName,
+					Status:          "FAILURE",
+					FailureType:     taskpb.FailureType_FILE_NOT_STABLE_FAILURE,
+				},
+			}},
+		}},
+		StatsTracker: stats.NewTracker(ctx),
+	}
+	wp.processMessage(ctx, psTaskReqMsg)
+
+	// The task is nacked, so it's redelivered instead of reported.
+	if redelivered := getMessageOrTimeout(t, workMsgs); string(redelivered.Data) != string(data) {
+		t.Errorf("redelivered message data = %q, want %q", redelivered.Data, data)
+	}
+	progressMsgs := make(chan *pubsub.Message)
+	receiveMessages(ctx, progressMsgs, progressSub)
+	select {
+	case msg := <-progressMsgs:
+		t.Errorf("wp.processMessage(%v) published progress message %q, want none", taskReqMsg, msg.Data)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// otlpExporter is a sdktrace.SpanExporter posting spans to an OTLP/HTTP
+// collector, using the protocol's JSON encoding.
+type otlpExporter struct {
+	url string
+	hc  *http.Client
+}
+
+func newOTLPExporter(url string) *otlpExporter {
+	return &otlpExporter{url: url, hc: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// The OTLP JSON encoding of an ExportTraceServiceRequest. Only the fields the
+// agent's spans use are included. Trace and span IDs are hex encoded, and
+// 64-bit integers are encoded as strings.
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// The OTLP status codes, which are ordered differently from codes.Code.
+const (
+	otlpStatusOK    = 1
+	otlpStatusError = 2
+)
+
+func unixNano(t time.Time) string {
+	return strconv.FormatInt(t.UnixNano(), 10)
+}
+
+func otlpAttributes(kvs []attribute.KeyValue) []otlpKeyValue {
+	var attrs []otlpKeyValue
+	for _, kv := range kvs {
+		var v otlpValue
+		switch kv.Value.Type() {
+		case attribute.BOOL:
+			b := kv.Value.AsBool()
+			v.BoolValue = &b
+		case attribute.INT64:
+			i := strconv.FormatInt(kv.Value.AsInt64(), 10)
+			v.IntValue = &i
+		case attribute.FLOAT64:
+			f := kv.Value.AsFloat64()
+			v.DoubleValue = &f
+		default:
+			// Slices are flattened to strings, the agent doesn't use them.
+			s := kv.Value.Emit()
+			v.StringValue = &s
+		}
+		attrs = append(attrs, otlpKeyValue{Key: string(kv.Key), Value: v})
+	}
+	return attrs
+}
+
+func otlpSpanOf(s sdktrace.ReadOnlySpan) otlpSpan {
+	span := otlpSpan{
+		TraceID:           s.SpanContext().TraceID().String(),
+		SpanID:            s.SpanContext().SpanID().String(),
+		Name:              s.Name(),
+		Kind:              int(s.SpanKind()),
+		StartTimeUnixNano: unixNano(s.StartTime()),
+		EndTimeUnixNano:   unixNano(s.EndTime()),
+		Attributes:        otlpAttributes(s.Attributes()),
+	}
+	if s.Parent().HasSpanID() {
+		span.ParentSpanID = s.Parent().SpanID().String()
+	}
+	for _, e := range s.Events() {
+		span.Events = append(span.Events, otlpEvent{
+			TimeUnixNano: unixNano(e.Time),
+			Name:         e.Name,
+			Attributes:   otlpAttributes(e.Attributes),
+		})
+	}
+	switch s.Status().Code {
+	case codes.Ok:
+		span.Status.Code = otlpStatusOK
+	case codes.Error:
+		span.Status = otlpStatus{Code: otlpStatusError, Message: s.Status().Description}
+	}
+	return span
+}
+
+// encodeSpans returns the OTLP request for spans. All of the agent's spans
+// share the tracer provider's resource, so it's taken from the first span.
+func encodeSpans(spans []sdktrace.ReadOnlySpan) otlpRequest {
+	rs := otlpResourceSpans{Resource: otlpResource{Attributes: otlpAttributes(spans[0].Resource().Attributes())}}
+	scopes := make(map[string]int) // Indices into rs.ScopeSpans.
+	for _, s := range spans {
+		scope := s.InstrumentationScope()
+		i, ok := scopes[scope.Name]
+		if !ok {
+			i = len(rs.ScopeSpans)
+			scopes[scope.Name] = i
+			rs.ScopeSpans = append(rs.ScopeSpans, otlpScopeSpans{Scope: otlpScope{Name: scope.Name, Version: scope.Version}})
+		}
+		rs.ScopeSpans[i].Spans = append(rs.ScopeSpans[i].Spans, otlpSpanOf(s))
+	}
+	return otlpRequest{ResourceSpans: []otlpResourceSpans{rs}}
+}
+
+// ExportSpans implements the sdktrace.SpanExporter interface.
+func (e *otlpExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(encodeSpans(spans))
+	if err != nil {
+		return fmt.Errorf("json.Marshal of spans err: %v", err)
+	}
+	req, err := http.NewRequest("POST", e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("http.NewRequest err: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.hc.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("exporting %d spans to %s got HTTP %d", len(spans), e.url, resp.StatusCode)
+	}
+	return nil
+}
+
+// Shutdown implements the sdktrace.SpanExporter interface.
+func (e *otlpExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing provides OpenTelemetry spans for following individual tasks
+// through the agent.
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/GoogleCloudPlatform/cloud-ingest/agent"
+	serviceName         = "cloud-ingest-agent"
+)
+
+// StartSpan starts a span named name, with the given attributes, as a child of
+// any span in ctx. Until Init is called the span is a no-op, so instrumented
+// code doesn't need to check whether tracing is enabled. A span which isn't
+// recorded isn't added to the returned context.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	spanCtx, span := otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+	if !span.IsRecording() {
+		return ctx, span
+	}
+	return spanCtx, span
+}
+
+// Init exports the agent's spans to the OTLP/HTTP collector at endpoint, for
+// example http://localhost:4318. The returned func flushes any buffered spans
+// and stops the export, it should be called before the agent exits.
+func Init(endpoint string) func(context.Context) error {
+	exp := newOTLPExporter(tracesURL(endpoint))
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown
+}
+
+// tracesURL returns the URL spans are posted to for the collector at endpoint.
+// An endpoint without a scheme is assumed to be plain HTTP.
+func tracesURL(endpoint string) string {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/v1/traces"
+}
+
+// End records err, if any, on span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2019 Google Inc. All Rights Reserved.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func TestTracesURL(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     string
+	}{
+		{"localhost:4318", "http://localhost:4318/v1/traces"},
+		{"http://localhost:4318/", "http://localhost:4318/v1/traces"},
+		{"https://collector.example.com", "https://collector.example.com/v1/traces"},
+	}
+	for _, tc := range tests {
+		if got := tracesURL(tc.endpoint); got != tc.want {
+			t.Errorf("tracesURL(%q) = %q, want %q", tc.endpoint, got, tc.want)
+		}
+	}
+}
+
+func TestStartSpanIsNoopByDefault(t *testing.T) {
+	ctx := context.Background()
+	spanCtx, span := StartSpan(ctx, "test", attribute.String("dst_object", "obj"))
+	defer span.End()
+	if span.IsRecording() {
+		t.Errorf("span.IsRecording() = true before Init, want false")
+	}
+	if spanCtx != ctx {
+		t.Errorf("StartSpan returned ctx %v, want the unchanged %v", spanCtx, ctx)
+	}
+}
+
+func TestOTLPExporter(t *testing.T) {
+	var req otlpRequest
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("ReadAll(body) got err: %v", err)
+		}
+		req = otlpRequest{}
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("json.Unmarshal(%s) got err: %v", body, err)
+		}
+	}))
+	defer server.Close()
+
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(newOTLPExporter(server.URL + "/v1/traces")))
+	ctx, parent := tp.Tracer(instrumentationName).Start(context.Background(), "parent")
+	_, child := tp.Tracer(instrumentationName).Start(ctx, "child")
+	child.SetAttributes(attribute.String("dst_object", "obj"), attribute.Int64("bytes", 42))
+	End(child, errors.New("copy failed"))
+	End(parent, nil)
+
+	if contentType != "application/json" {
+		t.Errorf("got Content-Type %q, want application/json", contentType)
+	}
+	if len(req.ResourceSpans) != 1 || len(req.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("got request %+v, want a single resource and scope", req)
+	}
+	scope := req.ResourceSpans[0].ScopeSpans[0]
+	if scope.Scope.Name != instrumentationName {
+		t.Errorf("got scope %q, want %q", scope.Scope.Name, instrumentationName)
+	}
+	// The syncer exports each span as it ends, the last is the parent.
+	if len(scope.Spans) != 1 || scope.Spans[0].Name != "parent" {
+		t.Fatalf("got spans %+v, want the parent span", scope.Spans)
+	}
+	if len(scope.Spans[0].TraceID) != 32 || len(scope.Spans[0].SpanID) != 16 || scope.Spans[0].ParentSpanID != "" {
+		t.Errorf("got span IDs %+v, want a hex trace and span ID without a parent", scope.Spans[0])
+	}
+}
+
+func TestOTLPSpanOf(t *testing.T) {
+	var spans []sdktrace.ReadOnlySpan
+	exp := exporterFunc(func(s []sdktrace.ReadOnlySpan) { spans = append(spans, s...) })
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	ctx, parent := tp.Tracer(instrumentationName).Start(context.Background(), "parent")
+	_, child := tp.Tracer(instrumentationName).Start(ctx, "child")
+	child.SetAttributes(attribute.String("dst_object", "obj"), attribute.Int64("bytes", 42), attribute.Bool("final", true))
+	child.AddEvent("retry")
+	End(child, errors.New("copy failed"))
+	End(parent, nil)
+
+	got := otlpSpanOf(spans[0])
+	if got.Name != "child" || got.ParentSpanID != spans[1].SpanContext().SpanID().String() {
+		t.Errorf("got span %q with parent %q, want child of %q", got.Name, got.ParentSpanID, spans[1].SpanContext().SpanID())
+	}
+	if got.Status.Code != otlpStatusError || got.Status.Message != "copy failed" {
+		t.Errorf("got status %+v, want error \"copy failed\"", got.Status)
+	}
+	attrs := make(map[string]otlpValue)
+	for _, kv := range got.Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	if v := attrs["dst_object"].StringValue; v == nil || *v != "obj" {
+		t.Errorf("got dst_object %v, want \"obj\"", v)
+	}
+	if v := attrs["bytes"].IntValue; v == nil || *v != "42" {
+		t.Errorf("got bytes %v, want \"42\"", v)
+	}
+	if v := attrs["final"].BoolValue; v == nil || !*v {
+		t.Errorf("got final %v, want true", v)
+	}
+	// RecordError adds an exception event after the retry event.
+	if len(got.Events) != 2 || got.Events[0].Name != "retry" {
+		t.Errorf("got events %+v, want retry and exception", got.Events)
+	}
+}
+
+// exporterFunc is a sdktrace.SpanExporter calling itself with the spans.
+type exporterFunc func([]sdktrace.ReadOnlySpan)
+
+func (f exporterFunc) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	f(spans)
+	return nil
+}
+
+func (f exporterFunc) Shutdown(ctx context.Context) error {
+	return nil
+}
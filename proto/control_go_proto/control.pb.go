@@ -23,8 +23,11 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 // Specifies bandwidth allocated to a job run.
 type JobRunBandwidth struct {
-	JobrunRelRsrcName    string   `protobuf:"bytes,1,opt,name=jobrun_rel_rsrc_name,json=jobrunRelRsrcName,proto3" json:"jobrun_rel_rsrc_name,omitempty"`
-	Bandwidth            int64    `protobuf:"varint,2,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	JobrunRelRsrcName string `protobuf:"bytes,1,opt,name=jobrun_rel_rsrc_name,json=jobrunRelRsrcName,proto3" json:"jobrun_rel_rsrc_name,omitempty"`
+	Bandwidth         int64  `protobuf:"varint,2,opt,name=bandwidth,proto3" json:"bandwidth,omitempty"`
+	// How long to spend copying a single file of the job run before yielding.
+	// If 0, the agent's copy-work-duration flag is used.
+	CopyWorkDurationMs   int64    `protobuf:"varint,3,opt,name=copy_work_duration_ms,json=copyWorkDurationMs,proto3" json:"copy_work_duration_ms,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -69,6 +72,13 @@ func (m *JobRunBandwidth) GetBandwidth() int64 {
 	return 0
 }
 
+func (m *JobRunBandwidth) GetCopyWorkDurationMs() int64 {
+	if m != nil {
+		return m.CopyWorkDurationMs
+	}
+	return 0
+}
+
 // This message contains the agent update source for a corresponding agent.
 type AgentUpdateSource struct {
 	AgentIds             []*pulse_go_proto.AgentId `protobuf:"bytes,1,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
@@ -158,22 +168,176 @@ func (m *AgentUpdate) GetAgentUpdateSources() []*AgentUpdateSource {
 	return nil
 }
 
+// Sets the glog verbosity (the --v flag) of the listed agents.
+type LogVerbosity struct {
+	AgentIds             []*pulse_go_proto.AgentId `protobuf:"bytes,1,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	Verbosity            int32                     `protobuf:"varint,2,opt,name=verbosity,proto3" json:"verbosity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *LogVerbosity) Reset()         { *m = LogVerbosity{} }
+func (m *LogVerbosity) String() string { return proto.CompactTextString(m) }
+func (*LogVerbosity) ProtoMessage()    {}
+func (*LogVerbosity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{3}
+}
+
+func (m *LogVerbosity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogVerbosity.Unmarshal(m, b)
+}
+func (m *LogVerbosity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogVerbosity.Marshal(b, m, deterministic)
+}
+func (m *LogVerbosity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogVerbosity.Merge(m, src)
+}
+func (m *LogVerbosity) XXX_Size() int {
+	return xxx_messageInfo_LogVerbosity.Size(m)
+}
+func (m *LogVerbosity) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogVerbosity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogVerbosity proto.InternalMessageInfo
+
+func (m *LogVerbosity) GetAgentIds() []*pulse_go_proto.AgentId {
+	if m != nil {
+		return m.AgentIds
+	}
+	return nil
+}
+
+func (m *LogVerbosity) GetVerbosity() int32 {
+	if m != nil {
+		return m.Verbosity
+	}
+	return 0
+}
+
+// Caps the total bandwidth (bytes per second) of the listed agents across all
+// job runs. A non-positive max_bytes_per_sec removes the cap.
+type AgentBandwidth struct {
+	AgentIds             []*pulse_go_proto.AgentId `protobuf:"bytes,1,rep,name=agent_ids,json=agentIds,proto3" json:"agent_ids,omitempty"`
+	MaxBytesPerSec       int64                     `protobuf:"varint,2,opt,name=max_bytes_per_sec,json=maxBytesPerSec,proto3" json:"max_bytes_per_sec,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
+}
+
+func (m *AgentBandwidth) Reset()         { *m = AgentBandwidth{} }
+func (m *AgentBandwidth) String() string { return proto.CompactTextString(m) }
+func (*AgentBandwidth) ProtoMessage()    {}
+func (*AgentBandwidth) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{4}
+}
+
+func (m *AgentBandwidth) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AgentBandwidth.Unmarshal(m, b)
+}
+func (m *AgentBandwidth) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AgentBandwidth.Marshal(b, m, deterministic)
+}
+func (m *AgentBandwidth) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AgentBandwidth.Merge(m, src)
+}
+func (m *AgentBandwidth) XXX_Size() int {
+	return xxx_messageInfo_AgentBandwidth.Size(m)
+}
+func (m *AgentBandwidth) XXX_DiscardUnknown() {
+	xxx_messageInfo_AgentBandwidth.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AgentBandwidth proto.InternalMessageInfo
+
+func (m *AgentBandwidth) GetAgentIds() []*pulse_go_proto.AgentId {
+	if m != nil {
+		return m.AgentIds
+	}
+	return nil
+}
+
+func (m *AgentBandwidth) GetMaxBytesPerSec() int64 {
+	if m != nil {
+		return m.MaxBytesPerSec
+	}
+	return 0
+}
+
+// Pauses or resumes a job run on the agents. A paused job run finishes the
+// chunks in flight, but takes no new work until it's resumed.
+type JobRunPause struct {
+	JobrunRelRsrcName    string   `protobuf:"bytes,1,opt,name=jobrun_rel_rsrc_name,json=jobrunRelRsrcName,proto3" json:"jobrun_rel_rsrc_name,omitempty"`
+	Paused               bool     `protobuf:"varint,2,opt,name=paused,proto3" json:"paused,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobRunPause) Reset()         { *m = JobRunPause{} }
+func (m *JobRunPause) String() string { return proto.CompactTextString(m) }
+func (*JobRunPause) ProtoMessage()    {}
+func (*JobRunPause) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c5120591600887d, []int{5}
+}
+
+func (m *JobRunPause) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobRunPause.Unmarshal(m, b)
+}
+func (m *JobRunPause) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobRunPause.Marshal(b, m, deterministic)
+}
+func (m *JobRunPause) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobRunPause.Merge(m, src)
+}
+func (m *JobRunPause) XXX_Size() int {
+	return xxx_messageInfo_JobRunPause.Size(m)
+}
+func (m *JobRunPause) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobRunPause.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobRunPause proto.InternalMessageInfo
+
+func (m *JobRunPause) GetJobrunRelRsrcName() string {
+	if m != nil {
+		return m.JobrunRelRsrcName
+	}
+	return ""
+}
+
+func (m *JobRunPause) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
 // Specifies the control messages to send to the agents for a specific project.
 type Control struct {
 	// The bandwidth associated for each active job run in the project.
 	JobRunsBandwidths []*JobRunBandwidth `protobuf:"bytes,1,rep,name=job_runs_bandwidths,json=jobRunsBandwidths,proto3" json:"job_runs_bandwidths,omitempty"`
 	// The agent update URL for each active agent in the project.
-	AgentUpdates         *AgentUpdate `protobuf:"bytes,2,opt,name=agent_updates,json=agentUpdates,proto3" json:"agent_updates,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	AgentUpdates *AgentUpdate `protobuf:"bytes,2,opt,name=agent_updates,json=agentUpdates,proto3" json:"agent_updates,omitempty"`
+	// The log verbosity for agents that should not use the default.
+	LogVerbosities []*LogVerbosity `protobuf:"bytes,3,rep,name=log_verbosities,json=logVerbosities,proto3" json:"log_verbosities,omitempty"`
+	// The bandwidth cap for agents that should not use their
+	// agent-max-bytes-per-sec flag.
+	AgentBandwidths []*AgentBandwidth `protobuf:"bytes,4,rep,name=agent_bandwidths,json=agentBandwidths,proto3" json:"agent_bandwidths,omitempty"`
+	// Job runs to pause or resume. Job runs that are not listed keep their
+	// paused state.
+	JobRunPauses         []*JobRunPause `protobuf:"bytes,5,rep,name=job_run_pauses,json=jobRunPauses,proto3" json:"job_run_pauses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
 func (m *Control) Reset()         { *m = Control{} }
 func (m *Control) String() string { return proto.CompactTextString(m) }
 func (*Control) ProtoMessage()    {}
 func (*Control) Descriptor() ([]byte, []int) {
-	return fileDescriptor_0c5120591600887d, []int{3}
+	return fileDescriptor_0c5120591600887d, []int{6}
 }
 
 func (m *Control) XXX_Unmarshal(b []byte) error {
@@ -208,37 +372,73 @@ func (m *Control) GetAgentUpdates() *AgentUpdate {
 	return nil
 }
 
+func (m *Control) GetLogVerbosities() []*LogVerbosity {
+	if m != nil {
+		return m.LogVerbosities
+	}
+	return nil
+}
+
+func (m *Control) GetAgentBandwidths() []*AgentBandwidth {
+	if m != nil {
+		return m.AgentBandwidths
+	}
+	return nil
+}
+
+func (m *Control) GetJobRunPauses() []*JobRunPause {
+	if m != nil {
+		return m.JobRunPauses
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*JobRunBandwidth)(nil), "cloud_ingest_control.JobRunBandwidth")
 	proto.RegisterType((*AgentUpdateSource)(nil), "cloud_ingest_control.AgentUpdateSource")
 	proto.RegisterType((*AgentUpdate)(nil), "cloud_ingest_control.AgentUpdate")
+	proto.RegisterType((*LogVerbosity)(nil), "cloud_ingest_control.LogVerbosity")
+	proto.RegisterType((*AgentBandwidth)(nil), "cloud_ingest_control.AgentBandwidth")
+	proto.RegisterType((*JobRunPause)(nil), "cloud_ingest_control.JobRunPause")
 	proto.RegisterType((*Control)(nil), "cloud_ingest_control.Control")
 }
 
 func init() { proto.RegisterFile("control.proto", fileDescriptor_0c5120591600887d) }
 
 var fileDescriptor_0c5120591600887d = []byte{
-	// 348 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0x4d, 0x4b, 0xf3, 0x40,
-	0x14, 0x85, 0xc9, 0x5b, 0x78, 0x35, 0x13, 0x8b, 0x74, 0xec, 0xa2, 0xf8, 0x01, 0x35, 0x20, 0x76,
-	0x63, 0x02, 0x75, 0xe3, 0xd6, 0x56, 0x14, 0x5d, 0x88, 0x8c, 0x74, 0xa1, 0x9b, 0x71, 0x92, 0x8c,
-	0x69, 0xca, 0x64, 0x6e, 0x99, 0x0f, 0xfc, 0x4b, 0xfe, 0x4c, 0xe9, 0x4c, 0x5b, 0x63, 0x2d, 0xb8,
-	0x4a, 0x38, 0x37, 0xe7, 0x3c, 0xe7, 0x5e, 0x82, 0xda, 0x39, 0x48, 0xa3, 0x40, 0x24, 0x73, 0x05,
-	0x06, 0x70, 0x37, 0x17, 0x60, 0x0b, 0x5a, 0xc9, 0x92, 0x6b, 0x43, 0x97, 0xb3, 0xc3, 0x68, 0x6e,
-	0x85, 0xe6, 0xfe, 0x93, 0xf8, 0x0d, 0xed, 0x3f, 0x40, 0x46, 0xac, 0x1c, 0x31, 0x59, 0x7c, 0x54,
-	0x85, 0x99, 0xe2, 0x14, 0x75, 0x67, 0x90, 0x29, 0x2b, 0xa9, 0xe2, 0x82, 0x2a, 0xad, 0x72, 0x2a,
-	0x59, 0xcd, 0x7b, 0x41, 0x3f, 0x18, 0x84, 0xa4, 0xe3, 0x67, 0x84, 0x0b, 0xa2, 0x55, 0xfe, 0xc8,
-	0x6a, 0x8e, 0x8f, 0x51, 0x98, 0xad, 0xdc, 0xbd, 0x7f, 0xfd, 0x60, 0xd0, 0x22, 0xdf, 0x42, 0x2c,
-	0x50, 0xe7, 0xba, 0xe4, 0xd2, 0x4c, 0xe6, 0x05, 0x33, 0xfc, 0x19, 0xac, 0xca, 0x39, 0xbe, 0x42,
-	0x21, 0x5b, 0x88, 0xb4, 0x2a, 0x74, 0x2f, 0xe8, 0xb7, 0x06, 0xd1, 0xf0, 0x28, 0xf9, 0xd1, 0xd6,
-	0x97, 0x74, 0xce, 0xfb, 0x82, 0xec, 0x32, 0xff, 0xa2, 0xf1, 0x09, 0x42, 0xd6, 0x25, 0x51, 0xab,
-	0x84, 0xa3, 0x85, 0x24, 0xf4, 0xca, 0x44, 0x89, 0x78, 0x8a, 0xa2, 0x06, 0x0d, 0xbf, 0xa0, 0xae,
-	0xe7, 0x2c, 0x3d, 0xda, 0xe1, 0x57, 0xc8, 0xf3, 0x64, 0xdb, 0x81, 0x92, 0x5f, 0x75, 0x09, 0x66,
-	0x9b, 0x92, 0x8e, 0x3f, 0x03, 0xb4, 0x33, 0xf6, 0x0e, 0x3c, 0x41, 0x07, 0x33, 0xc8, 0xa8, 0xb2,
-	0x52, 0xd3, 0xf5, 0xe6, 0x2b, 0xca, 0xd9, 0x76, 0xca, 0xc6, 0xd9, 0xdd, 0x61, 0x89, 0x95, 0x7a,
-	0xad, 0x68, 0x7c, 0x8b, 0xda, 0xcd, 0xf6, 0xda, 0xad, 0x1b, 0x0d, 0x4f, 0xff, 0xac, 0x4d, 0xf6,
-	0x1a, 0x85, 0xf5, 0xe8, 0xe6, 0x75, 0x54, 0x56, 0x66, 0x6a, 0xb3, 0x24, 0x87, 0x3a, 0xbd, 0x03,
-	0x28, 0x05, 0x1f, 0x2f, 0x22, 0x9e, 0x04, 0x33, 0xef, 0xa0, 0xea, 0xd4, 0x05, 0x5e, 0xf8, 0xc0,
-	0xd4, 0xfd, 0x19, 0xe9, 0x32, 0x96, 0x96, 0x40, 0x9d, 0x90, 0xfd, 0x77, 0x8f, 0xcb, 0xaf, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xeb, 0xdb, 0xbb, 0xe7, 0x65, 0x02, 0x00, 0x00,
+	// 540 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x54, 0x4d, 0x6f, 0xd3, 0x40,
+	0x10, 0x55, 0x08, 0x2d, 0xf5, 0xa4, 0x4d, 0xc8, 0x12, 0x50, 0x04, 0x45, 0x0a, 0x16, 0x88, 0x70,
+	0x20, 0x11, 0xe5, 0xc2, 0x95, 0xb4, 0xa2, 0xe2, 0x3b, 0xda, 0x2a, 0x45, 0x70, 0x59, 0xad, 0xed,
+	0xad, 0xe3, 0x74, 0xed, 0xb5, 0x76, 0xbc, 0xb4, 0xf9, 0x15, 0x1c, 0xf8, 0xc3, 0xc8, 0x6b, 0xa7,
+	0x76, 0x4b, 0x00, 0x01, 0xa7, 0x64, 0xde, 0xec, 0xbc, 0x99, 0x37, 0x6f, 0x64, 0xd8, 0xf1, 0x55,
+	0x92, 0x69, 0x25, 0x47, 0xa9, 0x56, 0x99, 0x22, 0x3d, 0x5f, 0x2a, 0x13, 0xb0, 0x28, 0x09, 0x05,
+	0x66, 0xac, 0xcc, 0xdd, 0x6d, 0xa5, 0x46, 0xa2, 0x28, 0x9e, 0xb8, 0xdf, 0x1b, 0xd0, 0x79, 0xa3,
+	0x3c, 0x6a, 0x92, 0x09, 0x4f, 0x82, 0xb3, 0x28, 0xc8, 0xe6, 0x64, 0x0c, 0xbd, 0x85, 0xf2, 0xb4,
+	0x49, 0x98, 0x16, 0x92, 0x69, 0xd4, 0x3e, 0x4b, 0x78, 0x2c, 0xfa, 0x8d, 0x41, 0x63, 0xe8, 0xd0,
+	0x6e, 0x91, 0xa3, 0x42, 0x52, 0xd4, 0xfe, 0x07, 0x1e, 0x0b, 0xb2, 0x0b, 0x8e, 0xb7, 0xaa, 0xee,
+	0x5f, 0x1b, 0x34, 0x86, 0x4d, 0x5a, 0x01, 0xe4, 0x19, 0xdc, 0xf6, 0x55, 0xba, 0x64, 0x67, 0x4a,
+	0x9f, 0xb2, 0xc0, 0x68, 0x9e, 0x45, 0x2a, 0x61, 0x31, 0xf6, 0x9b, 0xf6, 0x25, 0xc9, 0x93, 0x9f,
+	0x94, 0x3e, 0x3d, 0x28, 0x53, 0xef, 0xd1, 0x95, 0xd0, 0x7d, 0x19, 0x8a, 0x24, 0x9b, 0xa5, 0x01,
+	0xcf, 0xc4, 0x91, 0x32, 0xda, 0x17, 0xe4, 0x05, 0x38, 0x3c, 0x07, 0x59, 0x14, 0x60, 0xbf, 0x31,
+	0x68, 0x0e, 0x5b, 0x7b, 0xf7, 0x46, 0x97, 0x14, 0x16, 0xc2, 0x6c, 0xe5, 0xeb, 0x80, 0x6e, 0xf1,
+	0xe2, 0x0f, 0x92, 0xfb, 0x00, 0xc6, 0x32, 0x31, 0xa3, 0xa5, 0x1d, 0xd0, 0xa1, 0x4e, 0x81, 0xcc,
+	0xb4, 0x74, 0xe7, 0xd0, 0xaa, 0x75, 0x23, 0x9f, 0xa1, 0x57, 0xf4, 0x29, 0x6b, 0xd0, 0xb6, 0x5f,
+	0xb5, 0x7c, 0x3c, 0x5a, 0xb7, 0xd4, 0xd1, 0x4f, 0xe3, 0x52, 0xc2, 0xaf, 0x42, 0xe8, 0x9e, 0xc0,
+	0xf6, 0x3b, 0x15, 0x1e, 0x0b, 0xed, 0x29, 0x8c, 0xb2, 0xe5, 0x7f, 0x48, 0xda, 0x05, 0xe7, 0xeb,
+	0x8a, 0xc6, 0x2a, 0xda, 0xa0, 0x15, 0xe0, 0x1a, 0x68, 0xdb, 0x92, 0xca, 0xd3, 0x7f, 0xef, 0xf4,
+	0x04, 0xba, 0x31, 0x3f, 0x67, 0xde, 0x32, 0x13, 0xc8, 0x52, 0xa1, 0x19, 0x0a, 0xbf, 0x34, 0xb9,
+	0x1d, 0xf3, 0xf3, 0x49, 0x8e, 0x4f, 0x85, 0x3e, 0x12, 0xbe, 0x7b, 0x0c, 0xad, 0xe2, 0x96, 0xa6,
+	0xdc, 0xa0, 0xf8, 0xfb, 0x3b, 0xba, 0x03, 0x9b, 0x69, 0x5e, 0x19, 0x58, 0xfe, 0x2d, 0x5a, 0x46,
+	0xee, 0xb7, 0x26, 0xdc, 0xd8, 0x2f, 0x16, 0x4d, 0x66, 0x70, 0x6b, 0xa1, 0x3c, 0xa6, 0x4d, 0x82,
+	0xec, 0xe2, 0xc6, 0x56, 0x92, 0x1e, 0xad, 0x37, 0xe7, 0xca, 0x81, 0xdb, 0xd6, 0xd4, 0x24, 0x78,
+	0x81, 0x20, 0x79, 0x05, 0x3b, 0x75, 0xd3, 0xd1, 0x4e, 0xd0, 0xda, 0x7b, 0xf0, 0x47, 0xb7, 0xe9,
+	0x76, 0xcd, 0x67, 0x24, 0x6f, 0xa1, 0x23, 0x55, 0xc8, 0x56, 0x56, 0x44, 0x22, 0x3f, 0xf3, 0x7c,
+	0x34, 0x77, 0x3d, 0x53, 0xfd, 0x1c, 0x68, 0x5b, 0x56, 0x51, 0x24, 0x90, 0x7c, 0x84, 0x9b, 0xc5,
+	0x50, 0x35, 0xa1, 0xd7, 0x2d, 0xdb, 0xc3, 0xdf, 0xcc, 0x55, 0xe9, 0xec, 0xf0, 0x4b, 0x31, 0x92,
+	0x43, 0x68, 0x97, 0xcb, 0x63, 0x76, 0xb5, 0xd8, 0xdf, 0x18, 0x34, 0x7f, 0x2d, 0xb3, 0x66, 0x26,
+	0xdd, 0x5e, 0x54, 0x01, 0x4e, 0x0e, 0xbe, 0x4c, 0xc2, 0x28, 0x9b, 0x1b, 0x6f, 0xe4, 0xab, 0x78,
+	0x7c, 0xa8, 0x54, 0x28, 0xc5, 0x7e, 0x4e, 0x31, 0x95, 0x3c, 0x3b, 0x51, 0x3a, 0x1e, 0x5b, 0xc2,
+	0xa7, 0x05, 0xe1, 0xd8, 0x7e, 0x6b, 0xc6, 0x25, 0x2d, 0x0b, 0x15, 0xb3, 0x80, 0xb7, 0x69, 0x7f,
+	0x9e, 0xff, 0x18, 0x00, 0x18, 0x5a, 0xcf, 0x20, 0xb7, 0x04, 0x00, 0x00,
 }
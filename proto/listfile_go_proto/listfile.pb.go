@@ -6,6 +6,7 @@ package listfile_go_proto
 import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	math "math"
 )
 
@@ -123,10 +124,13 @@ type FileInfo struct {
 	// Last modified time of the file in seconds since the epoch.
 	LastModifiedTime int64 `protobuf:"varint,2,opt,name=last_modified_time,json=lastModifiedTime,proto3" json:"last_modified_time,omitempty"`
 	// The size of the file in bytes.
-	Size                 int64    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Size int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// The CRC32C of the file, only set by agents run with list-file-checksums.
+	// It can be passed on as the copy's src_file_crc32c.
+	Crc32C               *wrappers.UInt32Value `protobuf:"bytes,4,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
 func (m *FileInfo) Reset()         { *m = FileInfo{} }
@@ -175,6 +179,13 @@ func (m *FileInfo) GetSize() int64 {
 	return 0
 }
 
+func (m *FileInfo) GetCrc32C() *wrappers.UInt32Value {
+	if m != nil {
+		return m.Crc32C
+	}
+	return nil
+}
+
 // Represents a single directory's metadata.
 type DirectoryInfo struct {
 	// The full path of the directory in the format used by the local OS.
@@ -279,26 +290,29 @@ func init() {
 func init() { proto.RegisterFile("listfile.proto", fileDescriptor_944e22c88393983d) }
 
 var fileDescriptor_944e22c88393983d = []byte{
-	// 327 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xcf, 0x4b, 0xfb, 0x40,
-	0x10, 0xc5, 0xdb, 0x6f, 0xbf, 0x6a, 0x3b, 0xa5, 0x3f, 0x58, 0x10, 0x7a, 0xab, 0x44, 0x11, 0x0f,
-	0x9a, 0x80, 0xde, 0x3d, 0xb4, 0xb6, 0x56, 0xb0, 0x20, 0xd1, 0x93, 0x97, 0x35, 0xed, 0x4e, 0xd2,
-	0x81, 0xdd, 0x6c, 0x49, 0x36, 0x87, 0xfa, 0xb7, 0x7b, 0x90, 0xdd, 0x26, 0x68, 0xa5, 0xe2, 0x29,
-	0xc3, 0xbc, 0xdd, 0x4f, 0xde, 0x7b, 0x2c, 0x74, 0x25, 0xe5, 0x26, 0x26, 0x89, 0xfe, 0x3a, 0xd3,
-	0x46, 0xb3, 0xe3, 0xa5, 0xd4, 0x85, 0xe0, 0x94, 0x26, 0x98, 0x1b, 0x5e, 0x89, 0xde, 0x47, 0x1d,
-	0x3a, 0x8f, 0x94, 0x9b, 0x29, 0x49, 0x9c, 0xa4, 0x26, 0xdb, 0xb0, 0x5b, 0x68, 0x59, 0x85, 0x53,
-	0x1a, 0xeb, 0x41, 0xfd, 0xa4, 0x7e, 0xd1, 0xbe, 0x1e, 0xfa, 0x7b, 0x2f, 0xfb, 0xf6, 0xd2, 0x43,
-	0x1a, 0xeb, 0x59, 0x2d, 0x6c, 0xc6, 0xe5, 0xcc, 0xe6, 0xd0, 0x15, 0x94, 0xe1, 0xd2, 0xe8, 0x6c,
-	0xb3, 0x85, 0xfc, 0x73, 0x90, 0xb3, 0x5f, 0x20, 0x77, 0xd5, 0xe1, 0x92, 0xd4, 0x11, 0xdf, 0x17,
-	0xec, 0x19, 0xfa, 0x5f, 0xb8, 0x15, 0x46, 0x02, 0xb3, 0x41, 0xc3, 0x01, 0xcf, 0xff, 0x02, 0xce,
-	0xdc, 0xe9, 0x59, 0x2d, 0xec, 0x89, 0xdd, 0xd5, 0xe8, 0x08, 0x0e, 0xd0, 0x86, 0xf5, 0xde, 0xa0,
-	0x59, 0x85, 0x60, 0x0c, 0xfe, 0xaf, 0x23, 0xb3, 0x72, 0x99, 0x5b, 0xa1, 0x9b, 0xd9, 0x25, 0x30,
-	0x19, 0xe5, 0x86, 0x2b, 0x2d, 0x28, 0x26, 0x14, 0xdc, 0x90, 0x42, 0x17, 0xa8, 0x11, 0xf6, 0xad,
-	0x32, 0x2f, 0x85, 0x17, 0x52, 0x68, 0x09, 0x39, 0xbd, 0xa3, 0xf3, 0xd7, 0x08, 0xdd, 0xec, 0x9d,
-	0x42, 0x67, 0x27, 0xe1, 0xbe, 0xdf, 0x78, 0x53, 0xe8, 0xfd, 0x70, 0xbd, 0xd7, 0xcd, 0x10, 0xda,
-	0x69, 0xa1, 0xb8, 0xb5, 0x4e, 0x98, 0x97, 0x36, 0x20, 0x2d, 0xd4, 0x64, 0xbb, 0x19, 0x4d, 0x5e,
-	0xc7, 0x09, 0x99, 0x55, 0xb1, 0xf0, 0x97, 0x5a, 0x05, 0xf7, 0x5a, 0x27, 0x12, 0xc7, 0xb6, 0xa4,
-	0x27, 0x19, 0x99, 0x58, 0x67, 0x2a, 0x70, 0x95, 0x5d, 0x6d, 0x2b, 0x0b, 0xdc, 0xcb, 0x08, 0xaa,
-	0xe2, 0x78, 0xa2, 0xb9, 0xdb, 0x2c, 0x0e, 0xdd, 0xe7, 0xe6, 0x33, 0x00, 0x00, 0xff, 0xff, 0xac,
-	0x0f, 0x80, 0xd6, 0x44, 0x02, 0x00, 0x00,
+	// 377 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0x4b, 0x4b, 0xfb, 0x40,
+	0x14, 0xc5, 0x9b, 0x7f, 0xfb, 0xaf, 0xed, 0x94, 0x3e, 0x18, 0x10, 0x8a, 0x88, 0x95, 0x28, 0xe2,
+	0x42, 0x13, 0x68, 0x5d, 0xbb, 0x68, 0x6d, 0x6d, 0xc1, 0x82, 0xc4, 0xc7, 0xc2, 0xcd, 0x90, 0x26,
+	0x37, 0xe9, 0xc0, 0x24, 0x13, 0x26, 0x13, 0xa4, 0x7e, 0x10, 0x3f, 0xad, 0x0b, 0xc9, 0x4c, 0x82,
+	0x56, 0x2a, 0xae, 0x72, 0xb9, 0x8f, 0x1f, 0xe7, 0x9c, 0x09, 0xea, 0x30, 0x9a, 0xca, 0x80, 0x32,
+	0xb0, 0x12, 0xc1, 0x25, 0xc7, 0xfb, 0x1e, 0xe3, 0x99, 0x4f, 0x68, 0x1c, 0x42, 0x2a, 0x49, 0x39,
+	0x3c, 0x38, 0x0a, 0x39, 0x0f, 0x19, 0xd8, 0x6a, 0x69, 0x95, 0x05, 0xf6, 0xab, 0x70, 0x93, 0x04,
+	0x44, 0xaa, 0xcf, 0xcc, 0x0f, 0x03, 0xb5, 0xef, 0x68, 0x2a, 0x67, 0x94, 0xc1, 0x34, 0x96, 0x62,
+	0x83, 0xaf, 0x51, 0x33, 0xbf, 0x24, 0x34, 0x0e, 0x78, 0xdf, 0x38, 0x36, 0xce, 0x5b, 0xc3, 0x81,
+	0xb5, 0x13, 0x6e, 0xe5, 0x47, 0x8b, 0x38, 0xe0, 0xf3, 0x8a, 0xd3, 0x08, 0x8a, 0x1a, 0x2f, 0x51,
+	0xc7, 0xa7, 0x02, 0x3c, 0xc9, 0xc5, 0x46, 0x43, 0xfe, 0x29, 0xc8, 0xe9, 0x2f, 0x90, 0x9b, 0x72,
+	0xb9, 0x20, 0xb5, 0xfd, 0xef, 0x0d, 0xfc, 0x80, 0x7a, 0x5f, 0xb8, 0x35, 0xb8, 0x3e, 0x88, 0x7e,
+	0x55, 0x01, 0xcf, 0xfe, 0x02, 0xce, 0xd5, 0xf6, 0xbc, 0xe2, 0x74, 0xfd, 0xed, 0xd6, 0x78, 0x0f,
+	0xfd, 0x87, 0xdc, 0xac, 0xf9, 0x6e, 0xa0, 0x46, 0xe9, 0x02, 0x63, 0x54, 0x4b, 0x5c, 0xb9, 0x56,
+	0xa6, 0x9b, 0x8e, 0xaa, 0xf1, 0x05, 0xc2, 0xcc, 0x4d, 0x25, 0x89, 0xb8, 0x4f, 0x03, 0x0a, 0x3e,
+	0x91, 0x34, 0x02, 0xe5, 0xa8, 0xea, 0xf4, 0xf2, 0xc9, 0xb2, 0x18, 0x3c, 0xd2, 0x08, 0x72, 0x42,
+	0x4a, 0xdf, 0x40, 0x09, 0xac, 0x3a, 0xaa, 0xc6, 0x57, 0xa8, 0xee, 0x09, 0x6f, 0x34, 0xf4, 0xfa,
+	0x35, 0x25, 0xfb, 0xd0, 0xd2, 0x4f, 0x62, 0x95, 0x4f, 0x62, 0x3d, 0x2d, 0x62, 0x39, 0x1a, 0x3e,
+	0xbb, 0x2c, 0x03, 0xa7, 0xd8, 0x35, 0x4f, 0x50, 0x7b, 0x2b, 0x98, 0x5d, 0xe2, 0xcc, 0x19, 0xea,
+	0xfe, 0x30, 0xbb, 0xd3, 0xc3, 0x00, 0xb5, 0xe2, 0x2c, 0x22, 0xb9, 0x63, 0x0a, 0x69, 0x21, 0x1e,
+	0xc5, 0x59, 0x34, 0xd5, 0x9d, 0xf1, 0xf4, 0x65, 0x12, 0x52, 0xb9, 0xce, 0x56, 0x96, 0xc7, 0x23,
+	0xfb, 0x56, 0xc9, 0x9b, 0xe4, 0xd9, 0xde, 0x33, 0x57, 0x06, 0x5c, 0x44, 0xb6, 0x4a, 0xfa, 0x52,
+	0x27, 0xad, 0xff, 0x25, 0xbb, 0xcc, 0x9b, 0x84, 0x9c, 0x68, 0x2b, 0x75, 0xf5, 0x19, 0x7d, 0x0e,
+	0x00, 0x2d, 0xed, 0x9a, 0x5f, 0x9b, 0x02, 0x00, 0x00,
 }
@@ -37,6 +37,15 @@ type Msg struct {
 	CopyReadMs                int64 `protobuf:"varint,12,opt,name=copy_read_ms,json=copyReadMs,proto3" json:"copy_read_ms,omitempty"`
 	CopyWriteMs               int64 `protobuf:"varint,13,opt,name=copy_write_ms,json=copyWriteMs,proto3" json:"copy_write_ms,omitempty"`
 	CopyInternalRetries       int64 `protobuf:"varint,14,opt,name=copy_internal_retries,json=copyInternalRetries,proto3" json:"copy_internal_retries,omitempty"`
+	// Duration in millis spent reading source ahead of, and overlapping with,
+	// writing to destination.
+	CopyReadOverlapMs int64 `protobuf:"varint,26,opt,name=copy_read_overlap_ms,json=copyReadOverlapMs,proto3" json:"copy_read_overlap_ms,omitempty"`
+	// Files copied server-side from an object with the same content, and their
+	// bytes which weren't read or uploaded.
+	CopyDedupHits  int64 `protobuf:"varint,27,opt,name=copy_dedup_hits,json=copyDedupHits,proto3" json:"copy_dedup_hits,omitempty"`
+	CopyDedupBytes int64 `protobuf:"varint,28,opt,name=copy_dedup_bytes,json=copyDedupBytes,proto3" json:"copy_dedup_bytes,omitempty"`
+	// Bytes of resumable copy requests which were sent again by a retry.
+	CopyResentBytes int64 `protobuf:"varint,29,opt,name=copy_resent_bytes,json=copyResentBytes,proto3" json:"copy_resent_bytes,omitempty"`
 	// Duration in millis spent opening directories.
 	ListDirOpenMs int64 `protobuf:"varint,15,opt,name=list_dir_open_ms,json=listDirOpenMs,proto3" json:"list_dir_open_ms,omitempty"`
 	// Duration in millis spent reading directories.
@@ -44,10 +53,22 @@ type Msg struct {
 	// Duration in millis spent writing file listing output.
 	ListFileWriteMs int64 `protobuf:"varint,17,opt,name=list_file_write_ms,json=listFileWriteMs,proto3" json:"list_file_write_ms,omitempty"`
 	// Duration in millis spent writing unexplored dir listing output.
-	ListDirWriteMs       int64    `protobuf:"varint,18,opt,name=list_dir_write_ms,json=listDirWriteMs,proto3" json:"list_dir_write_ms,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	ListDirWriteMs int64 `protobuf:"varint,18,opt,name=list_dir_write_ms,json=listDirWriteMs,proto3" json:"list_dir_write_ms,omitempty"`
+	// Duration in millis the task messages that finished processing were held,
+	// during which the Pub/Sub client kept extending their leases.
+	TaskHeldMs int64 `protobuf:"varint,25,opt,name=task_held_ms,json=taskHeldMs,proto3" json:"task_held_ms,omitempty"`
+	// Below measurements are live when the pulse is sent, and aren't reset.
+	AgentLifetimeCopiedBytes int64 `protobuf:"varint,19,opt,name=agent_lifetime_copied_bytes,json=agentLifetimeCopiedBytes,proto3" json:"agent_lifetime_copied_bytes,omitempty"`
+	CopyTasksInFlight        int64 `protobuf:"varint,20,opt,name=copy_tasks_in_flight,json=copyTasksInFlight,proto3" json:"copy_tasks_in_flight,omitempty"`
+	ListTasksInFlight        int64 `protobuf:"varint,21,opt,name=list_tasks_in_flight,json=listTasksInFlight,proto3" json:"list_tasks_in_flight,omitempty"`
+	// Metadata of the machine running the agent, collected once at startup. It's
+	// unset until it has been collected.
+	MachineInfo          *MachineInfo `protobuf:"bytes,22,opt,name=machine_info,json=machineInfo,proto3" json:"machine_info,omitempty"`
+	NumGoroutines        int64        `protobuf:"varint,23,opt,name=num_goroutines,json=numGoroutines,proto3" json:"num_goroutines,omitempty"`
+	HeapAllocBytes       int64        `protobuf:"varint,24,opt,name=heap_alloc_bytes,json=heapAllocBytes,proto3" json:"heap_alloc_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *Msg) Reset()         { *m = Msg{} }
@@ -159,6 +180,34 @@ func (m *Msg) GetCopyInternalRetries() int64 {
 	return 0
 }
 
+func (m *Msg) GetCopyReadOverlapMs() int64 {
+	if m != nil {
+		return m.CopyReadOverlapMs
+	}
+	return 0
+}
+
+func (m *Msg) GetCopyDedupHits() int64 {
+	if m != nil {
+		return m.CopyDedupHits
+	}
+	return 0
+}
+
+func (m *Msg) GetCopyDedupBytes() int64 {
+	if m != nil {
+		return m.CopyDedupBytes
+	}
+	return 0
+}
+
+func (m *Msg) GetCopyResentBytes() int64 {
+	if m != nil {
+		return m.CopyResentBytes
+	}
+	return 0
+}
+
 func (m *Msg) GetListDirOpenMs() int64 {
 	if m != nil {
 		return m.ListDirOpenMs
@@ -187,8 +236,120 @@ func (m *Msg) GetListDirWriteMs() int64 {
 	return 0
 }
 
+func (m *Msg) GetTaskHeldMs() int64 {
+	if m != nil {
+		return m.TaskHeldMs
+	}
+	return 0
+}
+
+func (m *Msg) GetAgentLifetimeCopiedBytes() int64 {
+	if m != nil {
+		return m.AgentLifetimeCopiedBytes
+	}
+	return 0
+}
+
+func (m *Msg) GetCopyTasksInFlight() int64 {
+	if m != nil {
+		return m.CopyTasksInFlight
+	}
+	return 0
+}
+
+func (m *Msg) GetListTasksInFlight() int64 {
+	if m != nil {
+		return m.ListTasksInFlight
+	}
+	return 0
+}
+
+func (m *Msg) GetMachineInfo() *MachineInfo {
+	if m != nil {
+		return m.MachineInfo
+	}
+	return nil
+}
+
+func (m *Msg) GetNumGoroutines() int64 {
+	if m != nil {
+		return m.NumGoroutines
+	}
+	return 0
+}
+
+func (m *Msg) GetHeapAllocBytes() int64 {
+	if m != nil {
+		return m.HeapAllocBytes
+	}
+	return 0
+}
+
 // This message stores a unique identifier for each agent.
 // The DCP can use this to separate each agent and monitor future behaviors.
+type MachineInfo struct {
+	Os                   string   `protobuf:"bytes,1,opt,name=os,proto3" json:"os,omitempty"`
+	Arch                 string   `protobuf:"bytes,2,opt,name=arch,proto3" json:"arch,omitempty"`
+	CpuCount             int64    `protobuf:"varint,3,opt,name=cpu_count,json=cpuCount,proto3" json:"cpu_count,omitempty"`
+	TotalMemoryBytes     int64    `protobuf:"varint,4,opt,name=total_memory_bytes,json=totalMemoryBytes,proto3" json:"total_memory_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MachineInfo) Reset()         { *m = MachineInfo{} }
+func (m *MachineInfo) String() string { return proto.CompactTextString(m) }
+func (*MachineInfo) ProtoMessage()    {}
+func (*MachineInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c067e3d82b299225, []int{1}
+}
+
+func (m *MachineInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MachineInfo.Unmarshal(m, b)
+}
+func (m *MachineInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MachineInfo.Marshal(b, m, deterministic)
+}
+func (m *MachineInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MachineInfo.Merge(m, src)
+}
+func (m *MachineInfo) XXX_Size() int {
+	return xxx_messageInfo_MachineInfo.Size(m)
+}
+func (m *MachineInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_MachineInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MachineInfo proto.InternalMessageInfo
+
+func (m *MachineInfo) GetOs() string {
+	if m != nil {
+		return m.Os
+	}
+	return ""
+}
+
+func (m *MachineInfo) GetArch() string {
+	if m != nil {
+		return m.Arch
+	}
+	return ""
+}
+
+func (m *MachineInfo) GetCpuCount() int64 {
+	if m != nil {
+		return m.CpuCount
+	}
+	return 0
+}
+
+func (m *MachineInfo) GetTotalMemoryBytes() int64 {
+	if m != nil {
+		return m.TotalMemoryBytes
+	}
+	return 0
+}
+
 type AgentId struct {
 	HostName             string   `protobuf:"bytes,1,opt,name=host_name,json=hostName,proto3" json:"host_name,omitempty"`
 	ProcessId            string   `protobuf:"bytes,2,opt,name=process_id,json=processId,proto3" json:"process_id,omitempty"`
@@ -203,7 +364,7 @@ func (m *AgentId) Reset()         { *m = AgentId{} }
 func (m *AgentId) String() string { return proto.CompactTextString(m) }
 func (*AgentId) ProtoMessage()    {}
 func (*AgentId) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c067e3d82b299225, []int{1}
+	return fileDescriptor_c067e3d82b299225, []int{2}
 }
 
 func (m *AgentId) XXX_Unmarshal(b []byte) error {
@@ -254,44 +415,64 @@ func (m *AgentId) GetContainerId() string {
 
 func init() {
 	proto.RegisterType((*Msg)(nil), "cloud_ingest_pulse.Msg")
+	proto.RegisterType((*MachineInfo)(nil), "cloud_ingest_pulse.MachineInfo")
 	proto.RegisterType((*AgentId)(nil), "cloud_ingest_pulse.AgentId")
 }
 
 func init() { proto.RegisterFile("pulse.proto", fileDescriptor_c067e3d82b299225) }
 
 var fileDescriptor_c067e3d82b299225 = []byte{
-	// 526 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x93, 0xdd, 0x6e, 0x13, 0x31,
-	0x10, 0x85, 0x95, 0xa6, 0x4d, 0x93, 0xc9, 0x6f, 0x8d, 0x0a, 0x8b, 0x0a, 0x52, 0x08, 0x08, 0x82,
-	0x10, 0x89, 0x54, 0xa4, 0xde, 0x02, 0xa1, 0x02, 0xa5, 0x6a, 0x00, 0x2d, 0x7f, 0x12, 0x37, 0x96,
-	0x93, 0x9d, 0x6c, 0xad, 0xee, 0xda, 0x2b, 0xdb, 0x01, 0x7a, 0xc7, 0x9b, 0xf1, 0x6a, 0xc8, 0xe3,
-	0x6d, 0x12, 0xe8, 0x55, 0xe2, 0x73, 0xbe, 0xe3, 0x1d, 0xdb, 0x33, 0xd0, 0x2c, 0x56, 0x99, 0xc5,
-	0x51, 0x61, 0xb4, 0xd3, 0x8c, 0x2d, 0x32, 0xbd, 0x4a, 0xb8, 0x54, 0x29, 0x5a, 0xc7, 0xc9, 0x19,
-	0xfc, 0xd9, 0x83, 0xea, 0xcc, 0xa6, 0xec, 0x04, 0xea, 0x22, 0x45, 0xe5, 0xb8, 0x4c, 0xa2, 0x4a,
-	0xbf, 0x32, 0x6c, 0x1e, 0x1f, 0x8d, 0x6e, 0xe2, 0xa3, 0xd7, 0x9e, 0x99, 0x26, 0xf1, 0xbe, 0x08,
-	0x7f, 0xd8, 0x43, 0x68, 0x87, 0xdc, 0x0f, 0x34, 0x56, 0x6a, 0x15, 0x55, 0xfb, 0x95, 0x61, 0x23,
-	0x6e, 0x91, 0xf8, 0x35, 0x68, 0xec, 0x11, 0x74, 0x02, 0x94, 0xe9, 0xd4, 0xf2, 0x44, 0x9a, 0x68,
-	0x77, 0x8b, 0x3a, 0xd7, 0xa9, 0x3d, 0x95, 0x86, 0x3d, 0x86, 0x6e, 0xa0, 0x56, 0x85, 0x93, 0x39,
-	0xf2, 0xdc, 0x46, 0xfb, 0xfd, 0xca, 0xb0, 0x1a, 0x87, 0x2f, 0x7c, 0x21, 0x75, 0x66, 0xd9, 0x09,
-	0xdc, 0x09, 0x9c, 0x33, 0x42, 0xd9, 0x25, 0x1a, 0x83, 0x09, 0x9f, 0x5f, 0x39, 0xb4, 0x51, 0x8d,
-	0xf8, 0x43, 0xb2, 0x3f, 0x6f, 0xdc, 0x89, 0x37, 0xd9, 0x4b, 0xb8, 0x77, 0x33, 0x97, 0x49, 0xeb,
-	0xca, 0x70, 0x9d, 0xc2, 0x77, 0xff, 0x0f, 0x9f, 0x4b, 0xeb, 0xc2, 0x06, 0x7d, 0x68, 0x2d, 0x74,
-	0x71, 0xc5, 0x75, 0x81, 0xca, 0x57, 0xd7, 0xa0, 0x00, 0x78, 0xed, 0x43, 0x81, 0x6a, 0xb6, 0x21,
-	0xac, 0x13, 0xce, 0x13, 0xb0, 0x21, 0x3e, 0x39, 0xe1, 0xb6, 0x09, 0xc4, 0x4b, 0x4f, 0x34, 0xb7,
-	0x08, 0xc4, 0xcb, 0x2d, 0xc2, 0xa0, 0x48, 0x3c, 0xd1, 0xda, 0x10, 0x31, 0x8a, 0x64, 0x66, 0xd9,
-	0x00, 0xda, 0x44, 0xfc, 0x34, 0xd2, 0xd1, 0x35, 0xb5, 0x09, 0x69, 0x7a, 0xf1, 0x9b, 0xd7, 0x66,
-	0x96, 0x1d, 0xc3, 0x21, 0x31, 0x52, 0x39, 0x34, 0x4a, 0x64, 0xdc, 0xa0, 0x33, 0x12, 0x6d, 0xd4,
-	0x21, 0xf6, 0x96, 0x37, 0xa7, 0xa5, 0x17, 0x07, 0x8b, 0x3d, 0x81, 0x1e, 0x5d, 0x47, 0x22, 0xcd,
-	0xfa, 0x8c, 0xdd, 0xf0, 0x02, 0x5e, 0x3f, 0x95, 0xa6, 0x3c, 0xe6, 0x36, 0x78, 0x5d, 0x66, 0xef,
-	0x1f, 0xb0, 0xac, 0xf4, 0x19, 0x30, 0x02, 0x97, 0x32, 0xc3, 0x4d, 0xb9, 0x07, 0x84, 0x76, 0xbd,
-	0xf3, 0x56, 0x66, 0x78, 0x5d, 0xf2, 0x53, 0x38, 0x58, 0xef, 0xba, 0x66, 0x19, 0xb1, 0x9d, 0x72,
-	0xdb, 0x12, 0x3d, 0xdb, 0xad, 0xef, 0xf4, 0xaa, 0x67, 0xbb, 0xf5, 0xbd, 0x5e, 0x6d, 0xf0, 0xbb,
-	0x02, 0xfb, 0x65, 0x5b, 0xb2, 0x23, 0x68, 0x5c, 0x68, 0xeb, 0xb8, 0x12, 0x39, 0x52, 0x1b, 0x37,
-	0xe2, 0xba, 0x17, 0xde, 0x8b, 0x1c, 0xd9, 0x7d, 0x80, 0xc2, 0xe8, 0x05, 0x5a, 0xeb, 0x9b, 0x7c,
-	0x87, 0xdc, 0x46, 0xa9, 0x4c, 0x13, 0x76, 0x1b, 0x6a, 0x85, 0xc1, 0xa5, 0xfc, 0x55, 0xb6, 0x70,
-	0xb9, 0x62, 0x0f, 0xfc, 0x7b, 0x28, 0x27, 0xa4, 0x42, 0xe3, 0x83, 0xa1, 0x75, 0x9b, 0x6b, 0x6d,
-	0x9a, 0x4c, 0x26, 0xdf, 0x5f, 0xa5, 0xd2, 0x5d, 0xac, 0xe6, 0xa3, 0x85, 0xce, 0xc7, 0xef, 0xb4,
-	0x4e, 0x33, 0x7c, 0xe3, 0x87, 0xe7, 0x63, 0x26, 0xdc, 0x52, 0x9b, 0x7c, 0x4c, 0xa3, 0xf4, 0x3c,
-	0x8c, 0xd2, 0x98, 0xa6, 0x71, 0x4c, 0x03, 0xc5, 0x53, 0xcd, 0x69, 0x39, 0xaf, 0xd1, 0xcf, 0x8b,
-	0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x98, 0x58, 0xf7, 0x63, 0xb2, 0x03, 0x00, 0x00,
+	// 817 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x95, 0x6d, 0x6f, 0x1b, 0x45,
+	0x10, 0xc7, 0xe5, 0xd8, 0x24, 0xf6, 0xd8, 0x71, 0x9c, 0x6d, 0xd3, 0x5e, 0x49, 0x2b, 0x4c, 0x78,
+	0x0a, 0x4f, 0xb1, 0x54, 0xa4, 0xbe, 0x43, 0xd0, 0x24, 0x6a, 0xeb, 0xaa, 0xa6, 0xc8, 0x14, 0x90,
+	0x78, 0xb3, 0xda, 0xdc, 0x8d, 0xcf, 0xab, 0xdc, 0xed, 0x9e, 0x76, 0xf7, 0x0a, 0x91, 0x78, 0xc1,
+	0xa7, 0xe3, 0x73, 0xa1, 0x99, 0x3d, 0x3f, 0xb4, 0xe1, 0x55, 0xbc, 0xff, 0xf9, 0xcd, 0xce, 0xdc,
+	0xfc, 0xef, 0x26, 0xd0, 0xaf, 0xea, 0xc2, 0xe3, 0x59, 0xe5, 0x6c, 0xb0, 0x42, 0xa4, 0x85, 0xad,
+	0x33, 0xa9, 0x4d, 0x8e, 0x3e, 0x48, 0x8e, 0x9c, 0xfc, 0xdb, 0x83, 0xf6, 0xcc, 0xe7, 0xe2, 0x09,
+	0x74, 0x55, 0x8e, 0x26, 0x48, 0x9d, 0x25, 0xad, 0x71, 0xeb, 0xb4, 0xff, 0xf8, 0xf8, 0xec, 0x36,
+	0x7e, 0xf6, 0x94, 0x98, 0x69, 0x36, 0xdf, 0x53, 0xf1, 0x87, 0xf8, 0x04, 0xf6, 0x63, 0xde, 0x5b,
+	0x74, 0x5e, 0x5b, 0x93, 0xb4, 0xc7, 0xad, 0xd3, 0xde, 0x7c, 0xc0, 0xe2, 0x6f, 0x51, 0x13, 0x9f,
+	0xc2, 0x30, 0x42, 0x85, 0xcd, 0xbd, 0xcc, 0xb4, 0x4b, 0x3a, 0x5b, 0xd4, 0x2b, 0x9b, 0xfb, 0x4b,
+	0xed, 0xc4, 0xe7, 0x70, 0x10, 0xa9, 0xba, 0x0a, 0xba, 0x44, 0x59, 0xfa, 0x64, 0x6f, 0xdc, 0x3a,
+	0x6d, 0xcf, 0x63, 0x85, 0x5f, 0x59, 0x9d, 0x79, 0xf1, 0x04, 0xee, 0x47, 0x2e, 0x38, 0x65, 0xfc,
+	0x02, 0x9d, 0xc3, 0x4c, 0x5e, 0xdd, 0x04, 0xf4, 0xc9, 0x2e, 0xf3, 0x47, 0x1c, 0x7e, 0xb3, 0x89,
+	0x9e, 0x53, 0x50, 0xfc, 0x00, 0x0f, 0x6f, 0xe7, 0x15, 0xda, 0x87, 0x26, 0xb9, 0xcb, 0xc9, 0x0f,
+	0xde, 0x4f, 0x7e, 0xa5, 0x7d, 0x88, 0x17, 0x8c, 0x61, 0x90, 0xda, 0xea, 0x46, 0xda, 0x0a, 0x0d,
+	0x75, 0xd7, 0xe3, 0x04, 0x20, 0xed, 0x75, 0x85, 0x66, 0xb6, 0x21, 0x7c, 0x50, 0x81, 0x08, 0xd8,
+	0x10, 0xbf, 0x04, 0x15, 0xb6, 0x09, 0xc4, 0x6b, 0x22, 0xfa, 0x5b, 0x04, 0xe2, 0xf5, 0x16, 0xe1,
+	0x50, 0x65, 0x44, 0x0c, 0x36, 0xc4, 0x1c, 0x55, 0x36, 0xf3, 0xe2, 0x04, 0xf6, 0x99, 0xf8, 0xd3,
+	0xe9, 0xc0, 0x63, 0xda, 0x67, 0xa4, 0x4f, 0xe2, 0xef, 0xa4, 0xcd, 0xbc, 0x78, 0x0c, 0x47, 0xcc,
+	0x68, 0x13, 0xd0, 0x19, 0x55, 0x48, 0x87, 0xc1, 0x69, 0xf4, 0xc9, 0x90, 0xd9, 0x3b, 0x14, 0x9c,
+	0x36, 0xb1, 0x79, 0x0c, 0x89, 0x09, 0xdc, 0xdd, 0x54, 0xb6, 0x6f, 0xd1, 0x15, 0xaa, 0xa2, 0xeb,
+	0x3f, 0xe4, 0x94, 0xc3, 0x55, 0x07, 0xaf, 0x63, 0x64, 0xe6, 0xc9, 0x31, 0x4e, 0xc8, 0x30, 0xab,
+	0x2b, 0xb9, 0xd4, 0xc1, 0x27, 0xc7, 0xd1, 0x31, 0x92, 0x2f, 0x49, 0x7d, 0xa1, 0x83, 0x17, 0xa7,
+	0x30, 0xda, 0xe2, 0xe2, 0xb4, 0x1f, 0x32, 0x38, 0x5c, 0x83, 0x71, 0xc4, 0x5f, 0xc1, 0x61, 0xd3,
+	0x82, 0x27, 0xa7, 0x22, 0xfa, 0x88, 0xd1, 0x83, 0x58, 0x9f, 0xf4, 0xc8, 0x7e, 0x01, 0x23, 0x76,
+	0x2f, 0xd3, 0x6e, 0x6d, 0xc9, 0x41, 0x2c, 0x4f, 0xfa, 0xa5, 0x76, 0x8d, 0x2b, 0xdb, 0xe0, 0x6a,
+	0xaa, 0xa3, 0x77, 0xc0, 0x66, 0xb0, 0x5f, 0x83, 0x60, 0x70, 0xa1, 0x0b, 0xdc, 0x4c, 0xf7, 0x30,
+	0x96, 0xa7, 0xc8, 0x33, 0x5d, 0xe0, 0x6a, 0xc2, 0x5f, 0xc2, 0xe1, 0xfa, 0xd6, 0x35, 0x2b, 0xe2,
+	0x53, 0x35, 0xd7, 0xae, 0xd0, 0x31, 0x0c, 0x82, 0xf2, 0xd7, 0x72, 0x89, 0x05, 0x17, 0x7f, 0x10,
+	0x2d, 0x25, 0xed, 0x05, 0x16, 0x54, 0xf9, 0x7b, 0x38, 0x6e, 0xbe, 0x10, 0xbd, 0x40, 0x7e, 0xfb,
+	0x53, 0x5b, 0xe9, 0xf5, 0x7b, 0x7d, 0x87, 0x13, 0x92, 0xf8, 0xb9, 0x34, 0xc4, 0x05, 0x03, 0x71,
+	0x14, 0x2b, 0xe7, 0xe8, 0x46, 0x2f, 0xb5, 0x91, 0x8b, 0x42, 0xe7, 0xcb, 0x90, 0xdc, 0xdd, 0x38,
+	0xf7, 0x86, 0x42, 0x53, 0xf3, 0x8c, 0x03, 0x94, 0xc0, 0xcd, 0xbf, 0x9f, 0x70, 0x14, 0x13, 0x28,
+	0xf6, 0x6e, 0xc2, 0x39, 0x0c, 0x4a, 0x95, 0x2e, 0xb5, 0x41, 0xa9, 0xcd, 0xc2, 0x26, 0xf7, 0x78,
+	0x47, 0x7c, 0xf4, 0x7f, 0x3b, 0x62, 0x16, 0xb9, 0xa9, 0x59, 0xd8, 0x79, 0xbf, 0xdc, 0x1c, 0xc4,
+	0x67, 0x30, 0x34, 0x75, 0x29, 0x73, 0xeb, 0x6c, 0x1d, 0xb4, 0x41, 0x9f, 0xdc, 0x8f, 0x2e, 0x98,
+	0xba, 0x7c, 0xbe, 0x16, 0xe9, 0x6d, 0x59, 0xa2, 0xaa, 0xa4, 0x2a, 0x0a, 0x9b, 0x36, 0x03, 0x48,
+	0xe2, 0x5c, 0x49, 0x7f, 0x4a, 0x32, 0x3f, 0xf6, 0xcb, 0x4e, 0x77, 0x67, 0xd4, 0x7e, 0xd9, 0xe9,
+	0x7e, 0x30, 0xda, 0x3d, 0xf9, 0x1b, 0xfa, 0x5b, 0x85, 0xc5, 0x10, 0x76, 0xac, 0xe7, 0x4d, 0xd6,
+	0x9b, 0xef, 0x58, 0x2f, 0x04, 0x74, 0x94, 0x4b, 0x97, 0xc9, 0x0e, 0x2b, 0xfc, 0x5b, 0x1c, 0x43,
+	0x2f, 0xad, 0x6a, 0x99, 0xda, 0xda, 0x04, 0xde, 0x5b, 0xed, 0x79, 0x37, 0xad, 0xea, 0x0b, 0x3a,
+	0x8b, 0x6f, 0x40, 0x04, 0x1b, 0x54, 0x21, 0x4b, 0x2c, 0xad, 0xbb, 0x69, 0xfa, 0xe8, 0x30, 0x35,
+	0xe2, 0xc8, 0x8c, 0x03, 0xdc, 0xc9, 0xc9, 0x3f, 0x2d, 0xd8, 0x6b, 0x76, 0x23, 0x5d, 0xbb, 0xb4,
+	0x3e, 0x48, 0xa3, 0x4a, 0x6c, 0x3a, 0xe8, 0x92, 0xf0, 0x93, 0x2a, 0x51, 0x3c, 0x02, 0xa8, 0x9c,
+	0x4d, 0xd1, 0x7b, 0xda, 0xb4, 0xb1, 0x9b, 0x5e, 0xa3, 0x4c, 0x33, 0x71, 0x0f, 0x76, 0x2b, 0x87,
+	0x0b, 0xfd, 0x57, 0xb3, 0x47, 0x9b, 0x93, 0xf8, 0x98, 0x96, 0x82, 0x09, 0x4a, 0x1b, 0x74, 0x94,
+	0x18, 0xf7, 0x67, 0x7f, 0xad, 0x4d, 0xb3, 0xf3, 0xf3, 0x3f, 0x7e, 0xcc, 0x75, 0x58, 0xd6, 0x57,
+	0x67, 0xa9, 0x2d, 0x27, 0xcf, 0xad, 0xcd, 0x0b, 0xbc, 0x20, 0x77, 0x7e, 0x2e, 0x54, 0x58, 0x58,
+	0x57, 0x4e, 0xd8, 0xab, 0x6f, 0xa3, 0x57, 0x13, 0xfe, 0x97, 0x30, 0x61, 0xc7, 0x64, 0x6e, 0x25,
+	0x1f, 0xaf, 0x76, 0xf9, 0xcf, 0x77, 0xff, 0x0d, 0x00, 0x1f, 0xdc, 0x0e, 0xeb, 0x37, 0x06, 0x00,
+	0x00,
 }
@@ -8,6 +8,7 @@ import (
 	pulse_go_proto "github.com/GoogleCloudPlatform/cloud-ingest/proto/pulse_go_proto"
 	proto "github.com/golang/protobuf/proto"
 	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	math "math"
 )
 
@@ -132,6 +133,30 @@ const (
 	FailureType_METADATA_OBJECT_NOT_FOUND_FAILURE FailureType = 16
 	// GCS returned a HTTP 410 "Gone" for a given resuamble ID.
 	FailureType_GCS_RESUMABLE_ID_GONE_FAILURE FailureType = 18
+	// GCS could not use the task's Cloud KMS key to encrypt the object.
+	FailureType_KMS_PERMISSION_FAILURE FailureType = 19
+	// The agent lacks permission to read the source file or directory.
+	FailureType_PERMISSION_DENIED_FAILURE FailureType = 20
+	// The source file's destination object name collides with another source
+	// file's once normalized, see the agent's dst-name-normalization flag.
+	FailureType_NAME_COLLISION_FAILURE FailureType = 21
+	// A GCS quota, such as a daily quota, is exhausted. Retrying won't succeed
+	// until the quota is replenished.
+	FailureType_QUOTA_EXCEEDED_FAILURE FailureType = 22
+	// The file was modified within the agent's min-file-stability-age, so it
+	// may still be being written. It wasn't copied, the task should be retried
+	// once the file is stable. Agents nack these tasks instead of reporting them.
+	FailureType_FILE_NOT_STABLE_FAILURE FailureType = 23
+	// The destination object already exists, and the copy's overwrite policy is
+	// FAIL_IF_EXISTS.
+	FailureType_OBJECT_ALREADY_EXISTS_FAILURE FailureType = 24
+	// The task didn't finish within the agent's task-deadline, for example
+	// because a read from the source hung. The agent nacks the task so it's
+	// redelivered, possibly to another agent.
+	FailureType_DEADLINE_EXCEEDED_FAILURE FailureType = 25
+	// The destination object name is longer than GCS allows, and the agent's
+	// object-name-length-policy is fail.
+	FailureType_OBJECT_NAME_TOO_LONG_FAILURE FailureType = 26
 )
 
 var FailureType_name = map[int32]string{
@@ -154,6 +179,14 @@ var FailureType_name = map[int32]string{
 	14: "BUCKET_NOT_FOUND",
 	16: "METADATA_OBJECT_NOT_FOUND_FAILURE",
 	18: "GCS_RESUMABLE_ID_GONE_FAILURE",
+	19: "KMS_PERMISSION_FAILURE",
+	20: "PERMISSION_DENIED_FAILURE",
+	21: "NAME_COLLISION_FAILURE",
+	22: "QUOTA_EXCEEDED_FAILURE",
+	23: "FILE_NOT_STABLE_FAILURE",
+	24: "OBJECT_ALREADY_EXISTS_FAILURE",
+	25: "DEADLINE_EXCEEDED_FAILURE",
+	26: "OBJECT_NAME_TOO_LONG_FAILURE",
 }
 
 var FailureType_value = map[string]int32{
@@ -176,6 +209,14 @@ var FailureType_value = map[string]int32{
 	"BUCKET_NOT_FOUND":                    14,
 	"METADATA_OBJECT_NOT_FOUND_FAILURE":   16,
 	"GCS_RESUMABLE_ID_GONE_FAILURE":       18,
+	"KMS_PERMISSION_FAILURE":              19,
+	"PERMISSION_DENIED_FAILURE":           20,
+	"NAME_COLLISION_FAILURE":              21,
+	"QUOTA_EXCEEDED_FAILURE":              22,
+	"FILE_NOT_STABLE_FAILURE":             23,
+	"OBJECT_ALREADY_EXISTS_FAILURE":       24,
+	"DEADLINE_EXCEEDED_FAILURE":           25,
+	"OBJECT_NAME_TOO_LONG_FAILURE":        26,
 }
 
 func (x FailureType) String() string {
@@ -186,6 +227,38 @@ func (FailureType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_ce5d8dd45b4a91ff, []int{2}
 }
 
+// Specifies what a copy does when its destination object already exists.
+type OverwritePolicy int32
+
+const (
+	// Overwrite the object if it matches expected_generation_num.
+	OverwritePolicy_OVERWRITE OverwritePolicy = 0
+	// Don't overwrite the object, and treat the copy as a successful skip.
+	OverwritePolicy_SKIP_IF_EXISTS OverwritePolicy = 1
+	// Don't overwrite the object, and fail with OBJECT_ALREADY_EXISTS_FAILURE.
+	OverwritePolicy_FAIL_IF_EXISTS OverwritePolicy = 2
+)
+
+var OverwritePolicy_name = map[int32]string{
+	0: "OVERWRITE",
+	1: "SKIP_IF_EXISTS",
+	2: "FAIL_IF_EXISTS",
+}
+
+var OverwritePolicy_value = map[string]int32{
+	"OVERWRITE":      0,
+	"SKIP_IF_EXISTS": 1,
+	"FAIL_IF_EXISTS": 2,
+}
+
+func (x OverwritePolicy) String() string {
+	return proto.EnumName(OverwritePolicy_name, int32(x))
+}
+
+func (OverwritePolicy) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{3}
+}
+
 // Contains information about a task. A task is a unit of work, one of:
 // 1) listing the contents of a single directory
 // 2) processing a list file
@@ -206,6 +279,8 @@ type Spec struct {
 	//	*Spec_ProcessUnexploredDirsSpec
 	//	*Spec_DeleteBundleSpec
 	//	*Spec_ProcessDeleteDirsSpec
+	//	*Spec_VerifySpec
+	//	*Spec_ReconcileSpec
 	Spec                 isSpec_Spec `protobuf_oneof:"spec"`
 	IssuanceNumber       int64       `protobuf:"varint,8,opt,name=issuance_number,json=issuanceNumber,proto3" json:"issuance_number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
@@ -270,6 +345,14 @@ type Spec_ProcessDeleteDirsSpec struct {
 	ProcessDeleteDirsSpec *ProcessDeleteDirsSpec `protobuf:"bytes,7,opt,name=process_delete_dirs_spec,json=processDeleteDirsSpec,proto3,oneof"`
 }
 
+type Spec_VerifySpec struct {
+	VerifySpec *VerifySpec `protobuf:"bytes,9,opt,name=verify_spec,json=verifySpec,proto3,oneof"`
+}
+
+type Spec_ReconcileSpec struct {
+	ReconcileSpec *ReconcileSpec `protobuf:"bytes,10,opt,name=reconcile_spec,json=reconcileSpec,proto3,oneof"`
+}
+
 func (*Spec_ListSpec) isSpec_Spec() {}
 
 func (*Spec_ProcessListSpec) isSpec_Spec() {}
@@ -284,6 +367,10 @@ func (*Spec_DeleteBundleSpec) isSpec_Spec() {}
 
 func (*Spec_ProcessDeleteDirsSpec) isSpec_Spec() {}
 
+func (*Spec_VerifySpec) isSpec_Spec() {}
+
+func (*Spec_ReconcileSpec) isSpec_Spec() {}
+
 func (m *Spec) GetSpec() isSpec_Spec {
 	if m != nil {
 		return m.Spec
@@ -340,6 +427,20 @@ func (m *Spec) GetProcessDeleteDirsSpec() *ProcessDeleteDirsSpec {
 	return nil
 }
 
+func (m *Spec) GetVerifySpec() *VerifySpec {
+	if x, ok := m.GetSpec().(*Spec_VerifySpec); ok {
+		return x.VerifySpec
+	}
+	return nil
+}
+
+func (m *Spec) GetReconcileSpec() *ReconcileSpec {
+	if x, ok := m.GetSpec().(*Spec_ReconcileSpec); ok {
+		return x.ReconcileSpec
+	}
+	return nil
+}
+
 func (m *Spec) GetIssuanceNumber() int64 {
 	if m != nil {
 		return m.IssuanceNumber
@@ -357,6 +458,8 @@ func (*Spec) XXX_OneofWrappers() []interface{} {
 		(*Spec_ProcessUnexploredDirsSpec)(nil),
 		(*Spec_DeleteBundleSpec)(nil),
 		(*Spec_ProcessDeleteDirsSpec)(nil),
+		(*Spec_VerifySpec)(nil),
+		(*Spec_ReconcileSpec)(nil),
 	}
 }
 
@@ -379,7 +482,28 @@ type ListSpec struct {
 	// Expected GCS generation number for dst_unexplored_dirs_object.
 	UnexploredDirsExpectedGenerationNum int64 `protobuf:"varint,6,opt,name=unexplored_dirs_expected_generation_num,json=unexploredDirsExpectedGenerationNum,proto3" json:"unexplored_dirs_expected_generation_num,omitempty"`
 	// The root directory specified in the JobConfig.
-	RootDirectory        string   `protobuf:"bytes,8,opt,name=root_directory,json=rootDirectory,proto3" json:"root_directory,omitempty"`
+	RootDirectory string `protobuf:"bytes,8,opt,name=root_directory,json=rootDirectory,proto3" json:"root_directory,omitempty"`
+	// If non-zero, files last modified before this Unix time (in seconds) are
+	// not written to the list file. Directories are always listed.
+	MinMtime int64 `protobuf:"varint,9,opt,name=min_mtime,json=minMtime,proto3" json:"min_mtime,omitempty"`
+	// If set, directories deeper than max_depth below root_directory are not
+	// listed by this task, and are instead returned as unexplored directories.
+	// The root directory has depth 0, so a max_depth of 0 lists only the root
+	// directory's immediate entries. Directories in src_directories are always
+	// listed. Ignored if root_directory is not set.
+	MaxDepth *wrappers.Int64Value `protobuf:"bytes,10,opt,name=max_depth,json=maxDepth,proto3" json:"max_depth,omitempty"`
+	// If true, each of src_directories is a manifest file instead of a
+	// directory. A manifest lists one file path per line, and may be an
+	// On-Premises file or a "gs://bucket/object" object. The listed files are
+	// written to the list file without walking any directories.
+	SrcIsManifest bool `protobuf:"varint,11,opt,name=src_is_manifest,json=srcIsManifest,proto3" json:"src_is_manifest,omitempty"`
+	// A directory of src_directories which a previous list task only partly
+	// listed, and the name of the last entry it listed. Listing resume_dir
+	// continues with the entries sorted after resume_after. A list task which
+	// yields partway through a directory returns these in its response spec,
+	// so the task can be re-queued to continue the same directory.
+	ResumeDir            string   `protobuf:"bytes,12,opt,name=resume_dir,json=resumeDir,proto3" json:"resume_dir,omitempty"`
+	ResumeAfter          string   `protobuf:"bytes,13,opt,name=resume_after,json=resumeAfter,proto3" json:"resume_after,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -466,6 +590,41 @@ func (m *ListSpec) GetRootDirectory() string {
 	return ""
 }
 
+func (m *ListSpec) GetMinMtime() int64 {
+	if m != nil {
+		return m.MinMtime
+	}
+	return 0
+}
+
+func (m *ListSpec) GetMaxDepth() *wrappers.Int64Value {
+	if m != nil {
+		return m.MaxDepth
+	}
+	return nil
+}
+
+func (m *ListSpec) GetSrcIsManifest() bool {
+	if m != nil {
+		return m.SrcIsManifest
+	}
+	return false
+}
+
+func (m *ListSpec) GetResumeDir() string {
+	if m != nil {
+		return m.ResumeDir
+	}
+	return ""
+}
+
+func (m *ListSpec) GetResumeAfter() string {
+	if m != nil {
+		return m.ResumeAfter
+	}
+	return ""
+}
+
 // Contains the information about a process list task. A process list task is
 // responsible for processing the list file produced by a list task.
 type ProcessListSpec struct {
@@ -600,11 +759,52 @@ type CopySpec struct {
 	DstObject             string `protobuf:"bytes,3,opt,name=dst_object,json=dstObject,proto3" json:"dst_object,omitempty"`
 	ExpectedGenerationNum int64  `protobuf:"varint,4,opt,name=expected_generation_num,json=expectedGenerationNum,proto3" json:"expected_generation_num,omitempty"`
 	// Fields only for managing resumable copies.
-	FileBytes            int64    `protobuf:"varint,6,opt,name=file_bytes,json=fileBytes,proto3" json:"file_bytes,omitempty"`
-	FileMTime            int64    `protobuf:"varint,7,opt,name=file_m_time,json=fileMTime,proto3" json:"file_m_time,omitempty"`
-	BytesCopied          int64    `protobuf:"varint,8,opt,name=bytes_copied,json=bytesCopied,proto3" json:"bytes_copied,omitempty"`
-	Crc32C               uint32   `protobuf:"varint,9,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
-	ResumableUploadId    string   `protobuf:"bytes,11,opt,name=resumable_upload_id,json=resumableUploadId,proto3" json:"resumable_upload_id,omitempty"`
+	FileBytes         int64  `protobuf:"varint,6,opt,name=file_bytes,json=fileBytes,proto3" json:"file_bytes,omitempty"`
+	FileMTime         int64  `protobuf:"varint,7,opt,name=file_m_time,json=fileMTime,proto3" json:"file_m_time,omitempty"`
+	BytesCopied       int64  `protobuf:"varint,8,opt,name=bytes_copied,json=bytesCopied,proto3" json:"bytes_copied,omitempty"`
+	Crc32C            uint32 `protobuf:"varint,9,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
+	ResumableUploadId string `protobuf:"bytes,11,opt,name=resumable_upload_id,json=resumableUploadId,proto3" json:"resumable_upload_id,omitempty"`
+	// Fields describing the destination object.
+	// The GCS storage class. If empty, the bucket default storage class is used.
+	StorageClass string `protobuf:"bytes,12,opt,name=storage_class,json=storageClass,proto3" json:"storage_class,omitempty"`
+	// The Cloud KMS key used to encrypt the object. If empty, the bucket default
+	// encryption is used.
+	KmsKeyName string `protobuf:"bytes,13,opt,name=kms_key_name,json=kmsKeyName,proto3" json:"kms_key_name,omitempty"`
+	// The object's Content-Type. If empty, it's detected from the file content.
+	ContentType string `protobuf:"bytes,14,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// An optional transform of dst_object, which re-roots the object without a
+	// dst_object per layout. dst_strip_prefix is removed from the start of
+	// dst_object if present, then dst_add_prefix is prepended. The agent applies
+	// the transform and clears these fields, so the response spec holds the
+	// final object name.
+	DstStripPrefix string `protobuf:"bytes,15,opt,name=dst_strip_prefix,json=dstStripPrefix,proto3" json:"dst_strip_prefix,omitempty"`
+	DstAddPrefix   string `protobuf:"bytes,16,opt,name=dst_add_prefix,json=dstAddPrefix,proto3" json:"dst_add_prefix,omitempty"`
+	// The CRC32C of the whole source file, if the source already checksummed
+	// it. Agents run with trust-source-checksum send it to GCS to verify,
+	// instead of computing it.
+	SrcFileCrc32C *wrappers.UInt32Value `protobuf:"bytes,17,opt,name=src_file_crc32c,json=srcFileCrc32c,proto3" json:"src_file_crc32c,omitempty"`
+	// Custom metadata set on the destination object, for example a migration
+	// batch for lifecycle rules to match. The metadata the agent sets itself,
+	// such as the source file mtime, takes precedence over these entries.
+	ObjectMetadata map[string]string `protobuf:"bytes,18,rep,name=object_metadata,json=objectMetadata,proto3" json:"object_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// What to do if the destination object exists. Unless it's OVERWRITE, the
+	// copy requires that the object doesn't exist, regardless of
+	// expected_generation_num.
+	OverwritePolicy OverwritePolicy `protobuf:"varint,19,opt,name=overwrite_policy,json=overwritePolicy,proto3,enum=cloud_ingest_task.OverwritePolicy" json:"overwrite_policy,omitempty"`
+	// The size of the next resumable copy request, chosen by agents running
+	// with adaptive-chunk-size from the throughput of the previous request. If
+	// 0, the agent's copy-chunk-size is used.
+	ChunkSize int64 `protobuf:"varint,20,opt,name=chunk_size,json=chunkSize,proto3" json:"chunk_size,omitempty"`
+	// The predefined ACL applied to the object, for example publicRead. If
+	// empty, the bucket default object ACL is used.
+	PredefinedAcl string `protobuf:"bytes,21,opt,name=predefined_acl,json=predefinedAcl,proto3" json:"predefined_acl,omitempty"`
+	// The temporary object a resumable copy uploads to, set by agents running
+	// with upload-via-temp-object. The final request copies it to dst_object.
+	TmpDstObject string `protobuf:"bytes,22,opt,name=tmp_dst_object,json=tmpDstObject,proto3" json:"tmp_dst_object,omitempty"`
+	// If true, only the metadata of the existing destination object, such as
+	// its mtime attribute, is updated from the source file's current stats. No
+	// content is uploaded. The object must have expected_generation_num.
+	MetadataOnly         bool     `protobuf:"varint,23,opt,name=metadata_only,json=metadataOnly,proto3" json:"metadata_only,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -698,6 +898,231 @@ func (m *CopySpec) GetResumableUploadId() string {
 	return ""
 }
 
+func (m *CopySpec) GetStorageClass() string {
+	if m != nil {
+		return m.StorageClass
+	}
+	return ""
+}
+
+func (m *CopySpec) GetKmsKeyName() string {
+	if m != nil {
+		return m.KmsKeyName
+	}
+	return ""
+}
+
+func (m *CopySpec) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *CopySpec) GetDstStripPrefix() string {
+	if m != nil {
+		return m.DstStripPrefix
+	}
+	return ""
+}
+
+func (m *CopySpec) GetDstAddPrefix() string {
+	if m != nil {
+		return m.DstAddPrefix
+	}
+	return ""
+}
+
+func (m *CopySpec) GetSrcFileCrc32C() *wrappers.UInt32Value {
+	if m != nil {
+		return m.SrcFileCrc32C
+	}
+	return nil
+}
+
+func (m *CopySpec) GetObjectMetadata() map[string]string {
+	if m != nil {
+		return m.ObjectMetadata
+	}
+	return nil
+}
+
+func (m *CopySpec) GetOverwritePolicy() OverwritePolicy {
+	if m != nil {
+		return m.OverwritePolicy
+	}
+	return OverwritePolicy_OVERWRITE
+}
+
+func (m *CopySpec) GetChunkSize() int64 {
+	if m != nil {
+		return m.ChunkSize
+	}
+	return 0
+}
+
+func (m *CopySpec) GetPredefinedAcl() string {
+	if m != nil {
+		return m.PredefinedAcl
+	}
+	return ""
+}
+
+func (m *CopySpec) GetTmpDstObject() string {
+	if m != nil {
+		return m.TmpDstObject
+	}
+	return ""
+}
+
+func (m *CopySpec) GetMetadataOnly() bool {
+	if m != nil {
+		return m.MetadataOnly
+	}
+	return false
+}
+
+// Contains the information about a verify task. A verify task checks that a
+// GCS object matches its source file, without copying anything.
+type VerifySpec struct {
+	SrcFile              string   `protobuf:"bytes,1,opt,name=src_file,json=srcFile,proto3" json:"src_file,omitempty"`
+	DstBucket            string   `protobuf:"bytes,2,opt,name=dst_bucket,json=dstBucket,proto3" json:"dst_bucket,omitempty"`
+	DstObject            string   `protobuf:"bytes,3,opt,name=dst_object,json=dstObject,proto3" json:"dst_object,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerifySpec) Reset()         { *m = VerifySpec{} }
+func (m *VerifySpec) String() string { return proto.CompactTextString(m) }
+func (*VerifySpec) ProtoMessage()    {}
+func (*VerifySpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{5}
+}
+
+func (m *VerifySpec) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifySpec.Unmarshal(m, b)
+}
+func (m *VerifySpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifySpec.Marshal(b, m, deterministic)
+}
+func (m *VerifySpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifySpec.Merge(m, src)
+}
+func (m *VerifySpec) XXX_Size() int {
+	return xxx_messageInfo_VerifySpec.Size(m)
+}
+func (m *VerifySpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifySpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifySpec proto.InternalMessageInfo
+
+func (m *VerifySpec) GetSrcFile() string {
+	if m != nil {
+		return m.SrcFile
+	}
+	return ""
+}
+
+func (m *VerifySpec) GetDstBucket() string {
+	if m != nil {
+		return m.DstBucket
+	}
+	return ""
+}
+
+func (m *VerifySpec) GetDstObject() string {
+	if m != nil {
+		return m.DstObject
+	}
+	return ""
+}
+
+// Contains the information about a reconcile task. A reconcile task lists a
+// source directory and the objects it was copied to, and writes a report of
+// their differences. It never copies anything.
+type ReconcileSpec struct {
+	SrcDirectory string `protobuf:"bytes,1,opt,name=src_directory,json=srcDirectory,proto3" json:"src_directory,omitempty"`
+	DstBucket    string `protobuf:"bytes,2,opt,name=dst_bucket,json=dstBucket,proto3" json:"dst_bucket,omitempty"`
+	// The object name prefix the directory was copied to. The object of a file
+	// is named dst_prefix followed by the file's path relative to src_directory.
+	DstPrefix                   string   `protobuf:"bytes,3,opt,name=dst_prefix,json=dstPrefix,proto3" json:"dst_prefix,omitempty"`
+	DstReportBucket             string   `protobuf:"bytes,4,opt,name=dst_report_bucket,json=dstReportBucket,proto3" json:"dst_report_bucket,omitempty"`
+	DstReportObject             string   `protobuf:"bytes,5,opt,name=dst_report_object,json=dstReportObject,proto3" json:"dst_report_object,omitempty"`
+	ReportExpectedGenerationNum int64    `protobuf:"varint,6,opt,name=report_expected_generation_num,json=reportExpectedGenerationNum,proto3" json:"report_expected_generation_num,omitempty"`
+	XXX_NoUnkeyedLiteral        struct{} `json:"-"`
+	XXX_unrecognized            []byte   `json:"-"`
+	XXX_sizecache               int32    `json:"-"`
+}
+
+func (m *ReconcileSpec) Reset()         { *m = ReconcileSpec{} }
+func (m *ReconcileSpec) String() string { return proto.CompactTextString(m) }
+func (*ReconcileSpec) ProtoMessage()    {}
+func (*ReconcileSpec) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{6}
+}
+
+func (m *ReconcileSpec) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconcileSpec.Unmarshal(m, b)
+}
+func (m *ReconcileSpec) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconcileSpec.Marshal(b, m, deterministic)
+}
+func (m *ReconcileSpec) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileSpec.Merge(m, src)
+}
+func (m *ReconcileSpec) XXX_Size() int {
+	return xxx_messageInfo_ReconcileSpec.Size(m)
+}
+func (m *ReconcileSpec) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileSpec.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconcileSpec proto.InternalMessageInfo
+
+func (m *ReconcileSpec) GetSrcDirectory() string {
+	if m != nil {
+		return m.SrcDirectory
+	}
+	return ""
+}
+
+func (m *ReconcileSpec) GetDstBucket() string {
+	if m != nil {
+		return m.DstBucket
+	}
+	return ""
+}
+
+func (m *ReconcileSpec) GetDstPrefix() string {
+	if m != nil {
+		return m.DstPrefix
+	}
+	return ""
+}
+
+func (m *ReconcileSpec) GetDstReportBucket() string {
+	if m != nil {
+		return m.DstReportBucket
+	}
+	return ""
+}
+
+func (m *ReconcileSpec) GetDstReportObject() string {
+	if m != nil {
+		return m.DstReportObject
+	}
+	return ""
+}
+
+func (m *ReconcileSpec) GetReportExpectedGenerationNum() int64 {
+	if m != nil {
+		return m.ReportExpectedGenerationNum
+	}
+	return 0
+}
+
 // Contains the information for a single file within a Copy Bundle task.
 type BundledFile struct {
 	CopySpec       *CopySpec   `protobuf:"bytes,1,opt,name=copy_spec,json=copySpec,proto3" json:"copy_spec,omitempty"`
@@ -717,7 +1142,7 @@ func (m *BundledFile) Reset()         { *m = BundledFile{} }
 func (m *BundledFile) String() string { return proto.CompactTextString(m) }
 func (*BundledFile) ProtoMessage()    {}
 func (*BundledFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{5}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{7}
 }
 
 func (m *BundledFile) XXX_Unmarshal(b []byte) error {
@@ -786,7 +1211,7 @@ func (m *CopyBundleSpec) Reset()         { *m = CopyBundleSpec{} }
 func (m *CopyBundleSpec) String() string { return proto.CompactTextString(m) }
 func (*CopyBundleSpec) ProtoMessage()    {}
 func (*CopyBundleSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{6}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{8}
 }
 
 func (m *CopyBundleSpec) XXX_Unmarshal(b []byte) error {
@@ -829,7 +1254,7 @@ func (m *DeleteObjectSpec) Reset()         { *m = DeleteObjectSpec{} }
 func (m *DeleteObjectSpec) String() string { return proto.CompactTextString(m) }
 func (*DeleteObjectSpec) ProtoMessage()    {}
 func (*DeleteObjectSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{7}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{9}
 }
 
 func (m *DeleteObjectSpec) XXX_Unmarshal(b []byte) error {
@@ -894,7 +1319,7 @@ func (m *BundledObject) Reset()         { *m = BundledObject{} }
 func (m *BundledObject) String() string { return proto.CompactTextString(m) }
 func (*BundledObject) ProtoMessage()    {}
 func (*BundledObject) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{8}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{10}
 }
 
 func (m *BundledObject) XXX_Unmarshal(b []byte) error {
@@ -963,7 +1388,7 @@ func (m *DeleteBundleSpec) Reset()         { *m = DeleteBundleSpec{} }
 func (m *DeleteBundleSpec) String() string { return proto.CompactTextString(m) }
 func (*DeleteBundleSpec) ProtoMessage()    {}
 func (*DeleteBundleSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{9}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{11}
 }
 
 func (m *DeleteBundleSpec) XXX_Unmarshal(b []byte) error {
@@ -1010,7 +1435,7 @@ func (m *ProcessDeleteDirsSpec) Reset()         { *m = ProcessDeleteDirsSpec{} }
 func (m *ProcessDeleteDirsSpec) String() string { return proto.CompactTextString(m) }
 func (*ProcessDeleteDirsSpec) ProtoMessage()    {}
 func (*ProcessDeleteDirsSpec) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{10}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{12}
 }
 
 func (m *ProcessDeleteDirsSpec) XXX_Unmarshal(b []byte) error {
@@ -1081,7 +1506,7 @@ func (m *TaskReqMsg) Reset()         { *m = TaskReqMsg{} }
 func (m *TaskReqMsg) String() string { return proto.CompactTextString(m) }
 func (*TaskReqMsg) ProtoMessage()    {}
 func (*TaskReqMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{11}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{13}
 }
 
 func (m *TaskReqMsg) XXX_Unmarshal(b []byte) error {
@@ -1160,7 +1585,7 @@ func (m *TaskRespMsg) Reset()         { *m = TaskRespMsg{} }
 func (m *TaskRespMsg) String() string { return proto.CompactTextString(m) }
 func (*TaskRespMsg) ProtoMessage()    {}
 func (*TaskRespMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{12}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{14}
 }
 
 func (m *TaskRespMsg) XXX_Unmarshal(b []byte) error {
@@ -1283,6 +1708,8 @@ type Log struct {
 	//	*Log_CopyBundleLog
 	//	*Log_ProcessUnexploredDirsLog
 	//	*Log_DeleteBundleLog
+	//	*Log_VerifyLog
+	//	*Log_ReconcileLog
 	Log                  isLog_Log `protobuf_oneof:"log"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
@@ -1293,7 +1720,7 @@ func (m *Log) Reset()         { *m = Log{} }
 func (m *Log) String() string { return proto.CompactTextString(m) }
 func (*Log) ProtoMessage()    {}
 func (*Log) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{13}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{15}
 }
 
 func (m *Log) XXX_Unmarshal(b []byte) error {
@@ -1342,6 +1769,14 @@ type Log_DeleteBundleLog struct {
 	DeleteBundleLog *DeleteBundleLog `protobuf:"bytes,6,opt,name=delete_bundle_log,json=deleteBundleLog,proto3,oneof"`
 }
 
+type Log_VerifyLog struct {
+	VerifyLog *VerifyLog `protobuf:"bytes,7,opt,name=verify_log,json=verifyLog,proto3,oneof"`
+}
+
+type Log_ReconcileLog struct {
+	ReconcileLog *ReconcileLog `protobuf:"bytes,8,opt,name=reconcile_log,json=reconcileLog,proto3,oneof"`
+}
+
 func (*Log_ListLog) isLog_Log() {}
 
 func (*Log_ProcessListLog) isLog_Log() {}
@@ -1354,6 +1789,10 @@ func (*Log_ProcessUnexploredDirsLog) isLog_Log() {}
 
 func (*Log_DeleteBundleLog) isLog_Log() {}
 
+func (*Log_VerifyLog) isLog_Log() {}
+
+func (*Log_ReconcileLog) isLog_Log() {}
+
 func (m *Log) GetLog() isLog_Log {
 	if m != nil {
 		return m.Log
@@ -1403,6 +1842,20 @@ func (m *Log) GetDeleteBundleLog() *DeleteBundleLog {
 	return nil
 }
 
+func (m *Log) GetVerifyLog() *VerifyLog {
+	if x, ok := m.GetLog().(*Log_VerifyLog); ok {
+		return x.VerifyLog
+	}
+	return nil
+}
+
+func (m *Log) GetReconcileLog() *ReconcileLog {
+	if x, ok := m.GetLog().(*Log_ReconcileLog); ok {
+		return x.ReconcileLog
+	}
+	return nil
+}
+
 // XXX_OneofWrappers is for the internal use of the proto package.
 func (*Log) XXX_OneofWrappers() []interface{} {
 	return []interface{}{
@@ -1412,6 +1865,8 @@ func (*Log) XXX_OneofWrappers() []interface{} {
 		(*Log_CopyBundleLog)(nil),
 		(*Log_ProcessUnexploredDirsLog)(nil),
 		(*Log_DeleteBundleLog)(nil),
+		(*Log_VerifyLog)(nil),
+		(*Log_ReconcileLog)(nil),
 	}
 }
 
@@ -1433,17 +1888,58 @@ type ListLog struct {
 	DirsNotListed int64 `protobuf:"varint,5,opt,name=dirs_not_listed,json=dirsNotListed,proto3" json:"dirs_not_listed,omitempty"`
 	// A list of directories that were included in the list spec's
 	// src_directories field but were not found on-prem.
-	DirsNotFound         []string `protobuf:"bytes,6,rep,name=dirs_not_found,json=dirsNotFound,proto3" json:"dirs_not_found,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	DirsNotFound []string `protobuf:"bytes,6,rep,name=dirs_not_found,json=dirsNotFound,proto3" json:"dirs_not_found,omitempty"`
+	// Counts of the symlinks skipped and followed by this list task, see the
+	// agent's symlink-policy flag.
+	SymlinksSkipped  int64 `protobuf:"varint,7,opt,name=symlinks_skipped,json=symlinksSkipped,proto3" json:"symlinks_skipped,omitempty"`
+	SymlinksFollowed int64 `protobuf:"varint,8,opt,name=symlinks_followed,json=symlinksFollowed,proto3" json:"symlinks_followed,omitempty"`
+	// A count of the files that were not written to the list file because they
+	// were modified before the list spec's min_mtime.
+	FilesSkippedByMtime int64 `protobuf:"varint,9,opt,name=files_skipped_by_mtime,json=filesSkippedByMtime,proto3" json:"files_skipped_by_mtime,omitempty"`
+	// A count of the directories that were not listed by this list task because
+	// they are deeper than the list spec's max_depth.
+	DirsDeferredByDepth int64 `protobuf:"varint,10,opt,name=dirs_deferred_by_depth,json=dirsDeferredByDepth,proto3" json:"dirs_deferred_by_depth,omitempty"`
+	// A list of directories that could not be listed, for example because the
+	// agent lacks permission to read them. Listing continues past these.
+	DirsErrored []*DirError `protobuf:"bytes,11,rep,name=dirs_errored,json=dirsErrored,proto3" json:"dirs_errored,omitempty"`
+	// A list of the files named in a manifest (see ListSpec.src_is_manifest)
+	// that were not found on-prem.
+	ManifestFilesNotFound []string `protobuf:"bytes,12,rep,name=manifest_files_not_found,json=manifestFilesNotFound,proto3" json:"manifest_files_not_found,omitempty"`
+	// A list of the files and directories whose destination names collide with
+	// another's in the same directory once normalized, see the agent's
+	// dst-name-normalization flag.
+	NameCollisions []string `protobuf:"bytes,13,rep,name=name_collisions,json=nameCollisions,proto3" json:"name_collisions,omitempty"`
+	// The directory this list task yielded partway through, if any, see
+	// ListSpec.resume_dir. Counts of the directory's entries listed by this
+	// task, and of those left for the re-queued task.
+	PartialDir                 string `protobuf:"bytes,14,opt,name=partial_dir,json=partialDir,proto3" json:"partial_dir,omitempty"`
+	PartialDirEntriesListed    int64  `protobuf:"varint,15,opt,name=partial_dir_entries_listed,json=partialDirEntriesListed,proto3" json:"partial_dir_entries_listed,omitempty"`
+	PartialDirEntriesRemaining int64  `protobuf:"varint,16,opt,name=partial_dir_entries_remaining,json=partialDirEntriesRemaining,proto3" json:"partial_dir_entries_remaining,omitempty"`
+	// The size of the largest file found by this list task. The average file
+	// size is bytes_found / files_found.
+	MaxFileBytes int64 `protobuf:"varint,17,opt,name=max_file_bytes,json=maxFileBytes,proto3" json:"max_file_bytes,omitempty"`
+	// A count of the directories which were not listed from the file system,
+	// but replayed from the agent's checkpoint of a previous attempt of this
+	// list task, see the agent's list-index-dir flag.
+	DirsListedFromIndex int64 `protobuf:"varint,18,opt,name=dirs_listed_from_index,json=dirsListedFromIndex,proto3" json:"dirs_listed_from_index,omitempty"`
+	// The time spent and bytes read computing the CRC32C of listed files, by
+	// agents run with list-file-checksums.
+	ChecksumReadMs    int64 `protobuf:"varint,19,opt,name=checksum_read_ms,json=checksumReadMs,proto3" json:"checksum_read_ms,omitempty"`
+	ChecksumBytesRead int64 `protobuf:"varint,20,opt,name=checksum_bytes_read,json=checksumBytesRead,proto3" json:"checksum_bytes_read,omitempty"`
+	// The number of listed files which changed before their entries were
+	// written, by agents run with list-restat-policy. Depending on the policy
+	// they were left out of the list file or listed with their current stats.
+	FilesChangedDuringList int64    `protobuf:"varint,21,opt,name=files_changed_during_list,json=filesChangedDuringList,proto3" json:"files_changed_during_list,omitempty"`
+	XXX_NoUnkeyedLiteral   struct{} `json:"-"`
+	XXX_unrecognized       []byte   `json:"-"`
+	XXX_sizecache          int32    `json:"-"`
 }
 
 func (m *ListLog) Reset()         { *m = ListLog{} }
 func (m *ListLog) String() string { return proto.CompactTextString(m) }
 func (*ListLog) ProtoMessage()    {}
 func (*ListLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{14}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{16}
 }
 
 func (m *ListLog) XXX_Unmarshal(b []byte) error {
@@ -1506,6 +2002,159 @@ func (m *ListLog) GetDirsNotFound() []string {
 	return nil
 }
 
+func (m *ListLog) GetSymlinksSkipped() int64 {
+	if m != nil {
+		return m.SymlinksSkipped
+	}
+	return 0
+}
+
+func (m *ListLog) GetSymlinksFollowed() int64 {
+	if m != nil {
+		return m.SymlinksFollowed
+	}
+	return 0
+}
+
+func (m *ListLog) GetFilesSkippedByMtime() int64 {
+	if m != nil {
+		return m.FilesSkippedByMtime
+	}
+	return 0
+}
+
+func (m *ListLog) GetDirsDeferredByDepth() int64 {
+	if m != nil {
+		return m.DirsDeferredByDepth
+	}
+	return 0
+}
+
+func (m *ListLog) GetDirsErrored() []*DirError {
+	if m != nil {
+		return m.DirsErrored
+	}
+	return nil
+}
+
+func (m *ListLog) GetManifestFilesNotFound() []string {
+	if m != nil {
+		return m.ManifestFilesNotFound
+	}
+	return nil
+}
+
+func (m *ListLog) GetNameCollisions() []string {
+	if m != nil {
+		return m.NameCollisions
+	}
+	return nil
+}
+
+func (m *ListLog) GetPartialDir() string {
+	if m != nil {
+		return m.PartialDir
+	}
+	return ""
+}
+
+func (m *ListLog) GetPartialDirEntriesListed() int64 {
+	if m != nil {
+		return m.PartialDirEntriesListed
+	}
+	return 0
+}
+
+func (m *ListLog) GetPartialDirEntriesRemaining() int64 {
+	if m != nil {
+		return m.PartialDirEntriesRemaining
+	}
+	return 0
+}
+
+func (m *ListLog) GetMaxFileBytes() int64 {
+	if m != nil {
+		return m.MaxFileBytes
+	}
+	return 0
+}
+
+func (m *ListLog) GetDirsListedFromIndex() int64 {
+	if m != nil {
+		return m.DirsListedFromIndex
+	}
+	return 0
+}
+
+func (m *ListLog) GetChecksumReadMs() int64 {
+	if m != nil {
+		return m.ChecksumReadMs
+	}
+	return 0
+}
+
+func (m *ListLog) GetChecksumBytesRead() int64 {
+	if m != nil {
+		return m.ChecksumBytesRead
+	}
+	return 0
+}
+
+func (m *ListLog) GetFilesChangedDuringList() int64 {
+	if m != nil {
+		return m.FilesChangedDuringList
+	}
+	return 0
+}
+
+// A directory that could not be listed, and the reason why.
+type DirError struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Reason               string   `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DirError) Reset()         { *m = DirError{} }
+func (m *DirError) String() string { return proto.CompactTextString(m) }
+func (*DirError) ProtoMessage()    {}
+func (*DirError) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{17}
+}
+
+func (m *DirError) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DirError.Unmarshal(m, b)
+}
+func (m *DirError) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DirError.Marshal(b, m, deterministic)
+}
+func (m *DirError) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DirError.Merge(m, src)
+}
+func (m *DirError) XXX_Size() int {
+	return xxx_messageInfo_DirError.Size(m)
+}
+func (m *DirError) XXX_DiscardUnknown() {
+	xxx_messageInfo_DirError.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DirError proto.InternalMessageInfo
+
+func (m *DirError) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *DirError) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 // Contains log fields for a ProcessList task.
 type ProcessListLog struct {
 	EntriesProcessed     int64    `protobuf:"varint,1,opt,name=entries_processed,json=entriesProcessed,proto3" json:"entries_processed,omitempty"`
@@ -1520,7 +2169,7 @@ func (m *ProcessListLog) Reset()         { *m = ProcessListLog{} }
 func (m *ProcessListLog) String() string { return proto.CompactTextString(m) }
 func (*ProcessListLog) ProtoMessage()    {}
 func (*ProcessListLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{15}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{18}
 }
 
 func (m *ProcessListLog) XXX_Unmarshal(b []byte) error {
@@ -1576,7 +2225,7 @@ func (m *ProcessUnexploredDirsLog) Reset()         { *m = ProcessUnexploredDirsL
 func (m *ProcessUnexploredDirsLog) String() string { return proto.CompactTextString(m) }
 func (*ProcessUnexploredDirsLog) ProtoMessage()    {}
 func (*ProcessUnexploredDirsLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{16}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{19}
 }
 
 func (m *ProcessUnexploredDirsLog) XXX_Unmarshal(b []byte) error {
@@ -1618,18 +2267,210 @@ func (m *ProcessUnexploredDirsLog) GetEndingOffset() int64 {
 	return 0
 }
 
+// Contains log fields for a Verify task.
+type VerifyLog struct {
+	SrcFile   string `protobuf:"bytes,1,opt,name=src_file,json=srcFile,proto3" json:"src_file,omitempty"`
+	SrcBytes  int64  `protobuf:"varint,2,opt,name=src_bytes,json=srcBytes,proto3" json:"src_bytes,omitempty"`
+	SrcCrc32C uint32 `protobuf:"varint,3,opt,name=src_crc32c,json=srcCrc32c,proto3" json:"src_crc32c,omitempty"`
+	DstFile   string `protobuf:"bytes,4,opt,name=dst_file,json=dstFile,proto3" json:"dst_file,omitempty"`
+	DstBytes  int64  `protobuf:"varint,5,opt,name=dst_bytes,json=dstBytes,proto3" json:"dst_bytes,omitempty"`
+	DstCrc32C uint32 `protobuf:"varint,6,opt,name=dst_crc32c,json=dstCrc32c,proto3" json:"dst_crc32c,omitempty"`
+	// True if the object's size and CRC32C match the source file.
+	Match bool `protobuf:"varint,7,opt,name=match,proto3" json:"match,omitempty"`
+	// True if the object is gzip-encoded (see --gzip-files), so its CRC32C
+	// covers the compressed content and can't be compared with the source file.
+	// The source file isn't read, and match is false.
+	GzipEncoded          bool     `protobuf:"varint,8,opt,name=gzip_encoded,json=gzipEncoded,proto3" json:"gzip_encoded,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VerifyLog) Reset()         { *m = VerifyLog{} }
+func (m *VerifyLog) String() string { return proto.CompactTextString(m) }
+func (*VerifyLog) ProtoMessage()    {}
+func (*VerifyLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{20}
+}
+
+func (m *VerifyLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyLog.Unmarshal(m, b)
+}
+func (m *VerifyLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyLog.Marshal(b, m, deterministic)
+}
+func (m *VerifyLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyLog.Merge(m, src)
+}
+func (m *VerifyLog) XXX_Size() int {
+	return xxx_messageInfo_VerifyLog.Size(m)
+}
+func (m *VerifyLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyLog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyLog proto.InternalMessageInfo
+
+func (m *VerifyLog) GetSrcFile() string {
+	if m != nil {
+		return m.SrcFile
+	}
+	return ""
+}
+
+func (m *VerifyLog) GetSrcBytes() int64 {
+	if m != nil {
+		return m.SrcBytes
+	}
+	return 0
+}
+
+func (m *VerifyLog) GetSrcCrc32C() uint32 {
+	if m != nil {
+		return m.SrcCrc32C
+	}
+	return 0
+}
+
+func (m *VerifyLog) GetDstFile() string {
+	if m != nil {
+		return m.DstFile
+	}
+	return ""
+}
+
+func (m *VerifyLog) GetDstBytes() int64 {
+	if m != nil {
+		return m.DstBytes
+	}
+	return 0
+}
+
+func (m *VerifyLog) GetDstCrc32C() uint32 {
+	if m != nil {
+		return m.DstCrc32C
+	}
+	return 0
+}
+
+func (m *VerifyLog) GetMatch() bool {
+	if m != nil {
+		return m.Match
+	}
+	return false
+}
+
+func (m *VerifyLog) GetGzipEncoded() bool {
+	if m != nil {
+		return m.GzipEncoded
+	}
+	return false
+}
+
+// Contains log fields for a Reconcile task.
+type ReconcileLog struct {
+	FilesFound           int64    `protobuf:"varint,1,opt,name=files_found,json=filesFound,proto3" json:"files_found,omitempty"`
+	ObjectsFound         int64    `protobuf:"varint,2,opt,name=objects_found,json=objectsFound,proto3" json:"objects_found,omitempty"`
+	MissingObjects       int64    `protobuf:"varint,3,opt,name=missing_objects,json=missingObjects,proto3" json:"missing_objects,omitempty"`
+	ExtraObjects         int64    `protobuf:"varint,4,opt,name=extra_objects,json=extraObjects,proto3" json:"extra_objects,omitempty"`
+	SizeMismatches       int64    `protobuf:"varint,5,opt,name=size_mismatches,json=sizeMismatches,proto3" json:"size_mismatches,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReconcileLog) Reset()         { *m = ReconcileLog{} }
+func (m *ReconcileLog) String() string { return proto.CompactTextString(m) }
+func (*ReconcileLog) ProtoMessage()    {}
+func (*ReconcileLog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{21}
+}
+
+func (m *ReconcileLog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReconcileLog.Unmarshal(m, b)
+}
+func (m *ReconcileLog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReconcileLog.Marshal(b, m, deterministic)
+}
+func (m *ReconcileLog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReconcileLog.Merge(m, src)
+}
+func (m *ReconcileLog) XXX_Size() int {
+	return xxx_messageInfo_ReconcileLog.Size(m)
+}
+func (m *ReconcileLog) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReconcileLog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReconcileLog proto.InternalMessageInfo
+
+func (m *ReconcileLog) GetFilesFound() int64 {
+	if m != nil {
+		return m.FilesFound
+	}
+	return 0
+}
+
+func (m *ReconcileLog) GetObjectsFound() int64 {
+	if m != nil {
+		return m.ObjectsFound
+	}
+	return 0
+}
+
+func (m *ReconcileLog) GetMissingObjects() int64 {
+	if m != nil {
+		return m.MissingObjects
+	}
+	return 0
+}
+
+func (m *ReconcileLog) GetExtraObjects() int64 {
+	if m != nil {
+		return m.ExtraObjects
+	}
+	return 0
+}
+
+func (m *ReconcileLog) GetSizeMismatches() int64 {
+	if m != nil {
+		return m.SizeMismatches
+	}
+	return 0
+}
+
 // Contains log fields for a Copy task.
 type CopyLog struct {
-	SrcFile              string   `protobuf:"bytes,1,opt,name=src_file,json=srcFile,proto3" json:"src_file,omitempty"`
-	SrcBytes             int64    `protobuf:"varint,2,opt,name=src_bytes,json=srcBytes,proto3" json:"src_bytes,omitempty"`
-	SrcMTime             int64    `protobuf:"varint,3,opt,name=src_m_time,json=srcMTime,proto3" json:"src_m_time,omitempty"`
-	SrcCrc32C            uint32   `protobuf:"varint,4,opt,name=src_crc32c,json=srcCrc32c,proto3" json:"src_crc32c,omitempty"`
-	DstFile              string   `protobuf:"bytes,5,opt,name=dst_file,json=dstFile,proto3" json:"dst_file,omitempty"`
-	DstBytes             int64    `protobuf:"varint,6,opt,name=dst_bytes,json=dstBytes,proto3" json:"dst_bytes,omitempty"`
-	DstMTime             int64    `protobuf:"varint,7,opt,name=dst_m_time,json=dstMTime,proto3" json:"dst_m_time,omitempty"`
-	DstCrc32C            uint32   `protobuf:"varint,8,opt,name=dst_crc32c,json=dstCrc32c,proto3" json:"dst_crc32c,omitempty"`
-	DstMd5               string   `protobuf:"bytes,10,opt,name=dst_md5,json=dstMd5,proto3" json:"dst_md5,omitempty"`
-	BytesCopied          int64    `protobuf:"varint,9,opt,name=bytes_copied,json=bytesCopied,proto3" json:"bytes_copied,omitempty"`
+	SrcFile     string `protobuf:"bytes,1,opt,name=src_file,json=srcFile,proto3" json:"src_file,omitempty"`
+	SrcBytes    int64  `protobuf:"varint,2,opt,name=src_bytes,json=srcBytes,proto3" json:"src_bytes,omitempty"`
+	SrcMTime    int64  `protobuf:"varint,3,opt,name=src_m_time,json=srcMTime,proto3" json:"src_m_time,omitempty"`
+	SrcCrc32C   uint32 `protobuf:"varint,4,opt,name=src_crc32c,json=srcCrc32c,proto3" json:"src_crc32c,omitempty"`
+	DstFile     string `protobuf:"bytes,5,opt,name=dst_file,json=dstFile,proto3" json:"dst_file,omitempty"`
+	DstBytes    int64  `protobuf:"varint,6,opt,name=dst_bytes,json=dstBytes,proto3" json:"dst_bytes,omitempty"`
+	DstMTime    int64  `protobuf:"varint,7,opt,name=dst_m_time,json=dstMTime,proto3" json:"dst_m_time,omitempty"`
+	DstCrc32C   uint32 `protobuf:"varint,8,opt,name=dst_crc32c,json=dstCrc32c,proto3" json:"dst_crc32c,omitempty"`
+	DstMd5      string `protobuf:"bytes,10,opt,name=dst_md5,json=dstMd5,proto3" json:"dst_md5,omitempty"`
+	BytesCopied int64  `protobuf:"varint,9,opt,name=bytes_copied,json=bytesCopied,proto3" json:"bytes_copied,omitempty"`
+	// True if the copy was skipped because the destination object was
+	// unchanged.
+	Skipped bool `protobuf:"varint,11,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	// Set when the source file is deleted after a successful copy.
+	SrcDeleted     bool   `protobuf:"varint,12,opt,name=src_deleted,json=srcDeleted,proto3" json:"src_deleted,omitempty"`
+	SrcDeleteError string `protobuf:"bytes,13,opt,name=src_delete_error,json=srcDeleteError,proto3" json:"src_delete_error,omitempty"`
+	// The number of bytes sent after compression, for files uploaded with
+	// Content-Encoding: gzip.
+	CompressedBytes int64 `protobuf:"varint,14,opt,name=compressed_bytes,json=compressedBytes,proto3" json:"compressed_bytes,omitempty"`
+	// The hex SHA256 digest of the source file, if requested. This is only
+	// recorded for auditing and isn't verified against GCS.
+	SrcSha256 string `protobuf:"bytes,15,opt,name=src_sha256,json=srcSha256,proto3" json:"src_sha256,omitempty"`
+	// The custom metadata of the destination object, for auditing.
+	DstMetadata map[string]string `protobuf:"bytes,16,rep,name=dst_metadata,json=dstMetadata,proto3" json:"dst_metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The type of the source file system, such as "nfs" or "ext4", if the agent
+	// could detect it.
+	SrcFsType string `protobuf:"bytes,17,opt,name=src_fs_type,json=srcFsType,proto3" json:"src_fs_type,omitempty"`
+	// True if only the destination object's metadata was updated, see
+	// CopySpec.metadata_only.
+	MetadataOnly         bool     `protobuf:"varint,18,opt,name=metadata_only,json=metadataOnly,proto3" json:"metadata_only,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1639,7 +2480,7 @@ func (m *CopyLog) Reset()         { *m = CopyLog{} }
 func (m *CopyLog) String() string { return proto.CompactTextString(m) }
 func (*CopyLog) ProtoMessage()    {}
 func (*CopyLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{17}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{22}
 }
 
 func (m *CopyLog) XXX_Unmarshal(b []byte) error {
@@ -1730,6 +2571,62 @@ func (m *CopyLog) GetBytesCopied() int64 {
 	return 0
 }
 
+func (m *CopyLog) GetSkipped() bool {
+	if m != nil {
+		return m.Skipped
+	}
+	return false
+}
+
+func (m *CopyLog) GetSrcDeleted() bool {
+	if m != nil {
+		return m.SrcDeleted
+	}
+	return false
+}
+
+func (m *CopyLog) GetSrcDeleteError() string {
+	if m != nil {
+		return m.SrcDeleteError
+	}
+	return ""
+}
+
+func (m *CopyLog) GetCompressedBytes() int64 {
+	if m != nil {
+		return m.CompressedBytes
+	}
+	return 0
+}
+
+func (m *CopyLog) GetSrcSha256() string {
+	if m != nil {
+		return m.SrcSha256
+	}
+	return ""
+}
+
+func (m *CopyLog) GetDstMetadata() map[string]string {
+	if m != nil {
+		return m.DstMetadata
+	}
+	return nil
+}
+
+func (m *CopyLog) GetSrcFsType() string {
+	if m != nil {
+		return m.SrcFsType
+	}
+	return ""
+}
+
+func (m *CopyLog) GetMetadataOnly() bool {
+	if m != nil {
+		return m.MetadataOnly
+	}
+	return false
+}
+
 type BundledFileLog struct {
 	Status               Status      `protobuf:"varint,1,opt,name=status,proto3,enum=cloud_ingest_task.Status" json:"status,omitempty"`
 	FailureType          FailureType `protobuf:"varint,2,opt,name=failure_type,json=failureType,proto3,enum=cloud_ingest_task.FailureType" json:"failure_type,omitempty"`
@@ -1744,7 +2641,7 @@ func (m *BundledFileLog) Reset()         { *m = BundledFileLog{} }
 func (m *BundledFileLog) String() string { return proto.CompactTextString(m) }
 func (*BundledFileLog) ProtoMessage()    {}
 func (*BundledFileLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{18}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{23}
 }
 
 func (m *BundledFileLog) XXX_Unmarshal(b []byte) error {
@@ -1795,21 +2692,26 @@ func (m *BundledFileLog) GetCopyLog() *CopyLog {
 
 // Contains log fields for a CopyBundle task.
 type CopyBundleLog struct {
-	FilesCopied          int64             `protobuf:"varint,1,opt,name=files_copied,json=filesCopied,proto3" json:"files_copied,omitempty"`
-	BytesCopied          int64             `protobuf:"varint,2,opt,name=bytes_copied,json=bytesCopied,proto3" json:"bytes_copied,omitempty"`
-	FilesFailed          int64             `protobuf:"varint,3,opt,name=files_failed,json=filesFailed,proto3" json:"files_failed,omitempty"`
-	BytesFailed          int64             `protobuf:"varint,4,opt,name=bytes_failed,json=bytesFailed,proto3" json:"bytes_failed,omitempty"`
-	BundledFilesLogs     []*BundledFileLog `protobuf:"bytes,5,rep,name=bundled_files_logs,json=bundledFilesLogs,proto3" json:"bundled_files_logs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	FilesCopied      int64             `protobuf:"varint,1,opt,name=files_copied,json=filesCopied,proto3" json:"files_copied,omitempty"`
+	BytesCopied      int64             `protobuf:"varint,2,opt,name=bytes_copied,json=bytesCopied,proto3" json:"bytes_copied,omitempty"`
+	FilesFailed      int64             `protobuf:"varint,3,opt,name=files_failed,json=filesFailed,proto3" json:"files_failed,omitempty"`
+	BytesFailed      int64             `protobuf:"varint,4,opt,name=bytes_failed,json=bytesFailed,proto3" json:"bytes_failed,omitempty"`
+	BundledFilesLogs []*BundledFileLog `protobuf:"bytes,5,rep,name=bundled_files_logs,json=bundledFilesLogs,proto3" json:"bundled_files_logs,omitempty"`
+	// Breakdown of files_failed by the most common failure types.
+	FilesHashMismatch     int64    `protobuf:"varint,6,opt,name=files_hash_mismatch,json=filesHashMismatch,proto3" json:"files_hash_mismatch,omitempty"`
+	FilesNotFound         int64    `protobuf:"varint,7,opt,name=files_not_found,json=filesNotFound,proto3" json:"files_not_found,omitempty"`
+	FilesModified         int64    `protobuf:"varint,8,opt,name=files_modified,json=filesModified,proto3" json:"files_modified,omitempty"`
+	FilesPermissionDenied int64    `protobuf:"varint,9,opt,name=files_permission_denied,json=filesPermissionDenied,proto3" json:"files_permission_denied,omitempty"`
+	XXX_NoUnkeyedLiteral  struct{} `json:"-"`
+	XXX_unrecognized      []byte   `json:"-"`
+	XXX_sizecache         int32    `json:"-"`
 }
 
 func (m *CopyBundleLog) Reset()         { *m = CopyBundleLog{} }
 func (m *CopyBundleLog) String() string { return proto.CompactTextString(m) }
 func (*CopyBundleLog) ProtoMessage()    {}
 func (*CopyBundleLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{19}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{24}
 }
 
 func (m *CopyBundleLog) XXX_Unmarshal(b []byte) error {
@@ -1865,6 +2767,34 @@ func (m *CopyBundleLog) GetBundledFilesLogs() []*BundledFileLog {
 	return nil
 }
 
+func (m *CopyBundleLog) GetFilesHashMismatch() int64 {
+	if m != nil {
+		return m.FilesHashMismatch
+	}
+	return 0
+}
+
+func (m *CopyBundleLog) GetFilesNotFound() int64 {
+	if m != nil {
+		return m.FilesNotFound
+	}
+	return 0
+}
+
+func (m *CopyBundleLog) GetFilesModified() int64 {
+	if m != nil {
+		return m.FilesModified
+	}
+	return 0
+}
+
+func (m *CopyBundleLog) GetFilesPermissionDenied() int64 {
+	if m != nil {
+		return m.FilesPermissionDenied
+	}
+	return 0
+}
+
 type BundledObjectLog struct {
 	Status               Status      `protobuf:"varint,1,opt,name=status,proto3,enum=cloud_ingest_task.Status" json:"status,omitempty"`
 	FailureType          FailureType `protobuf:"varint,2,opt,name=failure_type,json=failureType,proto3,enum=cloud_ingest_task.FailureType" json:"failure_type,omitempty"`
@@ -1881,7 +2811,7 @@ func (m *BundledObjectLog) Reset()         { *m = BundledObjectLog{} }
 func (m *BundledObjectLog) String() string { return proto.CompactTextString(m) }
 func (*BundledObjectLog) ProtoMessage()    {}
 func (*BundledObjectLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{20}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{25}
 }
 
 func (m *BundledObjectLog) XXX_Unmarshal(b []byte) error {
@@ -1960,7 +2890,7 @@ func (m *DeleteBundleLog) Reset()         { *m = DeleteBundleLog{} }
 func (m *DeleteBundleLog) String() string { return proto.CompactTextString(m) }
 func (*DeleteBundleLog) ProtoMessage()    {}
 func (*DeleteBundleLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_ce5d8dd45b4a91ff, []int{21}
+	return fileDescriptor_ce5d8dd45b4a91ff, []int{26}
 }
 
 func (m *DeleteBundleLog) XXX_Unmarshal(b []byte) error {
@@ -2020,11 +2950,15 @@ func init() {
 	proto.RegisterEnum("cloud_ingest_task.Type", Type_name, Type_value)
 	proto.RegisterEnum("cloud_ingest_task.Status", Status_name, Status_value)
 	proto.RegisterEnum("cloud_ingest_task.FailureType", FailureType_name, FailureType_value)
+	proto.RegisterEnum("cloud_ingest_task.OverwritePolicy", OverwritePolicy_name, OverwritePolicy_value)
 	proto.RegisterType((*Spec)(nil), "cloud_ingest_task.Spec")
 	proto.RegisterType((*ListSpec)(nil), "cloud_ingest_task.ListSpec")
 	proto.RegisterType((*ProcessListSpec)(nil), "cloud_ingest_task.ProcessListSpec")
 	proto.RegisterType((*ProcessUnexploredDirsSpec)(nil), "cloud_ingest_task.ProcessUnexploredDirsSpec")
 	proto.RegisterType((*CopySpec)(nil), "cloud_ingest_task.CopySpec")
+	proto.RegisterMapType((map[string]string)(nil), "cloud_ingest_task.CopySpec.ObjectMetadataEntry")
+	proto.RegisterType((*VerifySpec)(nil), "cloud_ingest_task.VerifySpec")
+	proto.RegisterType((*ReconcileSpec)(nil), "cloud_ingest_task.ReconcileSpec")
 	proto.RegisterType((*BundledFile)(nil), "cloud_ingest_task.BundledFile")
 	proto.RegisterType((*CopyBundleSpec)(nil), "cloud_ingest_task.CopyBundleSpec")
 	proto.RegisterType((*DeleteObjectSpec)(nil), "cloud_ingest_task.DeleteObjectSpec")
@@ -2035,9 +2969,13 @@ func init() {
 	proto.RegisterType((*TaskRespMsg)(nil), "cloud_ingest_task.TaskRespMsg")
 	proto.RegisterType((*Log)(nil), "cloud_ingest_task.Log")
 	proto.RegisterType((*ListLog)(nil), "cloud_ingest_task.ListLog")
+	proto.RegisterType((*DirError)(nil), "cloud_ingest_task.DirError")
 	proto.RegisterType((*ProcessListLog)(nil), "cloud_ingest_task.ProcessListLog")
 	proto.RegisterType((*ProcessUnexploredDirsLog)(nil), "cloud_ingest_task.ProcessUnexploredDirsLog")
+	proto.RegisterType((*VerifyLog)(nil), "cloud_ingest_task.VerifyLog")
+	proto.RegisterType((*ReconcileLog)(nil), "cloud_ingest_task.ReconcileLog")
 	proto.RegisterType((*CopyLog)(nil), "cloud_ingest_task.CopyLog")
+	proto.RegisterMapType((map[string]string)(nil), "cloud_ingest_task.CopyLog.DstMetadataEntry")
 	proto.RegisterType((*BundledFileLog)(nil), "cloud_ingest_task.BundledFileLog")
 	proto.RegisterType((*CopyBundleLog)(nil), "cloud_ingest_task.CopyBundleLog")
 	proto.RegisterType((*BundledObjectLog)(nil), "cloud_ingest_task.BundledObjectLog")
@@ -2047,155 +2985,242 @@ func init() {
 func init() { proto.RegisterFile("task.proto", fileDescriptor_ce5d8dd45b4a91ff) }
 
 var fileDescriptor_ce5d8dd45b4a91ff = []byte{
-	// 2391 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x59, 0x4b, 0x93, 0xdb, 0x58,
-	0x15, 0x8e, 0xfc, 0xf6, 0xf1, 0x4b, 0xbe, 0x49, 0xba, 0xdd, 0x9d, 0xc9, 0xa4, 0xe3, 0x26, 0xa4,
-	0x2b, 0x61, 0xba, 0x8b, 0x84, 0x0c, 0x14, 0x54, 0x01, 0x7e, 0xa8, 0x13, 0x27, 0x7e, 0x8d, 0x2c,
-	0x07, 0x42, 0x15, 0xa5, 0xb2, 0xa5, 0xdb, 0x1e, 0x25, 0xb2, 0xa5, 0xe8, 0xca, 0x54, 0x7a, 0xc7,
-	0x9e, 0x35, 0x54, 0xb1, 0x60, 0xc1, 0x8a, 0x1d, 0x14, 0xbf, 0x80, 0x62, 0xc5, 0x9a, 0x2a, 0x36,
-	0xb3, 0x60, 0xcb, 0x8a, 0x7f, 0xc0, 0x86, 0xba, 0x0f, 0xd9, 0x92, 0x5b, 0xee, 0xce, 0xa4, 0x28,
-	0x66, 0x56, 0x91, 0xcf, 0xe3, 0x3b, 0xe7, 0xdc, 0x7b, 0xee, 0x3d, 0xf7, 0xeb, 0x00, 0xf8, 0x13,
-	0xf2, 0xe6, 0xd8, 0xf5, 0x1c, 0xdf, 0x41, 0x55, 0xc3, 0x76, 0x96, 0xa6, 0x6e, 0x2d, 0x66, 0x98,
-	0xf8, 0x3a, 0x55, 0xec, 0xdf, 0x99, 0x39, 0xce, 0xcc, 0xc6, 0x27, 0xcc, 0x60, 0xba, 0x3c, 0x3b,
-	0xf1, 0xad, 0x39, 0x26, 0xfe, 0x64, 0xee, 0x72, 0x9f, 0xfd, 0x82, 0xbb, 0xb4, 0x09, 0xe6, 0x3f,
-	0xea, 0xff, 0x49, 0x41, 0x6a, 0xe4, 0x62, 0x03, 0x7d, 0x1f, 0xf2, 0xb6, 0x45, 0x7c, 0x9d, 0xb8,
-	0xd8, 0xa8, 0x49, 0x07, 0xd2, 0x51, 0xe1, 0xd1, 0xad, 0xe3, 0x0b, 0xe8, 0xc7, 0x5d, 0x8b, 0xf8,
-	0xd4, 0xfe, 0xd9, 0x35, 0x35, 0x67, 0x8b, 0x6f, 0x34, 0x84, 0xaa, 0xeb, 0x39, 0x06, 0x26, 0x44,
-	0x5f, 0x63, 0x24, 0x18, 0x46, 0x3d, 0x06, 0x63, 0xc8, 0x6d, 0x43, 0x50, 0x15, 0x37, 0x2a, 0xa2,
-	0xd9, 0x18, 0x8e, 0x7b, 0xce, 0x91, 0x92, 0x5b, 0xb3, 0x69, 0x39, 0xee, 0x79, 0x90, 0x8d, 0x21,
-	0xbe, 0x51, 0x0f, 0x64, 0xe6, 0x3b, 0x5d, 0x2e, 0x4c, 0x1b, 0x73, 0x88, 0x14, 0x83, 0xb8, 0xbb,
-	0x05, 0xa2, 0xc9, 0x2c, 0x05, 0x50, 0xd9, 0x88, 0x48, 0x90, 0x03, 0x1f, 0x05, 0xc5, 0x2d, 0x17,
-	0xf8, 0x9d, 0x6b, 0x3b, 0x1e, 0x36, 0x75, 0xd3, 0xf2, 0x08, 0x87, 0x4e, 0x33, 0xe8, 0x6f, 0x6d,
-	0xaf, 0x73, 0xbc, 0xf2, 0x6a, 0x5b, 0x1e, 0x11, 0x51, 0xf6, 0xdc, 0x6d, 0x4a, 0x34, 0x02, 0x64,
-	0x62, 0x1b, 0xfb, 0x38, 0x52, 0x41, 0x86, 0x85, 0x39, 0x8c, 0x09, 0xd3, 0x66, 0xc6, 0x91, 0x1a,
-	0x64, 0x73, 0x43, 0x86, 0x0c, 0xa8, 0x05, 0x55, 0x08, 0xf0, 0x75, 0x05, 0x59, 0x06, 0x7d, 0xb4,
-	0xbd, 0x02, 0x1e, 0x21, 0x94, 0xfd, 0x4d, 0x37, 0x4e, 0x81, 0xee, 0x43, 0xc5, 0x22, 0x64, 0x39,
-	0x59, 0x18, 0x58, 0x5f, 0x2c, 0xe7, 0x53, 0xec, 0xd5, 0x72, 0x07, 0xd2, 0x51, 0x52, 0x2d, 0x07,
-	0xe2, 0x3e, 0x93, 0x36, 0x33, 0x90, 0xa2, 0x91, 0xeb, 0xff, 0x4c, 0x42, 0x6e, 0xb5, 0xe7, 0x8f,
-	0x61, 0xc7, 0x24, 0x3e, 0xef, 0x20, 0x0f, 0x93, 0xa5, 0xed, 0xeb, 0xd3, 0xa5, 0xf1, 0x06, 0xfb,
-	0xac, 0x1d, 0xf3, 0xea, 0x75, 0x93, 0xf8, 0xd4, 0x58, 0x65, 0xba, 0x26, 0x53, 0xc5, 0x39, 0x39,
-	0xd3, 0xd7, 0xd8, 0xf0, 0x59, 0xff, 0x6d, 0x3a, 0x0d, 0x98, 0x0a, 0xfd, 0x00, 0xf6, 0xa9, 0xd3,
-	0xe6, 0x76, 0x0a, 0xc7, 0x34, 0x73, 0xdc, 0x35, 0x89, 0x1f, 0xdd, 0x1c, 0xe1, 0x7c, 0x1f, 0x2a,
-	0xc4, 0x33, 0xa8, 0x07, 0x36, 0x7c, 0xc7, 0xb3, 0x30, 0xa9, 0x25, 0x0f, 0x92, 0x47, 0x79, 0xb5,
-	0x4c, 0x3c, 0xa3, 0xbd, 0x96, 0xa2, 0x4f, 0x61, 0x17, 0xbf, 0x73, 0xb1, 0xe1, 0x63, 0x53, 0x9f,
-	0xe1, 0x05, 0xf6, 0x26, 0xbe, 0xe5, 0x2c, 0xe8, 0xc2, 0xb0, 0x76, 0x4c, 0xaa, 0x37, 0x03, 0xf5,
-	0xd3, 0x95, 0xb6, 0xbf, 0x9c, 0xa3, 0x2e, 0x1c, 0x86, 0xcb, 0xd9, 0x86, 0x91, 0x65, 0x18, 0x77,
-	0xec, 0x55, 0x71, 0x4a, 0x2c, 0x9a, 0x06, 0xf7, 0x37, 0xeb, 0xdc, 0x86, 0x98, 0x61, 0x88, 0x87,
-	0xcb, 0x48, 0xd5, 0xf1, 0xa8, 0xf7, 0xa0, 0xec, 0x39, 0x8e, 0xbf, 0x5a, 0x85, 0x73, 0xb6, 0xd1,
-	0x79, 0xb5, 0x44, 0xa5, 0xc1, 0x22, 0x9c, 0xd7, 0xff, 0x2a, 0x41, 0x65, 0xe3, 0xb4, 0xff, 0x1f,
-	0xb7, 0xf9, 0x10, 0x4a, 0xe1, 0x9d, 0x3a, 0x67, 0x17, 0x49, 0x5e, 0x2d, 0x86, 0xf6, 0xe9, 0x1c,
-	0xdd, 0x81, 0xc2, 0xf4, 0xdc, 0xc7, 0xba, 0x73, 0x76, 0x46, 0xb0, 0x2f, 0x76, 0x06, 0xa8, 0x68,
-	0xc0, 0x24, 0xf5, 0x3f, 0x4a, 0xb0, 0xb7, 0xf5, 0x24, 0x7f, 0x58, 0x35, 0x97, 0xf7, 0x5f, 0xe2,
-	0xf2, 0xfe, 0xdb, 0x48, 0x38, 0x79, 0x21, 0xe1, 0xbf, 0x27, 0x20, 0x17, 0x5c, 0x8c, 0x68, 0x0f,
-	0x72, 0x74, 0x0d, 0xce, 0x2c, 0x1b, 0x8b, 0x8c, 0xb2, 0xc4, 0x33, 0x4e, 0x2d, 0x1b, 0xa3, 0xdb,
-	0x00, 0x34, 0x0b, 0x91, 0x2e, 0x8f, 0x9a, 0x37, 0x49, 0x90, 0xa4, 0x50, 0x8b, 0xa4, 0x92, 0x2b,
-	0xb5, 0x48, 0xe3, 0x43, 0xbb, 0xfb, 0x36, 0x00, 0x4d, 0x46, 0xa7, 0x09, 0x13, 0xd1, 0x72, 0x79,
-	0x2a, 0x69, 0x52, 0x01, 0xfa, 0x18, 0x0a, 0x4c, 0x3d, 0xd7, 0xe9, 0xd8, 0x12, 0x4d, 0xce, 0xf4,
-	0x3d, 0xcd, 0x9a, 0x63, 0x74, 0x17, 0x8a, 0xcc, 0x53, 0x37, 0x1c, 0xd7, 0xc2, 0xa6, 0xb8, 0x5f,
-	0xd8, 0x8a, 0x90, 0x16, 0x13, 0xa1, 0x1d, 0xc8, 0x18, 0x9e, 0xf1, 0xf8, 0x91, 0x51, 0xcb, 0x1f,
-	0x48, 0x47, 0x25, 0x55, 0xfc, 0x42, 0xc7, 0x70, 0x9d, 0xee, 0xd0, 0x7c, 0x32, 0xb5, 0xb1, 0xbe,
-	0x74, 0x6d, 0x67, 0x62, 0xea, 0x96, 0x59, 0x2b, 0xb0, 0xca, 0xaa, 0x2b, 0xd5, 0x98, 0x69, 0x3a,
-	0xe6, 0xf3, 0x54, 0x2e, 0x2d, 0x67, 0x9e, 0xa7, 0x72, 0x20, 0x17, 0xea, 0xbf, 0x4b, 0x40, 0x81,
-	0xdf, 0xa6, 0x26, 0x5b, 0xbb, 0xef, 0x85, 0xe7, 0x93, 0x74, 0xe5, 0x7c, 0x0a, 0x4d, 0xa7, 0x6f,
-	0x43, 0x86, 0xf8, 0x13, 0x7f, 0x49, 0xd8, 0x8a, 0x97, 0x1f, 0xed, 0xc5, 0xb8, 0x8d, 0x98, 0x81,
-	0x2a, 0x0c, 0x51, 0x03, 0x8a, 0x67, 0x13, 0xcb, 0x5e, 0x7a, 0x58, 0xf7, 0xcf, 0x5d, 0xcc, 0xf6,
-	0xa2, 0xfc, 0xe8, 0xe3, 0x18, 0xc7, 0x53, 0x6e, 0xa6, 0x9d, 0xbb, 0x58, 0x2d, 0x9c, 0xad, 0x7f,
-	0xd0, 0x4b, 0x2b, 0x80, 0x98, 0x63, 0x42, 0x26, 0x33, 0xcc, 0x76, 0x29, 0xaf, 0x96, 0x85, 0xb8,
-	0xc7, 0xa5, 0xe8, 0x09, 0xb0, 0x54, 0x75, 0xdb, 0x99, 0x89, 0xc9, 0xb6, 0xbf, 0xa5, 0xae, 0xae,
-	0x33, 0x53, 0xb3, 0x06, 0xff, 0xa8, 0x8f, 0xa1, 0x1c, 0x1d, 0xa4, 0xa8, 0x05, 0x25, 0x3e, 0xbe,
-	0x4c, 0xd6, 0x7c, 0xa4, 0x26, 0x1d, 0x24, 0x8f, 0x0a, 0xb1, 0x59, 0x87, 0x16, 0x56, 0x2d, 0x4e,
-	0xd7, 0x3f, 0x48, 0xfd, 0xf7, 0x12, 0xc8, 0x7c, 0xc6, 0xf0, 0xae, 0x63, 0xc8, 0xd1, 0xbe, 0x95,
-	0x2e, 0xef, 0xdb, 0xc4, 0x66, 0xdf, 0xde, 0x83, 0xf2, 0x46, 0xbb, 0xf2, 0x13, 0x54, 0x9a, 0x45,
-	0xda, 0xf4, 0x08, 0xe4, 0x35, 0x8a, 0x68, 0x56, 0xde, 0xd7, 0xe5, 0x15, 0x16, 0xeb, 0xd8, 0xfa,
-	0x3f, 0x12, 0x50, 0x12, 0x15, 0x88, 0x10, 0x9f, 0xad, 0x06, 0xb8, 0x70, 0x0f, 0x75, 0xc9, 0xf6,
-	0x01, 0xbe, 0xae, 0x30, 0x18, 0xdf, 0xa1, 0x9a, 0xbf, 0xe6, 0x5d, 0xf3, 0x19, 0xa0, 0x60, 0xb3,
-	0x45, 0xc9, 0xeb, 0xfe, 0x39, 0xdc, 0xbe, 0xe3, 0xbc, 0x40, 0xda, 0x48, 0xf2, 0x74, 0x43, 0x52,
-	0xff, 0x79, 0xb0, 0xf3, 0xa1, 0x9e, 0xea, 0x40, 0x25, 0x1a, 0x26, 0xe8, 0xaa, 0x83, 0xab, 0x62,
-	0xa8, 0xe5, 0x48, 0x00, 0x52, 0xff, 0x9b, 0x04, 0x37, 0x63, 0x5f, 0x37, 0x57, 0xb5, 0xd7, 0x0e,
-	0x64, 0x5c, 0x0f, 0x9f, 0x59, 0xef, 0x6a, 0x09, 0x36, 0xf5, 0xc5, 0x2f, 0x3a, 0x6c, 0xf8, 0x57,
-	0xf4, 0x62, 0x2e, 0x72, 0x21, 0xbf, 0x9a, 0xa9, 0x91, 0x58, 0x9f, 0xc8, 0xb8, 0x29, 0x72, 0xa1,
-	0x30, 0xfa, 0x04, 0x90, 0xe1, 0x2c, 0x7c, 0x6b, 0xb1, 0xe4, 0x3d, 0xea, 0x3b, 0x6f, 0xf0, 0x42,
-	0xbc, 0x4a, 0xaa, 0x61, 0x8d, 0x46, 0x15, 0xf5, 0xbf, 0x48, 0x00, 0xda, 0x84, 0xbc, 0x51, 0xf1,
-	0xdb, 0x1e, 0x99, 0xa1, 0x87, 0x80, 0x68, 0xf9, 0xba, 0x87, 0x6d, 0xdd, 0xa3, 0x57, 0xff, 0x62,
-	0x32, 0x0f, 0xae, 0xfe, 0x8a, 0xcf, 0xec, 0x6c, 0x95, 0x78, 0x46, 0x7f, 0x32, 0xc7, 0xe8, 0x04,
-	0x6e, 0xbc, 0x76, 0xa6, 0xde, 0x72, 0xb1, 0x61, 0xce, 0x6f, 0xfb, 0x2a, 0xd7, 0x85, 0x1d, 0xbe,
-	0x09, 0x95, 0xd7, 0xce, 0x54, 0xa7, 0x1e, 0xbf, 0xc0, 0x1e, 0xb1, 0x9c, 0x85, 0xe8, 0x88, 0xd2,
-	0x6b, 0x67, 0xaa, 0x2e, 0x17, 0x2f, 0xb9, 0x10, 0x3d, 0xe4, 0x0f, 0x3c, 0x41, 0x02, 0x76, 0xe3,
-	0xba, 0x95, 0x36, 0x3a, 0x7f, 0x05, 0xfe, 0x21, 0x0d, 0x05, 0x5e, 0x01, 0x71, 0xbf, 0x74, 0x09,
-	0x31, 0x19, 0xe5, 0xe2, 0x32, 0x3a, 0x84, 0xd2, 0x64, 0x86, 0x17, 0xfe, 0xca, 0x2a, 0xcf, 0x1f,
-	0x03, 0x4c, 0x18, 0x18, 0xed, 0x44, 0x8e, 0x59, 0xfe, 0x2b, 0x39, 0x4b, 0x47, 0x90, 0x5c, 0x1f,
-	0x9e, 0x9d, 0x38, 0x0a, 0xe6, 0xcc, 0x54, 0x6a, 0x82, 0x1e, 0x41, 0xce, 0xc3, 0x6f, 0xc3, 0xf4,
-	0x60, 0xeb, 0x42, 0x67, 0x3d, 0xfc, 0x96, 0x75, 0xf7, 0x77, 0x20, 0xef, 0x61, 0xe2, 0x86, 0x1f,
-	0xfe, 0x5b, 0x9d, 0x72, 0xd4, 0x92, 0x79, 0xb5, 0x41, 0xa6, 0x91, 0xdc, 0xe5, 0xd4, 0xb6, 0xc8,
-	0xe7, 0x7c, 0x34, 0x83, 0x98, 0x0e, 0x9c, 0x6e, 0x1e, 0x07, 0x74, 0xf3, 0x58, 0x0b, 0xe8, 0xa6,
-	0x5a, 0xf6, 0xf0, 0xdb, 0x21, 0x77, 0x61, 0xb3, 0xfb, 0xc7, 0x50, 0x66, 0xf9, 0xfa, 0x13, 0xcf,
-	0xe7, 0x18, 0x85, 0x2b, 0x31, 0x8a, 0x34, 0x71, 0xea, 0xc0, 0x10, 0x4e, 0xa1, 0xca, 0xb2, 0x8f,
-	0x24, 0x52, 0xbc, 0x12, 0xa4, 0x42, 0x9d, 0xc2, 0x99, 0x7c, 0x0a, 0x39, 0xde, 0x0c, 0x96, 0x59,
-	0x2b, 0xc5, 0x4d, 0x6f, 0x4e, 0x91, 0x1b, 0xd4, 0xa6, 0x63, 0xaa, 0xd9, 0x09, 0xff, 0xa8, 0x7f,
-	0x91, 0x84, 0x64, 0xd7, 0x99, 0xa1, 0xef, 0x02, 0x23, 0xbf, 0xec, 0x96, 0x93, 0xb6, 0x4e, 0x49,
-	0xfa, 0xee, 0xeb, 0x3a, 0xb3, 0x67, 0xd7, 0xd4, 0xac, 0xcd, 0x3f, 0x29, 0x37, 0x8d, 0x30, 0x65,
-	0x0a, 0x90, 0xd8, 0xca, 0x4d, 0x43, 0x4f, 0x67, 0x8e, 0x53, 0x76, 0x23, 0x12, 0x9a, 0xc7, 0x6a,
-	0x5a, 0x27, 0xaf, 0x9a, 0xd6, 0x34, 0x0f, 0x31, 0xaf, 0xd1, 0x73, 0xa8, 0x84, 0x39, 0x32, 0xf5,
-	0xe7, 0x14, 0xf9, 0xe0, 0x52, 0x8a, 0xcc, 0x51, 0x4a, 0x46, 0x58, 0x80, 0x6c, 0xb8, 0xb5, 0x8d,
-	0x20, 0xaf, 0x1b, 0xf9, 0xe1, 0xfb, 0xf2, 0x63, 0x1e, 0xa2, 0xe6, 0x6e, 0xd1, 0xa1, 0x21, 0x54,
-	0xa3, 0xec, 0x98, 0xc6, 0xc8, 0x6c, 0xfd, 0x5b, 0x43, 0x78, 0x86, 0x70, 0xe8, 0x8a, 0x19, 0x15,
-	0x35, 0xd3, 0xec, 0xc0, 0xd5, 0xbf, 0x90, 0x20, 0x1b, 0xac, 0xeb, 0x1d, 0xfe, 0x0a, 0x25, 0xfa,
-	0x99, 0xb3, 0x5c, 0x98, 0x6c, 0x8b, 0x93, 0x2a, 0x7b, 0xb7, 0x92, 0x53, 0x2a, 0x09, 0x1e, 0xe1,
-	0x81, 0x41, 0x62, 0xfd, 0x08, 0x17, 0x06, 0x74, 0x8a, 0xd0, 0x15, 0xe0, 0x7a, 0x3e, 0x0b, 0xf2,
-	0x54, 0xb2, 0xf2, 0xe7, 0x0b, 0x64, 0x11, 0x1f, 0x9b, 0x01, 0xeb, 0xa0, 0xa2, 0x2e, 0x93, 0xd0,
-	0x6b, 0x8d, 0x19, 0x2c, 0x1c, 0x3f, 0x30, 0x4a, 0xf3, 0x77, 0x0a, 0x15, 0xf7, 0x1d, 0x5f, 0xd8,
-	0x7d, 0x03, 0xca, 0x2b, 0x3b, 0x1e, 0x2b, 0xc3, 0xc6, 0x52, 0x51, 0x98, 0xb1, 0x70, 0xf5, 0x5f,
-	0x49, 0x50, 0x8e, 0x36, 0x13, 0x7a, 0x08, 0x55, 0xbc, 0xf0, 0x29, 0x51, 0xd5, 0xc5, 0x5a, 0xe3,
-	0xa0, 0x50, 0x59, 0x28, 0x86, 0x81, 0x9c, 0x71, 0x5e, 0x7a, 0x08, 0xad, 0xc5, 0x2c, 0x98, 0x5c,
-	0xbc, 0xe4, 0x72, 0x20, 0x5e, 0x0f, 0x38, 0xbc, 0x30, 0x43, 0x66, 0x62, 0x0a, 0x72, 0xa1, 0x20,
-	0x28, 0xbf, 0x96, 0xa0, 0xb6, 0x6d, 0xef, 0xbf, 0xca, 0xbc, 0xfe, 0x94, 0x80, 0xac, 0x38, 0x2b,
-	0x97, 0xf1, 0xa6, 0x5b, 0x90, 0xa7, 0x2a, 0xfe, 0x26, 0xe4, 0xe1, 0xa8, 0x2d, 0xe7, 0x2f, 0x1f,
-	0x01, 0x50, 0xa5, 0xa0, 0x2f, 0xc9, 0x95, 0x96, 0xb3, 0x97, 0xdb, 0x5c, 0x2b, 0xe8, 0x49, 0x8a,
-	0xd1, 0x13, 0x0a, 0xd6, 0xe2, 0x0c, 0x65, 0x0f, 0x72, 0xf4, 0xe9, 0xc1, 0x82, 0xf2, 0x79, 0x9f,
-	0x35, 0x89, 0x1f, 0x04, 0x65, 0xaf, 0x92, 0x10, 0x6b, 0xa2, 0xb6, 0xab, 0xa0, 0x54, 0x19, 0xe1,
-	0x4c, 0x54, 0xbb, 0x0a, 0x4a, 0xb5, 0x22, 0x68, 0x8e, 0x07, 0x35, 0x89, 0x2f, 0x82, 0xee, 0x42,
-	0x96, 0x39, 0x9b, 0x4f, 0xd8, 0x95, 0x9e, 0x57, 0x33, 0xd4, 0xd3, 0x7c, 0x72, 0x81, 0x6a, 0xe5,
-	0x2f, 0x50, 0xad, 0xfa, 0xbf, 0x24, 0x28, 0x87, 0x5e, 0xef, 0x74, 0xe1, 0xd6, 0x2f, 0x55, 0xe9,
-	0x43, 0x5f, 0xaa, 0x89, 0xff, 0xc9, 0x74, 0x4d, 0x5e, 0xc9, 0x6f, 0x52, 0xef, 0xcf, 0x6f, 0xfe,
-	0x2d, 0x41, 0x29, 0x72, 0x0d, 0xd2, 0xd5, 0xe1, 0x57, 0x84, 0x58, 0x1d, 0xde, 0xa2, 0xfc, 0xda,
-	0x10, 0x44, 0x74, 0x73, 0x01, 0x13, 0x17, 0xb9, 0xea, 0x0a, 0x85, 0xa6, 0x89, 0x83, 0x8b, 0x82,
-	0xa3, 0x9c, 0x32, 0xd1, 0x1a, 0x45, 0x98, 0xa4, 0x42, 0x28, 0xc2, 0x64, 0xb0, 0x7e, 0x7e, 0x73,
-	0x34, 0xdb, 0x99, 0x91, 0x5a, 0x9a, 0x3d, 0x8d, 0xef, 0x5e, 0x4e, 0xb8, 0xc2, 0x8f, 0x6f, 0xc6,
-	0xb9, 0xba, 0xce, 0x8c, 0xd4, 0x7f, 0x9b, 0x00, 0x79, 0xf3, 0x8d, 0xfe, 0x75, 0xdf, 0xd9, 0xe8,
-	0xbb, 0x3d, 0x73, 0x39, 0x2d, 0x4c, 0x6d, 0xd2, 0xc2, 0x38, 0xbe, 0x97, 0x8e, 0xe5, 0x7b, 0xbf,
-	0x4c, 0x40, 0x65, 0x63, 0xaa, 0xd0, 0x24, 0x05, 0x21, 0x11, 0x7f, 0x5d, 0x0d, 0xfa, 0xa1, 0x2c,
-	0xc4, 0xdc, 0xc1, 0xa4, 0xf7, 0x10, 0xdf, 0xcc, 0xc0, 0x8c, 0xf7, 0x04, 0xdf, 0xe1, 0xc0, 0xe8,
-	0x1e, 0x04, 0x6e, 0xd1, 0xb6, 0x10, 0xdc, 0xe1, 0x4b, 0x34, 0xc6, 0x18, 0x6e, 0x6c, 0x10, 0xa6,
-	0x70, 0x6b, 0xbc, 0x17, 0x33, 0x43, 0x51, 0xe2, 0x44, 0xdb, 0xe3, 0xc1, 0x6f, 0x24, 0x48, 0xb1,
-	0xcd, 0x29, 0x03, 0x8c, 0xfb, 0x23, 0x45, 0xd3, 0xb5, 0x57, 0x43, 0x45, 0xbe, 0x86, 0x72, 0x90,
-	0xea, 0x76, 0x46, 0x9a, 0x2c, 0x21, 0x19, 0x8a, 0x43, 0x75, 0xd0, 0x52, 0x46, 0x23, 0x9d, 0x49,
-	0x12, 0x54, 0xd7, 0x1a, 0x0c, 0x5f, 0xc9, 0x49, 0x54, 0x81, 0x02, 0xfd, 0xd2, 0x9b, 0xe3, 0x7e,
-	0xbb, 0xab, 0xc8, 0x29, 0x74, 0x0b, 0x76, 0x03, 0xe3, 0x71, 0x5f, 0xf9, 0xe9, 0xb0, 0x3b, 0x50,
-	0x95, 0xb6, 0xde, 0xee, 0xa8, 0x23, 0x39, 0x8d, 0xaa, 0x50, 0x6a, 0x2b, 0x5d, 0x45, 0x53, 0x02,
-	0xfb, 0x0c, 0xda, 0x85, 0xeb, 0x81, 0xbd, 0x50, 0x31, 0xdb, 0xec, 0x83, 0x1f, 0x42, 0x86, 0x77,
-	0x20, 0x8d, 0xcf, 0x33, 0x1b, 0x69, 0x0d, 0x6d, 0x3c, 0x92, 0xaf, 0xa1, 0x3c, 0xa4, 0x55, 0xa5,
-	0xd1, 0x7e, 0x25, 0x4b, 0x08, 0x20, 0x73, 0xda, 0xe8, 0x74, 0x95, 0xb6, 0x9c, 0x40, 0x05, 0xc8,
-	0x8e, 0xc6, 0x2d, 0x8a, 0x25, 0x27, 0x1f, 0xfc, 0x39, 0x05, 0x85, 0x50, 0x27, 0xa2, 0x1d, 0x40,
-	0x1c, 0x85, 0x9a, 0x8f, 0x55, 0x25, 0xa8, 0xf3, 0x3a, 0x54, 0xc6, 0xfd, 0x17, 0xfd, 0xc1, 0x4f,
-	0xfa, 0x81, 0x46, 0x96, 0xd0, 0x1e, 0xdc, 0x3c, 0xed, 0x74, 0x15, 0xbd, 0x37, 0x68, 0x77, 0x4e,
-	0x3b, 0x4a, 0x7b, 0xa5, 0x4a, 0x50, 0xd5, 0xb3, 0xc6, 0xe8, 0x99, 0xde, 0xeb, 0x8c, 0x7a, 0x0d,
-	0xad, 0xf5, 0x6c, 0xa5, 0x4a, 0xa2, 0x1a, 0xdc, 0x18, 0xaa, 0x4a, 0x6b, 0xd0, 0x6f, 0x77, 0xb4,
-	0xce, 0x60, 0x8d, 0x97, 0x42, 0xfb, 0xb0, 0xc3, 0xf0, 0xfa, 0x03, 0x4d, 0x3f, 0x1d, 0x8c, 0xfb,
-	0x6b, 0xc0, 0x34, 0x4d, 0x6c, 0xa8, 0xa8, 0xbd, 0xce, 0x68, 0x14, 0xf6, 0xc9, 0xa0, 0x8f, 0x61,
-	0x7f, 0xa4, 0xa8, 0x2f, 0x3b, 0x2d, 0x45, 0x8f, 0xd1, 0x57, 0xd0, 0x4d, 0xa8, 0x52, 0xb8, 0x46,
-	0x4b, 0xeb, 0xbc, 0x54, 0xf4, 0xe7, 0x83, 0xa6, 0x3a, 0xee, 0xcb, 0x59, 0x74, 0x1b, 0xf6, 0x1a,
-	0x4f, 0x95, 0xbe, 0xa6, 0x8f, 0xfb, 0xa3, 0xf1, 0x70, 0x38, 0x50, 0x35, 0xa5, 0xad, 0xbf, 0x54,
-	0x54, 0xea, 0x2d, 0xe7, 0xd0, 0x1d, 0xb8, 0x15, 0xa0, 0xc6, 0x19, 0xe4, 0xd1, 0x5d, 0xb8, 0xad,
-	0x35, 0x46, 0x2f, 0xd8, 0xf2, 0xc4, 0x9a, 0x54, 0x69, 0x88, 0x66, 0xb7, 0xd1, 0x7a, 0x41, 0xbb,
-	0x41, 0x69, 0xeb, 0x3c, 0x5c, 0xa0, 0x06, 0xba, 0x0c, 0xa3, 0xc1, 0x58, 0x6d, 0xb1, 0xad, 0x5c,
-	0x97, 0x2c, 0x17, 0x68, 0xca, 0x9d, 0xfe, 0xcb, 0x46, 0xb7, 0xd3, 0xd6, 0xf9, 0x72, 0x34, 0x7a,
-	0x8a, 0x5c, 0x44, 0xf7, 0xe1, 0x90, 0x5a, 0x05, 0x79, 0x75, 0xfa, 0xed, 0x71, 0x4b, 0x69, 0xeb,
-	0x9b, 0xdb, 0x52, 0x42, 0x37, 0x40, 0x6e, 0x8e, 0x5b, 0x2f, 0x14, 0x2d, 0x84, 0x5a, 0x46, 0xf7,
-	0xe0, 0x6e, 0x4f, 0xd1, 0x1a, 0xed, 0x86, 0xd6, 0xd0, 0x07, 0xcd, 0xe7, 0x4a, 0x4b, 0x8b, 0x59,
-	0x67, 0x99, 0x16, 0xf6, 0xb4, 0x35, 0xd2, 0x55, 0x65, 0x34, 0xee, 0x35, 0x9a, 0x5d, 0x45, 0xef,
-	0xb4, 0xf5, 0xa7, 0x83, 0xbe, 0xb2, 0x32, 0x41, 0xcd, 0xc6, 0xcf, 0x7e, 0x34, 0xb3, 0xfc, 0xcf,
-	0x97, 0xd3, 0x63, 0xc3, 0x99, 0x9f, 0x3c, 0x65, 0x24, 0xa4, 0x45, 0xcf, 0xd5, 0xd0, 0x9e, 0xf8,
-	0x67, 0x8e, 0x37, 0x3f, 0x61, 0xa7, 0xec, 0x13, 0x7e, 0xca, 0xf8, 0x7f, 0xcb, 0x9d, 0x30, 0x7e,
-	0x3b, 0x73, 0x74, 0x4e, 0x56, 0x32, 0xec, 0x9f, 0xc7, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x2e,
-	0x02, 0x61, 0xba, 0xda, 0x1b, 0x00, 0x00,
+	// 3780 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x5a, 0x4f, 0x6f, 0x1b, 0x49,
+	0x76, 0x1f, 0x92, 0x12, 0xff, 0x3c, 0xfe, 0x55, 0xd9, 0x96, 0x28, 0x79, 0x6c, 0xcb, 0x74, 0xbc,
+	0xd6, 0x7a, 0xb2, 0x32, 0xe2, 0xd9, 0xf1, 0x4e, 0x76, 0x91, 0xc9, 0x52, 0x64, 0xcb, 0xa6, 0xcd,
+	0x3f, 0x9a, 0x26, 0xe9, 0x9d, 0x09, 0x10, 0x34, 0x5a, 0xdd, 0x45, 0xaa, 0x47, 0xcd, 0xee, 0x76,
+	0x57, 0xd3, 0x63, 0xce, 0x29, 0xc7, 0x00, 0x39, 0xe4, 0x94, 0x00, 0x39, 0x24, 0x40, 0x10, 0x04,
+	0xb9, 0xe5, 0x92, 0x0f, 0x10, 0xe4, 0x94, 0x43, 0x80, 0x9c, 0xf2, 0x01, 0x72, 0xca, 0x07, 0xc8,
+	0x27, 0x08, 0x5e, 0x55, 0x75, 0xb3, 0x9b, 0x22, 0x65, 0xc7, 0x48, 0x76, 0xf7, 0x64, 0xf6, 0x7b,
+	0xbf, 0x7a, 0xf5, 0xaa, 0xea, 0xd5, 0x7b, 0xaf, 0x7e, 0x32, 0x40, 0xa0, 0xb3, 0xcb, 0x63, 0xcf,
+	0x77, 0x03, 0x97, 0xec, 0x18, 0xb6, 0x3b, 0x37, 0x35, 0xcb, 0x99, 0x52, 0x16, 0x68, 0xa8, 0x38,
+	0xb8, 0x37, 0x75, 0xdd, 0xa9, 0x4d, 0x9f, 0x70, 0xc0, 0xf9, 0x7c, 0xf2, 0x24, 0xb0, 0x66, 0x94,
+	0x05, 0xfa, 0xcc, 0x13, 0x63, 0x0e, 0xee, 0xae, 0x02, 0xbe, 0xf7, 0x75, 0xcf, 0xa3, 0x3e, 0x93,
+	0xfa, 0xa2, 0x37, 0xb7, 0x19, 0x15, 0x1f, 0x8d, 0x3f, 0xcd, 0xc2, 0xd6, 0xd0, 0xa3, 0x06, 0xf9,
+	0x39, 0x14, 0x6c, 0x8b, 0x05, 0x1a, 0xf3, 0xa8, 0x51, 0x4f, 0x1d, 0xa6, 0x8e, 0x8a, 0x4f, 0x6f,
+	0x1f, 0x5f, 0x99, 0xfd, 0xb8, 0x6b, 0xb1, 0x00, 0xf1, 0x2f, 0x3e, 0x51, 0xf3, 0xb6, 0xfc, 0x4d,
+	0xce, 0x60, 0xc7, 0xf3, 0x5d, 0x83, 0x32, 0xa6, 0x2d, 0x6d, 0xa4, 0xb9, 0x8d, 0xc6, 0x1a, 0x1b,
+	0x67, 0x02, 0x1b, 0x33, 0x55, 0xf5, 0x92, 0x22, 0xf4, 0xc6, 0x70, 0xbd, 0x85, 0xb0, 0x94, 0xd9,
+	0xe8, 0x4d, 0xcb, 0xf5, 0x16, 0xa1, 0x37, 0x86, 0xfc, 0x4d, 0x7a, 0x50, 0xe3, 0x63, 0xcf, 0xe7,
+	0x8e, 0x69, 0x53, 0x61, 0x62, 0x8b, 0x9b, 0xb8, 0xbf, 0xc1, 0xc4, 0x09, 0x47, 0x4a, 0x43, 0x15,
+	0x23, 0x21, 0x21, 0x2e, 0x7c, 0x1a, 0x2e, 0x6e, 0xee, 0xd0, 0x77, 0x9e, 0xed, 0xfa, 0xd4, 0xd4,
+	0x4c, 0xcb, 0x67, 0xc2, 0xf4, 0x36, 0x37, 0xfd, 0xbb, 0x9b, 0xd7, 0x39, 0x8e, 0x46, 0xb5, 0x2d,
+	0x9f, 0xc9, 0x59, 0xf6, 0xbd, 0x4d, 0x4a, 0x32, 0x04, 0x62, 0x52, 0x9b, 0x06, 0x34, 0xb1, 0x82,
+	0x2c, 0x9f, 0xe6, 0xc1, 0x9a, 0x69, 0xda, 0x1c, 0x9c, 0x58, 0x43, 0xcd, 0x5c, 0x91, 0x11, 0x03,
+	0xea, 0xe1, 0x2a, 0xa4, 0xf1, 0xe5, 0x0a, 0x72, 0xdc, 0xf4, 0xd1, 0xe6, 0x15, 0x88, 0x19, 0x62,
+	0xde, 0xdf, 0xf2, 0xd6, 0x29, 0xc8, 0x2f, 0xa1, 0xf8, 0x96, 0xfa, 0xd6, 0x44, 0x9e, 0x5b, 0x81,
+	0xdb, 0xbd, 0xb3, 0xc6, 0xee, 0x6b, 0x8e, 0x92, 0xc6, 0xe0, 0x6d, 0xf4, 0x45, 0x3a, 0x50, 0xf1,
+	0xa9, 0xe1, 0x3a, 0x86, 0x15, 0xae, 0x1b, 0xb8, 0x91, 0xc3, 0x35, 0x46, 0xd4, 0x10, 0x28, 0xed,
+	0x94, 0xfd, 0xb8, 0x80, 0x3c, 0x82, 0xaa, 0xc5, 0xd8, 0x5c, 0x77, 0x0c, 0xaa, 0x39, 0xf3, 0xd9,
+	0x39, 0xf5, 0xeb, 0xf9, 0xc3, 0xd4, 0x51, 0x46, 0xad, 0x84, 0xe2, 0x3e, 0x97, 0x9e, 0x64, 0x61,
+	0x0b, 0x67, 0x6a, 0xfc, 0xcd, 0x36, 0xe4, 0xa3, 0x00, 0xfc, 0x1c, 0x76, 0x4d, 0x16, 0x88, 0x70,
+	0xf6, 0x29, 0x9b, 0xdb, 0x81, 0x76, 0x3e, 0x37, 0x2e, 0x69, 0xc0, 0xef, 0x46, 0x41, 0xbd, 0x61,
+	0xb2, 0x00, 0xc1, 0x2a, 0xd7, 0x9d, 0x70, 0xd5, 0xba, 0x41, 0xee, 0xf9, 0x77, 0xd4, 0x08, 0xea,
+	0xe9, 0x35, 0x83, 0x06, 0x5c, 0x45, 0x7e, 0x01, 0x07, 0x38, 0x68, 0x35, 0xb6, 0xe4, 0xc0, 0x6d,
+	0x3e, 0x70, 0xcf, 0x64, 0x41, 0x32, 0x52, 0xe4, 0xe0, 0x47, 0x50, 0x65, 0xbe, 0x81, 0x23, 0xa8,
+	0x11, 0xb8, 0xbe, 0x45, 0x59, 0x3d, 0x73, 0x98, 0x39, 0x2a, 0xa8, 0x15, 0xe6, 0x1b, 0xed, 0xa5,
+	0x94, 0x3c, 0x83, 0x3d, 0xfa, 0xce, 0xa3, 0x46, 0x40, 0x4d, 0x6d, 0x4a, 0x1d, 0xea, 0xeb, 0x81,
+	0xe5, 0x3a, 0xb8, 0x31, 0xfc, 0x6e, 0x64, 0xd4, 0x5b, 0xa1, 0xfa, 0x79, 0xa4, 0xed, 0xcf, 0x67,
+	0xa4, 0x0b, 0x0f, 0xe2, 0xcb, 0xd9, 0x64, 0x23, 0xc7, 0x6d, 0xdc, 0xb3, 0xa3, 0xc5, 0x29, 0x6b,
+	0xad, 0x8d, 0xe0, 0xd1, 0xea, 0x3a, 0x37, 0x59, 0xcc, 0x72, 0x8b, 0x0f, 0xe6, 0x89, 0x55, 0xaf,
+	0xb7, 0xfa, 0x10, 0x2a, 0xbe, 0xeb, 0x06, 0xd1, 0x2e, 0x2c, 0xf8, 0x41, 0x17, 0xd4, 0x32, 0x4a,
+	0xc3, 0x4d, 0x58, 0x90, 0xdb, 0x50, 0x98, 0x59, 0x8e, 0x36, 0xc3, 0x7c, 0xc9, 0x63, 0x33, 0xa3,
+	0xe6, 0x67, 0x96, 0xd3, 0xc3, 0x6f, 0xf2, 0x25, 0x14, 0x66, 0xfa, 0x3b, 0xcd, 0xa4, 0x5e, 0x70,
+	0x21, 0x63, 0xee, 0xf6, 0xb1, 0x48, 0xa4, 0xc7, 0x61, 0x22, 0x3d, 0xee, 0x38, 0xc1, 0xb3, 0x9f,
+	0xbe, 0xd6, 0xed, 0x39, 0x55, 0xf3, 0x33, 0xfd, 0x5d, 0x1b, 0xc1, 0xe4, 0x47, 0xe2, 0x08, 0x2c,
+	0xa6, 0xcd, 0x74, 0xc7, 0x9a, 0x50, 0x16, 0xd4, 0x8b, 0x87, 0xa9, 0xa3, 0xbc, 0x5a, 0x66, 0xbe,
+	0xd1, 0x61, 0x3d, 0x29, 0x24, 0x77, 0x00, 0x70, 0x13, 0x67, 0xfc, 0xe6, 0xd5, 0x4b, 0xdc, 0xc3,
+	0x82, 0x90, 0xb4, 0x2d, 0x9f, 0xdc, 0x87, 0x92, 0x54, 0xeb, 0x93, 0x80, 0xfa, 0xf5, 0x32, 0x07,
+	0x14, 0x85, 0xac, 0x89, 0xa2, 0xc6, 0xbf, 0xa4, 0xa0, 0xba, 0x92, 0x3b, 0x7f, 0x8d, 0x71, 0xfa,
+	0x00, 0xca, 0xf1, 0x50, 0x5b, 0xf0, 0xb4, 0x5c, 0x50, 0x4b, 0xb1, 0x40, 0x5b, 0x90, 0x7b, 0x50,
+	0x3c, 0x5f, 0x04, 0x54, 0x73, 0x27, 0x13, 0x46, 0x03, 0x19, 0x5a, 0x80, 0xa2, 0x01, 0x97, 0x34,
+	0xfe, 0x31, 0x05, 0xfb, 0x1b, 0xf3, 0xe2, 0xc7, 0xad, 0xe6, 0xfa, 0x0b, 0x94, 0xbe, 0xfe, 0x02,
+	0xad, 0x38, 0x9c, 0xb9, 0xe2, 0xf0, 0xbf, 0xe7, 0x20, 0x1f, 0x96, 0x19, 0xb2, 0x0f, 0x79, 0xdc,
+	0x83, 0x89, 0x65, 0x53, 0xe9, 0x51, 0x8e, 0xf9, 0xc6, 0xa9, 0x65, 0x53, 0x3c, 0x5e, 0x93, 0x45,
+	0xee, 0x8a, 0x59, 0x0b, 0x26, 0x0b, 0x9d, 0x94, 0x6a, 0xe9, 0x54, 0x26, 0x52, 0x4b, 0x37, 0x3e,
+	0xf6, 0x7a, 0xde, 0x01, 0x40, 0x67, 0x34, 0x74, 0x98, 0xc9, 0x3b, 0x53, 0x40, 0xc9, 0x09, 0x0a,
+	0xc8, 0x5d, 0x28, 0x72, 0xf5, 0x4c, 0xe3, 0x41, 0x9f, 0x5b, 0xea, 0x7b, 0x23, 0x8c, 0xfa, 0xfb,
+	0x50, 0xe2, 0x23, 0x35, 0xc3, 0xf5, 0x2c, 0x6a, 0xca, 0x04, 0xc9, 0x77, 0x84, 0xb5, 0xb8, 0x88,
+	0xec, 0x42, 0xd6, 0xf0, 0x8d, 0xcf, 0x9f, 0x8a, 0x74, 0x5e, 0x56, 0xe5, 0x17, 0x39, 0x86, 0x1b,
+	0x3c, 0x36, 0xf5, 0x73, 0x9b, 0x6a, 0x73, 0xcf, 0x76, 0x75, 0x53, 0xb3, 0x4c, 0x1e, 0xfa, 0x05,
+	0x75, 0x27, 0x52, 0x8d, 0xb9, 0xa6, 0x63, 0xf2, 0xf0, 0x09, 0x5c, 0x5f, 0x9f, 0x52, 0xcd, 0xb0,
+	0x75, 0xc6, 0xe4, 0x0d, 0x28, 0x49, 0x61, 0x0b, 0x65, 0xe4, 0x10, 0x4a, 0x97, 0x33, 0xa6, 0x5d,
+	0xd2, 0x85, 0xe6, 0xe8, 0x33, 0x2a, 0x2f, 0x01, 0x5c, 0xce, 0xd8, 0x2b, 0xba, 0xe8, 0xeb, 0xc2,
+	0x63, 0xc3, 0x75, 0x02, 0xea, 0x04, 0x5a, 0xb0, 0xf0, 0x68, 0xbd, 0x22, 0xae, 0x89, 0x94, 0x8d,
+	0x16, 0x1e, 0x25, 0x47, 0x50, 0xc3, 0xad, 0x66, 0x81, 0x6f, 0x79, 0x9a, 0xe7, 0xd3, 0x89, 0xf5,
+	0xae, 0x5e, 0xe5, 0xb0, 0x8a, 0xc9, 0x82, 0x21, 0x8a, 0xcf, 0xb8, 0x94, 0xfc, 0x0e, 0xa0, 0x44,
+	0xd3, 0x4d, 0x33, 0xc4, 0xd5, 0x84, 0x53, 0x26, 0x0b, 0x9a, 0xa6, 0x29, 0x51, 0x6d, 0x71, 0xc1,
+	0xf9, 0x46, 0xca, 0xad, 0xd8, 0xe1, 0x09, 0xe2, 0xd3, 0x2b, 0x09, 0x62, 0xdc, 0x71, 0x82, 0xcf,
+	0x9f, 0x8a, 0x0c, 0x51, 0x96, 0x91, 0xd1, 0x12, 0xfb, 0xf5, 0x0d, 0x54, 0xc5, 0xe1, 0x6b, 0x33,
+	0x1a, 0xe8, 0xa6, 0x1e, 0xe8, 0x75, 0x72, 0x98, 0x39, 0x2a, 0x3e, 0x7d, 0x72, 0x4d, 0x5f, 0x73,
+	0x2c, 0xc2, 0xa3, 0x27, 0x47, 0x28, 0x4e, 0xe0, 0x2f, 0xd4, 0x8a, 0x9b, 0x10, 0x62, 0xbf, 0xe3,
+	0xbe, 0xa5, 0xfe, 0xf7, 0xbe, 0x15, 0x50, 0xcd, 0x73, 0x6d, 0xcb, 0x58, 0xd4, 0x6f, 0x1c, 0xa6,
+	0x8e, 0x2a, 0x6b, 0x9b, 0xaf, 0x41, 0x08, 0x3d, 0xe3, 0x48, 0xb5, 0xea, 0x26, 0x05, 0x18, 0x52,
+	0xc6, 0xc5, 0xdc, 0xb9, 0xd4, 0x98, 0xf5, 0x03, 0xad, 0xdf, 0x14, 0x21, 0xc3, 0x25, 0x43, 0xeb,
+	0x07, 0x8a, 0xc9, 0xd6, 0xf3, 0xa9, 0x49, 0x27, 0x96, 0x43, 0x4d, 0x4d, 0x37, 0xec, 0xfa, 0x2d,
+	0x91, 0x6c, 0x97, 0xd2, 0xa6, 0x61, 0xe3, 0xd6, 0x06, 0x33, 0x4f, 0x8b, 0xc5, 0xfc, 0xae, 0xd8,
+	0xda, 0x60, 0xe6, 0xb5, 0x59, 0x2c, 0xa7, 0x84, 0xbb, 0xa1, 0xb9, 0x8e, 0xbd, 0xa8, 0xef, 0xf1,
+	0xcc, 0x59, 0x0a, 0x85, 0x03, 0xc7, 0x5e, 0x1c, 0x34, 0xe1, 0xc6, 0x9a, 0x6d, 0x20, 0x35, 0xc8,
+	0x5c, 0xd2, 0x85, 0xbc, 0x86, 0xf8, 0x93, 0xdc, 0x84, 0xed, 0xb7, 0xb8, 0xf5, 0xf2, 0xf6, 0x89,
+	0x8f, 0x9f, 0xa7, 0xbf, 0x4c, 0xbd, 0xdc, 0xca, 0x6f, 0xd7, 0xb2, 0x2f, 0xb7, 0xf2, 0x50, 0x2b,
+	0x36, 0x28, 0xc0, 0xb2, 0xfd, 0xf8, 0x7f, 0xbb, 0xd1, 0x8d, 0x3f, 0x4f, 0x43, 0x39, 0xd1, 0xa1,
+	0x5c, 0x4d, 0xa0, 0xa9, 0x35, 0x09, 0xf4, 0xc3, 0x26, 0x95, 0xd1, 0xba, 0x9c, 0x54, 0x86, 0xea,
+	0x63, 0xd8, 0x31, 0x79, 0xea, 0xf4, 0x5c, 0x3f, 0x32, 0xb2, 0xc5, 0x51, 0x55, 0x13, 0xd3, 0x26,
+	0xca, 0xa5, 0xa9, 0x24, 0x36, 0xd1, 0x6e, 0x2c, 0xb1, 0xf2, 0x9c, 0x5a, 0x70, 0x57, 0xe2, 0xae,
+	0x2f, 0xd7, 0xb7, 0x05, 0x6a, 0x6d, 0x99, 0x6e, 0xfc, 0x75, 0x1a, 0x8a, 0xa2, 0x23, 0x35, 0xf9,
+	0xfe, 0x7e, 0x19, 0xef, 0xf1, 0x53, 0xef, 0xed, 0xf1, 0x63, 0x1d, 0xfe, 0xef, 0x41, 0x96, 0x05,
+	0x7a, 0x30, 0x67, 0x7c, 0x83, 0x2a, 0x4f, 0xf7, 0xd7, 0x0c, 0x1b, 0x72, 0x80, 0x2a, 0x81, 0xa4,
+	0x09, 0xa5, 0x89, 0x6e, 0xd9, 0x73, 0x9f, 0x8a, 0xbc, 0x91, 0xe1, 0x03, 0xef, 0xae, 0x19, 0x78,
+	0x2a, 0x60, 0x98, 0x4a, 0xd4, 0xe2, 0x64, 0xf9, 0x81, 0xbd, 0x56, 0x68, 0x62, 0x46, 0x19, 0xd3,
+	0xa7, 0x54, 0x6e, 0x6d, 0x45, 0x8a, 0x7b, 0x42, 0x4a, 0xbe, 0x00, 0xee, 0xaa, 0x66, 0xbb, 0x53,
+	0xf9, 0x3a, 0x38, 0xd8, 0xb0, 0xae, 0xae, 0x3b, 0x55, 0x73, 0x86, 0xf8, 0xd1, 0x18, 0x43, 0x25,
+	0xf9, 0x18, 0x21, 0x2d, 0x28, 0x8b, 0x27, 0x80, 0xc9, 0x03, 0x94, 0xd5, 0x53, 0x3c, 0x63, 0xac,
+	0xf3, 0x3a, 0xb6, 0xb1, 0x6a, 0xe9, 0x7c, 0xf9, 0xc1, 0x1a, 0x7f, 0x9b, 0x82, 0x9a, 0xe8, 0xd3,
+	0xc5, 0x61, 0x72, 0xcb, 0xc9, 0x30, 0x4b, 0x5d, 0x1f, 0xdb, 0xe9, 0xd5, 0x6a, 0xf5, 0x10, 0x2a,
+	0x2b, 0xc7, 0x2f, 0xea, 0x66, 0x79, 0x9a, 0x28, 0x4e, 0x32, 0x11, 0xcb, 0xb4, 0x27, 0x4a, 0x94,
+	0xa8, 0x66, 0x95, 0xc8, 0x16, 0xaf, 0x53, 0x8d, 0xff, 0x48, 0x43, 0x59, 0xae, 0x40, 0x4e, 0xf1,
+	0x75, 0xf4, 0x08, 0x92, 0xc3, 0x63, 0x51, 0xb2, 0xf9, 0x11, 0xb4, 0x5c, 0x61, 0xf8, 0x04, 0x8a,
+	0xad, 0xf9, 0xb7, 0x3c, 0x6a, 0xbe, 0x06, 0x12, 0x1e, 0xb6, 0x5c, 0xf2, 0x32, 0x7e, 0x1e, 0x6c,
+	0x3e, 0x71, 0xb1, 0x40, 0x0c, 0xa4, 0xda, 0xf9, 0x8a, 0xa4, 0xf1, 0xc7, 0xe1, 0xc9, 0xc7, 0x62,
+	0xaa, 0x03, 0xd5, 0xe4, 0x34, 0x61, 0x54, 0x1d, 0xbe, 0x6f, 0x0e, 0xb5, 0x92, 0x98, 0x80, 0x35,
+	0xfe, 0x35, 0x05, 0xb7, 0xd6, 0xbe, 0x10, 0xdf, 0x17, 0x5e, 0xbb, 0x90, 0x95, 0x19, 0x2c, 0xcd,
+	0x1f, 0x2b, 0xf2, 0x0b, 0x33, 0xa4, 0xf8, 0x95, 0x6c, 0xc7, 0x4a, 0x42, 0x28, 0x1a, 0x32, 0x04,
+	0xc9, 0xfd, 0x49, 0x34, 0x99, 0x25, 0x21, 0x94, 0xa0, 0x9f, 0x00, 0xc1, 0x96, 0xc0, 0x72, 0xe6,
+	0x22, 0x46, 0x03, 0xf7, 0x92, 0x3a, 0x32, 0xbb, 0xed, 0xc4, 0x35, 0x23, 0x54, 0x34, 0xfe, 0x39,
+	0x05, 0x30, 0xd2, 0xd9, 0xa5, 0x4a, 0xdf, 0xf4, 0xd8, 0x94, 0x7c, 0x06, 0x04, 0x97, 0xaf, 0xf9,
+	0xd4, 0xd6, 0x7c, 0xcc, 0xd9, 0xbc, 0x19, 0x11, 0xcb, 0xa8, 0x06, 0x1c, 0x67, 0xab, 0xcc, 0x37,
+	0x78, 0x47, 0xf2, 0x04, 0x6e, 0x7e, 0xe7, 0x9e, 0xfb, 0x73, 0x67, 0x05, 0x2e, 0x92, 0xf3, 0x8e,
+	0xd0, 0xc5, 0x07, 0xfc, 0x08, 0xaa, 0xdf, 0xb9, 0xe7, 0x1a, 0x8e, 0x78, 0x4b, 0x7d, 0x66, 0xb9,
+	0x8e, 0x8c, 0x88, 0xf2, 0x77, 0xee, 0xb9, 0x3a, 0x77, 0x5e, 0x0b, 0x21, 0xf9, 0x4c, 0xbc, 0x4b,
+	0x25, 0x91, 0xb2, 0xb7, 0x2e, 0x5a, 0x31, 0xd0, 0xc5, 0xe3, 0xf5, 0x1f, 0xb6, 0xa1, 0x28, 0x56,
+	0xc0, 0xbc, 0xff, 0xf5, 0x12, 0xd6, 0x78, 0x94, 0x5f, 0xe7, 0xd1, 0x03, 0x28, 0xeb, 0x53, 0x6c,
+	0xbd, 0x42, 0x54, 0x41, 0x54, 0x30, 0x2e, 0x0c, 0x41, 0xbb, 0x89, 0x6b, 0x56, 0xf8, 0x8d, 0xdc,
+	0xa5, 0x23, 0xc8, 0x2c, 0x2f, 0xcf, 0xee, 0x3a, 0x1a, 0xcb, 0x9d, 0xaa, 0x08, 0x21, 0x4f, 0x21,
+	0xef, 0xd3, 0x37, 0x71, 0x8a, 0x65, 0xe3, 0x46, 0xe7, 0x7c, 0xfa, 0x06, 0x7f, 0x90, 0x9f, 0x02,
+	0xbe, 0xdb, 0xbc, 0x38, 0x79, 0xb2, 0x71, 0x50, 0x1e, 0x91, 0x7c, 0x54, 0x1b, 0x6a, 0x38, 0x93,
+	0x37, 0x3f, 0xb7, 0x2d, 0x76, 0x21, 0x1a, 0x72, 0x90, 0xd5, 0x61, 0xb5, 0x8f, 0x1c, 0x85, 0x94,
+	0x9e, 0x5a, 0xf1, 0xe9, 0x9b, 0x33, 0x31, 0x04, 0x85, 0xe4, 0x97, 0x48, 0x90, 0xbc, 0xd1, 0x58,
+	0xa0, 0xfb, 0x81, 0xb0, 0x51, 0x7c, 0xaf, 0x8d, 0x12, 0x3a, 0x8e, 0x03, 0xb8, 0x85, 0x53, 0xd8,
+	0xe1, 0xde, 0x27, 0x1c, 0x29, 0xbd, 0xd7, 0x48, 0x15, 0x07, 0xc5, 0x3d, 0x79, 0x06, 0x79, 0x11,
+	0x0c, 0x96, 0x59, 0x2f, 0xaf, 0xab, 0xde, 0x82, 0x66, 0x6c, 0x22, 0xa6, 0x63, 0xaa, 0x39, 0x5d,
+	0xfc, 0x68, 0xfc, 0xe7, 0x16, 0x64, 0xba, 0xee, 0x94, 0xfc, 0x0c, 0x38, 0x81, 0xc8, 0xb3, 0x5c,
+	0x6a, 0x63, 0x95, 0xc4, 0xd7, 0x5e, 0xd7, 0x9d, 0xbe, 0xf8, 0x44, 0xcd, 0xd9, 0xe2, 0x27, 0xf6,
+	0xbb, 0x09, 0xb6, 0x11, 0x0d, 0xa4, 0x37, 0xf2, 0x7b, 0xb1, 0x07, 0xb3, 0xb0, 0x53, 0xf1, 0x12,
+	0x12, 0xf4, 0x23, 0xaa, 0xd6, 0x99, 0xf7, 0x55, 0x6b, 0xf4, 0x43, 0xd6, 0x6b, 0xf2, 0x12, 0xaa,
+	0x71, 0x9e, 0x11, 0xc7, 0x6f, 0x6d, 0x24, 0xab, 0x96, 0x95, 0x5d, 0x58, 0x29, 0x1b, 0x71, 0x01,
+	0xb1, 0xe1, 0xf6, 0x26, 0x92, 0x71, 0x19, 0xc8, 0x9f, 0x7d, 0x28, 0xc7, 0x28, 0xa6, 0xa8, 0x7b,
+	0x1b, 0x74, 0xc8, 0xd7, 0x26, 0x19, 0x46, 0x9c, 0x23, 0xbb, 0x91, 0xaf, 0x8d, 0xd7, 0x10, 0x61,
+	0xba, 0x6a, 0x26, 0x45, 0xe4, 0x0f, 0x40, 0xb2, 0x78, 0xdc, 0x54, 0x4e, 0x3e, 0x8f, 0x36, 0x11,
+	0x7f, 0xc2, 0x48, 0xe1, 0x6d, 0xf8, 0x41, 0x4e, 0x61, 0x49, 0xde, 0x71, 0x0b, 0x79, 0x6e, 0xe1,
+	0xde, 0x75, 0xac, 0x9f, 0x30, 0x52, 0xf2, 0x63, 0xdf, 0x27, 0xdb, 0xfc, 0xde, 0xe3, 0x9b, 0x3d,
+	0x17, 0x1e, 0xef, 0x3d, 0xf1, 0x04, 0x66, 0xda, 0xc4, 0x9d, 0x3b, 0x26, 0x8f, 0xb4, 0x8c, 0xca,
+	0x1f, 0xcd, 0xec, 0x14, 0x25, 0x21, 0x03, 0x10, 0x02, 0xd2, 0x4b, 0x06, 0x40, 0x02, 0xb0, 0x98,
+	0x59, 0x7e, 0xa8, 0x17, 0x25, 0xa9, 0x80, 0x92, 0x68, 0xbc, 0x38, 0x27, 0x8b, 0x05, 0xd4, 0x0c,
+	0x29, 0x0f, 0x14, 0x75, 0xb9, 0x04, 0xb3, 0x2b, 0x07, 0x38, 0x6e, 0x10, 0x82, 0xb6, 0x45, 0xbb,
+	0x84, 0xe2, 0xbe, 0x1b, 0x48, 0x1c, 0xbe, 0x46, 0x43, 0x9c, 0x98, 0x2b, 0xcb, 0xab, 0x63, 0x49,
+	0xc2, 0xc4, 0x74, 0x3f, 0x86, 0x1a, 0x5b, 0xcc, 0x6c, 0xcb, 0xb9, 0x64, 0x1a, 0xbb, 0xb4, 0x3c,
+	0x8f, 0x9a, 0xf2, 0x5d, 0x5f, 0x0d, 0xe5, 0x43, 0x21, 0x26, 0x9f, 0xc1, 0x4e, 0x04, 0x9d, 0xb8,
+	0xb6, 0xed, 0x7e, 0x1f, 0x3d, 0xf1, 0x23, 0x1b, 0xa7, 0x52, 0x8e, 0xd4, 0x8b, 0xd8, 0x27, 0x69,
+	0x54, 0x3b, 0x5f, 0x24, 0xa8, 0xb2, 0x1b, 0x5c, 0x2b, 0x4d, 0x9f, 0x2c, 0x04, 0x6b, 0x86, 0x7c,
+	0x0d, 0xba, 0x6c, 0xd2, 0x09, 0xf5, 0x7d, 0x31, 0x68, 0x49, 0xa1, 0x65, 0xd4, 0x1b, 0xa8, 0x6d,
+	0x4b, 0xe5, 0xc9, 0x42, 0x10, 0x66, 0x5f, 0x01, 0x5f, 0x91, 0x46, 0x7d, 0x1f, 0x83, 0xb2, 0x5e,
+	0x3c, 0xcc, 0x5c, 0x4d, 0x1e, 0x22, 0xf0, 0x2c, 0x5f, 0x41, 0x90, 0xca, 0x77, 0x58, 0x11, 0x78,
+	0xf2, 0x33, 0xa8, 0x87, 0x4c, 0x9b, 0x68, 0x8b, 0x63, 0x3b, 0x56, 0xe2, 0x3b, 0x76, 0x2b, 0xd4,
+	0xf3, 0x0e, 0x38, 0xda, 0xba, 0x47, 0x50, 0xc5, 0x2a, 0xa8, 0x19, 0xae, 0x6d, 0x5b, 0x58, 0xab,
+	0x58, 0xbd, 0x2c, 0xc8, 0x52, 0x14, 0xb7, 0x22, 0x29, 0x1e, 0xa9, 0xa7, 0xfb, 0x81, 0xa5, 0xdb,
+	0x9c, 0xab, 0x13, 0x1c, 0x03, 0x48, 0x11, 0x92, 0x75, 0xbf, 0x80, 0x83, 0x18, 0x40, 0xa3, 0x4e,
+	0xe0, 0x5b, 0x34, 0x0a, 0x81, 0x2a, 0x5f, 0xfb, 0xde, 0x12, 0xaf, 0x08, 0xbd, 0x3c, 0xe7, 0x26,
+	0xdc, 0x59, 0x37, 0xd8, 0xa7, 0x33, 0xdd, 0x72, 0x2c, 0x67, 0xca, 0x49, 0x88, 0x8c, 0x7a, 0x70,
+	0x65, 0xbc, 0x1a, 0x22, 0x30, 0x54, 0x90, 0xad, 0x8c, 0x51, 0x3f, 0x3b, 0xa2, 0x09, 0x9a, 0xe9,
+	0xef, 0x4e, 0x23, 0xf6, 0x27, 0x3c, 0x1d, 0xe1, 0x96, 0x36, 0xf1, 0xdd, 0x99, 0x66, 0x39, 0x26,
+	0x7d, 0x57, 0x27, 0xcb, 0xd3, 0x11, 0x4e, 0x9d, 0xfa, 0xee, 0xac, 0x83, 0x2a, 0x6c, 0xda, 0x8d,
+	0x0b, 0x6a, 0x5c, 0xb2, 0xf9, 0x4c, 0xf3, 0xa9, 0x6e, 0x6a, 0x33, 0xc6, 0xd9, 0x84, 0x8c, 0x5a,
+	0x09, 0xe5, 0x2a, 0xd5, 0xcd, 0x1e, 0x43, 0x06, 0x28, 0x42, 0x8a, 0x1b, 0x84, 0x78, 0xc9, 0x18,
+	0xec, 0x84, 0x2a, 0xee, 0x0a, 0x8e, 0x20, 0xbf, 0x0f, 0xfb, 0xe2, 0xb8, 0x8c, 0x0b, 0xdd, 0x99,
+	0x62, 0x7a, 0x9b, 0xfb, 0x96, 0x33, 0xe5, 0xee, 0x71, 0x12, 0x21, 0xa3, 0x8a, 0x10, 0x6c, 0x09,
+	0x7d, 0x9b, 0xab, 0xd1, 0xbf, 0xc6, 0x33, 0xc8, 0x87, 0xb1, 0x40, 0x08, 0x6c, 0x79, 0x7a, 0x70,
+	0x21, 0x7b, 0x19, 0xfe, 0x1b, 0x7b, 0x0e, 0x9f, 0xea, 0xcc, 0x75, 0xc2, 0x9e, 0x43, 0x7c, 0x35,
+	0xfe, 0x2c, 0x05, 0x95, 0x64, 0x01, 0xc0, 0x4b, 0x11, 0xee, 0xb8, 0xcc, 0x8f, 0x34, 0xcc, 0x0a,
+	0x35, 0xa9, 0x38, 0x0b, 0xe5, 0x9c, 0x5e, 0xc7, 0xc2, 0x89, 0x6e, 0xca, 0x6e, 0x53, 0xe4, 0x87,
+	0x4a, 0x28, 0x5e, 0x36, 0xa5, 0xd4, 0x31, 0x63, 0x30, 0xd9, 0xb9, 0x0a, 0xa1, 0xa4, 0x12, 0xff,
+	0x22, 0x05, 0xf5, 0x4d, 0xf9, 0xfa, 0x37, 0xe9, 0xd7, 0x7f, 0xa7, 0xa0, 0x10, 0x25, 0xe6, 0xeb,
+	0x18, 0x91, 0xdb, 0x50, 0x40, 0x95, 0x88, 0x38, 0x31, 0x21, 0x62, 0x45, 0xb4, 0xdd, 0x01, 0x40,
+	0xa5, 0x64, 0xc8, 0x32, 0x9c, 0x2c, 0x44, 0xb8, 0xe4, 0xbf, 0xf6, 0x21, 0x6f, 0xca, 0x0b, 0x2b,
+	0x9b, 0xb6, 0x9c, 0xc9, 0x82, 0xd0, 0x2c, 0xaa, 0x84, 0x59, 0x91, 0x1a, 0x11, 0x1b, 0x99, 0x45,
+	0xa5, 0x34, 0x9b, 0x15, 0x66, 0x4d, 0x16, 0x48, 0xb3, 0x37, 0x61, 0x7b, 0xa6, 0x07, 0xc6, 0x05,
+	0xcf, 0x81, 0x79, 0x55, 0x7c, 0x20, 0x4b, 0x38, 0xfd, 0xc1, 0xf2, 0x34, 0xea, 0x18, 0xae, 0x29,
+	0x93, 0x5e, 0x5e, 0x2d, 0xa2, 0x4c, 0x11, 0xa2, 0xc6, 0xbf, 0xa5, 0xa0, 0x14, 0xaf, 0x25, 0xef,
+	0x2f, 0x14, 0xd1, 0xc3, 0x23, 0x59, 0x2a, 0xe4, 0xc3, 0x83, 0x45, 0x39, 0x66, 0x66, 0x31, 0xc6,
+	0x77, 0x5c, 0xc8, 0xe5, 0x96, 0x57, 0xa4, 0x58, 0x3e, 0x9e, 0xf8, 0xc9, 0xbc, 0x0b, 0x7c, 0x3d,
+	0x82, 0xc9, 0x67, 0x0c, 0x17, 0x86, 0x20, 0x3c, 0x67, 0xeb, 0x07, 0xaa, 0xcd, 0x2c, 0xc6, 0x17,
+	0x16, 0xed, 0x4f, 0x05, 0xc5, 0xbd, 0x48, 0xda, 0xf8, 0xa7, 0x6d, 0xc8, 0xc9, 0x16, 0xe5, 0xa3,
+	0x0f, 0xf0, 0x53, 0x71, 0x80, 0x92, 0x2b, 0xce, 0x44, 0x5a, 0x41, 0x15, 0x27, 0x8f, 0x77, 0xeb,
+	0xba, 0xe3, 0xdd, 0xbe, 0xe6, 0x78, 0xb3, 0x2b, 0xc7, 0xfb, 0xa9, 0x38, 0xde, 0x04, 0x41, 0x8d,
+	0xda, 0x68, 0xd2, 0xd8, 0xe1, 0xe7, 0x57, 0x0f, 0x7f, 0x0f, 0x72, 0x7c, 0xb0, 0xf9, 0x05, 0xaf,
+	0x37, 0x05, 0x35, 0x8b, 0x23, 0xcd, 0x2f, 0xae, 0xf0, 0xda, 0x85, 0xab, 0xbc, 0x76, 0x1d, 0x72,
+	0x61, 0xf9, 0x14, 0x7f, 0xae, 0x09, 0x3f, 0x31, 0x10, 0x70, 0xa5, 0xa2, 0xc5, 0x31, 0x79, 0x6b,
+	0x9c, 0x57, 0x71, 0xf1, 0xa2, 0x0f, 0x32, 0x31, 0x45, 0x2e, 0x01, 0xa2, 0x8c, 0x49, 0xa6, 0xba,
+	0x12, 0xa1, 0x44, 0xae, 0xfa, 0x31, 0xfe, 0x29, 0x7a, 0xe6, 0xf9, 0xfc, 0xd6, 0xca, 0x1d, 0xa8,
+	0x88, 0x62, 0xbd, 0x94, 0x27, 0xae, 0x0f, 0xbb, 0xd0, 0x9f, 0x7e, 0xf1, 0x4c, 0xf2, 0xd5, 0xb8,
+	0xbf, 0x43, 0x2e, 0x20, 0x7d, 0x28, 0xf1, 0xa5, 0x86, 0xdc, 0x71, 0xed, 0x30, 0xb3, 0xa1, 0x23,
+	0x94, 0x61, 0x70, 0xdc, 0x66, 0x2b, 0xbc, 0x71, 0xd1, 0x5c, 0x4a, 0xf0, 0x2f, 0x03, 0x3c, 0x48,
+	0x98, 0x78, 0x8c, 0xed, 0x44, 0xf3, 0x9d, 0x32, 0xfe, 0xd4, 0xba, 0xc2, 0xcc, 0x92, 0x35, 0xcc,
+	0xec, 0x57, 0x50, 0x6b, 0xb3, 0x8f, 0xa7, 0x65, 0x1b, 0xff, 0x95, 0x82, 0x4a, 0x8c, 0xb8, 0xc2,
+	0xe0, 0x5d, 0x92, 0x34, 0xa9, 0x8f, 0x25, 0x69, 0xd2, 0xff, 0x27, 0x0f, 0xcb, 0xcc, 0x7b, 0xa9,
+	0xbd, 0xad, 0x0f, 0xa7, 0xf6, 0xfe, 0x2e, 0x03, 0xe5, 0xc4, 0x0b, 0x00, 0x23, 0x54, 0x16, 0x43,
+	0x11, 0xa1, 0x22, 0xdd, 0x88, 0x0c, 0x24, 0x23, 0x74, 0x35, 0x88, 0xd3, 0x57, 0x83, 0x38, 0xb2,
+	0x82, 0x6e, 0xd2, 0xb0, 0x39, 0x15, 0x56, 0x4e, 0xb9, 0x68, 0x69, 0x45, 0x42, 0xb6, 0x62, 0x56,
+	0x24, 0x64, 0xb0, 0x64, 0x9e, 0x84, 0x35, 0xdb, 0x9d, 0x62, 0xa2, 0xc9, 0x6c, 0x78, 0x52, 0x25,
+	0x8f, 0x2c, 0xe2, 0x9d, 0xf0, 0x1b, 0x4b, 0x19, 0xef, 0x0c, 0x84, 0xa1, 0x0b, 0x9d, 0x5d, 0x44,
+	0xc9, 0x4b, 0xde, 0xfd, 0x1d, 0xae, 0x7a, 0xa1, 0xb3, 0x8b, 0x30, 0x7f, 0x61, 0x87, 0xbc, 0xda,
+	0xc8, 0x89, 0x4c, 0x50, 0x9e, 0x24, 0x1a, 0xb8, 0x87, 0x50, 0x11, 0xb8, 0x99, 0x6b, 0x5a, 0x93,
+	0xe5, 0x1f, 0xac, 0x04, 0xac, 0x27, 0x85, 0xf8, 0xc7, 0x34, 0x01, 0xf3, 0xa8, 0xcf, 0xb3, 0xae,
+	0xeb, 0x68, 0x26, 0x75, 0x96, 0x89, 0xe0, 0x16, 0x57, 0x9f, 0x45, 0xda, 0x36, 0x57, 0x36, 0xfe,
+	0x2a, 0x0d, 0xb5, 0x55, 0x56, 0xed, 0xb7, 0x3d, 0x20, 0x93, 0x4c, 0x5b, 0xf6, 0x7a, 0x22, 0x77,
+	0x6b, 0x95, 0xc8, 0x5d, 0xc7, 0xd0, 0x6e, 0xaf, 0x65, 0x68, 0xff, 0x24, 0x0d, 0xd5, 0x95, 0x77,
+	0x20, 0x3a, 0x19, 0x16, 0xc4, 0x30, 0x59, 0x8a, 0x30, 0x96, 0x7f, 0xa1, 0x62, 0x61, 0xc2, 0x7c,
+	0x00, 0x65, 0x11, 0x83, 0x21, 0x4c, 0x56, 0x4e, 0x2e, 0x0c, 0x41, 0x0f, 0xa1, 0x12, 0x95, 0xd7,
+	0x78, 0x34, 0x87, 0x45, 0xf7, 0xc3, 0xe3, 0x79, 0x0c, 0x37, 0x57, 0x28, 0xce, 0x78, 0x44, 0x7f,
+	0x10, 0x97, 0x4a, 0x92, 0x54, 0x27, 0x46, 0xf5, 0xe3, 0xbf, 0x4c, 0xc1, 0x16, 0x3f, 0x9c, 0x0a,
+	0xc0, 0xb8, 0x3f, 0x54, 0x46, 0xda, 0xe8, 0xdb, 0x33, 0xa5, 0xf6, 0x09, 0xc9, 0xc3, 0x56, 0xb7,
+	0x33, 0x1c, 0xd5, 0x52, 0xa4, 0x06, 0xa5, 0x33, 0x75, 0xd0, 0x52, 0x86, 0x43, 0x8d, 0x4b, 0xd2,
+	0xa8, 0x6b, 0x0d, 0xce, 0xbe, 0xad, 0x65, 0x48, 0x15, 0x8a, 0xf8, 0x4b, 0x3b, 0x19, 0xf7, 0xdb,
+	0x5d, 0xa5, 0xb6, 0x45, 0x6e, 0xc3, 0x5e, 0x08, 0x1e, 0xf7, 0x95, 0x6f, 0xce, 0xba, 0x03, 0x55,
+	0x69, 0x6b, 0xed, 0x8e, 0x3a, 0xac, 0x6d, 0x93, 0x1d, 0x28, 0xb7, 0x95, 0xae, 0x32, 0x52, 0x42,
+	0x7c, 0x96, 0xec, 0xc1, 0x8d, 0x10, 0x2f, 0x55, 0x1c, 0x9b, 0x7b, 0xfc, 0x15, 0x64, 0x45, 0x04,
+	0xe2, 0xfc, 0xc2, 0xb3, 0xe1, 0xa8, 0x39, 0x1a, 0x0f, 0x6b, 0x9f, 0x90, 0x02, 0x6c, 0xab, 0x4a,
+	0xb3, 0xfd, 0x6d, 0x2d, 0x45, 0x00, 0xb2, 0xa7, 0xcd, 0x4e, 0x57, 0x69, 0xd7, 0xd2, 0xa4, 0x08,
+	0xb9, 0xe1, 0xb8, 0x85, 0xb6, 0x6a, 0x99, 0xc7, 0x7f, 0x9f, 0x85, 0x62, 0x2c, 0x12, 0xc9, 0x2e,
+	0x10, 0x61, 0x05, 0xe1, 0x63, 0x55, 0x09, 0xd7, 0x79, 0x03, 0xaa, 0xe3, 0xfe, 0xab, 0xfe, 0xe0,
+	0x57, 0xfd, 0x50, 0x53, 0x4b, 0x91, 0x7d, 0xb8, 0x75, 0xda, 0xe9, 0x2a, 0x5a, 0x6f, 0xd0, 0xee,
+	0x9c, 0x76, 0x94, 0x76, 0xa4, 0x4a, 0xa3, 0xea, 0x45, 0x73, 0xf8, 0x42, 0xeb, 0x75, 0x86, 0xbd,
+	0xe6, 0xa8, 0xf5, 0x22, 0x52, 0x65, 0x48, 0x1d, 0x6e, 0x9e, 0xa9, 0x4a, 0x6b, 0xd0, 0x6f, 0x77,
+	0x46, 0x9d, 0xc1, 0xd2, 0xde, 0x16, 0x39, 0x80, 0x5d, 0x6e, 0xaf, 0x3f, 0x18, 0x69, 0xa7, 0x83,
+	0x71, 0x7f, 0x69, 0x70, 0x1b, 0x1d, 0x3b, 0x53, 0xd4, 0x5e, 0x67, 0x38, 0x8c, 0x8f, 0xc9, 0x92,
+	0xbb, 0x70, 0x30, 0x54, 0xd4, 0xd7, 0x9d, 0x96, 0xa2, 0xad, 0xd1, 0x57, 0xc9, 0x2d, 0xd8, 0x41,
+	0x73, 0xcd, 0xd6, 0xa8, 0xf3, 0x5a, 0xd1, 0x5e, 0x0e, 0x4e, 0xd4, 0x71, 0xbf, 0x96, 0x23, 0x77,
+	0x60, 0xbf, 0xf9, 0x5c, 0xe9, 0x8f, 0xb4, 0x71, 0x7f, 0x38, 0x3e, 0x3b, 0x1b, 0xa8, 0x23, 0xa5,
+	0xad, 0xbd, 0x56, 0x54, 0x1c, 0x5d, 0xcb, 0x93, 0x7b, 0x70, 0x3b, 0xb4, 0xba, 0x0e, 0x50, 0x20,
+	0xf7, 0xe1, 0xce, 0xa8, 0x39, 0x7c, 0xc5, 0xb7, 0x67, 0x2d, 0x64, 0x07, 0xa7, 0x38, 0xe9, 0x36,
+	0x5b, 0xaf, 0x30, 0x1a, 0x94, 0xb6, 0x26, 0xa6, 0x0b, 0xd5, 0x80, 0xdb, 0x30, 0x1c, 0x8c, 0xd5,
+	0x16, 0x3f, 0xca, 0xe5, 0x92, 0x6b, 0x45, 0x74, 0xb9, 0xd3, 0x7f, 0xdd, 0xec, 0x76, 0xda, 0x9a,
+	0xd8, 0x8e, 0x66, 0x4f, 0xa9, 0x95, 0xc8, 0x23, 0x78, 0x80, 0xa8, 0xd0, 0xaf, 0x4e, 0xbf, 0x3d,
+	0x6e, 0x29, 0x6d, 0x6d, 0xf5, 0x58, 0xca, 0xe4, 0x26, 0xd4, 0x4e, 0xc6, 0xad, 0x57, 0xca, 0x28,
+	0x66, 0xb5, 0x42, 0x1e, 0xc2, 0xfd, 0x9e, 0x32, 0x6a, 0xb6, 0x9b, 0xa3, 0xa6, 0x36, 0x38, 0x79,
+	0xa9, 0xb4, 0x46, 0x6b, 0xf6, 0xb9, 0x86, 0x0b, 0x7b, 0xde, 0x1a, 0x6a, 0xaa, 0x32, 0x1c, 0xf7,
+	0x9a, 0x27, 0x5d, 0x45, 0xeb, 0xb4, 0xb5, 0xe7, 0x83, 0xbe, 0x12, 0x41, 0x08, 0x1e, 0xd3, 0xab,
+	0xde, 0x70, 0xdd, 0x76, 0xdf, 0xc0, 0x45, 0xc7, 0xe4, 0x6d, 0xa5, 0x1f, 0x0f, 0x8b, 0x9b, 0x38,
+	0x14, 0x57, 0xa3, 0xb5, 0x06, 0xdd, 0x6e, 0x27, 0x31, 0xf4, 0x16, 0xea, 0xbe, 0x1e, 0x0f, 0x46,
+	0x4d, 0x4d, 0xf9, 0xa6, 0xa5, 0x28, 0xed, 0xd8, 0xb8, 0x5d, 0xbc, 0x2f, 0x51, 0x64, 0x0c, 0x47,
+	0xdc, 0xaf, 0x50, 0xb9, 0x87, 0x2e, 0xcb, 0x05, 0x35, 0xbb, 0x3c, 0xe0, 0x35, 0xe5, 0x9b, 0xce,
+	0x70, 0x34, 0x8c, 0x20, 0x75, 0x74, 0xab, 0xad, 0x34, 0xdb, 0xdd, 0x4e, 0x5f, 0xb9, 0x6a, 0x7e,
+	0x9f, 0x1c, 0xc2, 0xa7, 0xe1, 0x96, 0xa0, 0x77, 0xa3, 0xc1, 0x40, 0xeb, 0x0e, 0xfa, 0xcf, 0x23,
+	0xc4, 0xc1, 0xe3, 0x17, 0x50, 0x5d, 0xf9, 0xeb, 0x39, 0x29, 0x43, 0x61, 0xf0, 0x5a, 0x51, 0x7f,
+	0xa5, 0x76, 0x46, 0x78, 0x43, 0x08, 0x54, 0x86, 0xaf, 0x3a, 0x67, 0x5a, 0xe7, 0x54, 0x4e, 0x5f,
+	0x4b, 0xa1, 0x0c, 0x4d, 0xc4, 0x64, 0xe9, 0x93, 0xe6, 0x1f, 0xfd, 0xe1, 0xd4, 0x0a, 0x2e, 0xe6,
+	0xe7, 0xc7, 0x86, 0x3b, 0x7b, 0xf2, 0x9c, 0x93, 0xae, 0x2d, 0xcc, 0x4a, 0x67, 0xb6, 0x1e, 0x4c,
+	0x5c, 0x7f, 0xf6, 0x84, 0xe7, 0xa8, 0x9f, 0x88, 0x1c, 0x25, 0xfe, 0x27, 0xe7, 0x13, 0xce, 0xe7,
+	0x4f, 0x5d, 0x8d, 0x7f, 0x9d, 0x67, 0xf9, 0x3f, 0x9f, 0xff, 0xcf, 0x00, 0x92, 0x1b, 0x5d, 0x1a,
+	0x2e, 0x2a, 0x00, 0x00,
 }